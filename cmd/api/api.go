@@ -3,27 +3,53 @@ package api
 import (
 	"database/sql"
 	"log"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 
 	"github.com/jayden1905/event-registration-software/cmd/pkg/database"
 	"github.com/jayden1905/event-registration-software/config"
+	"github.com/jayden1905/event-registration-software/service/admin"
 	"github.com/jayden1905/event-registration-software/service/attendee"
+	"github.com/jayden1905/event-registration-software/service/audit"
+	"github.com/jayden1905/event-registration-software/service/badge"
+	"github.com/jayden1905/event-registration-software/service/campaign"
+	"github.com/jayden1905/event-registration-software/service/checkin"
+	"github.com/jayden1905/event-registration-software/service/clone"
+	"github.com/jayden1905/event-registration-software/service/customfield"
+	"github.com/jayden1905/event-registration-software/service/dedup"
 	"github.com/jayden1905/event-registration-software/service/email"
 	"github.com/jayden1905/event-registration-software/service/event"
+	"github.com/jayden1905/event-registration-software/service/group"
+	"github.com/jayden1905/event-registration-software/service/live"
+	"github.com/jayden1905/event-registration-software/service/payment"
+	"github.com/jayden1905/event-registration-software/service/plan"
+	"github.com/jayden1905/event-registration-software/service/portal"
+	"github.com/jayden1905/event-registration-software/service/purge"
+	"github.com/jayden1905/event-registration-software/service/report"
+	"github.com/jayden1905/event-registration-software/service/rsvp"
+	"github.com/jayden1905/event-registration-software/service/search"
+	"github.com/jayden1905/event-registration-software/service/seating"
+	"github.com/jayden1905/event-registration-software/service/session"
+	"github.com/jayden1905/event-registration-software/service/suppression"
+	"github.com/jayden1905/event-registration-software/service/ticket"
+	"github.com/jayden1905/event-registration-software/service/tracking"
 	"github.com/jayden1905/event-registration-software/service/user"
 )
 
 type apiConfig struct {
 	addr string
 	db   *database.Queries
+	// conn is the connection pool the stores run their transactions on
+	conn *sql.DB
 }
 
 func NewAPIServer(addr string, db *sql.DB) *apiConfig {
 	return &apiConfig{
 		addr: addr,
 		db:   database.New(db),
+		conn: db,
 	}
 }
 
@@ -39,28 +65,152 @@ func (s *apiConfig) Run() error {
 	// Define the apiV1 group
 	apiV1 := app.Group("/api/v1")
 
-	// Define the user store and handler
+	// Define the user store, the plan store and the enforcer of plan limits
 	userStore := user.NewStore(s.db)
+	planStore := plan.NewStore(s.db)
+	planEnforcer := plan.NewEnforcer(planStore, userStore)
+
+	// Define the user and plan handlers
 	mailer := email.NewEmailService()
-	userHandler := user.NewHandler(userStore, mailer)
+	userHandler := user.NewHandler(userStore, planStore, planEnforcer, mailer)
+	planHandler := plan.NewHandler(planStore, userStore)
 
 	// Define the event store and handler
 	eventStore := event.NewStore(s.db)
-	eventHandler := event.NewHandler(eventStore, userStore)
+	eventHandler := event.NewHandler(eventStore, userStore, planEnforcer)
+
+	// Record every successful mutation in the v1 group in the audit log
+	auditStore := audit.NewStore(s.db)
+	apiV1.Use(audit.Middleware(auditStore, eventStore, "/api/v1"))
 
 	// Define the email store and handler
 	emailTemplateStore := email.NewStore(s.db)
-	emailHandler := email.NewHandler(emailTemplateStore, eventStore, userStore)
+	emailHandler := email.NewHandler(emailTemplateStore, eventStore, userStore, planEnforcer)
+
+	// Define the suppression store shared by the attendee and suppression handlers and the campaign scheduler
+	suppressionStore := suppression.NewStore(s.db)
+	suppressionHandler := suppression.NewHandler(suppressionStore, userStore)
+
+	// Define the live check-in hub shared by the attendee and check-in handlers
+	liveHub := live.NewHub()
+
+	// Define the seating store shared by the attendee and seating handlers
+	seatingStore := seating.NewStore(s.db, s.conn)
+
+	// Define the session store shared by the attendee, check-in and session handlers
+	sessionStore := session.NewStore(s.db)
+
+	// Define the ticket type store shared by the attendee, badge and ticket handlers
+	ticketTypeStore := ticket.NewStore(s.db)
+
+	// Define the check-in store shared by the attendee and check-in handlers
+	checkInStore := checkin.NewStore(s.db, s.conn)
 
 	// Define the attendee store and handler
-	attendeeStore := attendee.NewStore(s.db)
-	attendeeHandler := attendee.NewHandler(attendeeStore, eventStore, userStore, emailTemplateStore, mailer)
+	attendeeStore := attendee.NewStore(s.db, s.conn)
+	attendeeHandler := attendee.NewHandler(attendeeStore, eventStore, userStore, emailTemplateStore, emailTemplateStore, suppressionStore, seatingStore, checkInStore, sessionStore, ticketTypeStore, planEnforcer, mailer, liveHub)
+
+	// Define the session handler
+	sessionHandler := session.NewHandler(sessionStore, attendeeStore, eventStore, userStore)
+
+	// Define the seating handler
+	seatingHandler := seating.NewHandler(seatingStore, attendeeStore, eventStore, userStore)
+
+	// Define the check-in handler
+	checkInHandler := checkin.NewHandler(checkInStore, checkInStore, sessionStore, attendeeStore, eventStore, userStore, liveHub)
+
+	// Define the report store and handler
+	reportStore := report.NewStore(s.db)
+	reportHandler := report.NewHandler(reportStore, eventStore, userStore)
+
+	// Define the badge handler
+	badgeHandler := badge.NewHandler(attendeeStore, ticketTypeStore, eventStore, userStore)
+
+	// Define the order store, payment provider and handler
+	orderStore := payment.NewStore(s.db)
+	// Without a usable provider the API still starts, with paid registration and payment webhooks disabled
+	paymentProvider, err := payment.NewProvider(config.Envs.PaymentProvider, config.Envs.PaymentWebhookSecret, config.Envs.ISProduction)
+	if err != nil {
+		log.Printf("Warning: payments are disabled: %v", err)
+	}
+	paymentHandler := payment.NewHandler(orderStore, paymentProvider, attendeeStore, ticketTypeStore, eventStore, userStore, planEnforcer, mailer, liveHub)
+
+	// Define the ticket type handler
+	ticketHandler := ticket.NewHandler(ticketTypeStore, eventStore, userStore)
+
+	// Define the custom field store and handler
+	customFieldStore := customfield.NewStore(s.db)
+	customFieldHandler := customfield.NewHandler(customFieldStore, eventStore, userStore)
+
+	// Define the event template store and the handler cloning events
+	eventTemplateStore := clone.NewStore(s.db)
+	cloneHandler := clone.NewHandler(eventTemplateStore, eventStore, emailTemplateStore, customFieldStore, seatingStore, attendeeStore, userStore, planEnforcer)
+
+	// Define the campaign store and handler
+	campaignStore := campaign.NewStore(s.db)
+	campaignHandler := campaign.NewHandler(campaignStore, eventStore, userStore)
+
+	// Define the attendee search store and handler
+	searchHandler := search.NewHandler(search.NewStore(s.conn), eventStore, userStore)
+
+	// Define the handler finding and merging duplicate attendees
+	dedupHandler := dedup.NewHandler(dedup.NewStore(s.db, s.conn), attendeeStore, eventStore, userStore, liveHub)
+
+	// Define the attendee self-service portal handler
+	portalHandler := portal.NewHandler(attendeeStore, eventStore, customFieldStore, ticketTypeStore, userStore, liveHub)
+
+	// Define the handler recording the responses to invitations
+	rsvpHandler := rsvp.NewHandler(attendeeStore, eventStore, liveHub)
+
+	// Define the handler of attendee groups and their guests
+	groupHandler := group.NewHandler(attendeeStore, seatingStore, eventStore, userStore, liveHub)
+
+	// Define the handler recording opens and clicks of tracked emails
+	trackingHandler := tracking.NewHandler(tracking.NewStore(s.db), eventStore, userStore)
+
+	// Define the audit log handler
+	auditHandler := audit.NewHandler(auditStore, userStore)
+
+	// Define the admin console store and handler
+	adminStore := admin.NewStore(s.db)
+	adminHandler := admin.NewHandler(adminStore, userStore, eventStore, attendeeStore)
+
+	// Permanently delete what has been in the trash for longer than the retention period
+	purger := purge.NewPurger(purge.NewStore(s.db), time.Duration(config.Envs.DeletedRetentionDays)*24*time.Hour)
+	purger.Start(time.Hour)
+
+	// Send the campaigns that are due, resuming the ones a restart interrupted
+	scheduler := campaign.NewScheduler(campaignStore, eventStore, attendeeStore, emailTemplateStore, emailTemplateStore, suppressionStore, planEnforcer, mailer)
+	scheduler.Start(time.Minute)
+
+	// Give back the tickets held by registrations left unpaid
+	paymentHandler.StartCheckoutExpiry(time.Minute)
 
 	// Register the routes in v1 group
 	userHandler.RegisterRoutes(apiV1)
+	planHandler.RegisterRoutes(apiV1)
 	eventHandler.RegisterRoutes(apiV1)
 	attendeeHandler.RegisterRoutes(apiV1)
 	emailHandler.RegisterRoutes(apiV1)
+	reportHandler.RegisterRoutes(apiV1)
+	badgeHandler.RegisterRoutes(apiV1)
+	seatingHandler.RegisterRoutes(apiV1)
+	checkInHandler.RegisterRoutes(apiV1)
+	sessionHandler.RegisterRoutes(apiV1)
+	ticketHandler.RegisterRoutes(apiV1)
+	customFieldHandler.RegisterRoutes(apiV1)
+	cloneHandler.RegisterRoutes(apiV1)
+	campaignHandler.RegisterRoutes(apiV1)
+	searchHandler.RegisterRoutes(apiV1)
+	dedupHandler.RegisterRoutes(apiV1)
+	portalHandler.RegisterRoutes(apiV1)
+	rsvpHandler.RegisterRoutes(apiV1)
+	groupHandler.RegisterRoutes(apiV1)
+	trackingHandler.RegisterRoutes(apiV1)
+	suppressionHandler.RegisterRoutes(apiV1)
+	paymentHandler.RegisterRoutes(apiV1)
+	adminHandler.RegisterRoutes(apiV1)
+	auditHandler.RegisterRoutes(apiV1)
 
 	app.Use("/health", func(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{"status": "ok"})
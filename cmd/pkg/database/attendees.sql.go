@@ -8,9 +8,148 @@ package database
 import (
 	"context"
 	"database/sql"
+	"time"
 )
 
-const createAttendee = `-- name: CreateAttendee :exec
+const clearGuestsByPrimaryAttendeeID = `-- name: ClearGuestsByPrimaryAttendeeID :exec
+UPDATE attendees
+SET primary_attendee_id = NULL
+WHERE primary_attendee_id = ?
+`
+
+func (q *Queries) ClearGuestsByPrimaryAttendeeID(ctx context.Context, primaryAttendeeID sql.NullInt32) error {
+	_, err := q.db.ExecContext(ctx, clearGuestsByPrimaryAttendeeID, primaryAttendeeID)
+	return err
+}
+
+const confirmAttendeeRegistration = `-- name: ConfirmAttendeeRegistration :exec
+UPDATE attendees
+SET registration_status = 'confirmed'
+WHERE id = ?
+    AND deleted_at IS NULL
+`
+
+func (q *Queries) ConfirmAttendeeRegistration(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, confirmAttendeeRegistration, id)
+	return err
+}
+
+const countDeletedAttendeesByEventID = `-- name: CountDeletedAttendeesByEventID :one
+SELECT COUNT(*)
+FROM attendees
+WHERE attendees.event_id = ?
+    AND attendees.deleted_at IS NOT NULL
+`
+
+func (q *Queries) CountDeletedAttendeesByEventID(ctx context.Context, eventID int32) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countDeletedAttendeesByEventID, eventID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countFilteredAttendeesByEventID = `-- name: CountFilteredAttendeesByEventID :one
+SELECT COUNT(*)
+FROM attendees
+WHERE attendees.event_id = ?
+    AND attendees.deleted_at IS NULL
+    AND (
+        ? IS NULL
+        OR attendees.role = ?
+    )
+    AND (
+        ? IS NULL
+        OR attendees.company_name = ?
+    )
+    AND (
+        ? IS NULL
+        OR attendees.table_no = ?
+    )
+    AND (
+        ? IS NULL
+        OR attendees.attendance = ?
+    )
+    AND (
+        ? IS NULL
+        OR attendees.rsvp_status = ?
+    )
+    AND (
+        ? IS NULL
+        OR EXISTS (
+            SELECT 1
+            FROM email_deliveries
+                JOIN email_template ON email_template.id = email_deliveries.template_id
+            WHERE email_deliveries.attendee_id = attendees.id
+                AND email_deliveries.status = 'sent'
+                AND email_template.purpose = 'invitation'
+        ) = ?
+    )
+    AND (
+        ? IS NULL
+        OR EXISTS (
+            SELECT 1
+            FROM attendees_custom_fields
+            WHERE attendees_custom_fields.attendee_id = attendees.id
+                AND attendees_custom_fields.field_name = ?
+                AND (
+                    ? IS NULL
+                    OR attendees_custom_fields.field_value = ?
+                )
+        )
+    )
+    AND (
+        ? IS NULL
+        OR attendees.first_name LIKE ?
+        OR attendees.last_name LIKE ?
+        OR attendees.email LIKE ?
+        OR attendees.company_name LIKE ?
+    )
+`
+
+type CountFilteredAttendeesByEventIDParams struct {
+	EventID     int32
+	Role        sql.NullString
+	CompanyName sql.NullString
+	TableNo     sql.NullInt32
+	Attendance  NullAttendeesAttendance
+	RsvpStatus  NullAttendeesRsvpStatus
+	Invited     sql.NullInt32
+	FieldName   sql.NullString
+	FieldValue  sql.NullString
+	Search      sql.NullString
+}
+
+func (q *Queries) CountFilteredAttendeesByEventID(ctx context.Context, arg CountFilteredAttendeesByEventIDParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countFilteredAttendeesByEventID,
+		arg.EventID,
+		arg.Role,
+		arg.Role,
+		arg.CompanyName,
+		arg.CompanyName,
+		arg.TableNo,
+		arg.TableNo,
+		arg.Attendance,
+		arg.Attendance,
+		arg.RsvpStatus,
+		arg.RsvpStatus,
+		arg.Invited,
+		arg.Invited,
+		arg.FieldName,
+		arg.FieldName,
+		arg.FieldValue,
+		arg.FieldValue,
+		arg.Search,
+		arg.Search,
+		arg.Search,
+		arg.Search,
+		arg.Search,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createAttendee = `-- name: CreateAttendee :execlastid
 INSERT INTO attendees (
         first_name,
         last_name,
@@ -21,26 +160,32 @@ INSERT INTO attendees (
         table_no,
         role,
         attendance,
-        event_id
+        event_id,
+        ticket_type_id,
+        registration_status,
+        primary_attendee_id
     )
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateAttendeeParams struct {
-	FirstName   string
-	LastName    string
-	Email       string
-	QrCode      sql.NullString
-	CompanyName sql.NullString
-	Title       sql.NullString
-	TableNo     sql.NullInt32
-	Role        sql.NullString
-	Attendance  NullAttendeesAttendance
-	EventID     int32
+	FirstName          string
+	LastName           string
+	Email              string
+	QrCode             sql.NullString
+	CompanyName        sql.NullString
+	Title              sql.NullString
+	TableNo            sql.NullInt32
+	Role               sql.NullString
+	Attendance         AttendeesAttendance
+	EventID            int32
+	TicketTypeID       sql.NullInt32
+	RegistrationStatus AttendeesRegistrationStatus
+	PrimaryAttendeeID  sql.NullInt32
 }
 
-func (q *Queries) CreateAttendee(ctx context.Context, arg CreateAttendeeParams) error {
-	_, err := q.db.ExecContext(ctx, createAttendee,
+func (q *Queries) CreateAttendee(ctx context.Context, arg CreateAttendeeParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createAttendee,
 		arg.FirstName,
 		arg.LastName,
 		arg.Email,
@@ -51,13 +196,21 @@ func (q *Queries) CreateAttendee(ctx context.Context, arg CreateAttendeeParams)
 		arg.Role,
 		arg.Attendance,
 		arg.EventID,
+		arg.TicketTypeID,
+		arg.RegistrationStatus,
+		arg.PrimaryAttendeeID,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
 }
 
 const deleteAllAttendeesByEventID = `-- name: DeleteAllAttendeesByEventID :exec
-DELETE FROM attendees
+UPDATE attendees
+SET deleted_at = CURRENT_TIMESTAMP
 WHERE event_id = ?
+    AND deleted_at IS NULL
 `
 
 func (q *Queries) DeleteAllAttendeesByEventID(ctx context.Context, eventID int32) error {
@@ -66,8 +219,10 @@ func (q *Queries) DeleteAllAttendeesByEventID(ctx context.Context, eventID int32
 }
 
 const deleteAttendeeByID = `-- name: DeleteAttendeeByID :exec
-DELETE FROM attendees
+UPDATE attendees
+SET deleted_at = CURRENT_TIMESTAMP
 WHERE id = ?
+    AND deleted_at IS NULL
 `
 
 func (q *Queries) DeleteAttendeeByID(ctx context.Context, id int32) error {
@@ -76,9 +231,10 @@ func (q *Queries) DeleteAttendeeByID(ctx context.Context, id int32) error {
 }
 
 const getAllAttendeesByEventID = `-- name: GetAllAttendeesByEventID :many
-SELECT id, first_name, last_name, email, qr_code, company_name, title, table_no, role, attendance, event_id
+SELECT id, first_name, last_name, email, qr_code, company_name, title, table_no, role, event_id, attendance, checked_in_at, updated_at, sessions_attended, ticket_type_id, registration_status, deleted_at, rsvp_status, rsvp_reason, rsvp_plus_ones, rsvp_responded_at, primary_attendee_id
 FROM attendees
 WHERE event_id = ?
+    AND deleted_at IS NULL
 `
 
 func (q *Queries) GetAllAttendeesByEventID(ctx context.Context, eventID int32) ([]Attendee, error) {
@@ -100,8 +256,19 @@ func (q *Queries) GetAllAttendeesByEventID(ctx context.Context, eventID int32) (
 			&i.Title,
 			&i.TableNo,
 			&i.Role,
-			&i.Attendance,
 			&i.EventID,
+			&i.Attendance,
+			&i.CheckedInAt,
+			&i.UpdatedAt,
+			&i.SessionsAttended,
+			&i.TicketTypeID,
+			&i.RegistrationStatus,
+			&i.DeletedAt,
+			&i.RsvpStatus,
+			&i.RsvpReason,
+			&i.RsvpPlusOnes,
+			&i.RsvpRespondedAt,
+			&i.PrimaryAttendeeID,
 		); err != nil {
 			return nil, err
 		}
@@ -117,9 +284,10 @@ func (q *Queries) GetAllAttendeesByEventID(ctx context.Context, eventID int32) (
 }
 
 const getAllAttendeesPaginatedByEventID = `-- name: GetAllAttendeesPaginatedByEventID :many
-SELECT id, first_name, last_name, email, qr_code, company_name, title, table_no, role, attendance, event_id
+SELECT id, first_name, last_name, email, qr_code, company_name, title, table_no, role, event_id, attendance, checked_in_at, updated_at, sessions_attended, ticket_type_id, registration_status, deleted_at, rsvp_status, rsvp_reason, rsvp_plus_ones, rsvp_responded_at, primary_attendee_id
 FROM attendees
 WHERE event_id = ?
+    AND deleted_at IS NULL
 LIMIT ? OFFSET ?
 `
 
@@ -148,8 +316,19 @@ func (q *Queries) GetAllAttendeesPaginatedByEventID(ctx context.Context, arg Get
 			&i.Title,
 			&i.TableNo,
 			&i.Role,
-			&i.Attendance,
 			&i.EventID,
+			&i.Attendance,
+			&i.CheckedInAt,
+			&i.UpdatedAt,
+			&i.SessionsAttended,
+			&i.TicketTypeID,
+			&i.RegistrationStatus,
+			&i.DeletedAt,
+			&i.RsvpStatus,
+			&i.RsvpReason,
+			&i.RsvpPlusOnes,
+			&i.RsvpRespondedAt,
+			&i.PrimaryAttendeeID,
 		); err != nil {
 			return nil, err
 		}
@@ -164,10 +343,195 @@ func (q *Queries) GetAllAttendeesPaginatedByEventID(ctx context.Context, arg Get
 	return items, nil
 }
 
+const getAttendanceByCompanyByEventID = `-- name: GetAttendanceByCompanyByEventID :many
+SELECT COALESCE(company_name, '') AS company_name,
+    COUNT(*) AS expected,
+    CAST(COALESCE(SUM(attendance = 'Yes'), 0) AS SIGNED) AS checked_in
+FROM attendees
+WHERE event_id = ?
+    AND deleted_at IS NULL
+GROUP BY COALESCE(company_name, '')
+ORDER BY expected DESC,
+    company_name
+`
+
+type GetAttendanceByCompanyByEventIDRow struct {
+	CompanyName string
+	Expected    int64
+	CheckedIn   int64
+}
+
+func (q *Queries) GetAttendanceByCompanyByEventID(ctx context.Context, eventID int32) ([]GetAttendanceByCompanyByEventIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAttendanceByCompanyByEventID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAttendanceByCompanyByEventIDRow
+	for rows.Next() {
+		var i GetAttendanceByCompanyByEventIDRow
+		if err := rows.Scan(&i.CompanyName, &i.Expected, &i.CheckedIn); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAttendanceByRoleByEventID = `-- name: GetAttendanceByRoleByEventID :many
+SELECT COALESCE(role, '') AS role,
+    COUNT(*) AS expected,
+    CAST(COALESCE(SUM(attendance = 'Yes'), 0) AS SIGNED) AS checked_in
+FROM attendees
+WHERE event_id = ?
+    AND deleted_at IS NULL
+GROUP BY COALESCE(role, '')
+ORDER BY role
+`
+
+type GetAttendanceByRoleByEventIDRow struct {
+	Role      string
+	Expected  int64
+	CheckedIn int64
+}
+
+func (q *Queries) GetAttendanceByRoleByEventID(ctx context.Context, eventID int32) ([]GetAttendanceByRoleByEventIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAttendanceByRoleByEventID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAttendanceByRoleByEventIDRow
+	for rows.Next() {
+		var i GetAttendanceByRoleByEventIDRow
+		if err := rows.Scan(&i.Role, &i.Expected, &i.CheckedIn); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAttendanceByTableByEventID = `-- name: GetAttendanceByTableByEventID :many
+SELECT COALESCE(table_no, 0) AS table_no,
+    COUNT(*) AS expected,
+    CAST(COALESCE(SUM(attendance = 'Yes'), 0) AS SIGNED) AS checked_in
+FROM attendees
+WHERE event_id = ?
+    AND deleted_at IS NULL
+GROUP BY COALESCE(table_no, 0)
+ORDER BY table_no
+`
+
+type GetAttendanceByTableByEventIDRow struct {
+	TableNo   int32
+	Expected  int64
+	CheckedIn int64
+}
+
+func (q *Queries) GetAttendanceByTableByEventID(ctx context.Context, eventID int32) ([]GetAttendanceByTableByEventIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAttendanceByTableByEventID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAttendanceByTableByEventIDRow
+	for rows.Next() {
+		var i GetAttendanceByTableByEventIDRow
+		if err := rows.Scan(&i.TableNo, &i.Expected, &i.CheckedIn); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAttendanceByTicketTypeByEventID = `-- name: GetAttendanceByTicketTypeByEventID :many
+SELECT COALESCE(ticket_types.name, '') AS ticket_type,
+    COUNT(*) AS expected,
+    CAST(
+        COALESCE(SUM(attendees.attendance = 'Yes'), 0) AS SIGNED
+    ) AS checked_in
+FROM attendees
+    LEFT JOIN ticket_types ON ticket_types.id = attendees.ticket_type_id
+WHERE attendees.event_id = ?
+    AND attendees.deleted_at IS NULL
+GROUP BY COALESCE(ticket_types.name, '')
+ORDER BY ticket_type
+`
+
+type GetAttendanceByTicketTypeByEventIDRow struct {
+	TicketType string
+	Expected   int64
+	CheckedIn  int64
+}
+
+func (q *Queries) GetAttendanceByTicketTypeByEventID(ctx context.Context, eventID int32) ([]GetAttendanceByTicketTypeByEventIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAttendanceByTicketTypeByEventID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAttendanceByTicketTypeByEventIDRow
+	for rows.Next() {
+		var i GetAttendanceByTicketTypeByEventIDRow
+		if err := rows.Scan(&i.TicketType, &i.Expected, &i.CheckedIn); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAttendanceSummaryByEventID = `-- name: GetAttendanceSummaryByEventID :one
+SELECT COUNT(*) AS expected,
+    CAST(COALESCE(SUM(attendance = 'Yes'), 0) AS SIGNED) AS checked_in
+FROM attendees
+WHERE event_id = ?
+    AND deleted_at IS NULL
+`
+
+type GetAttendanceSummaryByEventIDRow struct {
+	Expected  int64
+	CheckedIn int64
+}
+
+func (q *Queries) GetAttendanceSummaryByEventID(ctx context.Context, eventID int32) (GetAttendanceSummaryByEventIDRow, error) {
+	row := q.db.QueryRowContext(ctx, getAttendanceSummaryByEventID, eventID)
+	var i GetAttendanceSummaryByEventIDRow
+	err := row.Scan(&i.Expected, &i.CheckedIn)
+	return i, err
+}
+
 const getAttendeeByEmail = `-- name: GetAttendeeByEmail :one
-SELECT id, first_name, last_name, email, qr_code, company_name, title, table_no, role, attendance, event_id
+SELECT id, first_name, last_name, email, qr_code, company_name, title, table_no, role, event_id, attendance, checked_in_at, updated_at, sessions_attended, ticket_type_id, registration_status, deleted_at, rsvp_status, rsvp_reason, rsvp_plus_ones, rsvp_responded_at, primary_attendee_id
 FROM attendees
 WHERE email = ?
+    AND deleted_at IS NULL
 `
 
 func (q *Queries) GetAttendeeByEmail(ctx context.Context, email string) (Attendee, error) {
@@ -183,16 +547,71 @@ func (q *Queries) GetAttendeeByEmail(ctx context.Context, email string) (Attende
 		&i.Title,
 		&i.TableNo,
 		&i.Role,
+		&i.EventID,
 		&i.Attendance,
+		&i.CheckedInAt,
+		&i.UpdatedAt,
+		&i.SessionsAttended,
+		&i.TicketTypeID,
+		&i.RegistrationStatus,
+		&i.DeletedAt,
+		&i.RsvpStatus,
+		&i.RsvpReason,
+		&i.RsvpPlusOnes,
+		&i.RsvpRespondedAt,
+		&i.PrimaryAttendeeID,
+	)
+	return i, err
+}
+
+const getAttendeeByEmailAndEventID = `-- name: GetAttendeeByEmailAndEventID :one
+SELECT id, first_name, last_name, email, qr_code, company_name, title, table_no, role, event_id, attendance, checked_in_at, updated_at, sessions_attended, ticket_type_id, registration_status, deleted_at, rsvp_status, rsvp_reason, rsvp_plus_ones, rsvp_responded_at, primary_attendee_id
+FROM attendees
+WHERE email = ?
+    AND event_id = ?
+    AND deleted_at IS NULL
+`
+
+type GetAttendeeByEmailAndEventIDParams struct {
+	Email   string
+	EventID int32
+}
+
+func (q *Queries) GetAttendeeByEmailAndEventID(ctx context.Context, arg GetAttendeeByEmailAndEventIDParams) (Attendee, error) {
+	row := q.db.QueryRowContext(ctx, getAttendeeByEmailAndEventID, arg.Email, arg.EventID)
+	var i Attendee
+	err := row.Scan(
+		&i.ID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.QrCode,
+		&i.CompanyName,
+		&i.Title,
+		&i.TableNo,
+		&i.Role,
 		&i.EventID,
+		&i.Attendance,
+		&i.CheckedInAt,
+		&i.UpdatedAt,
+		&i.SessionsAttended,
+		&i.TicketTypeID,
+		&i.RegistrationStatus,
+		&i.DeletedAt,
+		&i.RsvpStatus,
+		&i.RsvpReason,
+		&i.RsvpPlusOnes,
+		&i.RsvpRespondedAt,
+		&i.PrimaryAttendeeID,
 	)
 	return i, err
 }
 
 const getAttendeeByID = `-- name: GetAttendeeByID :one
-SELECT id, first_name, last_name, email, qr_code, company_name, title, table_no, role, attendance, event_id
+SELECT id, first_name, last_name, email, qr_code, company_name, title, table_no, role, event_id, attendance, checked_in_at, updated_at, sessions_attended, ticket_type_id, registration_status, deleted_at, rsvp_status, rsvp_reason, rsvp_plus_ones, rsvp_responded_at, primary_attendee_id
 FROM attendees
 WHERE id = ?
+    AND deleted_at IS NULL
 `
 
 func (q *Queries) GetAttendeeByID(ctx context.Context, id int32) (Attendee, error) {
@@ -208,16 +627,63 @@ func (q *Queries) GetAttendeeByID(ctx context.Context, id int32) (Attendee, erro
 		&i.Title,
 		&i.TableNo,
 		&i.Role,
-		&i.Attendance,
 		&i.EventID,
+		&i.Attendance,
+		&i.CheckedInAt,
+		&i.UpdatedAt,
+		&i.SessionsAttended,
+		&i.TicketTypeID,
+		&i.RegistrationStatus,
+		&i.DeletedAt,
+		&i.RsvpStatus,
+		&i.RsvpReason,
+		&i.RsvpPlusOnes,
+		&i.RsvpRespondedAt,
+		&i.PrimaryAttendeeID,
 	)
 	return i, err
 }
 
+const getAttendeesDeletedBefore = `-- name: GetAttendeesDeletedBefore :many
+SELECT id,
+    qr_code
+FROM attendees
+WHERE deleted_at < ?
+`
+
+type GetAttendeesDeletedBeforeRow struct {
+	ID     int32
+	QrCode sql.NullString
+}
+
+func (q *Queries) GetAttendeesDeletedBefore(ctx context.Context, deletedAt sql.NullTime) ([]GetAttendeesDeletedBeforeRow, error) {
+	rows, err := q.db.QueryContext(ctx, getAttendeesDeletedBefore, deletedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAttendeesDeletedBeforeRow
+	for rows.Next() {
+		var i GetAttendeesDeletedBeforeRow
+		if err := rows.Scan(&i.ID, &i.QrCode); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getAttendeesRowCountByEventID = `-- name: GetAttendeesRowCountByEventID :one
 SELECT COUNT(*)
 FROM attendees
 WHERE event_id = ?
+    AND deleted_at IS NULL
 `
 
 func (q *Queries) GetAttendeesRowCountByEventID(ctx context.Context, eventID int32) (int64, error) {
@@ -227,45 +693,893 @@ func (q *Queries) GetAttendeesRowCountByEventID(ctx context.Context, eventID int
 	return count, err
 }
 
-const updateAttendeeByID = `-- name: UpdateAttendeeByID :exec
-UPDATE attendees
-SET first_name = ?,
-    last_name = ?,
-    email = ?,
-    qr_code = ?,
-    company_name = ?,
-    title = ?,
-    table_no = ?,
-    role = ?,
-    attendance = ?
-WHERE id = ?
+const getCheckInsPerMinuteByEventID = `-- name: GetCheckInsPerMinuteByEventID :many
+SELECT CAST(
+        TIMESTAMPDIFF(MINUTE, '1970-01-01 00:00:00', checked_in_at) AS SIGNED
+    ) AS minute,
+    COUNT(*) AS check_ins
+FROM attendees
+WHERE event_id = ?
+    AND attendance = 'Yes'
+    AND checked_in_at IS NOT NULL
+    AND deleted_at IS NULL
+GROUP BY minute
+ORDER BY minute
 `
 
-type UpdateAttendeeByIDParams struct {
-	FirstName   string
-	LastName    string
-	Email       string
-	QrCode      sql.NullString
-	CompanyName sql.NullString
-	Title       sql.NullString
-	TableNo     sql.NullInt32
-	Role        sql.NullString
-	Attendance  NullAttendeesAttendance
-	ID          int32
+type GetCheckInsPerMinuteByEventIDRow struct {
+	Minute   int64
+	CheckIns int64
 }
 
-func (q *Queries) UpdateAttendeeByID(ctx context.Context, arg UpdateAttendeeByIDParams) error {
-	_, err := q.db.ExecContext(ctx, updateAttendeeByID,
-		arg.FirstName,
-		arg.LastName,
-		arg.Email,
-		arg.QrCode,
-		arg.CompanyName,
-		arg.Title,
-		arg.TableNo,
-		arg.Role,
-		arg.Attendance,
-		arg.ID,
-	)
+func (q *Queries) GetCheckInsPerMinuteByEventID(ctx context.Context, eventID int32) ([]GetCheckInsPerMinuteByEventIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getCheckInsPerMinuteByEventID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCheckInsPerMinuteByEventIDRow
+	for rows.Next() {
+		var i GetCheckInsPerMinuteByEventIDRow
+		if err := rows.Scan(&i.Minute, &i.CheckIns); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDeletedAttendeeByID = `-- name: GetDeletedAttendeeByID :one
+SELECT id, first_name, last_name, email, qr_code, company_name, title, table_no, role, event_id, attendance, checked_in_at, updated_at, sessions_attended, ticket_type_id, registration_status, deleted_at, rsvp_status, rsvp_reason, rsvp_plus_ones, rsvp_responded_at, primary_attendee_id
+FROM attendees
+WHERE id = ?
+    AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) GetDeletedAttendeeByID(ctx context.Context, id int32) (Attendee, error) {
+	row := q.db.QueryRowContext(ctx, getDeletedAttendeeByID, id)
+	var i Attendee
+	err := row.Scan(
+		&i.ID,
+		&i.FirstName,
+		&i.LastName,
+		&i.Email,
+		&i.QrCode,
+		&i.CompanyName,
+		&i.Title,
+		&i.TableNo,
+		&i.Role,
+		&i.EventID,
+		&i.Attendance,
+		&i.CheckedInAt,
+		&i.UpdatedAt,
+		&i.SessionsAttended,
+		&i.TicketTypeID,
+		&i.RegistrationStatus,
+		&i.DeletedAt,
+		&i.RsvpStatus,
+		&i.RsvpReason,
+		&i.RsvpPlusOnes,
+		&i.RsvpRespondedAt,
+		&i.PrimaryAttendeeID,
+	)
+	return i, err
+}
+
+const getDeletedAttendeesByEventID = `-- name: GetDeletedAttendeesByEventID :many
+SELECT id, first_name, last_name, email, qr_code, company_name, title, table_no, role, event_id, attendance, checked_in_at, updated_at, sessions_attended, ticket_type_id, registration_status, deleted_at, rsvp_status, rsvp_reason, rsvp_plus_ones, rsvp_responded_at, primary_attendee_id
+FROM attendees
+WHERE event_id = ?
+    AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC,
+    id
+`
+
+func (q *Queries) GetDeletedAttendeesByEventID(ctx context.Context, eventID int32) ([]Attendee, error) {
+	rows, err := q.db.QueryContext(ctx, getDeletedAttendeesByEventID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Attendee
+	for rows.Next() {
+		var i Attendee
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.QrCode,
+			&i.CompanyName,
+			&i.Title,
+			&i.TableNo,
+			&i.Role,
+			&i.EventID,
+			&i.Attendance,
+			&i.CheckedInAt,
+			&i.UpdatedAt,
+			&i.SessionsAttended,
+			&i.TicketTypeID,
+			&i.RegistrationStatus,
+			&i.DeletedAt,
+			&i.RsvpStatus,
+			&i.RsvpReason,
+			&i.RsvpPlusOnes,
+			&i.RsvpRespondedAt,
+			&i.PrimaryAttendeeID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDeletedAttendeesPaginated = `-- name: GetDeletedAttendeesPaginated :many
+SELECT id, first_name, last_name, email, qr_code, company_name, title, table_no, role, event_id, attendance, checked_in_at, updated_at, sessions_attended, ticket_type_id, registration_status, deleted_at, rsvp_status, rsvp_reason, rsvp_plus_ones, rsvp_responded_at, primary_attendee_id
+FROM attendees
+WHERE attendees.event_id = ?
+    AND attendees.deleted_at IS NOT NULL
+    AND (
+        ? IS NULL
+        OR attendees.deleted_at < ?
+        OR (
+            attendees.deleted_at = ?
+            AND attendees.id < ?
+        )
+    )
+ORDER BY attendees.deleted_at DESC,
+    attendees.id DESC
+LIMIT ?
+`
+
+type GetDeletedAttendeesPaginatedParams struct {
+	EventID        int32
+	AfterID        sql.NullInt32
+	AfterDeletedAt sql.NullTime
+	Limit          int32
+}
+
+func (q *Queries) GetDeletedAttendeesPaginated(ctx context.Context, arg GetDeletedAttendeesPaginatedParams) ([]Attendee, error) {
+	rows, err := q.db.QueryContext(ctx, getDeletedAttendeesPaginated,
+		arg.EventID,
+		arg.AfterID,
+		arg.AfterDeletedAt,
+		arg.AfterDeletedAt,
+		arg.AfterID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Attendee
+	for rows.Next() {
+		var i Attendee
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.QrCode,
+			&i.CompanyName,
+			&i.Title,
+			&i.TableNo,
+			&i.Role,
+			&i.EventID,
+			&i.Attendance,
+			&i.CheckedInAt,
+			&i.UpdatedAt,
+			&i.SessionsAttended,
+			&i.TicketTypeID,
+			&i.RegistrationStatus,
+			&i.DeletedAt,
+			&i.RsvpStatus,
+			&i.RsvpReason,
+			&i.RsvpPlusOnes,
+			&i.RsvpRespondedAt,
+			&i.PrimaryAttendeeID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getFilteredAttendeesByEventID = `-- name: GetFilteredAttendeesByEventID :many
+SELECT attendees.id, attendees.first_name, attendees.last_name, attendees.email, attendees.qr_code, attendees.company_name, attendees.title, attendees.table_no, attendees.role, attendees.event_id, attendees.attendance, attendees.checked_in_at, attendees.updated_at, attendees.sessions_attended, attendees.ticket_type_id, attendees.registration_status, attendees.deleted_at, attendees.rsvp_status, attendees.rsvp_reason, attendees.rsvp_plus_ones, attendees.rsvp_responded_at, attendees.primary_attendee_id,
+    CONCAT(
+        CASE
+            ?
+            WHEN 'name' THEN CONCAT(attendees.last_name, ' ', attendees.first_name)
+            WHEN 'email' THEN attendees.email
+            WHEN 'company_name' THEN COALESCE(attendees.company_name, '')
+            WHEN 'role' THEN COALESCE(attendees.role, '')
+            WHEN 'table_no' THEN COALESCE(attendees.table_no, 0)
+            WHEN 'checked_in_at' THEN COALESCE(attendees.checked_in_at, TIMESTAMP('1000-01-01'))
+            ELSE ''
+        END
+    ) AS sort_key
+FROM attendees
+WHERE attendees.event_id = ?
+    AND attendees.deleted_at IS NULL
+    AND (
+        ? IS NULL
+        OR attendees.role = ?
+    )
+    AND (
+        ? IS NULL
+        OR attendees.company_name = ?
+    )
+    AND (
+        ? IS NULL
+        OR attendees.table_no = ?
+    )
+    AND (
+        ? IS NULL
+        OR attendees.attendance = ?
+    )
+    AND (
+        ? IS NULL
+        OR attendees.rsvp_status = ?
+    )
+    AND (
+        ? IS NULL
+        OR EXISTS (
+            SELECT 1
+            FROM email_deliveries
+                JOIN email_template ON email_template.id = email_deliveries.template_id
+            WHERE email_deliveries.attendee_id = attendees.id
+                AND email_deliveries.status = 'sent'
+                AND email_template.purpose = 'invitation'
+        ) = ?
+    )
+    AND (
+        ? IS NULL
+        OR EXISTS (
+            SELECT 1
+            FROM attendees_custom_fields
+            WHERE attendees_custom_fields.attendee_id = attendees.id
+                AND attendees_custom_fields.field_name = ?
+                AND (
+                    ? IS NULL
+                    OR attendees_custom_fields.field_value = ?
+                )
+        )
+    )
+    AND (
+        ? IS NULL
+        OR attendees.first_name LIKE ?
+        OR attendees.last_name LIKE ?
+        OR attendees.email LIKE ?
+        OR attendees.company_name LIKE ?
+    )
+    AND (
+        ? IS NULL
+        OR NOT sqlc.arg(descending)
+        AND (
+            ? = 'email'
+            AND (
+                attendees.email > ?
+                OR attendees.email = ?
+                AND attendees.id > ?
+            )
+            OR ? = 'name'
+            AND (
+                CONCAT(attendees.last_name, ' ', attendees.first_name) > ?
+                OR CONCAT(attendees.last_name, ' ', attendees.first_name) = ?
+                AND attendees.id > ?
+            )
+            OR ? = 'company_name'
+            AND (
+                COALESCE(attendees.company_name, '') > ?
+                OR COALESCE(attendees.company_name, '') = ?
+                AND attendees.id > ?
+            )
+            OR ? = 'role'
+            AND (
+                COALESCE(attendees.role, '') > ?
+                OR COALESCE(attendees.role, '') = ?
+                AND attendees.id > ?
+            )
+            OR ? = 'table_no'
+            AND (
+                COALESCE(attendees.table_no, 0) > ?
+                OR COALESCE(attendees.table_no, 0) = ?
+                AND attendees.id > ?
+            )
+            OR ? = 'checked_in_at'
+            AND (
+                COALESCE(attendees.checked_in_at, TIMESTAMP('1000-01-01')) > ?
+                OR COALESCE(attendees.checked_in_at, TIMESTAMP('1000-01-01')) = ?
+                AND attendees.id > ?
+            )
+            OR ? = ''
+            AND attendees.id > ?
+        )
+        OR ?
+        AND (
+            ? = 'email'
+            AND (
+                attendees.email < ?
+                OR attendees.email = ?
+                AND attendees.id < ?
+            )
+            OR ? = 'name'
+            AND (
+                CONCAT(attendees.last_name, ' ', attendees.first_name) < ?
+                OR CONCAT(attendees.last_name, ' ', attendees.first_name) = ?
+                AND attendees.id < ?
+            )
+            OR ? = 'company_name'
+            AND (
+                COALESCE(attendees.company_name, '') < ?
+                OR COALESCE(attendees.company_name, '') = ?
+                AND attendees.id < ?
+            )
+            OR ? = 'role'
+            AND (
+                COALESCE(attendees.role, '') < ?
+                OR COALESCE(attendees.role, '') = ?
+                AND attendees.id < ?
+            )
+            OR ? = 'table_no'
+            AND (
+                COALESCE(attendees.table_no, 0) < ?
+                OR COALESCE(attendees.table_no, 0) = ?
+                AND attendees.id < ?
+            )
+            OR ? = 'checked_in_at'
+            AND (
+                COALESCE(attendees.checked_in_at, TIMESTAMP('1000-01-01')) < ?
+                OR COALESCE(attendees.checked_in_at, TIMESTAMP('1000-01-01')) = ?
+                AND attendees.id < ?
+            )
+            OR ? = ''
+            AND attendees.id < ?
+        )
+    )
+ORDER BY CASE
+        WHEN ?
+        AND ? = 'table_no' THEN COALESCE(attendees.table_no, 0)
+    END DESC,
+    CASE
+        WHEN ?
+        AND ? = 'checked_in_at' THEN COALESCE(attendees.checked_in_at, TIMESTAMP('1000-01-01'))
+    END DESC,
+    CASE
+        WHEN ? THEN CASE
+            ?
+            WHEN 'name' THEN CONCAT(attendees.last_name, ' ', attendees.first_name)
+            WHEN 'email' THEN attendees.email
+            WHEN 'company_name' THEN COALESCE(attendees.company_name, '')
+            WHEN 'role' THEN COALESCE(attendees.role, '')
+        END
+    END DESC,
+    CASE
+        WHEN ? THEN attendees.id
+    END DESC,
+    CASE
+        WHEN ? = 'table_no' THEN COALESCE(attendees.table_no, 0)
+    END,
+    CASE
+        WHEN ? = 'checked_in_at' THEN COALESCE(attendees.checked_in_at, TIMESTAMP('1000-01-01'))
+    END,
+    CASE
+        ?
+        WHEN 'name' THEN CONCAT(attendees.last_name, ' ', attendees.first_name)
+        WHEN 'email' THEN attendees.email
+        WHEN 'company_name' THEN COALESCE(attendees.company_name, '')
+        WHEN 'role' THEN COALESCE(attendees.role, '')
+    END,
+    attendees.id
+LIMIT ?
+`
+
+type GetFilteredAttendeesByEventIDParams struct {
+	SortColumn       interface{}
+	EventID          int32
+	Role             sql.NullString
+	CompanyName      sql.NullString
+	TableNo          sql.NullInt32
+	Attendance       NullAttendeesAttendance
+	RsvpStatus       NullAttendeesRsvpStatus
+	Invited          sql.NullInt32
+	FieldName        sql.NullString
+	FieldValue       sql.NullString
+	Search           sql.NullString
+	AfterID          sql.NullInt32
+	AfterKey         sql.NullString
+	AfterTableNo     sql.NullInt32
+	AfterCheckedInAt sql.NullTime
+	Descending       interface{}
+	Limit            int32
+}
+
+type GetFilteredAttendeesByEventIDRow struct {
+	ID                 int32
+	FirstName          string
+	LastName           string
+	Email              string
+	QrCode             sql.NullString
+	CompanyName        sql.NullString
+	Title              sql.NullString
+	TableNo            sql.NullInt32
+	Role               sql.NullString
+	EventID            int32
+	Attendance         AttendeesAttendance
+	CheckedInAt        sql.NullTime
+	UpdatedAt          time.Time
+	SessionsAttended   int32
+	TicketTypeID       sql.NullInt32
+	RegistrationStatus AttendeesRegistrationStatus
+	DeletedAt          sql.NullTime
+	RsvpStatus         AttendeesRsvpStatus
+	RsvpReason         sql.NullString
+	RsvpPlusOnes       int32
+	RsvpRespondedAt    sql.NullTime
+	PrimaryAttendeeID  sql.NullInt32
+	SortKey            string
+}
+
+func (q *Queries) GetFilteredAttendeesByEventID(ctx context.Context, arg GetFilteredAttendeesByEventIDParams) ([]GetFilteredAttendeesByEventIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getFilteredAttendeesByEventID,
+		arg.SortColumn,
+		arg.EventID,
+		arg.Role,
+		arg.Role,
+		arg.CompanyName,
+		arg.CompanyName,
+		arg.TableNo,
+		arg.TableNo,
+		arg.Attendance,
+		arg.Attendance,
+		arg.RsvpStatus,
+		arg.RsvpStatus,
+		arg.Invited,
+		arg.Invited,
+		arg.FieldName,
+		arg.FieldName,
+		arg.FieldValue,
+		arg.FieldValue,
+		arg.Search,
+		arg.Search,
+		arg.Search,
+		arg.Search,
+		arg.Search,
+		arg.AfterID,
+		arg.SortColumn,
+		arg.AfterKey,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.SortColumn,
+		arg.AfterKey,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.SortColumn,
+		arg.AfterKey,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.SortColumn,
+		arg.AfterKey,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.SortColumn,
+		arg.AfterTableNo,
+		arg.AfterTableNo,
+		arg.AfterID,
+		arg.SortColumn,
+		arg.AfterCheckedInAt,
+		arg.AfterCheckedInAt,
+		arg.AfterID,
+		arg.SortColumn,
+		arg.AfterID,
+		arg.Descending,
+		arg.SortColumn,
+		arg.AfterKey,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.SortColumn,
+		arg.AfterKey,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.SortColumn,
+		arg.AfterKey,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.SortColumn,
+		arg.AfterKey,
+		arg.AfterKey,
+		arg.AfterID,
+		arg.SortColumn,
+		arg.AfterTableNo,
+		arg.AfterTableNo,
+		arg.AfterID,
+		arg.SortColumn,
+		arg.AfterCheckedInAt,
+		arg.AfterCheckedInAt,
+		arg.AfterID,
+		arg.SortColumn,
+		arg.AfterID,
+		arg.Descending,
+		arg.SortColumn,
+		arg.Descending,
+		arg.SortColumn,
+		arg.Descending,
+		arg.SortColumn,
+		arg.Descending,
+		arg.SortColumn,
+		arg.SortColumn,
+		arg.SortColumn,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetFilteredAttendeesByEventIDRow
+	for rows.Next() {
+		var i GetFilteredAttendeesByEventIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.QrCode,
+			&i.CompanyName,
+			&i.Title,
+			&i.TableNo,
+			&i.Role,
+			&i.EventID,
+			&i.Attendance,
+			&i.CheckedInAt,
+			&i.UpdatedAt,
+			&i.SessionsAttended,
+			&i.TicketTypeID,
+			&i.RegistrationStatus,
+			&i.DeletedAt,
+			&i.RsvpStatus,
+			&i.RsvpReason,
+			&i.RsvpPlusOnes,
+			&i.RsvpRespondedAt,
+			&i.PrimaryAttendeeID,
+			&i.SortKey,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getGuestsByPrimaryAttendeeID = `-- name: GetGuestsByPrimaryAttendeeID :many
+SELECT id, first_name, last_name, email, qr_code, company_name, title, table_no, role, event_id, attendance, checked_in_at, updated_at, sessions_attended, ticket_type_id, registration_status, deleted_at, rsvp_status, rsvp_reason, rsvp_plus_ones, rsvp_responded_at, primary_attendee_id
+FROM attendees
+WHERE primary_attendee_id = ?
+    AND deleted_at IS NULL
+ORDER BY id
+`
+
+func (q *Queries) GetGuestsByPrimaryAttendeeID(ctx context.Context, primaryAttendeeID sql.NullInt32) ([]Attendee, error) {
+	rows, err := q.db.QueryContext(ctx, getGuestsByPrimaryAttendeeID, primaryAttendeeID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Attendee
+	for rows.Next() {
+		var i Attendee
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.QrCode,
+			&i.CompanyName,
+			&i.Title,
+			&i.TableNo,
+			&i.Role,
+			&i.EventID,
+			&i.Attendance,
+			&i.CheckedInAt,
+			&i.UpdatedAt,
+			&i.SessionsAttended,
+			&i.TicketTypeID,
+			&i.RegistrationStatus,
+			&i.DeletedAt,
+			&i.RsvpStatus,
+			&i.RsvpReason,
+			&i.RsvpPlusOnes,
+			&i.RsvpRespondedAt,
+			&i.PrimaryAttendeeID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNoShowsByEventID = `-- name: GetNoShowsByEventID :many
+SELECT id, first_name, last_name, email, qr_code, company_name, title, table_no, role, event_id, attendance, checked_in_at, updated_at, sessions_attended, ticket_type_id, registration_status, deleted_at, rsvp_status, rsvp_reason, rsvp_plus_ones, rsvp_responded_at, primary_attendee_id
+FROM attendees
+WHERE event_id = ?
+    AND attendance = 'No'
+    AND deleted_at IS NULL
+ORDER BY last_name,
+    first_name
+`
+
+func (q *Queries) GetNoShowsByEventID(ctx context.Context, eventID int32) ([]Attendee, error) {
+	rows, err := q.db.QueryContext(ctx, getNoShowsByEventID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Attendee
+	for rows.Next() {
+		var i Attendee
+		if err := rows.Scan(
+			&i.ID,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.QrCode,
+			&i.CompanyName,
+			&i.Title,
+			&i.TableNo,
+			&i.Role,
+			&i.EventID,
+			&i.Attendance,
+			&i.CheckedInAt,
+			&i.UpdatedAt,
+			&i.SessionsAttended,
+			&i.TicketTypeID,
+			&i.RegistrationStatus,
+			&i.DeletedAt,
+			&i.RsvpStatus,
+			&i.RsvpReason,
+			&i.RsvpPlusOnes,
+			&i.RsvpRespondedAt,
+			&i.PrimaryAttendeeID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getQrCodesByEventID = `-- name: GetQrCodesByEventID :many
+SELECT qr_code
+FROM attendees
+WHERE event_id = ?
+    AND qr_code IS NOT NULL
+`
+
+func (q *Queries) GetQrCodesByEventID(ctx context.Context, eventID int32) ([]sql.NullString, error) {
+	rows, err := q.db.QueryContext(ctx, getQrCodesByEventID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []sql.NullString
+	for rows.Next() {
+		var qr_code sql.NullString
+		if err := rows.Scan(&qr_code); err != nil {
+			return nil, err
+		}
+		items = append(items, qr_code)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRsvpCountsByEventID = `-- name: GetRsvpCountsByEventID :many
+SELECT rsvp_status,
+    COUNT(*) AS attendees,
+    CAST(COALESCE(SUM(rsvp_plus_ones), 0) AS SIGNED) AS plus_ones
+FROM attendees
+WHERE event_id = ?
+    AND deleted_at IS NULL
+GROUP BY rsvp_status
+`
+
+type GetRsvpCountsByEventIDRow struct {
+	RsvpStatus AttendeesRsvpStatus
+	Attendees  int64
+	PlusOnes   int64
+}
+
+func (q *Queries) GetRsvpCountsByEventID(ctx context.Context, eventID int32) ([]GetRsvpCountsByEventIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getRsvpCountsByEventID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetRsvpCountsByEventIDRow
+	for rows.Next() {
+		var i GetRsvpCountsByEventIDRow
+		if err := rows.Scan(&i.RsvpStatus, &i.Attendees, &i.PlusOnes); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeAttendeeByID = `-- name: PurgeAttendeeByID :exec
+DELETE FROM attendees
+WHERE id = ?
+    AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) PurgeAttendeeByID(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, purgeAttendeeByID, id)
+	return err
+}
+
+const purgeAttendeesByEventID = `-- name: PurgeAttendeesByEventID :exec
+DELETE FROM attendees
+WHERE event_id = (
+        SELECT event_id
+        FROM events
+        WHERE events.event_id = ?
+            AND events.deleted_at IS NOT NULL
+    )
+`
+
+func (q *Queries) PurgeAttendeesByEventID(ctx context.Context, eventID int32) error {
+	_, err := q.db.ExecContext(ctx, purgeAttendeesByEventID, eventID)
+	return err
+}
+
+const restoreAttendeeByID = `-- name: RestoreAttendeeByID :exec
+UPDATE attendees
+SET deleted_at = NULL
+WHERE id = ?
+`
+
+func (q *Queries) RestoreAttendeeByID(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, restoreAttendeeByID, id)
+	return err
+}
+
+const updateAttendeeByID = `-- name: UpdateAttendeeByID :exec
+UPDATE attendees
+SET first_name = ?,
+    last_name = ?,
+    email = ?,
+    qr_code = ?,
+    company_name = ?,
+    title = ?,
+    table_no = ?,
+    role = ?,
+    attendance = ?,
+    ticket_type_id = ?
+WHERE id = ?
+    AND deleted_at IS NULL
+`
+
+type UpdateAttendeeByIDParams struct {
+	FirstName    string
+	LastName     string
+	Email        string
+	QrCode       sql.NullString
+	CompanyName  sql.NullString
+	Title        sql.NullString
+	TableNo      sql.NullInt32
+	Role         sql.NullString
+	Attendance   AttendeesAttendance
+	TicketTypeID sql.NullInt32
+	ID           int32
+}
+
+func (q *Queries) UpdateAttendeeByID(ctx context.Context, arg UpdateAttendeeByIDParams) error {
+	_, err := q.db.ExecContext(ctx, updateAttendeeByID,
+		arg.FirstName,
+		arg.LastName,
+		arg.Email,
+		arg.QrCode,
+		arg.CompanyName,
+		arg.Title,
+		arg.TableNo,
+		arg.Role,
+		arg.Attendance,
+		arg.TicketTypeID,
+		arg.ID,
+	)
+	return err
+}
+
+const updateAttendeeRsvpByID = `-- name: UpdateAttendeeRsvpByID :exec
+UPDATE attendees
+SET rsvp_status = ?,
+    rsvp_reason = ?,
+    rsvp_plus_ones = ?,
+    rsvp_responded_at = CURRENT_TIMESTAMP
+WHERE id = ?
+    AND deleted_at IS NULL
+`
+
+type UpdateAttendeeRsvpByIDParams struct {
+	RsvpStatus   AttendeesRsvpStatus
+	RsvpReason   sql.NullString
+	RsvpPlusOnes int32
+	ID           int32
+}
+
+func (q *Queries) UpdateAttendeeRsvpByID(ctx context.Context, arg UpdateAttendeeRsvpByIDParams) error {
+	_, err := q.db.ExecContext(ctx, updateAttendeeRsvpByID,
+		arg.RsvpStatus,
+		arg.RsvpReason,
+		arg.RsvpPlusOnes,
+		arg.ID,
+	)
+	return err
+}
+
+const updatePrimaryAttendeeByID = `-- name: UpdatePrimaryAttendeeByID :exec
+UPDATE attendees
+SET primary_attendee_id = ?
+WHERE id = ?
+    AND deleted_at IS NULL
+`
+
+type UpdatePrimaryAttendeeByIDParams struct {
+	PrimaryAttendeeID sql.NullInt32
+	ID                int32
+}
+
+func (q *Queries) UpdatePrimaryAttendeeByID(ctx context.Context, arg UpdatePrimaryAttendeeByIDParams) error {
+	_, err := q.db.ExecContext(ctx, updatePrimaryAttendeeByID, arg.PrimaryAttendeeID, arg.ID)
 	return err
 }
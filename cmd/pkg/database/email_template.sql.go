@@ -10,9 +10,10 @@ import (
 	"database/sql"
 )
 
-const createEmailTemplate = `-- name: CreateEmailTemplate :exec
+const createEmailTemplate = `-- name: CreateEmailTemplate :execlastid
 INSERT INTO email_template (
         event_id,
+        purpose,
         header_image,
         content,
         footer_image,
@@ -20,11 +21,12 @@ INSERT INTO email_template (
         bg_color,
         message
     )
-VALUES (?, ?, ?, ?, ?, ?, ?)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 `
 
 type CreateEmailTemplateParams struct {
 	EventID     int32
+	Purpose     EmailTemplatePurpose
 	HeaderImage sql.NullString
 	Content     sql.NullString
 	FooterImage sql.NullString
@@ -33,9 +35,10 @@ type CreateEmailTemplateParams struct {
 	Message     sql.NullString
 }
 
-func (q *Queries) CreateEmailTemplate(ctx context.Context, arg CreateEmailTemplateParams) error {
-	_, err := q.db.ExecContext(ctx, createEmailTemplate,
+func (q *Queries) CreateEmailTemplate(ctx context.Context, arg CreateEmailTemplateParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createEmailTemplate,
 		arg.EventID,
+		arg.Purpose,
 		arg.HeaderImage,
 		arg.Content,
 		arg.FooterImage,
@@ -43,17 +46,33 @@ func (q *Queries) CreateEmailTemplate(ctx context.Context, arg CreateEmailTempla
 		arg.BgColor,
 		arg.Message,
 	)
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
+}
+
+const deleteEmailTemplateByID = `-- name: DeleteEmailTemplateByID :exec
+UPDATE email_template
+SET deleted_at = CURRENT_TIMESTAMP
+WHERE id = ?
+    AND deleted_at IS NULL
+`
+
+func (q *Queries) DeleteEmailTemplateByID(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, deleteEmailTemplateByID, id)
 	return err
 }
 
-const getEmailTemplateByEventID = `-- name: GetEmailTemplateByEventID :one
-SELECT id, event_id, header_image, content, footer_image, created_at, updated_at, subject, message, bg_color
+const getDeletedEmailTemplateByID = `-- name: GetDeletedEmailTemplateByID :one
+SELECT id, event_id, header_image, content, footer_image, created_at, updated_at, subject, message, bg_color, deleted_at, purpose
 FROM email_template
-WHERE event_id = ?
+WHERE id = ?
+    AND deleted_at IS NOT NULL
 `
 
-func (q *Queries) GetEmailTemplateByEventID(ctx context.Context, eventID int32) (EmailTemplate, error) {
-	row := q.db.QueryRowContext(ctx, getEmailTemplateByEventID, eventID)
+func (q *Queries) GetDeletedEmailTemplateByID(ctx context.Context, id int32) (EmailTemplate, error) {
+	row := q.db.QueryRowContext(ctx, getDeletedEmailTemplateByID, id)
 	var i EmailTemplate
 	err := row.Scan(
 		&i.ID,
@@ -66,14 +85,17 @@ func (q *Queries) GetEmailTemplateByEventID(ctx context.Context, eventID int32)
 		&i.Subject,
 		&i.Message,
 		&i.BgColor,
+		&i.DeletedAt,
+		&i.Purpose,
 	)
 	return i, err
 }
 
 const getEmailTemplateByID = `-- name: GetEmailTemplateByID :one
-SELECT id, event_id, header_image, content, footer_image, created_at, updated_at, subject, message, bg_color
+SELECT id, event_id, header_image, content, footer_image, created_at, updated_at, subject, message, bg_color, deleted_at, purpose
 FROM email_template
 WHERE id = ?
+    AND deleted_at IS NULL
 `
 
 func (q *Queries) GetEmailTemplateByID(ctx context.Context, id int32) (EmailTemplate, error) {
@@ -90,13 +112,113 @@ func (q *Queries) GetEmailTemplateByID(ctx context.Context, id int32) (EmailTemp
 		&i.Subject,
 		&i.Message,
 		&i.BgColor,
+		&i.DeletedAt,
+		&i.Purpose,
 	)
 	return i, err
 }
 
+const getEmailTemplateByPurpose = `-- name: GetEmailTemplateByPurpose :one
+SELECT id, event_id, header_image, content, footer_image, created_at, updated_at, subject, message, bg_color, deleted_at, purpose
+FROM email_template
+WHERE event_id = ?
+    AND purpose = ?
+    AND deleted_at IS NULL
+`
+
+type GetEmailTemplateByPurposeParams struct {
+	EventID int32
+	Purpose EmailTemplatePurpose
+}
+
+func (q *Queries) GetEmailTemplateByPurpose(ctx context.Context, arg GetEmailTemplateByPurposeParams) (EmailTemplate, error) {
+	row := q.db.QueryRowContext(ctx, getEmailTemplateByPurpose, arg.EventID, arg.Purpose)
+	var i EmailTemplate
+	err := row.Scan(
+		&i.ID,
+		&i.EventID,
+		&i.HeaderImage,
+		&i.Content,
+		&i.FooterImage,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Subject,
+		&i.Message,
+		&i.BgColor,
+		&i.DeletedAt,
+		&i.Purpose,
+	)
+	return i, err
+}
+
+const getEmailTemplatesByEventID = `-- name: GetEmailTemplatesByEventID :many
+SELECT id, event_id, header_image, content, footer_image, created_at, updated_at, subject, message, bg_color, deleted_at, purpose
+FROM email_template
+WHERE event_id = ?
+    AND deleted_at IS NULL
+ORDER BY purpose
+`
+
+func (q *Queries) GetEmailTemplatesByEventID(ctx context.Context, eventID int32) ([]EmailTemplate, error) {
+	rows, err := q.db.QueryContext(ctx, getEmailTemplatesByEventID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []EmailTemplate
+	for rows.Next() {
+		var i EmailTemplate
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventID,
+			&i.HeaderImage,
+			&i.Content,
+			&i.FooterImage,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Subject,
+			&i.Message,
+			&i.BgColor,
+			&i.DeletedAt,
+			&i.Purpose,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeEmailTemplatesDeletedBefore = `-- name: PurgeEmailTemplatesDeletedBefore :execresult
+DELETE FROM email_template
+WHERE deleted_at < ?
+`
+
+func (q *Queries) PurgeEmailTemplatesDeletedBefore(ctx context.Context, deletedAt sql.NullTime) (sql.Result, error) {
+	return q.db.ExecContext(ctx, purgeEmailTemplatesDeletedBefore, deletedAt)
+}
+
+const restoreEmailTemplateByID = `-- name: RestoreEmailTemplateByID :exec
+UPDATE email_template
+SET deleted_at = NULL
+WHERE id = ?
+`
+
+func (q *Queries) RestoreEmailTemplateByID(ctx context.Context, id int32) error {
+	_, err := q.db.ExecContext(ctx, restoreEmailTemplateByID, id)
+	return err
+}
+
 const updateEmailTemplateByID = `-- name: UpdateEmailTemplateByID :exec
 UPDATE email_template
 SET event_id = ?,
+    purpose = ?,
     header_image = ?,
     content = ?,
     footer_image = ?,
@@ -104,10 +226,12 @@ SET event_id = ?,
     bg_color = ?,
     message = ?
 WHERE id = ?
+    AND deleted_at IS NULL
 `
 
 type UpdateEmailTemplateByIDParams struct {
 	EventID     int32
+	Purpose     EmailTemplatePurpose
 	HeaderImage sql.NullString
 	Content     sql.NullString
 	FooterImage sql.NullString
@@ -120,6 +244,7 @@ type UpdateEmailTemplateByIDParams struct {
 func (q *Queries) UpdateEmailTemplateByID(ctx context.Context, arg UpdateEmailTemplateByIDParams) error {
 	_, err := q.db.ExecContext(ctx, updateEmailTemplateByID,
 		arg.EventID,
+		arg.Purpose,
 		arg.HeaderImage,
 		arg.Content,
 		arg.FooterImage,
@@ -7,10 +7,11 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"time"
 )
 
-const createEvent = `-- name: CreateEvent :exec
+const createEvent = `-- name: CreateEvent :execlastid
 INSERT INTO events (
         title,
         description,
@@ -31,8 +32,8 @@ type CreateEventParams struct {
 	UserID      int32
 }
 
-func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) error {
-	_, err := q.db.ExecContext(ctx, createEvent,
+func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, createEvent,
 		arg.Title,
 		arg.Description,
 		arg.StartDate,
@@ -40,12 +41,17 @@ func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) error
 		arg.Location,
 		arg.UserID,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.LastInsertId()
 }
 
 const deleteAllEventsByUserID = `-- name: DeleteAllEventsByUserID :exec
-DELETE FROM events
+UPDATE events
+SET deleted_at = CURRENT_TIMESTAMP
 WHERE user_id = ?
+    AND deleted_at IS NULL
 `
 
 func (q *Queries) DeleteAllEventsByUserID(ctx context.Context, userID int32) error {
@@ -54,8 +60,10 @@ func (q *Queries) DeleteAllEventsByUserID(ctx context.Context, userID int32) err
 }
 
 const deleteEventByID = `-- name: DeleteEventByID :exec
-DELETE FROM events
+UPDATE events
+SET deleted_at = CURRENT_TIMESTAMP
 WHERE event_id = ?
+    AND deleted_at IS NULL
 `
 
 func (q *Queries) DeleteEventByID(ctx context.Context, eventID int32) error {
@@ -64,9 +72,10 @@ func (q *Queries) DeleteEventByID(ctx context.Context, eventID int32) error {
 }
 
 const getAllEventsByUserID = `-- name: GetAllEventsByUserID :many
-SELECT event_id, title, description, start_date, end_date, location, user_id, created_at, updated_at
+SELECT event_id, title, description, start_date, end_date, location, user_id, created_at, updated_at, deleted_at
 FROM events
 WHERE user_id = ?
+    AND deleted_at IS NULL
 `
 
 func (q *Queries) GetAllEventsByUserID(ctx context.Context, userID int32) ([]Event, error) {
@@ -88,6 +97,74 @@ func (q *Queries) GetAllEventsByUserID(ctx context.Context, userID int32) ([]Eve
 			&i.UserID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDeletedEventByID = `-- name: GetDeletedEventByID :one
+SELECT event_id, title, description, start_date, end_date, location, user_id, created_at, updated_at, deleted_at
+FROM events
+WHERE event_id = ?
+    AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) GetDeletedEventByID(ctx context.Context, eventID int32) (Event, error) {
+	row := q.db.QueryRowContext(ctx, getDeletedEventByID, eventID)
+	var i Event
+	err := row.Scan(
+		&i.EventID,
+		&i.Title,
+		&i.Description,
+		&i.StartDate,
+		&i.EndDate,
+		&i.Location,
+		&i.UserID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const getDeletedEventsByUserID = `-- name: GetDeletedEventsByUserID :many
+SELECT event_id, title, description, start_date, end_date, location, user_id, created_at, updated_at, deleted_at
+FROM events
+WHERE user_id = ?
+    AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC
+`
+
+func (q *Queries) GetDeletedEventsByUserID(ctx context.Context, userID int32) ([]Event, error) {
+	rows, err := q.db.QueryContext(ctx, getDeletedEventsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.EventID,
+			&i.Title,
+			&i.Description,
+			&i.StartDate,
+			&i.EndDate,
+			&i.Location,
+			&i.UserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -103,9 +180,10 @@ func (q *Queries) GetAllEventsByUserID(ctx context.Context, userID int32) ([]Eve
 }
 
 const getEventByID = `-- name: GetEventByID :one
-SELECT event_id, title, description, start_date, end_date, location, user_id, created_at, updated_at
+SELECT event_id, title, description, start_date, end_date, location, user_id, created_at, updated_at, deleted_at
 FROM events
 WHERE event_id = ?
+    AND deleted_at IS NULL
 `
 
 func (q *Queries) GetEventByID(ctx context.Context, eventID int32) (Event, error) {
@@ -121,14 +199,16 @@ func (q *Queries) GetEventByID(ctx context.Context, eventID int32) (Event, error
 		&i.UserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const getEventByTitle = `-- name: GetEventByTitle :one
-SELECT event_id, title, description, start_date, end_date, location, user_id, created_at, updated_at
+SELECT event_id, title, description, start_date, end_date, location, user_id, created_at, updated_at, deleted_at
 FROM events
 WHERE title = ?
+    AND deleted_at IS NULL
 `
 
 func (q *Queries) GetEventByTitle(ctx context.Context, title string) (Event, error) {
@@ -144,10 +224,62 @@ func (q *Queries) GetEventByTitle(ctx context.Context, title string) (Event, err
 		&i.UserID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
+const getEventIDsDeletedBefore = `-- name: GetEventIDsDeletedBefore :many
+SELECT event_id
+FROM events
+WHERE deleted_at < ?
+`
+
+func (q *Queries) GetEventIDsDeletedBefore(ctx context.Context, deletedAt sql.NullTime) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, getEventIDsDeletedBefore, deletedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var event_id int32
+		if err := rows.Scan(&event_id); err != nil {
+			return nil, err
+		}
+		items = append(items, event_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeEventByID = `-- name: PurgeEventByID :exec
+DELETE FROM events
+WHERE event_id = ?
+    AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) PurgeEventByID(ctx context.Context, eventID int32) error {
+	_, err := q.db.ExecContext(ctx, purgeEventByID, eventID)
+	return err
+}
+
+const restoreEventByID = `-- name: RestoreEventByID :exec
+UPDATE events
+SET deleted_at = NULL
+WHERE event_id = ?
+`
+
+func (q *Queries) RestoreEventByID(ctx context.Context, eventID int32) error {
+	_, err := q.db.ExecContext(ctx, restoreEventByID, eventID)
+	return err
+}
+
 const updateEventByID = `-- name: UpdateEventByID :exec
 UPDATE events
 SET title = ?,
@@ -156,6 +288,7 @@ SET title = ?,
     end_date = ?,
     location = ?
 WHERE event_id = ?
+    AND deleted_at IS NULL
 `
 
 type UpdateEventByIDParams struct {
@@ -7,6 +7,7 @@ package database
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"time"
 )
@@ -53,6 +54,531 @@ func (ns NullAttendeesAttendance) Value() (driver.Value, error) {
 	return string(ns.AttendeesAttendance), nil
 }
 
+type AttendeesRegistrationStatus string
+
+const (
+	AttendeesRegistrationStatusConfirmed      AttendeesRegistrationStatus = "confirmed"
+	AttendeesRegistrationStatusPendingPayment AttendeesRegistrationStatus = "pending_payment"
+)
+
+func (e *AttendeesRegistrationStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AttendeesRegistrationStatus(s)
+	case string:
+		*e = AttendeesRegistrationStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AttendeesRegistrationStatus: %T", src)
+	}
+	return nil
+}
+
+type NullAttendeesRegistrationStatus struct {
+	AttendeesRegistrationStatus AttendeesRegistrationStatus
+	Valid                       bool // Valid is true if AttendeesRegistrationStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAttendeesRegistrationStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.AttendeesRegistrationStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AttendeesRegistrationStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAttendeesRegistrationStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AttendeesRegistrationStatus), nil
+}
+
+type AttendeesRsvpStatus string
+
+const (
+	AttendeesRsvpStatusPending  AttendeesRsvpStatus = "pending"
+	AttendeesRsvpStatusAccepted AttendeesRsvpStatus = "accepted"
+	AttendeesRsvpStatusDeclined AttendeesRsvpStatus = "declined"
+	AttendeesRsvpStatusMaybe    AttendeesRsvpStatus = "maybe"
+)
+
+func (e *AttendeesRsvpStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AttendeesRsvpStatus(s)
+	case string:
+		*e = AttendeesRsvpStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AttendeesRsvpStatus: %T", src)
+	}
+	return nil
+}
+
+type NullAttendeesRsvpStatus struct {
+	AttendeesRsvpStatus AttendeesRsvpStatus
+	Valid               bool // Valid is true if AttendeesRsvpStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAttendeesRsvpStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.AttendeesRsvpStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AttendeesRsvpStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAttendeesRsvpStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AttendeesRsvpStatus), nil
+}
+
+type CheckInsType string
+
+const (
+	CheckInsTypeCheckIn  CheckInsType = "check_in"
+	CheckInsTypeCheckOut CheckInsType = "check_out"
+)
+
+func (e *CheckInsType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = CheckInsType(s)
+	case string:
+		*e = CheckInsType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for CheckInsType: %T", src)
+	}
+	return nil
+}
+
+type NullCheckInsType struct {
+	CheckInsType CheckInsType
+	Valid        bool // Valid is true if CheckInsType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullCheckInsType) Scan(value interface{}) error {
+	if value == nil {
+		ns.CheckInsType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.CheckInsType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullCheckInsType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.CheckInsType), nil
+}
+
+type EmailCampaignsAnchor string
+
+const (
+	EmailCampaignsAnchorStart EmailCampaignsAnchor = "start"
+	EmailCampaignsAnchorEnd   EmailCampaignsAnchor = "end"
+)
+
+func (e *EmailCampaignsAnchor) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EmailCampaignsAnchor(s)
+	case string:
+		*e = EmailCampaignsAnchor(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EmailCampaignsAnchor: %T", src)
+	}
+	return nil
+}
+
+type NullEmailCampaignsAnchor struct {
+	EmailCampaignsAnchor EmailCampaignsAnchor
+	Valid                bool // Valid is true if EmailCampaignsAnchor is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEmailCampaignsAnchor) Scan(value interface{}) error {
+	if value == nil {
+		ns.EmailCampaignsAnchor, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EmailCampaignsAnchor.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEmailCampaignsAnchor) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EmailCampaignsAnchor), nil
+}
+
+type EmailCampaignsAudience string
+
+const (
+	EmailCampaignsAudienceAll          EmailCampaignsAudience = "all"
+	EmailCampaignsAudienceNotCheckedIn EmailCampaignsAudience = "not_checked_in"
+	EmailCampaignsAudienceCheckedIn    EmailCampaignsAudience = "checked_in"
+	EmailCampaignsAudienceRole         EmailCampaignsAudience = "role"
+)
+
+func (e *EmailCampaignsAudience) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EmailCampaignsAudience(s)
+	case string:
+		*e = EmailCampaignsAudience(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EmailCampaignsAudience: %T", src)
+	}
+	return nil
+}
+
+type NullEmailCampaignsAudience struct {
+	EmailCampaignsAudience EmailCampaignsAudience
+	Valid                  bool // Valid is true if EmailCampaignsAudience is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEmailCampaignsAudience) Scan(value interface{}) error {
+	if value == nil {
+		ns.EmailCampaignsAudience, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EmailCampaignsAudience.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEmailCampaignsAudience) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EmailCampaignsAudience), nil
+}
+
+type EmailCampaignsPurpose string
+
+const (
+	EmailCampaignsPurposeInvitation        EmailCampaignsPurpose = "invitation"
+	EmailCampaignsPurposeConfirmation      EmailCampaignsPurpose = "confirmation"
+	EmailCampaignsPurposeReminder          EmailCampaignsPurpose = "reminder"
+	EmailCampaignsPurposeThankYou          EmailCampaignsPurpose = "thank_you"
+	EmailCampaignsPurposeWaitlistPromotion EmailCampaignsPurpose = "waitlist_promotion"
+	EmailCampaignsPurposeCancellation      EmailCampaignsPurpose = "cancellation"
+)
+
+func (e *EmailCampaignsPurpose) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EmailCampaignsPurpose(s)
+	case string:
+		*e = EmailCampaignsPurpose(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EmailCampaignsPurpose: %T", src)
+	}
+	return nil
+}
+
+type NullEmailCampaignsPurpose struct {
+	EmailCampaignsPurpose EmailCampaignsPurpose
+	Valid                 bool // Valid is true if EmailCampaignsPurpose is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEmailCampaignsPurpose) Scan(value interface{}) error {
+	if value == nil {
+		ns.EmailCampaignsPurpose, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EmailCampaignsPurpose.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEmailCampaignsPurpose) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EmailCampaignsPurpose), nil
+}
+
+type EmailCampaignsStatus string
+
+const (
+	EmailCampaignsStatusScheduled EmailCampaignsStatus = "scheduled"
+	EmailCampaignsStatusSending   EmailCampaignsStatus = "sending"
+	EmailCampaignsStatusSent      EmailCampaignsStatus = "sent"
+	EmailCampaignsStatusFailed    EmailCampaignsStatus = "failed"
+	EmailCampaignsStatusSkipped   EmailCampaignsStatus = "skipped"
+)
+
+func (e *EmailCampaignsStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EmailCampaignsStatus(s)
+	case string:
+		*e = EmailCampaignsStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EmailCampaignsStatus: %T", src)
+	}
+	return nil
+}
+
+type NullEmailCampaignsStatus struct {
+	EmailCampaignsStatus EmailCampaignsStatus
+	Valid                bool // Valid is true if EmailCampaignsStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEmailCampaignsStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.EmailCampaignsStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EmailCampaignsStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEmailCampaignsStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EmailCampaignsStatus), nil
+}
+
+type EmailDeliveriesStatus string
+
+const (
+	EmailDeliveriesStatusSent    EmailDeliveriesStatus = "sent"
+	EmailDeliveriesStatusFailed  EmailDeliveriesStatus = "failed"
+	EmailDeliveriesStatusSkipped EmailDeliveriesStatus = "skipped"
+)
+
+func (e *EmailDeliveriesStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EmailDeliveriesStatus(s)
+	case string:
+		*e = EmailDeliveriesStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EmailDeliveriesStatus: %T", src)
+	}
+	return nil
+}
+
+type NullEmailDeliveriesStatus struct {
+	EmailDeliveriesStatus EmailDeliveriesStatus
+	Valid                 bool // Valid is true if EmailDeliveriesStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEmailDeliveriesStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.EmailDeliveriesStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EmailDeliveriesStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEmailDeliveriesStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EmailDeliveriesStatus), nil
+}
+
+type EmailSuppressionsReason string
+
+const (
+	EmailSuppressionsReasonSmtpError EmailSuppressionsReason = "smtp_error"
+	EmailSuppressionsReasonBounce    EmailSuppressionsReason = "bounce"
+	EmailSuppressionsReasonComplaint EmailSuppressionsReason = "complaint"
+	EmailSuppressionsReasonManual    EmailSuppressionsReason = "manual"
+)
+
+func (e *EmailSuppressionsReason) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EmailSuppressionsReason(s)
+	case string:
+		*e = EmailSuppressionsReason(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EmailSuppressionsReason: %T", src)
+	}
+	return nil
+}
+
+type NullEmailSuppressionsReason struct {
+	EmailSuppressionsReason EmailSuppressionsReason
+	Valid                   bool // Valid is true if EmailSuppressionsReason is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEmailSuppressionsReason) Scan(value interface{}) error {
+	if value == nil {
+		ns.EmailSuppressionsReason, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EmailSuppressionsReason.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEmailSuppressionsReason) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EmailSuppressionsReason), nil
+}
+
+type EmailTemplatePurpose string
+
+const (
+	EmailTemplatePurposeInvitation        EmailTemplatePurpose = "invitation"
+	EmailTemplatePurposeConfirmation      EmailTemplatePurpose = "confirmation"
+	EmailTemplatePurposeReminder          EmailTemplatePurpose = "reminder"
+	EmailTemplatePurposeThankYou          EmailTemplatePurpose = "thank_you"
+	EmailTemplatePurposeWaitlistPromotion EmailTemplatePurpose = "waitlist_promotion"
+	EmailTemplatePurposeCancellation      EmailTemplatePurpose = "cancellation"
+)
+
+func (e *EmailTemplatePurpose) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EmailTemplatePurpose(s)
+	case string:
+		*e = EmailTemplatePurpose(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EmailTemplatePurpose: %T", src)
+	}
+	return nil
+}
+
+type NullEmailTemplatePurpose struct {
+	EmailTemplatePurpose EmailTemplatePurpose
+	Valid                bool // Valid is true if EmailTemplatePurpose is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEmailTemplatePurpose) Scan(value interface{}) error {
+	if value == nil {
+		ns.EmailTemplatePurpose, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EmailTemplatePurpose.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEmailTemplatePurpose) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EmailTemplatePurpose), nil
+}
+
+type EmailTrackingEventsKind string
+
+const (
+	EmailTrackingEventsKindOpen  EmailTrackingEventsKind = "open"
+	EmailTrackingEventsKindClick EmailTrackingEventsKind = "click"
+)
+
+func (e *EmailTrackingEventsKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = EmailTrackingEventsKind(s)
+	case string:
+		*e = EmailTrackingEventsKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for EmailTrackingEventsKind: %T", src)
+	}
+	return nil
+}
+
+type NullEmailTrackingEventsKind struct {
+	EmailTrackingEventsKind EmailTrackingEventsKind
+	Valid                   bool // Valid is true if EmailTrackingEventsKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullEmailTrackingEventsKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.EmailTrackingEventsKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.EmailTrackingEventsKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullEmailTrackingEventsKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.EmailTrackingEventsKind), nil
+}
+
+type OrdersStatus string
+
+const (
+	OrdersStatusPending  OrdersStatus = "pending"
+	OrdersStatusPaid     OrdersStatus = "paid"
+	OrdersStatusFailed   OrdersStatus = "failed"
+	OrdersStatusRefunded OrdersStatus = "refunded"
+	OrdersStatusExpired  OrdersStatus = "expired"
+)
+
+func (e *OrdersStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = OrdersStatus(s)
+	case string:
+		*e = OrdersStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for OrdersStatus: %T", src)
+	}
+	return nil
+}
+
+type NullOrdersStatus struct {
+	OrdersStatus OrdersStatus
+	Valid        bool // Valid is true if OrdersStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullOrdersStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.OrdersStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.OrdersStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullOrdersStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.OrdersStatus), nil
+}
+
 type RolesName string
 
 const (
@@ -140,17 +666,28 @@ func (ns NullSubscriptionsStatus) Value() (driver.Value, error) {
 }
 
 type Attendee struct {
-	ID          int32
-	FirstName   string
-	LastName    string
-	Email       string
-	QrCode      sql.NullString
-	CompanyName sql.NullString
-	Title       sql.NullString
-	TableNo     sql.NullInt32
-	Role        sql.NullString
-	Attendance  NullAttendeesAttendance
-	EventID     int32
+	ID                 int32
+	FirstName          string
+	LastName           string
+	Email              string
+	QrCode             sql.NullString
+	CompanyName        sql.NullString
+	Title              sql.NullString
+	TableNo            sql.NullInt32
+	Role               sql.NullString
+	EventID            int32
+	Attendance         AttendeesAttendance
+	CheckedInAt        sql.NullTime
+	UpdatedAt          time.Time
+	SessionsAttended   int32
+	TicketTypeID       sql.NullInt32
+	RegistrationStatus AttendeesRegistrationStatus
+	DeletedAt          sql.NullTime
+	RsvpStatus         AttendeesRsvpStatus
+	RsvpReason         sql.NullString
+	RsvpPlusOnes       int32
+	RsvpRespondedAt    sql.NullTime
+	PrimaryAttendeeID  sql.NullInt32
 }
 
 type AttendeesCustomField struct {
@@ -161,6 +698,82 @@ type AttendeesCustomField struct {
 	FieldType  sql.NullString
 }
 
+type AuditLog struct {
+	ID             int64
+	ActorID        sql.NullInt32
+	ImpersonatorID sql.NullInt32
+	Action         string
+	TargetType     string
+	TargetID       sql.NullInt32
+	Ip             string
+	UserAgent      string
+	Details        json.RawMessage
+	CreatedAt      time.Time
+	EventID        sql.NullInt32
+	EventOwnerID   sql.NullInt32
+}
+
+type CheckIn struct {
+	ID         int32
+	AttendeeID int32
+	EventID    int32
+	SessionID  sql.NullInt32
+	Type       CheckInsType
+	ScannedBy  sql.NullInt32
+	Entrance   sql.NullString
+	DeviceID   sql.NullString
+	ScannedAt  time.Time
+}
+
+type DeletedAttendee struct {
+	ID         int32
+	AttendeeID int32
+	EventID    int32
+	Email      string
+	DeletedAt  time.Time
+}
+
+type EmailCampaign struct {
+	ID            int32
+	EventID       int32
+	Name          string
+	Purpose       EmailCampaignsPurpose
+	Anchor        EmailCampaignsAnchor
+	OffsetMinutes int32
+	Audience      EmailCampaignsAudience
+	Role          sql.NullString
+	Status        EmailCampaignsStatus
+	SentCount     int32
+	FailedCount   int32
+	LastError     sql.NullString
+	StartedAt     sql.NullTime
+	FinishedAt    sql.NullTime
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+type EmailDelivery struct {
+	ID         int32
+	EventID    int32
+	AttendeeID sql.NullInt32
+	TemplateID sql.NullInt32
+	Email      string
+	Error      sql.NullString
+	CreatedAt  time.Time
+	CampaignID sql.NullInt32
+	TrackingID sql.NullString
+	Status     EmailDeliveriesStatus
+}
+
+type EmailSuppression struct {
+	ID        int32
+	Email     string
+	UserID    sql.NullInt32
+	Reason    EmailSuppressionsReason
+	Detail    sql.NullString
+	CreatedAt time.Time
+}
+
 type EmailTemplate struct {
 	ID          int32
 	EventID     int32
@@ -172,6 +785,27 @@ type EmailTemplate struct {
 	Subject     sql.NullString
 	Message     sql.NullString
 	BgColor     sql.NullString
+	DeletedAt   sql.NullTime
+	Purpose     EmailTemplatePurpose
+}
+
+type EmailTrackingEvent struct {
+	ID         int32
+	DeliveryID int32
+	Kind       EmailTrackingEventsKind
+	Url        sql.NullString
+	CreatedAt  time.Time
+}
+
+type EmailTrackingOptOut struct {
+	EventID   int32
+	CreatedAt time.Time
+}
+
+type EmailUsage struct {
+	UserID int32
+	Period time.Time
+	Sent   int32
 }
 
 type Event struct {
@@ -184,6 +818,85 @@ type Event struct {
 	UserID      int32
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	DeletedAt   sql.NullTime
+}
+
+type EventCustomField struct {
+	ID        int32
+	EventID   int32
+	Name      string
+	FieldType string
+	Required  bool
+	Position  int32
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type EventSession struct {
+	ID        int32
+	EventID   int32
+	Title     string
+	StartTime time.Time
+	EndTime   time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Room      string
+	Capacity  int32
+	Speaker   string
+	SignedUp  int32
+}
+
+type EventTable struct {
+	ID        int32
+	EventID   int32
+	TableNo   int32
+	Name      string
+	Capacity  int32
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type EventTemplate struct {
+	ID        int32
+	UserID    int32
+	Name      string
+	Blueprint json.RawMessage
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Order struct {
+	ID            int32
+	Reference     string
+	EventID       int32
+	AttendeeID    sql.NullInt32
+	TicketTypeID  sql.NullInt32
+	Email         string
+	FirstName     string
+	LastName      string
+	AmountCents   int64
+	Currency      string
+	Provider      string
+	ProviderRef   sql.NullString
+	CheckoutUrl   sql.NullString
+	ReceiptNumber sql.NullString
+	PaidAt        sql.NullTime
+	RefundedAt    sql.NullTime
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Status        OrdersStatus
+}
+
+type Plan struct {
+	ID                   int32
+	Name                 string
+	MaxEvents            int32
+	MaxAttendeesPerEvent int32
+	EmailsPerMonth       int32
+	CustomBranding       bool
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+	RequiresSubscription bool
 }
 
 type Role struct {
@@ -191,11 +904,33 @@ type Role struct {
 	Name   RolesName
 }
 
+type SessionSignup struct {
+	ID         int32
+	SessionID  int32
+	AttendeeID int32
+	CreatedAt  time.Time
+}
+
 type Subscription struct {
 	SubscriptionID int8
 	Status         SubscriptionsStatus
 }
 
+type TicketType struct {
+	ID           int32
+	EventID      int32
+	Name         string
+	Quantity     int32
+	Sold         int32
+	PriceCents   int64
+	Currency     string
+	SaleStartsAt sql.NullTime
+	SaleEndsAt   sql.NullTime
+	Visible      bool
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
 type User struct {
 	UserID         int32
 	RoleID         int8
@@ -207,4 +942,7 @@ type User struct {
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
 	Verify         bool
+	PlanID         int32
+	DisabledAt     sql.NullTime
+	LockedUntil    sql.NullTime
 }
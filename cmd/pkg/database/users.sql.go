@@ -7,9 +7,64 @@ package database
 
 import (
 	"context"
+	"database/sql"
 	"time"
 )
 
+const countSearchUsers = `-- name: CountSearchUsers :one
+SELECT COUNT(*)
+FROM users
+    JOIN roles USING(role_id)
+    JOIN subscriptions USING (subscription_id)
+WHERE (
+        ? IS NULL
+        OR users.email LIKE ?
+    )
+    AND (
+        ? IS NULL
+        OR roles.name = ?
+    )
+    AND (
+        ? IS NULL
+        OR users.verify = ?
+    )
+    AND (
+        ? IS NULL
+        OR subscriptions.status = ?
+    )
+    -- A NULL disabled matches every user. The argument appears once so that
+    -- sqlc types it as a flag rather than as disabled_at.
+    AND (users.disabled_at IS NOT NULL) = COALESCE(
+        CAST(? AS UNSIGNED),
+        users.disabled_at IS NOT NULL
+    )
+`
+
+type CountSearchUsersParams struct {
+	EmailPattern sql.NullString
+	Role         NullRolesName
+	Verify       sql.NullBool
+	Subscription NullSubscriptionsStatus
+	Disabled     sql.NullInt64
+}
+
+func (q *Queries) CountSearchUsers(ctx context.Context, arg CountSearchUsersParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countSearchUsers,
+		arg.EmailPattern,
+		arg.EmailPattern,
+		arg.Role,
+		arg.Role,
+		arg.Verify,
+		arg.Verify,
+		arg.Subscription,
+		arg.Subscription,
+		arg.Disabled,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createNormalUser = `-- name: CreateNormalUser :exec
 INSERT INTO users (
         role_id,
@@ -78,76 +133,6 @@ func (q *Queries) DeleteUserByID(ctx context.Context, userID int32) error {
 	return err
 }
 
-const getAllUsersPaginated = `-- name: GetAllUsersPaginated :many
-SELECT users.user_id,
-    roles.name AS 'role',
-    users.first_name,
-    users.last_name,
-    users.email,
-    users.password,
-    users.verify,
-    subscriptions.status AS 'subscription status',
-    users.created_at,
-    users.updated_at
-FROM users
-    JOIN roles USING(role_id)
-    JOIN subscriptions USING (subscription_id)
-ORDER BY users.created_at DESC
-LIMIT ? OFFSET ?
-`
-
-type GetAllUsersPaginatedParams struct {
-	Limit  int32
-	Offset int32
-}
-
-type GetAllUsersPaginatedRow struct {
-	UserID             int32
-	Role               RolesName
-	FirstName          string
-	LastName           string
-	Email              string
-	Password           string
-	Verify             bool
-	SubscriptionStatus SubscriptionsStatus
-	CreatedAt          time.Time
-	UpdatedAt          time.Time
-}
-
-func (q *Queries) GetAllUsersPaginated(ctx context.Context, arg GetAllUsersPaginatedParams) ([]GetAllUsersPaginatedRow, error) {
-	rows, err := q.db.QueryContext(ctx, getAllUsersPaginated, arg.Limit, arg.Offset)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []GetAllUsersPaginatedRow
-	for rows.Next() {
-		var i GetAllUsersPaginatedRow
-		if err := rows.Scan(
-			&i.UserID,
-			&i.Role,
-			&i.FirstName,
-			&i.LastName,
-			&i.Email,
-			&i.Password,
-			&i.Verify,
-			&i.SubscriptionStatus,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Close(); err != nil {
-		return nil, err
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
-}
-
 const getUserByEmail = `-- name: GetUserByEmail :one
 SELECT users.user_id,
     roles.name AS 'role',
@@ -158,7 +143,9 @@ SELECT users.user_id,
     subscriptions.status AS 'subscription status',
     users.verify,
     users.created_at,
-    users.updated_at
+    users.updated_at,
+    users.disabled_at,
+    users.locked_until
 FROM users users
     JOIN roles roles USING(role_id)
     JOIN subscriptions subscriptions USING (subscription_id)
@@ -176,6 +163,8 @@ type GetUserByEmailRow struct {
 	Verify             bool
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
+	DisabledAt         sql.NullTime
+	LockedUntil        sql.NullTime
 }
 
 func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error) {
@@ -192,6 +181,8 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEm
 		&i.Verify,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DisabledAt,
+		&i.LockedUntil,
 	)
 	return i, err
 }
@@ -206,7 +197,9 @@ SELECT users.user_id,
     users.verify,
     subscriptions.status AS 'subscription status',
     users.created_at,
-    users.updated_at
+    users.updated_at,
+    users.disabled_at,
+    users.locked_until
 FROM users users
     JOIN roles roles USING(role_id)
     JOIN subscriptions subscriptions USING (subscription_id)
@@ -224,6 +217,8 @@ type GetUserByIDRow struct {
 	SubscriptionStatus SubscriptionsStatus
 	CreatedAt          time.Time
 	UpdatedAt          time.Time
+	DisabledAt         sql.NullTime
+	LockedUntil        sql.NullTime
 }
 
 func (q *Queries) GetUserByID(ctx context.Context, userID int32) (GetUserByIDRow, error) {
@@ -240,6 +235,8 @@ func (q *Queries) GetUserByID(ctx context.Context, userID int32) (GetUserByIDRow
 		&i.SubscriptionStatus,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DisabledAt,
+		&i.LockedUntil,
 	)
 	return i, err
 }
@@ -258,6 +255,166 @@ func (q *Queries) GetUserRoleByUserID(ctx context.Context, userID int32) (RolesN
 	return name, err
 }
 
+const searchUsers = `-- name: SearchUsers :many
+SELECT users.user_id,
+    roles.name AS 'role',
+    users.first_name,
+    users.last_name,
+    users.email,
+    users.password,
+    users.verify,
+    subscriptions.status AS 'subscription status',
+    users.created_at,
+    users.updated_at,
+    users.disabled_at,
+    users.locked_until
+FROM users
+    JOIN roles USING(role_id)
+    JOIN subscriptions USING (subscription_id)
+WHERE (
+        ? IS NULL
+        OR users.email LIKE ?
+    )
+    AND (
+        ? IS NULL
+        OR roles.name = ?
+    )
+    AND (
+        ? IS NULL
+        OR users.verify = ?
+    )
+    AND (
+        ? IS NULL
+        OR subscriptions.status = ?
+    )
+    -- A NULL disabled matches every user. The argument appears once so that
+    -- sqlc types it as a flag rather than as disabled_at.
+    AND (users.disabled_at IS NOT NULL) = COALESCE(
+        CAST(? AS UNSIGNED),
+        users.disabled_at IS NOT NULL
+    )
+    AND (
+        ? IS NULL
+        OR users.created_at < ?
+        OR (
+            users.created_at = ?
+            AND users.user_id < ?
+        )
+    )
+ORDER BY users.created_at DESC,
+    users.user_id DESC
+LIMIT ?
+`
+
+type SearchUsersParams struct {
+	EmailPattern sql.NullString
+	Role         NullRolesName
+	Verify       sql.NullBool
+	Subscription NullSubscriptionsStatus
+	Disabled     sql.NullInt64
+	AfterID      sql.NullInt32
+	AfterTime    sql.NullTime
+	Limit        int32
+}
+
+type SearchUsersRow struct {
+	UserID             int32
+	Role               RolesName
+	FirstName          string
+	LastName           string
+	Email              string
+	Password           string
+	Verify             bool
+	SubscriptionStatus SubscriptionsStatus
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+	DisabledAt         sql.NullTime
+	LockedUntil        sql.NullTime
+}
+
+func (q *Queries) SearchUsers(ctx context.Context, arg SearchUsersParams) ([]SearchUsersRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchUsers,
+		arg.EmailPattern,
+		arg.EmailPattern,
+		arg.Role,
+		arg.Role,
+		arg.Verify,
+		arg.Verify,
+		arg.Subscription,
+		arg.Subscription,
+		arg.Disabled,
+		arg.AfterID,
+		arg.AfterTime,
+		arg.AfterTime,
+		arg.AfterID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchUsersRow
+	for rows.Next() {
+		var i SearchUsersRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Role,
+			&i.FirstName,
+			&i.LastName,
+			&i.Email,
+			&i.Password,
+			&i.Verify,
+			&i.SubscriptionStatus,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DisabledAt,
+			&i.LockedUntil,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setUserDisabledAt = `-- name: SetUserDisabledAt :exec
+UPDATE users
+SET disabled_at = ?
+WHERE user_id = ?
+`
+
+type SetUserDisabledAtParams struct {
+	DisabledAt sql.NullTime
+	UserID     int32
+}
+
+func (q *Queries) SetUserDisabledAt(ctx context.Context, arg SetUserDisabledAtParams) error {
+	_, err := q.db.ExecContext(ctx, setUserDisabledAt, arg.DisabledAt, arg.UserID)
+	return err
+}
+
+const setUserLockedUntil = `-- name: SetUserLockedUntil :exec
+UPDATE users
+SET locked_until = ?
+WHERE user_id = ?
+`
+
+type SetUserLockedUntilParams struct {
+	LockedUntil sql.NullTime
+	UserID      int32
+}
+
+func (q *Queries) SetUserLockedUntil(ctx context.Context, arg SetUserLockedUntilParams) error {
+	_, err := q.db.ExecContext(ctx, setUserLockedUntil, arg.LockedUntil, arg.UserID)
+	return err
+}
+
 const updateUserInformation = `-- name: UpdateUserInformation :exec
 UPDATE users
 SET first_name = ?,
@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+UPDATE attendees
+SET attendance = IF(attendance IS NULL, 'No', 'Yes');
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE attendees
+MODIFY `attendance` enum('Yes', 'No') NOT NULL DEFAULT 'No';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE attendees
+MODIFY `attendance` enum('Yes', 'No') DEFAULT 'No';
+-- +goose StatementEnd
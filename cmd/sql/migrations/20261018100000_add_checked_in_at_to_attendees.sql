@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE attendees
+ADD COLUMN `checked_in_at` datetime DEFAULT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE attendees DROP COLUMN `checked_in_at`;
+-- +goose StatementEnd
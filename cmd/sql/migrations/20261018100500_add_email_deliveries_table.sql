@@ -0,0 +1,18 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS `email_deliveries` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `event_id` int NOT NULL,
+    `attendee_id` int DEFAULT NULL,
+    `template_id` int DEFAULT NULL,
+    `email` varchar(255) NOT NULL,
+    `status` enum('sent', 'failed') NOT NULL,
+    `error` text,
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `fk_email_deliveries_events` (`event_id`),
+    KEY `fk_email_deliveries_attendees` (`attendee_id`),
+    CONSTRAINT `fk_email_deliveries_events` FOREIGN KEY (`event_id`) REFERENCES `events` (`event_id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT `fk_email_deliveries_attendees` FOREIGN KEY (`attendee_id`) REFERENCES `attendees` (`id`) ON DELETE SET NULL ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose Down
+DROP TABLE `email_deliveries`;
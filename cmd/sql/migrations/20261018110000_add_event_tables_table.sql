@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS `event_tables` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `event_id` int NOT NULL,
+    `table_no` int NOT NULL,
+    `name` varchar(255) NOT NULL DEFAULT '',
+    `capacity` int NOT NULL,
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `event_tables_event_id_table_no_unique` (`event_id`, `table_no`),
+    CONSTRAINT `fk_event_tables_events` FOREIGN KEY (`event_id`) REFERENCES `events` (`event_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose Down
+DROP TABLE `event_tables`;
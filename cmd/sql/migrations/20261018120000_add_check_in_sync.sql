@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `attendees`
+ADD COLUMN `updated_at` timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6),
+ADD KEY `attendees_event_id_updated_at` (`event_id`, `updated_at`);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `deleted_attendees` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `attendee_id` int NOT NULL,
+    `event_id` int NOT NULL,
+    `email` varchar(255) NOT NULL,
+    `deleted_at` timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    PRIMARY KEY (`id`),
+    KEY `deleted_attendees_event_id_deleted_at` (`event_id`, `deleted_at`),
+    CONSTRAINT `fk_deleted_attendees_events` FOREIGN KEY (`event_id`) REFERENCES `events` (`event_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `deleted_attendees`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `attendees` DROP KEY `attendees_event_id_updated_at`,
+    DROP COLUMN `updated_at`;
+-- +goose StatementEnd
@@ -0,0 +1,67 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `event_sessions` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `event_id` int NOT NULL,
+    `title` varchar(255) NOT NULL,
+    `start_time` datetime NOT NULL,
+    `end_time` datetime NOT NULL,
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `fk_event_sessions_events` (`event_id`),
+    CONSTRAINT `fk_event_sessions_events` FOREIGN KEY (`event_id`) REFERENCES `events` (`event_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `check_ins` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `attendee_id` int NOT NULL,
+    `event_id` int NOT NULL,
+    `session_id` int DEFAULT NULL,
+    `type` enum('check_in', 'check_out') NOT NULL DEFAULT 'check_in',
+    `scanned_by` int DEFAULT NULL,
+    `entrance` varchar(255) DEFAULT NULL,
+    `device_id` varchar(255) DEFAULT NULL,
+    `scanned_at` timestamp(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    PRIMARY KEY (`id`),
+    KEY `check_ins_attendee_id_scanned_at` (`attendee_id`, `scanned_at`),
+    KEY `check_ins_event_id_scanned_at` (`event_id`, `scanned_at`),
+    KEY `fk_check_ins_event_sessions` (`session_id`),
+    KEY `fk_check_ins_users` (`scanned_by`),
+    CONSTRAINT `fk_check_ins_attendees` FOREIGN KEY (`attendee_id`) REFERENCES `attendees` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT `fk_check_ins_events` FOREIGN KEY (`event_id`) REFERENCES `events` (`event_id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT `fk_check_ins_event_sessions` FOREIGN KEY (`session_id`) REFERENCES `event_sessions` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT `fk_check_ins_users` FOREIGN KEY (`scanned_by`) REFERENCES `users` (`user_id`) ON DELETE SET NULL ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `attendees`
+ADD COLUMN `sessions_attended` int NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- Keep the check-ins made before the history existed
+INSERT INTO `check_ins` (`attendee_id`, `event_id`, `type`, `scanned_at`)
+SELECT `id`,
+    `event_id`,
+    'check_in',
+    COALESCE(`checked_in_at`, CURRENT_TIMESTAMP(6))
+FROM `attendees`
+WHERE `attendance` = 'Yes';
+-- +goose StatementEnd
+-- +goose StatementBegin
+UPDATE `attendees`
+SET `checked_in_at` = CURRENT_TIMESTAMP
+WHERE `attendance` = 'Yes'
+    AND `checked_in_at` IS NULL;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `attendees` DROP COLUMN `sessions_attended`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE `check_ins`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE `event_sessions`;
+-- +goose StatementEnd
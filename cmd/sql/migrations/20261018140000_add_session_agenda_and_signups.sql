@@ -0,0 +1,31 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `event_sessions`
+ADD COLUMN `room` varchar(255) NOT NULL DEFAULT '',
+ADD COLUMN `capacity` int NOT NULL DEFAULT 0,
+ADD COLUMN `speaker` varchar(255) NOT NULL DEFAULT '',
+ADD COLUMN `signed_up` int NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `session_signups` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `session_id` int NOT NULL,
+    `attendee_id` int NOT NULL,
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `session_signups_session_id_attendee_id_unique` (`session_id`, `attendee_id`),
+    KEY `fk_session_signups_attendees` (`attendee_id`),
+    CONSTRAINT `fk_session_signups_event_sessions` FOREIGN KEY (`session_id`) REFERENCES `event_sessions` (`id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT `fk_session_signups_attendees` FOREIGN KEY (`attendee_id`) REFERENCES `attendees` (`id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `session_signups`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `event_sessions` DROP COLUMN `room`,
+    DROP COLUMN `capacity`,
+    DROP COLUMN `speaker`,
+    DROP COLUMN `signed_up`;
+-- +goose StatementEnd
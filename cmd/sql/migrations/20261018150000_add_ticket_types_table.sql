@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `ticket_types` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `event_id` int NOT NULL,
+    `name` varchar(100) NOT NULL,
+    `quantity` int NOT NULL DEFAULT 0,
+    `sold` int NOT NULL DEFAULT 0,
+    `price_cents` bigint NOT NULL DEFAULT 0,
+    `currency` char(3) NOT NULL DEFAULT 'USD',
+    `sale_starts_at` timestamp NULL DEFAULT NULL,
+    `sale_ends_at` timestamp NULL DEFAULT NULL,
+    `visible` tinyint(1) NOT NULL DEFAULT 1,
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `ticket_types_event_id_name_unique` (`event_id`, `name`),
+    CONSTRAINT `fk_ticket_types_events` FOREIGN KEY (`event_id`) REFERENCES `events` (`event_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `attendees`
+ADD COLUMN `ticket_type_id` int DEFAULT NULL,
+ADD KEY `fk_attendees_ticket_types` (`ticket_type_id`),
+ADD CONSTRAINT `fk_attendees_ticket_types` FOREIGN KEY (`ticket_type_id`) REFERENCES `ticket_types` (`id`) ON DELETE SET NULL ON UPDATE CASCADE;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `attendees` DROP FOREIGN KEY `fk_attendees_ticket_types`,
+    DROP KEY `fk_attendees_ticket_types`,
+    DROP COLUMN `ticket_type_id`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE `ticket_types`;
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `attendees`
+ADD COLUMN `registration_status` enum('confirmed', 'pending_payment') NOT NULL DEFAULT 'confirmed';
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `orders` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `reference` varchar(32) NOT NULL,
+    `event_id` int NOT NULL,
+    `attendee_id` int DEFAULT NULL,
+    `ticket_type_id` int DEFAULT NULL,
+    `email` varchar(255) NOT NULL,
+    `first_name` varchar(255) NOT NULL,
+    `last_name` varchar(255) NOT NULL,
+    `amount_cents` bigint NOT NULL,
+    `currency` char(3) NOT NULL,
+    `status` enum('pending', 'paid', 'failed', 'refunded') NOT NULL DEFAULT 'pending',
+    `provider` varchar(32) NOT NULL,
+    `provider_ref` varchar(255) DEFAULT NULL,
+    `checkout_url` varchar(1024) DEFAULT NULL,
+    `receipt_number` varchar(32) DEFAULT NULL,
+    `paid_at` timestamp NULL DEFAULT NULL,
+    `refunded_at` timestamp NULL DEFAULT NULL,
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `orders_reference_unique` (`reference`),
+    UNIQUE KEY `orders_provider_provider_ref_unique` (`provider`, `provider_ref`),
+    KEY `fk_orders_events` (`event_id`),
+    KEY `fk_orders_attendees` (`attendee_id`),
+    KEY `fk_orders_ticket_types` (`ticket_type_id`),
+    CONSTRAINT `fk_orders_events` FOREIGN KEY (`event_id`) REFERENCES `events` (`event_id`) ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT `fk_orders_attendees` FOREIGN KEY (`attendee_id`) REFERENCES `attendees` (`id`) ON DELETE SET NULL ON UPDATE CASCADE,
+    CONSTRAINT `fk_orders_ticket_types` FOREIGN KEY (`ticket_type_id`) REFERENCES `ticket_types` (`id`) ON DELETE SET NULL ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `orders`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `attendees` DROP COLUMN `registration_status`;
+-- +goose StatementEnd
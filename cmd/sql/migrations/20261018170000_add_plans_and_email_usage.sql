@@ -0,0 +1,55 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `plans` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `name` varchar(50) NOT NULL,
+    `max_events` int NOT NULL DEFAULT 0,
+    `max_attendees_per_event` int NOT NULL DEFAULT 0,
+    `emails_per_month` int NOT NULL DEFAULT 0,
+    `custom_branding` tinyint(1) NOT NULL DEFAULT 0,
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `plans_name_unique` (`name`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+-- +goose StatementBegin
+INSERT IGNORE INTO plans (
+        id,
+        name,
+        max_events,
+        max_attendees_per_event,
+        emails_per_month,
+        custom_branding
+    )
+VALUES (1, 'Free', 1, 100, 200, 0),
+    (2, 'Pro', 20, 2000, 10000, 1),
+    (3, 'Enterprise', 0, 0, 0, 1);
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `users`
+ADD COLUMN `plan_id` int NOT NULL DEFAULT 1,
+ADD KEY `fk_users_plans` (`plan_id`),
+ADD CONSTRAINT `fk_users_plans` FOREIGN KEY (`plan_id`) REFERENCES `plans` (`id`) ON UPDATE CASCADE;
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `email_usage` (
+    `user_id` int NOT NULL,
+    `period` date NOT NULL,
+    `sent` int NOT NULL DEFAULT 0,
+    PRIMARY KEY (`user_id`, `period`),
+    CONSTRAINT `fk_email_usage_users` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `email_usage`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `users` DROP FOREIGN KEY `fk_users_plans`,
+    DROP KEY `fk_users_plans`,
+    DROP COLUMN `plan_id`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE `plans`;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `users`
+ADD COLUMN `disabled_at` timestamp NULL DEFAULT NULL,
+ADD COLUMN `locked_until` timestamp NULL DEFAULT NULL;
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `audit_log` (
+    `id` bigint NOT NULL AUTO_INCREMENT,
+    `actor_id` int DEFAULT NULL,
+    `impersonator_id` int DEFAULT NULL,
+    `action` varchar(100) NOT NULL,
+    `target_type` varchar(50) NOT NULL,
+    `target_id` int DEFAULT NULL,
+    `ip` varchar(45) NOT NULL DEFAULT '',
+    `user_agent` varchar(255) NOT NULL DEFAULT '',
+    `details` json DEFAULT NULL,
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `audit_log_actor_id` (`actor_id`),
+    KEY `audit_log_target` (`target_type`, `target_id`),
+    KEY `audit_log_created_at` (`created_at`)
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `audit_log`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `users` DROP COLUMN `disabled_at`,
+    DROP COLUMN `locked_until`;
+-- +goose StatementEnd
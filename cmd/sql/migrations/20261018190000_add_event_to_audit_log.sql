@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `audit_log`
+ADD COLUMN `event_id` int DEFAULT NULL,
+ADD COLUMN `event_owner_id` int DEFAULT NULL,
+ADD KEY `audit_log_event_id` (`event_id`),
+ADD KEY `audit_log_event_owner_id` (`event_owner_id`);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `audit_log` DROP KEY `audit_log_event_owner_id`,
+    DROP KEY `audit_log_event_id`,
+    DROP COLUMN `event_owner_id`,
+    DROP COLUMN `event_id`;
+-- +goose StatementEnd
@@ -0,0 +1,58 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `events`
+ADD COLUMN `deleted_at` timestamp NULL DEFAULT NULL,
+ADD KEY `events_deleted_at` (`deleted_at`);
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- Deleted attendees keep their row until purged, so an email only has to be
+-- unique among the attendees that are not deleted
+ALTER TABLE `attendees`
+ADD COLUMN `deleted_at` timestamp NULL DEFAULT NULL,
+ADD KEY `attendees_deleted_at` (`deleted_at`),
+DROP INDEX `Email_Event_UNIQUE`,
+ADD UNIQUE KEY `Email_Event_UNIQUE` (
+    `email`,
+    `event_id`,
+    (IF(`deleted_at` IS NULL, 1, NULL))
+);
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `email_template`
+ADD COLUMN `deleted_at` timestamp NULL DEFAULT NULL,
+ADD KEY `email_template_deleted_at` (`deleted_at`),
+DROP INDEX `email_template_event_id_unique`,
+ADD UNIQUE KEY `email_template_event_id_unique` (
+    `event_id`,
+    (IF(`deleted_at` IS NULL, 1, NULL))
+);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM `email_template`
+WHERE `deleted_at` IS NOT NULL;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `email_template` DROP INDEX `email_template_event_id_unique`,
+    ADD UNIQUE KEY `email_template_event_id_unique` (`event_id`),
+    DROP KEY `email_template_deleted_at`,
+    DROP COLUMN `deleted_at`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DELETE FROM `attendees`
+WHERE `deleted_at` IS NOT NULL;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `attendees` DROP INDEX `Email_Event_UNIQUE`,
+    ADD UNIQUE KEY `Email_Event_UNIQUE` (`email`, `event_id`),
+    DROP KEY `attendees_deleted_at`,
+    DROP COLUMN `deleted_at`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DELETE FROM `events`
+WHERE `deleted_at` IS NOT NULL;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `events` DROP KEY `events_deleted_at`,
+    DROP COLUMN `deleted_at`;
+-- +goose StatementEnd
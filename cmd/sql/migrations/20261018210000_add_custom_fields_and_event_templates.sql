@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+-- The extra fields collected for the attendees of an event, their values are
+-- kept per attendee in attendees_custom_fields
+CREATE TABLE IF NOT EXISTS `event_custom_fields` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `event_id` int NOT NULL,
+    `name` varchar(100) NOT NULL,
+    `field_type` varchar(20) NOT NULL DEFAULT 'text',
+    `required` tinyint(1) NOT NULL DEFAULT 0,
+    `position` int NOT NULL DEFAULT 0,
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `event_custom_fields_event_id_name_unique` (`event_id`, `name`),
+    CONSTRAINT `fk_event_custom_fields_events` FOREIGN KEY (`event_id`) REFERENCES `events` (`event_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- An event saved for reuse: its details, email template, custom fields and
+-- seating plan as JSON, without dates or attendees
+CREATE TABLE IF NOT EXISTS `event_templates` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `user_id` int NOT NULL,
+    `name` varchar(255) NOT NULL,
+    `blueprint` json NOT NULL,
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    UNIQUE KEY `event_templates_user_id_name_unique` (`user_id`, `name`),
+    CONSTRAINT `fk_event_templates_users` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `event_templates`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE `event_custom_fields`;
+-- +goose StatementEnd
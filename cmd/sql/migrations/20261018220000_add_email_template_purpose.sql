@@ -0,0 +1,33 @@
+-- +goose Up
+-- +goose StatementBegin
+-- An event has one email template for each purpose, the existing templates
+-- are its invitations
+ALTER TABLE `email_template`
+ADD COLUMN `purpose` enum(
+        'invitation',
+        'confirmation',
+        'reminder',
+        'thank_you',
+        'waitlist_promotion',
+        'cancellation'
+    ) NOT NULL DEFAULT 'invitation',
+    DROP INDEX `email_template_event_id_unique`,
+    ADD UNIQUE KEY `email_template_event_id_purpose_unique` (
+        `event_id`,
+        `purpose`,
+        (IF(`deleted_at` IS NULL, 1, NULL))
+    );
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM `email_template`
+WHERE `purpose` <> 'invitation';
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `email_template` DROP INDEX `email_template_event_id_purpose_unique`,
+    ADD UNIQUE KEY `email_template_event_id_unique` (
+        `event_id`,
+        (IF(`deleted_at` IS NULL, 1, NULL))
+    ),
+    DROP COLUMN `purpose`;
+-- +goose StatementEnd
@@ -0,0 +1,48 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Emails sent automatically at a time relative to the start or end of an
+-- event, offset_minutes is negative before and positive after that time
+CREATE TABLE IF NOT EXISTS `email_campaigns` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `event_id` int NOT NULL,
+    `name` varchar(255) NOT NULL,
+    `purpose` enum(
+        'invitation',
+        'confirmation',
+        'reminder',
+        'thank_you',
+        'waitlist_promotion',
+        'cancellation'
+    ) NOT NULL DEFAULT 'reminder',
+    `anchor` enum('start', 'end') NOT NULL DEFAULT 'start',
+    `offset_minutes` int NOT NULL DEFAULT 0,
+    `audience` enum('all', 'not_checked_in', 'checked_in', 'role') NOT NULL DEFAULT 'all',
+    `role` varchar(100) DEFAULT NULL,
+    `status` enum('scheduled', 'sending', 'sent', 'failed', 'skipped') NOT NULL DEFAULT 'scheduled',
+    `sent_count` int NOT NULL DEFAULT 0,
+    `failed_count` int NOT NULL DEFAULT 0,
+    `last_error` text,
+    `started_at` timestamp NULL DEFAULT NULL,
+    `finished_at` timestamp NULL DEFAULT NULL,
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    `updated_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `email_campaigns_status` (`status`),
+    CONSTRAINT `fk_email_campaigns_events` FOREIGN KEY (`event_id`) REFERENCES `events` (`event_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `email_deliveries`
+ADD COLUMN `campaign_id` int DEFAULT NULL,
+    ADD KEY `fk_email_deliveries_email_campaigns` (`campaign_id`),
+    ADD CONSTRAINT `fk_email_deliveries_email_campaigns` FOREIGN KEY (`campaign_id`) REFERENCES `email_campaigns` (`id`) ON DELETE SET NULL ON UPDATE CASCADE;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `email_deliveries` DROP FOREIGN KEY `fk_email_deliveries_email_campaigns`,
+    DROP KEY `fk_email_deliveries_email_campaigns`,
+    DROP COLUMN `campaign_id`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE `email_campaigns`;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Ranked search of attendees by name, email and company
+ALTER TABLE `attendees`
+ADD FULLTEXT INDEX `attendees_search_fulltext` (
+        `first_name`,
+        `last_name`,
+        `email`,
+        `company_name`
+    );
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `attendees` DROP INDEX `attendees_search_fulltext`;
+-- +goose StatementEnd
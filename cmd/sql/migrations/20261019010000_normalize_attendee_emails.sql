@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Store attendee emails trimmed and lowercased. An address that would clash
+-- with another attendee of the same event is left for the duplicate finder.
+UPDATE `attendees`
+SET `email` = LOWER(TRIM(`email`))
+WHERE BINARY `email` <> BINARY LOWER(TRIM(`email`))
+    AND NOT EXISTS (
+        SELECT 1
+        FROM (
+                SELECT `email`,
+                    `event_id`
+                FROM `attendees`
+            ) AS `other`
+        WHERE `other`.`event_id` = `attendees`.`event_id`
+            AND BINARY `other`.`email` = BINARY LOWER(TRIM(`attendees`.`email`))
+    );
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+-- The original spelling of the addresses is not kept
+SELECT 1;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE `attendees`
+ADD COLUMN `rsvp_status` enum('pending', 'accepted', 'declined', 'maybe') NOT NULL DEFAULT 'pending',
+ADD COLUMN `rsvp_reason` varchar(500) DEFAULT NULL,
+ADD COLUMN `rsvp_plus_ones` int NOT NULL DEFAULT 0,
+ADD COLUMN `rsvp_responded_at` timestamp NULL DEFAULT NULL,
+ADD KEY `idx_attendees_event_rsvp_status` (`event_id`, `rsvp_status`);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `attendees`
+DROP KEY `idx_attendees_event_rsvp_status`,
+DROP COLUMN `rsvp_responded_at`,
+DROP COLUMN `rsvp_plus_ones`,
+DROP COLUMN `rsvp_reason`,
+DROP COLUMN `rsvp_status`;
+-- +goose StatementEnd
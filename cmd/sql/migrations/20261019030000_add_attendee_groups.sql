@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- A guest points at the primary attendee of their group, the primary points at nobody
+ALTER TABLE `attendees`
+ADD COLUMN `primary_attendee_id` int DEFAULT NULL,
+ADD KEY `fk_attendees_primary_attendee` (`primary_attendee_id`),
+ADD CONSTRAINT `fk_attendees_primary_attendee` FOREIGN KEY (`primary_attendee_id`) REFERENCES `attendees` (`id`) ON DELETE SET NULL ON UPDATE CASCADE;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `attendees`
+DROP FOREIGN KEY `fk_attendees_primary_attendee`,
+DROP KEY `fk_attendees_primary_attendee`,
+DROP COLUMN `primary_attendee_id`;
+-- +goose StatementEnd
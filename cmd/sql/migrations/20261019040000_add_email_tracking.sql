@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Tracked emails carry a random tracking ID in their pixel and links, an
+-- email sent untracked has none
+ALTER TABLE `email_deliveries`
+ADD COLUMN `tracking_id` char(32) DEFAULT NULL,
+    ADD UNIQUE KEY `email_deliveries_tracking_id` (`tracking_id`);
+-- +goose StatementEnd
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS `email_tracking_events` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `delivery_id` int NOT NULL,
+    `kind` enum('open', 'click') NOT NULL,
+    `url` varchar(2048) DEFAULT NULL,
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `fk_email_tracking_events_email_deliveries` (`delivery_id`, `kind`),
+    CONSTRAINT `fk_email_tracking_events_email_deliveries` FOREIGN KEY (`delivery_id`) REFERENCES `email_deliveries` (`id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- Events whose organizer turned email tracking off, tracking is on otherwise
+CREATE TABLE IF NOT EXISTS `email_tracking_opt_outs` (
+    `event_id` int NOT NULL,
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`event_id`),
+    CONSTRAINT `fk_email_tracking_opt_outs_events` FOREIGN KEY (`event_id`) REFERENCES `events` (`event_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE `email_tracking_opt_outs`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE `email_tracking_events`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `email_deliveries` DROP KEY `email_deliveries_tracking_id`,
+    DROP COLUMN `tracking_id`;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Addresses no email is sent to. Bounces, complaints and permanent SMTP
+-- errors suppress an address for the whole platform, user_id is only set for
+-- the addresses an organizer suppressed for their own events.
+CREATE TABLE IF NOT EXISTS `email_suppressions` (
+    `id` int NOT NULL AUTO_INCREMENT,
+    `email` varchar(255) NOT NULL,
+    `user_id` int DEFAULT NULL,
+    `reason` enum('smtp_error', 'bounce', 'complaint', 'manual') NOT NULL,
+    `detail` varchar(1000) DEFAULT NULL,
+    `created_at` timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (`id`),
+    KEY `email_suppressions_email` (`email`),
+    KEY `fk_email_suppressions_users` (`user_id`),
+    CONSTRAINT `fk_email_suppressions_users` FOREIGN KEY (`user_id`) REFERENCES `users` (`user_id`) ON DELETE CASCADE ON UPDATE CASCADE
+) ENGINE = InnoDB DEFAULT CHARSET = utf8mb4 COLLATE = utf8mb4_0900_ai_ci;
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `email_deliveries`
+MODIFY `status` enum('sent', 'failed', 'skipped') NOT NULL;
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM `email_deliveries`
+WHERE `status` = 'skipped';
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `email_deliveries`
+MODIFY `status` enum('sent', 'failed') NOT NULL;
+-- +goose StatementEnd
+-- +goose StatementBegin
+DROP TABLE `email_suppressions`;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Orders left unpaid past the checkout expiry are expired and give their
+-- ticket back
+ALTER TABLE `orders`
+MODIFY `status` enum('pending', 'paid', 'failed', 'refunded', 'expired') NOT NULL DEFAULT 'pending',
+    ADD KEY `orders_status_created_at` (`status`, `created_at`);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+UPDATE `orders`
+SET `status` = 'failed'
+WHERE `status` = 'expired';
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `orders` DROP INDEX `orders_status_created_at`,
+    MODIFY `status` enum('pending', 'paid', 'failed', 'refunded') NOT NULL DEFAULT 'pending';
+-- +goose StatementEnd
@@ -0,0 +1,58 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Plans are seeded by migrations: Free, Pro and Enterprise when plans were
+-- added, and Legacy here. Super users add and change the others through the
+-- plans API; those always require an active subscription. A plan that does
+-- not require one applies whatever the subscription status of the user.
+ALTER TABLE `plans`
+ADD COLUMN `requires_subscription` tinyint(1) NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+-- +goose StatementBegin
+UPDATE `plans`
+SET `requires_subscription` = 0
+WHERE `id` = 1;
+-- +goose StatementEnd
+-- +goose StatementBegin
+INSERT IGNORE INTO `plans` (
+        `name`,
+        `max_events`,
+        `max_attendees_per_event`,
+        `emails_per_month`,
+        `custom_branding`,
+        `requires_subscription`
+    )
+VALUES ('Legacy', 0, 0, 0, 1, 0);
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- Users who signed up before plans were added keep the unlimited use they
+-- had instead of being held to the Free plan
+UPDATE `users`
+SET `plan_id` = (
+        SELECT `id`
+        FROM `plans`
+        WHERE `name` = 'Legacy'
+    )
+WHERE `plan_id` = 1
+    AND `created_at` < (
+        SELECT `created_at`
+        FROM `plans`
+        WHERE `id` = 1
+    );
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+UPDATE `users`
+SET `plan_id` = 1
+WHERE `plan_id` = (
+        SELECT `id`
+        FROM `plans`
+        WHERE `name` = 'Legacy'
+    );
+-- +goose StatementEnd
+-- +goose StatementBegin
+DELETE FROM `plans`
+WHERE `name` = 'Legacy';
+-- +goose StatementEnd
+-- +goose StatementBegin
+ALTER TABLE `plans` DROP COLUMN `requires_subscription`;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Lists the deleted attendees of an event page by page, latest deleted first
+ALTER TABLE `attendees`
+ADD KEY `attendees_event_id_deleted_at` (`event_id`, `deleted_at`);
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `attendees` DROP INDEX `attendees_event_id_deleted_at`;
+-- +goose StatementEnd
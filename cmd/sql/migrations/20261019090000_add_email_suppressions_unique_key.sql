@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Keep the first entry of an address that concurrent failures added twice
+DELETE duplicate
+FROM `email_suppressions` AS duplicate
+    JOIN `email_suppressions` AS kept ON kept.`email` = duplicate.`email`
+    AND kept.`user_id` <=> duplicate.`user_id`
+    AND kept.`id` < duplicate.`id`;
+-- +goose StatementEnd
+-- +goose StatementBegin
+-- An address is suppressed once per organizer and once for the whole
+-- platform. user_id is NULL for the platform, which a plain unique key would
+-- not compare, so the key part maps it to 0.
+ALTER TABLE `email_suppressions`
+ADD UNIQUE KEY `email_suppressions_email_user_id_unique` (`email`, (IFNULL(`user_id`, 0)));
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE `email_suppressions` DROP INDEX `email_suppressions_email_user_id_unique`;
+-- +goose StatementEnd
@@ -0,0 +1,93 @@
+-- name: GetEventsPaginated :many
+SELECT *
+FROM events
+WHERE (
+        sqlc.narg(user_id) IS NULL
+        OR user_id = sqlc.narg(user_id)
+    )
+    AND deleted_at IS NULL
+    AND (
+        sqlc.narg(after_id) IS NULL
+        OR start_date < sqlc.narg(after_date)
+        OR (
+            start_date = sqlc.narg(after_date)
+            AND event_id < sqlc.narg(after_id)
+        )
+    )
+ORDER BY start_date DESC,
+    event_id DESC
+LIMIT ?;
+-- name: CountEvents :one
+SELECT COUNT(*)
+FROM events
+WHERE (
+        sqlc.narg(user_id) IS NULL
+        OR user_id = sqlc.narg(user_id)
+    )
+    AND deleted_at IS NULL;
+-- name: GetSystemStats :one
+SELECT (
+        SELECT COUNT(*)
+        FROM users
+    ) AS users,
+    (
+        SELECT COUNT(*)
+        FROM users
+        WHERE users.verify = 1
+    ) AS verified_users,
+    (
+        SELECT COUNT(*)
+        FROM users
+        WHERE users.disabled_at IS NOT NULL
+    ) AS disabled_users,
+    (
+        SELECT COUNT(*)
+        FROM events
+        WHERE events.deleted_at IS NULL
+    ) AS events,
+    (
+        SELECT COUNT(*)
+        FROM events
+        WHERE events.end_date >= sqlc.arg(now)
+            AND events.deleted_at IS NULL
+    ) AS upcoming_events,
+    (
+        SELECT COUNT(*)
+        FROM attendees
+            JOIN events ON events.event_id = attendees.event_id
+        WHERE attendees.deleted_at IS NULL
+            AND events.deleted_at IS NULL
+    ) AS attendees,
+    (
+        SELECT COUNT(*)
+        FROM attendees
+            JOIN events ON events.event_id = attendees.event_id
+        WHERE attendees.attendance = 'Yes'
+            AND attendees.deleted_at IS NULL
+            AND events.deleted_at IS NULL
+    ) AS checked_in,
+    (
+        SELECT COUNT(*)
+        FROM orders
+        WHERE orders.status = 'paid'
+    ) AS paid_orders,
+    (
+        SELECT CAST(COALESCE(SUM(email_usage.sent), 0) AS SIGNED)
+        FROM email_usage
+        WHERE email_usage.period = sqlc.arg(period)
+    ) AS emails_sent;
+-- name: GetRevenueByCurrency :many
+SELECT currency,
+    CAST(SUM(amount_cents) AS SIGNED) AS amount_cents
+FROM orders
+WHERE status = 'paid'
+GROUP BY currency
+ORDER BY currency;
+-- name: GetUsersByPlan :many
+SELECT plans.name,
+    COUNT(users.user_id) AS users
+FROM plans
+    LEFT JOIN users ON users.plan_id = plans.id
+GROUP BY plans.id,
+    plans.name
+ORDER BY plans.id;
@@ -1,4 +1,4 @@
--- name: CreateAttendee :exec
+-- name: CreateAttendee :execlastid
 INSERT INTO attendees (
         first_name,
         last_name,
@@ -9,36 +9,54 @@ INSERT INTO attendees (
         table_no,
         role,
         attendance,
-        event_id
+        event_id,
+        ticket_type_id,
+        registration_status,
+        primary_attendee_id
     )
-VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
 -- name: GetAttendeeByEmail :one
 SELECT *
 FROM attendees
-WHERE email = ?;
+WHERE email = ?
+    AND deleted_at IS NULL;
+-- name: GetAttendeeByEmailAndEventID :one
+SELECT *
+FROM attendees
+WHERE email = ?
+    AND event_id = ?
+    AND deleted_at IS NULL;
 -- name: GetAttendeeByID :one
 SELECT *
 FROM attendees
-WHERE id = ?;
+WHERE id = ?
+    AND deleted_at IS NULL;
 -- name: GetAllAttendeesPaginatedByEventID :many
 SELECT *
 FROM attendees
 WHERE event_id = ?
+    AND deleted_at IS NULL
 LIMIT ? OFFSET ?;
 -- name: GetAllAttendeesByEventID :many
 SELECT *
 FROM attendees
-WHERE event_id = ?;
+WHERE event_id = ?
+    AND deleted_at IS NULL;
 -- name: GetAttendeesRowCountByEventID :one
 SELECT COUNT(*)
 FROM attendees
-WHERE event_id = ?;
+WHERE event_id = ?
+    AND deleted_at IS NULL;
 -- name: DeleteAttendeeByID :exec
-DELETE FROM attendees
-WHERE id = ?;
+UPDATE attendees
+SET deleted_at = CURRENT_TIMESTAMP
+WHERE id = ?
+    AND deleted_at IS NULL;
 -- name: DeleteAllAttendeesByEventID :exec
-DELETE FROM attendees
-WHERE event_id = ?;
+UPDATE attendees
+SET deleted_at = CURRENT_TIMESTAMP
+WHERE event_id = ?
+    AND deleted_at IS NULL;
 -- name: UpdateAttendeeByID :exec
 UPDATE attendees
 SET first_name = ?,
@@ -49,5 +67,412 @@ SET first_name = ?,
     title = ?,
     table_no = ?,
     role = ?,
-    attendance = ?
-WHERE id = ?;
\ No newline at end of file
+    attendance = ?,
+    ticket_type_id = ?
+WHERE id = ?
+    AND deleted_at IS NULL;
+-- name: GetAttendanceSummaryByEventID :one
+SELECT COUNT(*) AS expected,
+    CAST(COALESCE(SUM(attendance = 'Yes'), 0) AS SIGNED) AS checked_in
+FROM attendees
+WHERE event_id = ?
+    AND deleted_at IS NULL;
+-- name: GetAttendanceByRoleByEventID :many
+SELECT COALESCE(role, '') AS role,
+    COUNT(*) AS expected,
+    CAST(COALESCE(SUM(attendance = 'Yes'), 0) AS SIGNED) AS checked_in
+FROM attendees
+WHERE event_id = ?
+    AND deleted_at IS NULL
+GROUP BY COALESCE(role, '')
+ORDER BY role;
+-- name: GetAttendanceByTableByEventID :many
+SELECT COALESCE(table_no, 0) AS table_no,
+    COUNT(*) AS expected,
+    CAST(COALESCE(SUM(attendance = 'Yes'), 0) AS SIGNED) AS checked_in
+FROM attendees
+WHERE event_id = ?
+    AND deleted_at IS NULL
+GROUP BY COALESCE(table_no, 0)
+ORDER BY table_no;
+-- name: GetAttendanceByCompanyByEventID :many
+SELECT COALESCE(company_name, '') AS company_name,
+    COUNT(*) AS expected,
+    CAST(COALESCE(SUM(attendance = 'Yes'), 0) AS SIGNED) AS checked_in
+FROM attendees
+WHERE event_id = ?
+    AND deleted_at IS NULL
+GROUP BY COALESCE(company_name, '')
+ORDER BY expected DESC,
+    company_name;
+-- name: GetAttendanceByTicketTypeByEventID :many
+SELECT COALESCE(ticket_types.name, '') AS ticket_type,
+    COUNT(*) AS expected,
+    CAST(
+        COALESCE(SUM(attendees.attendance = 'Yes'), 0) AS SIGNED
+    ) AS checked_in
+FROM attendees
+    LEFT JOIN ticket_types ON ticket_types.id = attendees.ticket_type_id
+WHERE attendees.event_id = ?
+    AND attendees.deleted_at IS NULL
+GROUP BY COALESCE(ticket_types.name, '')
+ORDER BY ticket_type;
+-- name: GetCheckInsPerMinuteByEventID :many
+SELECT CAST(
+        TIMESTAMPDIFF(MINUTE, '1970-01-01 00:00:00', checked_in_at) AS SIGNED
+    ) AS minute,
+    COUNT(*) AS check_ins
+FROM attendees
+WHERE event_id = ?
+    AND attendance = 'Yes'
+    AND checked_in_at IS NOT NULL
+    AND deleted_at IS NULL
+GROUP BY minute
+ORDER BY minute;
+-- name: ConfirmAttendeeRegistration :exec
+UPDATE attendees
+SET registration_status = 'confirmed'
+WHERE id = ?
+    AND deleted_at IS NULL;
+-- name: GetNoShowsByEventID :many
+SELECT *
+FROM attendees
+WHERE event_id = ?
+    AND attendance = 'No'
+    AND deleted_at IS NULL
+ORDER BY last_name,
+    first_name;
+-- name: GetDeletedAttendeesByEventID :many
+SELECT *
+FROM attendees
+WHERE event_id = ?
+    AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC,
+    id;
+-- name: GetDeletedAttendeesPaginated :many
+SELECT *
+FROM attendees
+WHERE attendees.event_id = sqlc.arg(event_id)
+    AND attendees.deleted_at IS NOT NULL
+    AND (
+        sqlc.narg(after_id) IS NULL
+        OR attendees.deleted_at < sqlc.narg(after_deleted_at)
+        OR (
+            attendees.deleted_at = sqlc.narg(after_deleted_at)
+            AND attendees.id < sqlc.narg(after_id)
+        )
+    )
+ORDER BY attendees.deleted_at DESC,
+    attendees.id DESC
+LIMIT ?;
+-- name: CountDeletedAttendeesByEventID :one
+SELECT COUNT(*)
+FROM attendees
+WHERE attendees.event_id = ?
+    AND attendees.deleted_at IS NOT NULL;
+-- name: GetDeletedAttendeeByID :one
+SELECT *
+FROM attendees
+WHERE id = ?
+    AND deleted_at IS NOT NULL;
+-- name: RestoreAttendeeByID :exec
+UPDATE attendees
+SET deleted_at = NULL
+WHERE id = ?;
+-- name: GetAttendeesDeletedBefore :many
+SELECT id,
+    qr_code
+FROM attendees
+WHERE deleted_at < ?;
+-- name: GetQrCodesByEventID :many
+SELECT qr_code
+FROM attendees
+WHERE event_id = ?
+    AND qr_code IS NOT NULL;
+-- name: PurgeAttendeeByID :exec
+DELETE FROM attendees
+WHERE id = ?
+    AND deleted_at IS NOT NULL;
+-- name: PurgeAttendeesByEventID :exec
+DELETE FROM attendees
+WHERE event_id = (
+        SELECT event_id
+        FROM events
+        WHERE events.event_id = ?
+            AND events.deleted_at IS NOT NULL
+    );
+-- name: GetFilteredAttendeesByEventID :many
+SELECT attendees.*,
+    CONCAT(
+        CASE
+            sqlc.arg(sort_column)
+            WHEN 'name' THEN CONCAT(attendees.last_name, ' ', attendees.first_name)
+            WHEN 'email' THEN attendees.email
+            WHEN 'company_name' THEN COALESCE(attendees.company_name, '')
+            WHEN 'role' THEN COALESCE(attendees.role, '')
+            WHEN 'table_no' THEN COALESCE(attendees.table_no, 0)
+            WHEN 'checked_in_at' THEN COALESCE(attendees.checked_in_at, TIMESTAMP('1000-01-01'))
+            ELSE ''
+        END
+    ) AS sort_key
+FROM attendees
+WHERE attendees.event_id = sqlc.arg(event_id)
+    AND attendees.deleted_at IS NULL
+    AND (
+        sqlc.narg(role) IS NULL
+        OR attendees.role = sqlc.narg(role)
+    )
+    AND (
+        sqlc.narg(company_name) IS NULL
+        OR attendees.company_name = sqlc.narg(company_name)
+    )
+    AND (
+        sqlc.narg(table_no) IS NULL
+        OR attendees.table_no = sqlc.narg(table_no)
+    )
+    AND (
+        sqlc.narg(attendance) IS NULL
+        OR attendees.attendance = sqlc.narg(attendance)
+    )
+    AND (
+        sqlc.narg(rsvp_status) IS NULL
+        OR attendees.rsvp_status = sqlc.narg(rsvp_status)
+    )
+    AND (
+        sqlc.narg(invited) IS NULL
+        OR EXISTS (
+            SELECT 1
+            FROM email_deliveries
+                JOIN email_template ON email_template.id = email_deliveries.template_id
+            WHERE email_deliveries.attendee_id = attendees.id
+                AND email_deliveries.status = 'sent'
+                AND email_template.purpose = 'invitation'
+        ) = sqlc.narg(invited)
+    )
+    AND (
+        sqlc.narg(field_name) IS NULL
+        OR EXISTS (
+            SELECT 1
+            FROM attendees_custom_fields
+            WHERE attendees_custom_fields.attendee_id = attendees.id
+                AND attendees_custom_fields.field_name = sqlc.narg(field_name)
+                AND (
+                    sqlc.narg(field_value) IS NULL
+                    OR attendees_custom_fields.field_value = sqlc.narg(field_value)
+                )
+        )
+    )
+    AND (
+        sqlc.narg(search) IS NULL
+        OR attendees.first_name LIKE sqlc.narg(search)
+        OR attendees.last_name LIKE sqlc.narg(search)
+        OR attendees.email LIKE sqlc.narg(search)
+        OR attendees.company_name LIKE sqlc.narg(search)
+    )
+    AND (
+        sqlc.narg(after_id) IS NULL
+        OR NOT sqlc.arg(descending)
+        AND (
+            sqlc.arg(sort_column) = 'email'
+            AND (
+                attendees.email > sqlc.narg(after_key)
+                OR attendees.email = sqlc.narg(after_key)
+                AND attendees.id > sqlc.narg(after_id)
+            )
+            OR sqlc.arg(sort_column) = 'name'
+            AND (
+                CONCAT(attendees.last_name, ' ', attendees.first_name) > sqlc.narg(after_key)
+                OR CONCAT(attendees.last_name, ' ', attendees.first_name) = sqlc.narg(after_key)
+                AND attendees.id > sqlc.narg(after_id)
+            )
+            OR sqlc.arg(sort_column) = 'company_name'
+            AND (
+                COALESCE(attendees.company_name, '') > sqlc.narg(after_key)
+                OR COALESCE(attendees.company_name, '') = sqlc.narg(after_key)
+                AND attendees.id > sqlc.narg(after_id)
+            )
+            OR sqlc.arg(sort_column) = 'role'
+            AND (
+                COALESCE(attendees.role, '') > sqlc.narg(after_key)
+                OR COALESCE(attendees.role, '') = sqlc.narg(after_key)
+                AND attendees.id > sqlc.narg(after_id)
+            )
+            OR sqlc.arg(sort_column) = 'table_no'
+            AND (
+                COALESCE(attendees.table_no, 0) > sqlc.narg(after_table_no)
+                OR COALESCE(attendees.table_no, 0) = sqlc.narg(after_table_no)
+                AND attendees.id > sqlc.narg(after_id)
+            )
+            OR sqlc.arg(sort_column) = 'checked_in_at'
+            AND (
+                COALESCE(attendees.checked_in_at, TIMESTAMP('1000-01-01')) > sqlc.narg(after_checked_in_at)
+                OR COALESCE(attendees.checked_in_at, TIMESTAMP('1000-01-01')) = sqlc.narg(after_checked_in_at)
+                AND attendees.id > sqlc.narg(after_id)
+            )
+            OR sqlc.arg(sort_column) = ''
+            AND attendees.id > sqlc.narg(after_id)
+        )
+        OR sqlc.arg(descending)
+        AND (
+            sqlc.arg(sort_column) = 'email'
+            AND (
+                attendees.email < sqlc.narg(after_key)
+                OR attendees.email = sqlc.narg(after_key)
+                AND attendees.id < sqlc.narg(after_id)
+            )
+            OR sqlc.arg(sort_column) = 'name'
+            AND (
+                CONCAT(attendees.last_name, ' ', attendees.first_name) < sqlc.narg(after_key)
+                OR CONCAT(attendees.last_name, ' ', attendees.first_name) = sqlc.narg(after_key)
+                AND attendees.id < sqlc.narg(after_id)
+            )
+            OR sqlc.arg(sort_column) = 'company_name'
+            AND (
+                COALESCE(attendees.company_name, '') < sqlc.narg(after_key)
+                OR COALESCE(attendees.company_name, '') = sqlc.narg(after_key)
+                AND attendees.id < sqlc.narg(after_id)
+            )
+            OR sqlc.arg(sort_column) = 'role'
+            AND (
+                COALESCE(attendees.role, '') < sqlc.narg(after_key)
+                OR COALESCE(attendees.role, '') = sqlc.narg(after_key)
+                AND attendees.id < sqlc.narg(after_id)
+            )
+            OR sqlc.arg(sort_column) = 'table_no'
+            AND (
+                COALESCE(attendees.table_no, 0) < sqlc.narg(after_table_no)
+                OR COALESCE(attendees.table_no, 0) = sqlc.narg(after_table_no)
+                AND attendees.id < sqlc.narg(after_id)
+            )
+            OR sqlc.arg(sort_column) = 'checked_in_at'
+            AND (
+                COALESCE(attendees.checked_in_at, TIMESTAMP('1000-01-01')) < sqlc.narg(after_checked_in_at)
+                OR COALESCE(attendees.checked_in_at, TIMESTAMP('1000-01-01')) = sqlc.narg(after_checked_in_at)
+                AND attendees.id < sqlc.narg(after_id)
+            )
+            OR sqlc.arg(sort_column) = ''
+            AND attendees.id < sqlc.narg(after_id)
+        )
+    )
+ORDER BY CASE
+        WHEN sqlc.arg(descending)
+        AND sqlc.arg(sort_column) = 'table_no' THEN COALESCE(attendees.table_no, 0)
+    END DESC,
+    CASE
+        WHEN sqlc.arg(descending)
+        AND sqlc.arg(sort_column) = 'checked_in_at' THEN COALESCE(attendees.checked_in_at, TIMESTAMP('1000-01-01'))
+    END DESC,
+    CASE
+        WHEN sqlc.arg(descending) THEN CASE
+            sqlc.arg(sort_column)
+            WHEN 'name' THEN CONCAT(attendees.last_name, ' ', attendees.first_name)
+            WHEN 'email' THEN attendees.email
+            WHEN 'company_name' THEN COALESCE(attendees.company_name, '')
+            WHEN 'role' THEN COALESCE(attendees.role, '')
+        END
+    END DESC,
+    CASE
+        WHEN sqlc.arg(descending) THEN attendees.id
+    END DESC,
+    CASE
+        WHEN sqlc.arg(sort_column) = 'table_no' THEN COALESCE(attendees.table_no, 0)
+    END,
+    CASE
+        WHEN sqlc.arg(sort_column) = 'checked_in_at' THEN COALESCE(attendees.checked_in_at, TIMESTAMP('1000-01-01'))
+    END,
+    CASE
+        sqlc.arg(sort_column)
+        WHEN 'name' THEN CONCAT(attendees.last_name, ' ', attendees.first_name)
+        WHEN 'email' THEN attendees.email
+        WHEN 'company_name' THEN COALESCE(attendees.company_name, '')
+        WHEN 'role' THEN COALESCE(attendees.role, '')
+    END,
+    attendees.id
+LIMIT ?;
+-- name: CountFilteredAttendeesByEventID :one
+SELECT COUNT(*)
+FROM attendees
+WHERE attendees.event_id = sqlc.arg(event_id)
+    AND attendees.deleted_at IS NULL
+    AND (
+        sqlc.narg(role) IS NULL
+        OR attendees.role = sqlc.narg(role)
+    )
+    AND (
+        sqlc.narg(company_name) IS NULL
+        OR attendees.company_name = sqlc.narg(company_name)
+    )
+    AND (
+        sqlc.narg(table_no) IS NULL
+        OR attendees.table_no = sqlc.narg(table_no)
+    )
+    AND (
+        sqlc.narg(attendance) IS NULL
+        OR attendees.attendance = sqlc.narg(attendance)
+    )
+    AND (
+        sqlc.narg(rsvp_status) IS NULL
+        OR attendees.rsvp_status = sqlc.narg(rsvp_status)
+    )
+    AND (
+        sqlc.narg(invited) IS NULL
+        OR EXISTS (
+            SELECT 1
+            FROM email_deliveries
+                JOIN email_template ON email_template.id = email_deliveries.template_id
+            WHERE email_deliveries.attendee_id = attendees.id
+                AND email_deliveries.status = 'sent'
+                AND email_template.purpose = 'invitation'
+        ) = sqlc.narg(invited)
+    )
+    AND (
+        sqlc.narg(field_name) IS NULL
+        OR EXISTS (
+            SELECT 1
+            FROM attendees_custom_fields
+            WHERE attendees_custom_fields.attendee_id = attendees.id
+                AND attendees_custom_fields.field_name = sqlc.narg(field_name)
+                AND (
+                    sqlc.narg(field_value) IS NULL
+                    OR attendees_custom_fields.field_value = sqlc.narg(field_value)
+                )
+        )
+    )
+    AND (
+        sqlc.narg(search) IS NULL
+        OR attendees.first_name LIKE sqlc.narg(search)
+        OR attendees.last_name LIKE sqlc.narg(search)
+        OR attendees.email LIKE sqlc.narg(search)
+        OR attendees.company_name LIKE sqlc.narg(search)
+    );
+-- name: UpdateAttendeeRsvpByID :exec
+UPDATE attendees
+SET rsvp_status = ?,
+    rsvp_reason = ?,
+    rsvp_plus_ones = ?,
+    rsvp_responded_at = CURRENT_TIMESTAMP
+WHERE id = ?
+    AND deleted_at IS NULL;
+-- name: GetRsvpCountsByEventID :many
+SELECT rsvp_status,
+    COUNT(*) AS attendees,
+    CAST(COALESCE(SUM(rsvp_plus_ones), 0) AS SIGNED) AS plus_ones
+FROM attendees
+WHERE event_id = ?
+    AND deleted_at IS NULL
+GROUP BY rsvp_status;
+-- name: GetGuestsByPrimaryAttendeeID :many
+SELECT *
+FROM attendees
+WHERE primary_attendee_id = ?
+    AND deleted_at IS NULL
+ORDER BY id;
+-- name: UpdatePrimaryAttendeeByID :exec
+UPDATE attendees
+SET primary_attendee_id = ?
+WHERE id = ?
+    AND deleted_at IS NULL;
+-- name: ClearGuestsByPrimaryAttendeeID :exec
+UPDATE attendees
+SET primary_attendee_id = NULL
+WHERE primary_attendee_id = ?;
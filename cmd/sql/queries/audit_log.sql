@@ -0,0 +1,90 @@
+-- name: CreateAuditEntry :exec
+INSERT INTO audit_log (
+        actor_id,
+        impersonator_id,
+        action,
+        target_type,
+        target_id,
+        event_id,
+        event_owner_id,
+        ip,
+        user_agent,
+        details
+    )
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+-- name: GetAuditEntries :many
+SELECT *
+FROM audit_log
+WHERE (
+        sqlc.narg(actor_id) IS NULL
+        OR actor_id = sqlc.narg(actor_id)
+    )
+    AND (
+        sqlc.narg(action_pattern) IS NULL
+        OR action LIKE sqlc.narg(action_pattern)
+    )
+    AND (
+        sqlc.narg(target_type) IS NULL
+        OR target_type = sqlc.narg(target_type)
+    )
+    AND (
+        sqlc.narg(target_id) IS NULL
+        OR target_id = sqlc.narg(target_id)
+    )
+    AND (
+        sqlc.narg(event_id) IS NULL
+        OR event_id = sqlc.narg(event_id)
+    )
+    AND (
+        sqlc.narg(event_owner_id) IS NULL
+        OR event_owner_id = sqlc.narg(event_owner_id)
+    )
+    AND (
+        sqlc.narg(from_time) IS NULL
+        OR created_at >= sqlc.narg(from_time)
+    )
+    AND (
+        sqlc.narg(to_time) IS NULL
+        OR created_at < sqlc.narg(to_time)
+    )
+    AND (
+        sqlc.narg(after_id) IS NULL
+        OR id < sqlc.narg(after_id)
+    )
+ORDER BY id DESC
+LIMIT ?;
+-- name: CountAuditEntries :one
+SELECT COUNT(*)
+FROM audit_log
+WHERE (
+        sqlc.narg(actor_id) IS NULL
+        OR actor_id = sqlc.narg(actor_id)
+    )
+    AND (
+        sqlc.narg(action_pattern) IS NULL
+        OR action LIKE sqlc.narg(action_pattern)
+    )
+    AND (
+        sqlc.narg(target_type) IS NULL
+        OR target_type = sqlc.narg(target_type)
+    )
+    AND (
+        sqlc.narg(target_id) IS NULL
+        OR target_id = sqlc.narg(target_id)
+    )
+    AND (
+        sqlc.narg(event_id) IS NULL
+        OR event_id = sqlc.narg(event_id)
+    )
+    AND (
+        sqlc.narg(event_owner_id) IS NULL
+        OR event_owner_id = sqlc.narg(event_owner_id)
+    )
+    AND (
+        sqlc.narg(from_time) IS NULL
+        OR created_at >= sqlc.narg(from_time)
+    )
+    AND (
+        sqlc.narg(to_time) IS NULL
+        OR created_at < sqlc.narg(to_time)
+    );
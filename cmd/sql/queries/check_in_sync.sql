@@ -0,0 +1,49 @@
+-- name: GetSyncVersion :one
+SELECT CAST(
+        TIMESTAMPDIFF(
+            MICROSECOND,
+            '1970-01-01 00:00:00',
+            CURRENT_TIMESTAMP(6)
+        ) AS SIGNED
+    ) AS version;
+-- name: GetAttendeesUpdatedSince :many
+SELECT *
+FROM attendees
+WHERE event_id = ?
+    AND registration_status = 'confirmed'
+    AND deleted_at IS NULL
+    AND updated_at >= TIMESTAMPADD(
+        MICROSECOND,
+        CAST(sqlc.arg(since) AS SIGNED),
+        '1970-01-01 00:00:00'
+    )
+ORDER BY id;
+-- name: GetDeletedAttendeesSince :many
+SELECT *
+FROM deleted_attendees
+WHERE event_id = ?
+    AND deleted_at >= TIMESTAMPADD(
+        MICROSECOND,
+        CAST(sqlc.arg(since) AS SIGNED),
+        '1970-01-01 00:00:00'
+    )
+ORDER BY attendee_id;
+-- name: CreateDeletedAttendee :exec
+INSERT INTO deleted_attendees (attendee_id, event_id, email)
+SELECT attendees.id,
+    attendees.event_id,
+    attendees.email
+FROM attendees
+WHERE attendees.id = ?
+    AND attendees.deleted_at IS NULL;
+-- name: CreateDeletedAttendeesByEventID :exec
+INSERT INTO deleted_attendees (attendee_id, event_id, email)
+SELECT attendees.id,
+    attendees.event_id,
+    attendees.email
+FROM attendees
+WHERE attendees.event_id = ?
+    AND attendees.deleted_at IS NULL;
+-- name: DeleteDeletedAttendee :exec
+DELETE FROM deleted_attendees
+WHERE attendee_id = ?;
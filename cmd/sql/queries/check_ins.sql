@@ -0,0 +1,86 @@
+-- name: CreateCheckIn :execlastid
+INSERT INTO check_ins (
+        attendee_id,
+        event_id,
+        session_id,
+        type,
+        scanned_by,
+        entrance,
+        device_id,
+        scanned_at
+    )
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+-- name: GetCheckInByID :one
+SELECT *
+FROM check_ins
+WHERE id = ?;
+-- name: GetCheckInsByAttendeeID :many
+SELECT *
+FROM check_ins
+WHERE attendee_id = ?
+ORDER BY scanned_at;
+-- name: GetCheckInsByEventID :many
+SELECT *
+FROM check_ins
+WHERE check_ins.event_id = sqlc.arg(event_id)
+    AND (
+        sqlc.narg(after_id) IS NULL
+        OR check_ins.scanned_at < sqlc.narg(after_scanned_at)
+        OR (
+            check_ins.scanned_at = sqlc.narg(after_scanned_at)
+            AND check_ins.id < sqlc.narg(after_id)
+        )
+    )
+ORDER BY check_ins.scanned_at DESC,
+    check_ins.id DESC
+LIMIT ?;
+-- name: GetCheckInsBySessionID :many
+SELECT *
+FROM check_ins
+WHERE check_ins.session_id = sqlc.arg(session_id)
+    AND (
+        sqlc.narg(after_id) IS NULL
+        OR check_ins.scanned_at < sqlc.narg(after_scanned_at)
+        OR (
+            check_ins.scanned_at = sqlc.narg(after_scanned_at)
+            AND check_ins.id < sqlc.narg(after_id)
+        )
+    )
+ORDER BY check_ins.scanned_at DESC,
+    check_ins.id DESC
+LIMIT ?;
+-- name: CountCheckInsByEventID :one
+SELECT COUNT(*)
+FROM check_ins
+WHERE check_ins.event_id = ?;
+-- name: CountCheckInsBySessionID :one
+SELECT COUNT(*)
+FROM check_ins
+WHERE check_ins.session_id = ?;
+-- name: DeleteCheckIn :exec
+DELETE FROM check_ins
+WHERE id = ?;
+-- name: RefreshAttendeeAttendance :exec
+UPDATE attendees
+SET checked_in_at = (
+        SELECT MIN(check_ins.scanned_at)
+        FROM check_ins
+        WHERE check_ins.attendee_id = attendees.id
+            AND check_ins.type = 'check_in'
+    ),
+    attendance = IF(checked_in_at IS NULL, 'No', 'Yes'),
+    sessions_attended = (
+        SELECT COUNT(DISTINCT check_ins.session_id)
+        FROM check_ins
+        WHERE check_ins.attendee_id = attendees.id
+            AND check_ins.type = 'check_in'
+    )
+WHERE attendees.id = ?;
+-- name: GetSessionAttendanceByEventID :many
+SELECT session_id,
+    COUNT(DISTINCT attendee_id) AS checked_in
+FROM check_ins
+WHERE event_id = ?
+    AND session_id IS NOT NULL
+    AND type = 'check_in'
+GROUP BY session_id;
@@ -0,0 +1,36 @@
+-- name: CreateEventCustomField :execlastid
+INSERT INTO event_custom_fields (event_id, name, field_type, required, position)
+VALUES (?, ?, ?, ?, ?);
+-- name: GetEventCustomFieldsByEventID :many
+SELECT *
+FROM event_custom_fields
+WHERE event_id = ?
+ORDER BY position,
+    id;
+-- name: GetEventCustomFieldByID :one
+SELECT *
+FROM event_custom_fields
+WHERE id = ?;
+-- name: DeleteEventCustomField :exec
+DELETE FROM event_custom_fields
+WHERE id = ?;
+-- name: CopyAttendeeCustomFields :exec
+INSERT INTO attendees_custom_fields (attendee_id, field_name, field_value, field_type)
+SELECT sqlc.arg(to_attendee_id),
+    field_name,
+    field_value,
+    field_type
+FROM attendees_custom_fields
+WHERE attendees_custom_fields.attendee_id = sqlc.arg(from_attendee_id);
+-- name: GetAttendeeCustomFieldValues :many
+SELECT *
+FROM attendees_custom_fields
+WHERE attendee_id = ?
+ORDER BY id;
+-- name: DeleteAttendeeCustomFieldValue :exec
+DELETE FROM attendees_custom_fields
+WHERE attendee_id = ?
+    AND field_name = ?;
+-- name: CreateAttendeeCustomFieldValue :exec
+INSERT INTO attendees_custom_fields (attendee_id, field_name, field_value, field_type)
+VALUES (?, ?, ?, ?);
@@ -0,0 +1,82 @@
+-- name: CreateEmailCampaign :execlastid
+INSERT INTO email_campaigns (
+        event_id,
+        name,
+        purpose,
+        anchor,
+        offset_minutes,
+        audience,
+        role
+    )
+VALUES (?, ?, ?, ?, ?, ?, ?);
+-- name: UpdateEmailCampaign :execresult
+UPDATE email_campaigns
+SET name = ?,
+    purpose = ?,
+    anchor = ?,
+    offset_minutes = ?,
+    audience = ?,
+    role = ?
+WHERE id = ?
+    AND status = 'scheduled';
+-- name: DeleteEmailCampaign :exec
+DELETE FROM email_campaigns
+WHERE id = ?;
+-- name: GetEmailCampaignsByEventID :many
+SELECT *
+FROM email_campaigns
+WHERE event_id = ?
+ORDER BY id;
+-- name: GetEmailCampaignByID :one
+SELECT *
+FROM email_campaigns
+WHERE id = ?;
+-- name: GetDueEmailCampaigns :many
+SELECT email_campaigns.*
+FROM email_campaigns
+    JOIN events ON events.event_id = email_campaigns.event_id
+WHERE email_campaigns.status = 'scheduled'
+    AND events.deleted_at IS NULL
+    AND DATE_ADD(
+        IF(
+            email_campaigns.anchor = 'start',
+            events.start_date,
+            events.end_date
+        ),
+        INTERVAL email_campaigns.offset_minutes MINUTE
+    ) <= CAST(sqlc.arg(now) AS DATETIME)
+ORDER BY email_campaigns.id;
+-- name: ClaimEmailCampaign :execresult
+UPDATE email_campaigns
+SET status = 'sending',
+    started_at = COALESCE(started_at, CURRENT_TIMESTAMP)
+WHERE id = ?
+    AND status = 'scheduled';
+-- name: FinishEmailCampaign :exec
+UPDATE email_campaigns
+SET status = ?,
+    last_error = ?,
+    finished_at = CURRENT_TIMESTAMP,
+    sent_count = (
+        SELECT COUNT(*)
+        FROM email_deliveries
+        WHERE email_deliveries.campaign_id = email_campaigns.id
+            AND email_deliveries.status = 'sent'
+    ),
+    failed_count = (
+        SELECT COUNT(*)
+        FROM email_deliveries
+        WHERE email_deliveries.campaign_id = email_campaigns.id
+            AND email_deliveries.status = 'failed'
+    )
+WHERE email_campaigns.id = ?;
+-- name: RequeueSendingEmailCampaigns :execresult
+UPDATE email_campaigns
+SET status = 'scheduled'
+WHERE status = 'sending';
+-- name: GetCampaignSentAttendeeIDs :many
+SELECT attendee_id
+FROM email_deliveries
+WHERE campaign_id = ?
+    AND status = 'sent'
+    AND attendee_id IS NOT NULL;
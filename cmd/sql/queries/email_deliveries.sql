@@ -0,0 +1,24 @@
+-- name: CreateEmailDelivery :exec
+INSERT INTO email_deliveries (
+        event_id,
+        attendee_id,
+        template_id,
+        email,
+        status,
+        error,
+        campaign_id,
+        tracking_id
+    )
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+-- name: GetEmailDeliveryStatsByEventID :one
+SELECT COUNT(*) AS total,
+    CAST(COALESCE(SUM(status = 'sent'), 0) AS SIGNED) AS sent,
+    CAST(COALESCE(SUM(status = 'failed'), 0) AS SIGNED) AS failed,
+    CAST(COALESCE(SUM(status = 'skipped'), 0) AS SIGNED) AS skipped,
+    COUNT(
+        DISTINCT CASE
+            WHEN status = 'sent' THEN attendee_id
+        END
+    ) AS recipients
+FROM email_deliveries
+WHERE event_id = ?;
@@ -0,0 +1,62 @@
+-- name: CreateEmailSuppression :execresult
+INSERT INTO email_suppressions (email, user_id, reason, detail)
+SELECT sqlc.arg(email),
+    sqlc.narg(user_id),
+    sqlc.arg(reason),
+    sqlc.narg(detail)
+FROM DUAL
+WHERE NOT EXISTS (
+        SELECT 1
+        FROM email_suppressions
+        WHERE email_suppressions.email = sqlc.arg(email)
+            AND email_suppressions.user_id <=> sqlc.narg(user_id)
+    ) ON DUPLICATE KEY UPDATE id = id;
+-- name: GetEmailSuppressionByID :one
+SELECT *
+FROM email_suppressions
+WHERE id = ?;
+-- name: GetEmailSuppressionForUser :one
+SELECT *
+FROM email_suppressions
+WHERE email = sqlc.arg(email)
+    AND (
+        user_id IS NULL
+        OR user_id = sqlc.arg(user_id)
+    )
+ORDER BY user_id IS NULL DESC,
+    id
+LIMIT 1;
+-- name: GetEmailSuppressionsByEventID :many
+SELECT email_suppressions.*
+FROM email_suppressions
+    JOIN attendees ON attendees.email = email_suppressions.email
+    JOIN events ON events.event_id = attendees.event_id
+WHERE attendees.event_id = ?
+    AND attendees.deleted_at IS NULL
+    AND (
+        email_suppressions.user_id IS NULL
+        OR email_suppressions.user_id = events.user_id
+    )
+ORDER BY email_suppressions.user_id IS NULL DESC,
+    email_suppressions.id;
+-- name: GetEmailSuppressionsByUserID :many
+SELECT email_suppressions.*
+FROM email_suppressions,
+    users
+WHERE users.user_id = sqlc.arg(user_id)
+    AND (
+        email_suppressions.user_id = users.user_id
+        OR (
+            email_suppressions.user_id IS NULL
+            AND email_suppressions.email IN (
+                SELECT attendees.email
+                FROM attendees
+                    JOIN events ON events.event_id = attendees.event_id
+                WHERE events.user_id = users.user_id
+            )
+        )
+    )
+ORDER BY email_suppressions.id;
+-- name: DeleteEmailSuppressionByID :exec
+DELETE FROM email_suppressions
+WHERE id = ?;
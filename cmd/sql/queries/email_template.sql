@@ -1,14 +1,24 @@
 -- name: GetEmailTemplateByID :one
 SELECT *
 FROM email_template
-WHERE id = ?;
--- name: GetEmailTemplateByEventID :one
+WHERE id = ?
+    AND deleted_at IS NULL;
+-- name: GetEmailTemplatesByEventID :many
+SELECT *
+FROM email_template
+WHERE event_id = ?
+    AND deleted_at IS NULL
+ORDER BY purpose;
+-- name: GetEmailTemplateByPurpose :one
 SELECT *
 FROM email_template
-WHERE event_id = ?;
--- name: CreateEmailTemplate :exec
+WHERE event_id = ?
+    AND purpose = ?
+    AND deleted_at IS NULL;
+-- name: CreateEmailTemplate :execlastid
 INSERT INTO email_template (
         event_id,
+        purpose,
         header_image,
         content,
         footer_image,
@@ -16,14 +26,33 @@ INSERT INTO email_template (
         bg_color,
         message
     )
-VALUES (?, ?, ?, ?, ?, ?, ?);
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
 -- name: UpdateEmailTemplateByID :exec
 UPDATE email_template
 SET event_id = ?,
+    purpose = ?,
     header_image = ?,
     content = ?,
     footer_image = ?,
     subject = ?,
     bg_color = ?,
     message = ?
-WHERE id = ?;
\ No newline at end of file
+WHERE id = ?
+    AND deleted_at IS NULL;
+-- name: DeleteEmailTemplateByID :exec
+UPDATE email_template
+SET deleted_at = CURRENT_TIMESTAMP
+WHERE id = ?
+    AND deleted_at IS NULL;
+-- name: GetDeletedEmailTemplateByID :one
+SELECT *
+FROM email_template
+WHERE id = ?
+    AND deleted_at IS NOT NULL;
+-- name: RestoreEmailTemplateByID :exec
+UPDATE email_template
+SET deleted_at = NULL
+WHERE id = ?;
+-- name: PurgeEmailTemplatesDeletedBefore :execresult
+DELETE FROM email_template
+WHERE deleted_at < ?;
\ No newline at end of file
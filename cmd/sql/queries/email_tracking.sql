@@ -0,0 +1,123 @@
+-- name: GetTrackedDeliveryByTrackingID :one
+SELECT email_deliveries.id,
+    email_deliveries.event_id,
+    email_deliveries.attendee_id,
+    email_deliveries.template_id
+FROM email_deliveries
+WHERE email_deliveries.tracking_id = ?;
+-- name: CreateEmailTrackingEvent :exec
+INSERT INTO email_tracking_events (delivery_id, kind, url)
+VALUES (?, ?, ?);
+-- name: GetEmailTrackingStatsByEventID :one
+SELECT COUNT(DISTINCT email_deliveries.id) AS tracked,
+    COUNT(
+        DISTINCT CASE
+            WHEN email_tracking_events.id IS NOT NULL THEN email_deliveries.id
+        END
+    ) AS opened,
+    COUNT(
+        DISTINCT CASE
+            WHEN email_tracking_events.kind = 'click' THEN email_deliveries.id
+        END
+    ) AS clicked,
+    CAST(
+        COALESCE(SUM(email_tracking_events.kind = 'open'), 0) AS SIGNED
+    ) AS opens,
+    CAST(
+        COALESCE(SUM(email_tracking_events.kind = 'click'), 0) AS SIGNED
+    ) AS clicks
+FROM email_deliveries
+    LEFT JOIN email_tracking_events ON email_tracking_events.delivery_id = email_deliveries.id
+WHERE email_deliveries.event_id = ?
+    AND email_deliveries.status = 'sent'
+    AND email_deliveries.tracking_id IS NOT NULL;
+-- name: GetEmailTrackingStatsByTemplate :many
+SELECT email_deliveries.template_id,
+    email_template.purpose,
+    email_template.subject,
+    COUNT(DISTINCT email_deliveries.id) AS tracked,
+    COUNT(
+        DISTINCT CASE
+            WHEN email_tracking_events.id IS NOT NULL THEN email_deliveries.id
+        END
+    ) AS opened,
+    COUNT(
+        DISTINCT CASE
+            WHEN email_tracking_events.kind = 'click' THEN email_deliveries.id
+        END
+    ) AS clicked,
+    CAST(
+        COALESCE(SUM(email_tracking_events.kind = 'open'), 0) AS SIGNED
+    ) AS opens,
+    CAST(
+        COALESCE(SUM(email_tracking_events.kind = 'click'), 0) AS SIGNED
+    ) AS clicks
+FROM email_deliveries
+    LEFT JOIN email_template ON email_template.id = email_deliveries.template_id
+    LEFT JOIN email_tracking_events ON email_tracking_events.delivery_id = email_deliveries.id
+WHERE email_deliveries.event_id = ?
+    AND email_deliveries.status = 'sent'
+    AND email_deliveries.tracking_id IS NOT NULL
+GROUP BY email_deliveries.template_id,
+    email_template.purpose,
+    email_template.subject
+ORDER BY email_deliveries.template_id;
+-- name: GetEmailTrackingStatsByAttendee :many
+SELECT attendees.id AS attendee_id,
+    attendees.first_name,
+    attendees.last_name,
+    attendees.email,
+    COUNT(DISTINCT email_deliveries.id) AS tracked,
+    CAST(
+        COALESCE(SUM(email_tracking_events.kind = 'open'), 0) AS SIGNED
+    ) AS opens,
+    CAST(
+        COALESCE(SUM(email_tracking_events.kind = 'click'), 0) AS SIGNED
+    ) AS clicks,
+    MIN(
+        CASE
+            WHEN email_tracking_events.kind = 'open' THEN email_tracking_events.created_at
+        END
+    ) AS first_opened_at,
+    MAX(
+        CASE
+            WHEN email_tracking_events.kind = 'open' THEN email_tracking_events.created_at
+        END
+    ) AS last_opened_at,
+    MAX(
+        CASE
+            WHEN email_tracking_events.kind = 'click' THEN email_tracking_events.created_at
+        END
+    ) AS last_clicked_at
+FROM email_deliveries
+    JOIN attendees ON attendees.id = email_deliveries.attendee_id
+    LEFT JOIN email_tracking_events ON email_tracking_events.delivery_id = email_deliveries.id
+WHERE email_deliveries.event_id = sqlc.arg(event_id)
+    AND email_deliveries.status = 'sent'
+    AND email_deliveries.tracking_id IS NOT NULL
+    AND (
+        sqlc.narg(after_id) IS NULL
+        OR attendees.id > sqlc.narg(after_id)
+    )
+GROUP BY attendees.id,
+    attendees.first_name,
+    attendees.last_name,
+    attendees.email
+ORDER BY attendees.id
+LIMIT ?;
+-- name: CountEmailTrackingAttendees :one
+SELECT COUNT(DISTINCT email_deliveries.attendee_id)
+FROM email_deliveries
+WHERE email_deliveries.event_id = ?
+    AND email_deliveries.status = 'sent'
+    AND email_deliveries.tracking_id IS NOT NULL;
+-- name: GetEmailTrackingOptOut :one
+SELECT COUNT(*)
+FROM email_tracking_opt_outs
+WHERE event_id = ?;
+-- name: CreateEmailTrackingOptOut :exec
+INSERT IGNORE INTO email_tracking_opt_outs (event_id)
+VALUES (?);
+-- name: DeleteEmailTrackingOptOut :exec
+DELETE FROM email_tracking_opt_outs
+WHERE event_id = ?;
@@ -0,0 +1,55 @@
+-- name: CreateEventSession :execlastid
+INSERT INTO event_sessions (
+        event_id,
+        title,
+        start_time,
+        end_time,
+        room,
+        capacity,
+        speaker
+    )
+VALUES (?, ?, ?, ?, ?, ?, ?);
+-- name: GetEventSessionsByEventID :many
+SELECT *
+FROM event_sessions
+WHERE event_id = ?
+ORDER BY start_time,
+    id;
+-- name: GetEventSessionByID :one
+SELECT *
+FROM event_sessions
+WHERE id = ?;
+-- name: UpdateEventSession :exec
+UPDATE event_sessions
+SET title = ?,
+    start_time = ?,
+    end_time = ?,
+    room = ?,
+    capacity = ?,
+    speaker = ?
+WHERE id = ?;
+-- name: DeleteEventSession :exec
+DELETE FROM event_sessions
+WHERE id = ?;
+-- name: ReserveSessionSeat :execresult
+UPDATE event_sessions
+SET signed_up = signed_up + 1
+WHERE id = ?
+    AND (
+        capacity = 0
+        OR signed_up < capacity
+    );
+-- name: ReleaseSessionSeat :exec
+UPDATE event_sessions
+SET signed_up = GREATEST(signed_up - 1, 0)
+WHERE id = ?;
+-- name: RefreshSessionSignupCountsByEventID :exec
+UPDATE event_sessions
+SET signed_up = (
+        SELECT COUNT(*)
+        FROM session_signups
+            JOIN attendees ON attendees.id = session_signups.attendee_id
+        WHERE session_signups.session_id = event_sessions.id
+            AND attendees.deleted_at IS NULL
+    )
+WHERE event_sessions.event_id = ?;
@@ -0,0 +1,34 @@
+-- name: CreateEventTable :exec
+INSERT INTO event_tables (event_id, table_no, name, capacity)
+VALUES (?, ?, ?, ?);
+-- name: GetEventTablesByEventID :many
+SELECT *
+FROM event_tables
+WHERE event_id = ?
+ORDER BY table_no;
+-- name: GetEventTableByTableNo :one
+SELECT *
+FROM event_tables
+WHERE event_id = ?
+    AND table_no = ?;
+-- name: UpdateEventTable :exec
+UPDATE event_tables
+SET name = ?,
+    capacity = ?
+WHERE event_id = ?
+    AND table_no = ?;
+-- name: DeleteEventTable :exec
+DELETE FROM event_tables
+WHERE event_id = ?
+    AND table_no = ?;
+-- name: AssignAttendeeTable :exec
+UPDATE attendees
+SET table_no = ?
+WHERE id = ?
+    AND event_id = ?
+    AND deleted_at IS NULL;
+-- name: UnseatAttendeesByTableNo :exec
+UPDATE attendees
+SET table_no = NULL
+WHERE event_id = ?
+    AND table_no = ?;
@@ -0,0 +1,15 @@
+-- name: CreateEventTemplate :execlastid
+INSERT INTO event_templates (user_id, name, blueprint)
+VALUES (?, ?, ?);
+-- name: GetEventTemplatesByUserID :many
+SELECT *
+FROM event_templates
+WHERE user_id = ?
+ORDER BY name;
+-- name: GetEventTemplateByID :one
+SELECT *
+FROM event_templates
+WHERE id = ?;
+-- name: DeleteEventTemplate :exec
+DELETE FROM event_templates
+WHERE id = ?;
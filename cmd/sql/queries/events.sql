@@ -1,4 +1,4 @@
--- name: CreateEvent :exec
+-- name: CreateEvent :execlastid
 INSERT INTO events (
         title,
         description,
@@ -15,23 +15,54 @@ SET title = ?,
     start_date = ?,
     end_date = ?,
     location = ?
-WHERE event_id = ?;
+WHERE event_id = ?
+    AND deleted_at IS NULL;
 -- name: DeleteEventByID :exec
-DELETE FROM events
-WHERE event_id = ?;
+UPDATE events
+SET deleted_at = CURRENT_TIMESTAMP
+WHERE event_id = ?
+    AND deleted_at IS NULL;
 -- name: DeleteAllEventsByUserID :exec
-DELETE FROM events
-WHERE user_id = ?;
+UPDATE events
+SET deleted_at = CURRENT_TIMESTAMP
+WHERE user_id = ?
+    AND deleted_at IS NULL;
 -- name: GetAllEventsByUserID :many
 SELECT *
 FROM events
-WHERE user_id = ?;
+WHERE user_id = ?
+    AND deleted_at IS NULL;
 -- name: GetEventByTitle :one
 SELECT *
 FROM events
-WHERE title = ?;
+WHERE title = ?
+    AND deleted_at IS NULL;
 -- name: GetEventByID :one
 SELECT *
 FROM events
+WHERE event_id = ?
+    AND deleted_at IS NULL;
+-- name: GetDeletedEventsByUserID :many
+SELECT *
+FROM events
+WHERE user_id = ?
+    AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC;
+-- name: GetDeletedEventByID :one
+SELECT *
+FROM events
+WHERE event_id = ?
+    AND deleted_at IS NOT NULL;
+-- name: RestoreEventByID :exec
+UPDATE events
+SET deleted_at = NULL
 WHERE event_id = ?;
+-- name: GetEventIDsDeletedBefore :many
+SELECT event_id
+FROM events
+WHERE deleted_at < ?;
+-- name: PurgeEventByID :exec
+DELETE FROM events
+WHERE event_id = ?
+    AND deleted_at IS NOT NULL;
 
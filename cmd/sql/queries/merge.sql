@@ -0,0 +1,103 @@
+-- name: MergeAttendeeDetails :exec
+UPDATE attendees AS kept,
+    attendees AS merged
+SET kept.company_name = COALESCE(NULLIF(kept.company_name, ''), merged.company_name),
+    kept.title = COALESCE(NULLIF(kept.title, ''), merged.title),
+    kept.table_no = COALESCE(NULLIF(kept.table_no, 0), merged.table_no),
+    kept.role = COALESCE(NULLIF(kept.role, ''), merged.role),
+    kept.ticket_type_id = COALESCE(kept.ticket_type_id, merged.ticket_type_id),
+    kept.registration_status = IF(
+        merged.registration_status = 'confirmed',
+        'confirmed',
+        kept.registration_status
+    ),
+    kept.attendance = IF(merged.attendance = 'Yes', 'Yes', kept.attendance),
+    kept.checked_in_at = CASE
+        WHEN kept.checked_in_at IS NULL THEN merged.checked_in_at
+        WHEN merged.checked_in_at IS NULL THEN kept.checked_in_at
+        ELSE LEAST(kept.checked_in_at, merged.checked_in_at)
+    END,
+    kept.sessions_attended = GREATEST(kept.sessions_attended, merged.sessions_attended),
+    kept.rsvp_status = IF(
+        COALESCE(
+            merged.rsvp_responded_at > kept.rsvp_responded_at,
+            merged.rsvp_responded_at IS NOT NULL
+        ),
+        merged.rsvp_status,
+        kept.rsvp_status
+    ),
+    kept.rsvp_reason = IF(
+        COALESCE(
+            merged.rsvp_responded_at > kept.rsvp_responded_at,
+            merged.rsvp_responded_at IS NOT NULL
+        ),
+        merged.rsvp_reason,
+        kept.rsvp_reason
+    ),
+    kept.rsvp_plus_ones = IF(
+        COALESCE(
+            merged.rsvp_responded_at > kept.rsvp_responded_at,
+            merged.rsvp_responded_at IS NOT NULL
+        ),
+        merged.rsvp_plus_ones,
+        kept.rsvp_plus_ones
+    ),
+    kept.rsvp_responded_at = COALESCE(
+        GREATEST(kept.rsvp_responded_at, merged.rsvp_responded_at),
+        kept.rsvp_responded_at,
+        merged.rsvp_responded_at
+    )
+WHERE kept.id = sqlc.arg(keep_id)
+    AND merged.id = sqlc.arg(merge_id);
+-- name: MoveGuests :exec
+UPDATE attendees
+SET primary_attendee_id = IF(id = sqlc.arg(keep_id), NULL, sqlc.arg(keep_id))
+WHERE primary_attendee_id = sqlc.arg(merge_id);
+-- name: MoveCheckIns :exec
+UPDATE check_ins
+SET attendee_id = sqlc.arg(keep_id)
+WHERE attendee_id = sqlc.arg(merge_id);
+-- name: DeleteBlankCustomFieldValues :exec
+DELETE kept
+FROM attendees_custom_fields AS kept
+    JOIN attendees_custom_fields AS merged ON merged.field_name = kept.field_name
+WHERE kept.attendee_id = sqlc.arg(keep_id)
+    AND COALESCE(kept.field_value, '') = ''
+    AND merged.attendee_id = sqlc.arg(merge_id)
+    AND COALESCE(merged.field_value, '') <> '';
+-- name: MoveCustomFieldValues :exec
+UPDATE attendees_custom_fields
+SET attendees_custom_fields.attendee_id = sqlc.arg(keep_id)
+WHERE attendees_custom_fields.attendee_id = sqlc.arg(merge_id)
+    AND attendees_custom_fields.field_name NOT IN (
+        SELECT kept.field_name
+        FROM (
+                SELECT kept_fields.field_name
+                FROM attendees_custom_fields AS kept_fields
+                WHERE kept_fields.attendee_id = sqlc.arg(keep_id)
+                    AND kept_fields.field_name IS NOT NULL
+            ) AS kept
+    );
+-- name: MoveSessionSignups :exec
+UPDATE session_signups
+SET session_signups.attendee_id = sqlc.arg(keep_id)
+WHERE session_signups.attendee_id = sqlc.arg(merge_id)
+    AND session_signups.session_id NOT IN (
+        SELECT kept.session_id
+        FROM (
+                SELECT kept_signups.session_id
+                FROM session_signups AS kept_signups
+                WHERE kept_signups.attendee_id = sqlc.arg(keep_id)
+            ) AS kept
+    );
+-- name: MoveEmailDeliveries :exec
+UPDATE email_deliveries
+SET attendee_id = sqlc.arg(keep_id)
+WHERE attendee_id = sqlc.arg(merge_id);
+-- name: MoveOrders :exec
+UPDATE orders
+SET attendee_id = sqlc.arg(keep_id)
+WHERE attendee_id = sqlc.arg(merge_id);
+-- name: DeleteMergedAttendee :exec
+DELETE FROM attendees
+WHERE id = ?;
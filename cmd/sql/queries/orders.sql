@@ -0,0 +1,75 @@
+-- name: CreateOrder :execlastid
+INSERT INTO orders (
+        reference,
+        event_id,
+        attendee_id,
+        ticket_type_id,
+        email,
+        first_name,
+        last_name,
+        amount_cents,
+        currency,
+        provider
+    )
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
+-- name: SetOrderCheckout :exec
+UPDATE orders
+SET provider_ref = ?,
+    checkout_url = ?
+WHERE id = ?;
+-- name: GetOrderByID :one
+SELECT *
+FROM orders
+WHERE id = ?;
+-- name: GetOrderByReference :one
+SELECT *
+FROM orders
+WHERE reference = ?;
+-- name: GetOrderByProviderRef :one
+SELECT *
+FROM orders
+WHERE provider = ?
+    AND provider_ref = ?;
+-- name: GetOrdersByEventID :many
+SELECT *
+FROM orders
+WHERE orders.event_id = sqlc.arg(event_id)
+    AND (
+        sqlc.narg(after_id) IS NULL
+        OR orders.id < sqlc.narg(after_id)
+    )
+ORDER BY orders.id DESC
+LIMIT ?;
+-- name: CountOrdersByEventID :one
+SELECT COUNT(*)
+FROM orders
+WHERE orders.event_id = ?;
+-- name: MarkOrderPaid :execresult
+UPDATE orders
+SET status = 'paid',
+    receipt_number = ?,
+    paid_at = ?
+WHERE id = ?
+    AND status = 'pending';
+-- name: MarkOrderFailed :execresult
+UPDATE orders
+SET status = 'failed'
+WHERE id = ?
+    AND status = 'pending';
+-- name: MarkOrderRefunded :execresult
+UPDATE orders
+SET status = 'refunded',
+    refunded_at = ?
+WHERE id = ?
+    AND status = 'paid';
+-- name: GetPendingOrdersCreatedBefore :many
+SELECT *
+FROM orders
+WHERE status = 'pending'
+    AND created_at < ?
+ORDER BY id;
+-- name: MarkOrderExpired :execresult
+UPDATE orders
+SET status = 'expired'
+WHERE id = ?
+    AND status = 'pending';
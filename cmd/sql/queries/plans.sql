@@ -0,0 +1,73 @@
+-- name: GetPlans :many
+SELECT *
+FROM plans
+ORDER BY id;
+-- name: GetPlanByID :one
+SELECT *
+FROM plans
+WHERE id = ?;
+-- name: GetPlanByUserID :one
+SELECT plans.*
+FROM plans
+    JOIN users ON users.plan_id = plans.id
+WHERE users.user_id = ?;
+-- name: CreatePlan :execlastid
+INSERT INTO plans (
+        name,
+        max_events,
+        max_attendees_per_event,
+        emails_per_month,
+        custom_branding
+    )
+VALUES (?, ?, ?, ?, ?);
+-- name: UpdatePlan :exec
+UPDATE plans
+SET name = ?,
+    max_events = ?,
+    max_attendees_per_event = ?,
+    emails_per_month = ?,
+    custom_branding = ?
+WHERE id = ?;
+-- name: UpdateUserPlan :exec
+UPDATE users
+SET plan_id = ?
+WHERE user_id = ?;
+-- name: CountEventsByUserID :one
+SELECT COUNT(*)
+FROM events
+WHERE user_id = ?
+    AND deleted_at IS NULL;
+-- name: GetAttendeeCountsByUserID :many
+SELECT events.event_id,
+    events.title,
+    COUNT(attendees.id) AS attendees
+FROM events
+    LEFT JOIN attendees ON attendees.event_id = events.event_id
+    AND attendees.deleted_at IS NULL
+WHERE events.user_id = ?
+    AND events.deleted_at IS NULL
+GROUP BY events.event_id,
+    events.title
+ORDER BY events.event_id;
+-- name: CreateEmailUsage :exec
+INSERT IGNORE INTO email_usage (user_id, period)
+VALUES (?, ?);
+-- name: ReserveEmails :execresult
+UPDATE email_usage
+SET sent = sent + sqlc.arg(count)
+WHERE user_id = sqlc.arg(user_id)
+    AND period = sqlc.arg(period)
+    AND (
+        sqlc.arg(max_sent) = 0
+        OR sent + sqlc.arg(count) <= sqlc.arg(max_sent)
+    );
+-- name: ReleaseEmails :exec
+UPDATE email_usage
+SET sent = GREATEST(sent - sqlc.arg(count), 0)
+WHERE user_id = sqlc.arg(user_id)
+    AND period = sqlc.arg(period);
+-- name: GetEmailsSent :one
+SELECT CAST(COALESCE(SUM(sent), 0) AS SIGNED) AS sent
+FROM email_usage
+WHERE user_id = ?
+    AND period = ?;
@@ -0,0 +1,29 @@
+-- name: CreateSessionSignup :exec
+INSERT INTO session_signups (session_id, attendee_id)
+VALUES (?, ?);
+-- name: GetSessionSignup :one
+SELECT *
+FROM session_signups
+WHERE session_id = ?
+    AND attendee_id = ?;
+-- name: DeleteSessionSignup :exec
+DELETE FROM session_signups
+WHERE session_id = ?
+    AND attendee_id = ?;
+-- name: GetSessionSignupsBySessionID :many
+SELECT attendees.id AS attendee_id,
+    attendees.first_name,
+    attendees.last_name,
+    attendees.email,
+    attendees.company_name,
+    session_signups.created_at AS signed_up_at
+FROM session_signups
+    JOIN attendees ON attendees.id = session_signups.attendee_id
+WHERE session_signups.session_id = ?
+    AND attendees.deleted_at IS NULL
+ORDER BY attendees.last_name,
+    attendees.first_name;
+-- name: GetAttendeeIDsBySessionCheckIns :many
+SELECT DISTINCT attendee_id
+FROM check_ins
+WHERE session_id = ?;
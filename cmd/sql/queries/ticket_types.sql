@@ -0,0 +1,56 @@
+-- name: CreateTicketType :execlastid
+INSERT INTO ticket_types (
+        event_id,
+        name,
+        quantity,
+        price_cents,
+        currency,
+        sale_starts_at,
+        sale_ends_at,
+        visible
+    )
+VALUES (?, ?, ?, ?, ?, ?, ?, ?);
+-- name: GetTicketTypesByEventID :many
+SELECT *
+FROM ticket_types
+WHERE event_id = ?
+ORDER BY price_cents,
+    name;
+-- name: GetTicketTypeByID :one
+SELECT *
+FROM ticket_types
+WHERE id = ?;
+-- name: UpdateTicketType :exec
+UPDATE ticket_types
+SET name = ?,
+    quantity = ?,
+    price_cents = ?,
+    currency = ?,
+    sale_starts_at = ?,
+    sale_ends_at = ?,
+    visible = ?
+WHERE id = ?;
+-- name: DeleteTicketType :exec
+DELETE FROM ticket_types
+WHERE id = ?;
+-- name: ReserveTicket :execresult
+UPDATE ticket_types
+SET sold = sold + 1
+WHERE id = ?
+    AND (
+        quantity = 0
+        OR sold < quantity
+    );
+-- name: ReleaseTicket :exec
+UPDATE ticket_types
+SET sold = GREATEST(sold - 1, 0)
+WHERE id = ?;
+-- name: RefreshTicketSoldCountsByEventID :exec
+UPDATE ticket_types
+SET sold = (
+        SELECT COUNT(*)
+        FROM attendees
+        WHERE attendees.ticket_type_id = ticket_types.id
+            AND attendees.deleted_at IS NULL
+    )
+WHERE ticket_types.event_id = ?;
@@ -18,22 +18,6 @@ INSERT INTO users (
         subscription_id
     )
 VALUES (1, ?, ?, ?, ?, 1);
--- name: GetAllUsersPaginated :many
-SELECT users.user_id,
-    roles.name AS 'role',
-    users.first_name,
-    users.last_name,
-    users.email,
-    users.password,
-    users.verify,
-    subscriptions.status AS 'subscription status',
-    users.created_at,
-    users.updated_at
-FROM users
-    JOIN roles USING(role_id)
-    JOIN subscriptions USING (subscription_id)
-ORDER BY users.created_at DESC
-LIMIT ? OFFSET ?;
 -- name: GetUserByID :one
 SELECT users.user_id,
     roles.name AS 'role',
@@ -44,7 +28,9 @@ SELECT users.user_id,
     users.verify,
     subscriptions.status AS 'subscription status',
     users.created_at,
-    users.updated_at
+    users.updated_at,
+    users.disabled_at,
+    users.locked_until
 FROM users users
     JOIN roles roles USING(role_id)
     JOIN subscriptions subscriptions USING (subscription_id)
@@ -59,7 +45,9 @@ SELECT users.user_id,
     subscriptions.status AS 'subscription status',
     users.verify,
     users.created_at,
-    users.updated_at
+    users.updated_at,
+    users.disabled_at,
+    users.locked_until
 FROM users users
     JOIN roles roles USING(role_id)
     JOIN subscriptions subscriptions USING (subscription_id)
@@ -97,4 +85,88 @@ WHERE user_id = ?;
 -- name: UpdateUserVerificationStatus :exec
 UPDATE users
 SET verify = ?
+WHERE user_id = ?;
+-- name: SearchUsers :many
+SELECT users.user_id,
+    roles.name AS 'role',
+    users.first_name,
+    users.last_name,
+    users.email,
+    users.password,
+    users.verify,
+    subscriptions.status AS 'subscription status',
+    users.created_at,
+    users.updated_at,
+    users.disabled_at,
+    users.locked_until
+FROM users
+    JOIN roles USING(role_id)
+    JOIN subscriptions USING (subscription_id)
+WHERE (
+        sqlc.narg(email_pattern) IS NULL
+        OR users.email LIKE sqlc.narg(email_pattern)
+    )
+    AND (
+        sqlc.narg(role) IS NULL
+        OR roles.name = sqlc.narg(role)
+    )
+    AND (
+        sqlc.narg(verify) IS NULL
+        OR users.verify = sqlc.narg(verify)
+    )
+    AND (
+        sqlc.narg(subscription) IS NULL
+        OR subscriptions.status = sqlc.narg(subscription)
+    )
+    -- A NULL disabled matches every user. The argument appears once so that
+    -- sqlc types it as a flag rather than as disabled_at.
+    AND (users.disabled_at IS NOT NULL) = COALESCE(
+        CAST(sqlc.narg(disabled) AS UNSIGNED),
+        users.disabled_at IS NOT NULL
+    )
+    AND (
+        sqlc.narg(after_id) IS NULL
+        OR users.created_at < sqlc.narg(after_time)
+        OR (
+            users.created_at = sqlc.narg(after_time)
+            AND users.user_id < sqlc.narg(after_id)
+        )
+    )
+ORDER BY users.created_at DESC,
+    users.user_id DESC
+LIMIT ?;
+-- name: CountSearchUsers :one
+SELECT COUNT(*)
+FROM users
+    JOIN roles USING(role_id)
+    JOIN subscriptions USING (subscription_id)
+WHERE (
+        sqlc.narg(email_pattern) IS NULL
+        OR users.email LIKE sqlc.narg(email_pattern)
+    )
+    AND (
+        sqlc.narg(role) IS NULL
+        OR roles.name = sqlc.narg(role)
+    )
+    AND (
+        sqlc.narg(verify) IS NULL
+        OR users.verify = sqlc.narg(verify)
+    )
+    AND (
+        sqlc.narg(subscription) IS NULL
+        OR subscriptions.status = sqlc.narg(subscription)
+    )
+    -- A NULL disabled matches every user. The argument appears once so that
+    -- sqlc types it as a flag rather than as disabled_at.
+    AND (users.disabled_at IS NOT NULL) = COALESCE(
+        CAST(sqlc.narg(disabled) AS UNSIGNED),
+        users.disabled_at IS NOT NULL
+    );
+-- name: SetUserDisabledAt :exec
+UPDATE users
+SET disabled_at = ?
+WHERE user_id = ?;
+-- name: SetUserLockedUntil :exec
+UPDATE users
+SET locked_until = ?
 WHERE user_id = ?;
\ No newline at end of file
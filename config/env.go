@@ -28,6 +28,11 @@ type Config struct {
 	CloudinaryCloudName    string
 	CloudinaryAPIKey       string
 	CloudinarySecretKey    string
+	PaymentProvider        string
+	PaymentWebhookSecret   string
+	BounceWebhookSecret    string
+	DeletedRetentionDays   int64
+	CheckoutExpiryMinutes  int64
 }
 
 var Envs = initConfig()
@@ -57,6 +62,11 @@ func initConfig() Config {
 		CloudinaryCloudName:    getEnv("CLOUDINARY_CLOUD_NAME", ""),
 		CloudinaryAPIKey:       getEnv("CLOUDINARY_API_KEY", ""),
 		CloudinarySecretKey:    getEnv("CLOUDINARY_SECRET_KEY", ""),
+		PaymentProvider:        getEnv("PAYMENT_PROVIDER", "fake"),
+		PaymentWebhookSecret:   getEnv("PAYMENT_WEBHOOK_SECRET", ""),
+		BounceWebhookSecret:    getEnv("BOUNCE_WEBHOOK_SECRET", ""),
+		DeletedRetentionDays:   getEnvAsInt("DELETED_RETENTION_DAYS", 30),
+		CheckoutExpiryMinutes:  getEnvAsInt("CHECKOUT_EXPIRY_MINUTES", 30),
 	}
 }
 
@@ -1,10 +1,13 @@
 package db
 
 import (
+	"context"
 	"database/sql"
 	"log"
 
 	"github.com/go-sql-driver/mysql"
+
+	"github.com/jayden1905/event-registration-software/cmd/pkg/database"
 )
 
 func NewMySQLStorage(cfg mysql.Config) (*sql.DB, error) {
@@ -15,3 +18,19 @@ func NewMySQLStorage(cfg mysql.Config) (*sql.DB, error) {
 
 	return db, nil
 }
+
+// WithTx runs fn with the queries bound to one transaction. The transaction
+// is committed when fn succeeds and rolled back on any error.
+func WithTx(ctx context.Context, conn *sql.DB, queries *database.Queries, fn func(q *database.Queries) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(queries.WithTx(tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,462 @@
+package admin
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/config"
+	"github.com/jayden1905/event-registration-software/service/audit"
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+type Handler struct {
+	store         types.AdminStore
+	userStore     types.UserStore
+	eventStore    types.EventStore
+	attendeeStore types.AttendeeStore
+}
+
+func NewHandler(store types.AdminStore, userStore types.UserStore, eventStore types.EventStore, attendeeStore types.AttendeeStore) *Handler {
+	return &Handler{
+		store:         store,
+		userStore:     userStore,
+		eventStore:    eventStore,
+		attendeeStore: attendeeStore,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/admin/users", auth.WithSuperUser(h.handleSearchUsers, h.userStore))
+	router.Post("/admin/users/:id/impersonate", auth.WithSuperUser(h.handleImpersonate, h.userStore))
+	router.Put("/admin/users/:id/verification", auth.WithSuperUser(h.handleSetVerification, h.userStore))
+	router.Post("/admin/users/:id/reset_password", auth.WithSuperUser(h.handleResetPassword, h.userStore))
+	router.Post("/admin/users/:id/disable", auth.WithSuperUser(h.handleDisableUser, h.userStore))
+	router.Post("/admin/users/:id/enable", auth.WithSuperUser(h.handleEnableUser, h.userStore))
+	router.Post("/admin/users/:id/lock", auth.WithSuperUser(h.handleLockUser, h.userStore))
+	router.Delete("/admin/users/:id/lock", auth.WithSuperUser(h.handleUnlockUser, h.userStore))
+	router.Get("/admin/events", auth.WithSuperUser(h.handleGetEvents, h.userStore))
+	router.Get("/admin/events/:id", auth.WithSuperUser(h.handleGetEvent, h.userStore))
+	router.Get("/admin/events/:id/attendees", auth.WithSuperUser(h.handleGetEventAttendees, h.userStore))
+	router.Get("/admin/stats", auth.WithSuperUser(h.handleGetStats, h.userStore))
+}
+
+// Handler to search users by email, role, verification, subscription and whether they are disabled
+func (h *Handler) handleSearchUsers(c *fiber.Ctx) error {
+	page, ferr := utils.ParsePage(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	filter := types.UserFilter{
+		Email:        c.Query("email"),
+		Role:         c.Query("role"),
+		Subscription: c.Query("subscription"),
+	}
+
+	var err error
+	if filter.Verified, err = boolQuery(c, "verified"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid verified filter"})
+	}
+	if filter.Disabled, err = boolQuery(c, "disabled"); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid disabled filter"})
+	}
+
+	users, next, err := h.userStore.SearchUsers(filter, page)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to search users"})
+	}
+
+	var total *int64
+	if page.WithTotal {
+		count, err := h.userStore.CountUsers(filter)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to count users"})
+		}
+		total = &count
+	}
+
+	return c.Status(fiber.StatusOK).JSON(utils.NewPage(users, next, total, utils.AppliedFilters(filter)))
+}
+
+// Handler for a super user to sign in as another user. The token is short-lived
+// and everything done with it is traced back to the super user.
+func (h *Handler) handleImpersonate(c *fiber.Ctx) error {
+	user, ferr := h.getTargetUser(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	superUser, err := utils.IsSuperUser(user.ID, h.userStore)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get user role"})
+	}
+	if superUser {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Super users cannot be impersonated"})
+	}
+	if reason := auth.AccountBlockedReason(user, time.Now()); reason != "" {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "This account is disabled or locked"})
+	}
+
+	impersonatorID := auth.GetUserIDFromContext(c)
+	token, err := auth.CreateImpersonationJWT([]byte(config.Envs.JWTSecret), int(user.ID), int(impersonatorID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create token"})
+	}
+
+	audit.Note(c, audit.Mutation{
+		Action:     "user.impersonate",
+		TargetType: "user",
+		TargetID:   user.ID,
+		Details:    map[string]any{"email": user.Email},
+	})
+
+	expiresIn := int(auth.ImpersonationExpiration.Seconds())
+	c.Cookie(&fiber.Cookie{
+		Name:     "token",
+		Value:    token,
+		HTTPOnly: true,                     // Disallow JS access to the cookie
+		Secure:   config.Envs.ISProduction, // Set to true in production (HTTPS)
+		SameSite: "Lax",                    // Prevent CSRF attacks
+		Path:     "/",                      // Valid for the entire site
+		MaxAge:   expiresIn,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"token": token, "expires_in": fmt.Sprintf("%d", expiresIn)})
+}
+
+// Handler to force the verification status of a user
+func (h *Handler) handleSetVerification(c *fiber.Ctx) error {
+	user, ferr := h.getTargetUser(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var payload types.SetVerificationPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	if err := h.userStore.SetUserVerification(c.Context(), user.ID, *payload.Verify); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update verification"})
+	}
+
+	before := *user
+	user.Verify = *payload.Verify
+	user.Password = ""
+
+	action := "user.verify"
+	if !user.Verify {
+		action = "user.unverify"
+	}
+	audit.Note(c, audit.Mutation{Action: action, TargetType: "user", TargetID: user.ID, Before: before, After: user})
+
+	return c.Status(fiber.StatusOK).JSON(user)
+}
+
+// Handler to reset the password of a user. Without a password in the payload a
+// random one is generated; it is returned once and never stored in plain text.
+func (h *Handler) handleResetPassword(c *fiber.Ctx) error {
+	user, ferr := h.getTargetUser(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var payload types.ResetPasswordPayload
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&payload); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+	}
+
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	password := payload.Password
+	generated := password == ""
+	if generated {
+		var err error
+		if password, err = newPassword(); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to generate password"})
+		}
+	}
+
+	hashedPassword, err := auth.HashPassword(password)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Error hashing password"})
+	}
+
+	if err := h.userStore.UpdateUserPassword(c.Context(), user.ID, hashedPassword); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to reset password"})
+	}
+
+	audit.Note(c, audit.Mutation{
+		Action:     "user.reset_password",
+		TargetType: "user",
+		TargetID:   user.ID,
+		Details:    map[string]any{"generated": generated},
+	})
+
+	response := fiber.Map{"message": "Password reset successfully"}
+	if generated {
+		response["password"] = password
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// Handler to disable a user, who is signed out and cannot sign back in until enabled
+func (h *Handler) handleDisableUser(c *fiber.Ctx) error {
+	return h.setDisabled(c, true)
+}
+
+// Handler to enable a disabled user
+func (h *Handler) handleEnableUser(c *fiber.Ctx) error {
+	return h.setDisabled(c, false)
+}
+
+// Handler to lock a user out for a number of minutes
+func (h *Handler) handleLockUser(c *fiber.Ctx) error {
+	user, ferr := h.getTargetUser(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+	if user.ID == auth.GetUserIDFromContext(c) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "You cannot lock your own account"})
+	}
+
+	var payload types.LockUserPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	lockedUntil := time.Now().Add(time.Duration(payload.Minutes) * time.Minute).UTC()
+	if err := h.userStore.SetUserLockedUntil(c.Context(), user.ID, &lockedUntil); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to lock user"})
+	}
+
+	before := *user
+	user.LockedUntil = &lockedUntil
+	user.Password = ""
+
+	audit.Note(c, audit.Mutation{Action: "user.lock", TargetType: "user", TargetID: user.ID, Before: before, After: user})
+
+	return c.Status(fiber.StatusOK).JSON(user)
+}
+
+// Handler to lift the lock on a user before it runs out
+func (h *Handler) handleUnlockUser(c *fiber.Ctx) error {
+	user, ferr := h.getTargetUser(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	if err := h.userStore.SetUserLockedUntil(c.Context(), user.ID, nil); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to unlock user"})
+	}
+
+	before := *user
+	user.LockedUntil = nil
+	user.Password = ""
+
+	audit.Note(c, audit.Mutation{Action: "user.unlock", TargetType: "user", TargetID: user.ID, Before: before, After: user})
+
+	return c.Status(fiber.StatusOK).JSON(user)
+}
+
+// Handler to list the events of every user, or of one user with ?user_id=
+func (h *Handler) handleGetEvents(c *fiber.Ctx) error {
+	page, ferr := utils.ParsePage(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var userID int
+	if str := c.Query("user_id"); str != "" {
+		var err error
+		if userID, err = strconv.Atoi(str); err != nil || userID <= 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID"})
+		}
+	}
+
+	events, next, err := h.eventStore.GetEventsPaginated(int32(userID), page)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get events"})
+	}
+
+	var total *int64
+	if page.WithTotal {
+		count, err := h.eventStore.CountEvents(int32(userID))
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to count events"})
+		}
+		total = &count
+	}
+
+	filters := map[string]any{}
+	if userID != 0 {
+		filters["user_id"] = userID
+	}
+
+	return c.Status(fiber.StatusOK).JSON(utils.NewPage(events, next, total, filters))
+}
+
+// Handler to view any event, whoever owns it
+func (h *Handler) handleGetEvent(c *fiber.Ctx) error {
+	event, ferr := h.getEvent(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(event)
+}
+
+// Handler to view the attendees of any event, whoever owns it
+func (h *Handler) handleGetEventAttendees(c *fiber.Ctx) error {
+	event, ferr := h.getEvent(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	page, ferr := utils.ParsePage(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	filter := &types.AttendeeFilter{}
+	attendees, next, err := h.attendeeStore.GetFilteredAttendees(event.EventID, filter, page)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get attendees"})
+	}
+
+	var total *int64
+	if page.WithTotal {
+		count, err := h.attendeeStore.CountFilteredAttendees(event.EventID, filter)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to count attendees"})
+		}
+		total = &count
+	}
+
+	return c.Status(fiber.StatusOK).JSON(utils.NewPage(attendees, next, total, nil))
+}
+
+// Handler for an overview of users, events, attendees, revenue and emails
+func (h *Handler) handleGetStats(c *fiber.Ctx) error {
+	stats, err := h.store.GetSystemStats(c.Context(), time.Now())
+	if err != nil {
+		log.Printf("Error getting system stats: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get system stats"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(stats)
+}
+
+func (h *Handler) setDisabled(c *fiber.Ctx, disabled bool) error {
+	user, ferr := h.getTargetUser(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+	if disabled && user.ID == auth.GetUserIDFromContext(c) {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "You cannot disable your own account"})
+	}
+
+	if err := h.userStore.SetUserDisabled(c.Context(), user.ID, disabled); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update user"})
+	}
+
+	before := *user
+	user.Disabled = disabled
+	user.Password = ""
+
+	action := "user.disable"
+	if !disabled {
+		action = "user.enable"
+	}
+	audit.Note(c, audit.Mutation{Action: action, TargetType: "user", TargetID: user.ID, Before: before, After: user})
+
+	return c.Status(fiber.StatusOK).JSON(user)
+}
+
+// getTargetUser returns the user named by the :id route parameter
+func (h *Handler) getTargetUser(c *fiber.Ctx) (*types.User, *fiber.Error) {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid user ID")
+	}
+
+	user, err := h.userStore.GetUserByID(int32(id))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "User not found")
+	}
+
+	return user, nil
+}
+
+// getEvent returns the event named by the :id route parameter
+func (h *Handler) getEvent(c *fiber.Ctx) (*types.Event, *fiber.Error) {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid event ID")
+	}
+
+	event, err := h.eventStore.GetEventByID(int32(id))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Event not found")
+	}
+
+	return event, nil
+}
+
+// boolQuery reads an optional true or false query parameter
+func boolQuery(c *fiber.Ctx, key string) (*bool, error) {
+	str := c.Query(key)
+	if str == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseBool(str)
+	if err != nil {
+		return nil, err
+	}
+
+	return &value, nil
+}
+
+// newPassword generates a random password for a reset
+func newPassword() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
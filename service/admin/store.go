@@ -0,0 +1,63 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/cmd/pkg/database"
+	"github.com/jayden1905/event-registration-software/service/plan"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// GetSystemStats counts users, events, attendees, orders and emails across the whole system
+func (s *Store) GetSystemStats(ctx context.Context, now time.Time) (*types.SystemStats, error) {
+	row, err := s.db.GetSystemStats(ctx, database.GetSystemStatsParams{
+		Now:    now,
+		Period: plan.Period(now),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	revenue, err := s.db.GetRevenueByCurrency(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plans, err := s.db.GetUsersByPlan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &types.SystemStats{
+		Users:          row.Users,
+		VerifiedUsers:  row.VerifiedUsers,
+		DisabledUsers:  row.DisabledUsers,
+		UsersByPlan:    map[string]int64{},
+		Events:         row.Events,
+		UpcomingEvents: row.UpcomingEvents,
+		Attendees:      row.Attendees,
+		CheckedIn:      row.CheckedIn,
+		PaidOrders:     row.PaidOrders,
+		Revenue:        map[string]int64{},
+		EmailsSent:     row.EmailsSent,
+		GeneratedAt:    now,
+	}
+	for _, r := range revenue {
+		stats.Revenue[r.Currency] = r.AmountCents
+	}
+	for _, p := range plans {
+		stats.UsersByPlan[p.Name] = p.Users
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,42 @@
+package attendee
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+// WriteCSV writes attendees with the columns of the import file first, so an
+// export can be edited and imported into another event
+func WriteCSV(w io.Writer, attendees []*types.Attendee) error {
+	writer := csv.NewWriter(w)
+
+	rows := [][]string{{"first_name", "last_name", "email", "company_name", "title", "table_no", "role", "attendance", "registration_status", "rsvp_status", "rsvp_plus_ones"}}
+	for _, attendee := range attendees {
+		tableNo := ""
+		if attendee.TableNo != 0 {
+			tableNo = strconv.Itoa(int(attendee.TableNo))
+		}
+		rows = append(rows, []string{
+			attendee.FirstName,
+			attendee.LastName,
+			attendee.Email,
+			attendee.CompanyName,
+			attendee.Title,
+			tableNo,
+			attendee.Role,
+			strconv.FormatBool(attendee.Attendance),
+			attendee.RegistrationStatus,
+			attendee.RSVPStatus,
+			strconv.Itoa(int(attendee.RSVPPlusOnes)),
+		})
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+
+	return writer.Error()
+}
@@ -0,0 +1,117 @@
+package attendee
+
+import (
+	"database/sql"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/cmd/pkg/database"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+func TestParseFilter(t *testing.T) {
+	app := fiber.New()
+	var got *types.AttendeeFilter
+	app.Get("/", func(c *fiber.Ctx) error {
+		filter, invalid := parseFilter(c, "purpose")
+		if invalid != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(invalid)
+		}
+		got = filter
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	resp, err := app.Test(httptest.NewRequest("GET", "/?role=Speaker&table_no=4&attendance=false&q=ann&sort=-name&purpose=reminder", nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.StatusCode != fiber.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if got.Role != "Speaker" || got.Search != "ann" || got.Sort != "-name" {
+		t.Errorf("unexpected filter %+v", got)
+	}
+	if got.TableNo == nil || *got.TableNo != 4 {
+		t.Errorf("expected table 4, got %v", got.TableNo)
+	}
+	if got.Attendance == nil || *got.Attendance {
+		t.Errorf("expected attendance false, got %v", got.Attendance)
+	}
+	if got.Invited != nil {
+		t.Errorf("expected no invited filter, got %v", *got.Invited)
+	}
+
+	for _, query := range []string{"/?sort=password", "/?field_value=Vegan", "/?table_no=four", "/?rol=Speaker", "/?role=Speaker&page=2"} {
+		resp, err := app.Test(httptest.NewRequest("GET", query, nil))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", query, resp.StatusCode)
+		}
+	}
+}
+
+func TestMatchesAll(t *testing.T) {
+	table := int32(4)
+	tests := []struct {
+		name   string
+		filter types.AttendeeFilter
+		want   bool
+	}{
+		{"empty", types.AttendeeFilter{}, true},
+		{"sorted", types.AttendeeFilter{Sort: "-name"}, true},
+		{"by role", types.AttendeeFilter{Role: "Speaker"}, false},
+		{"by table", types.AttendeeFilter{TableNo: &table}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesAll(&tt.filter); got != tt.want {
+				t.Errorf("matchesAll() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetAfterKey(t *testing.T) {
+	checkedInAt := time.Date(2026, 10, 19, 9, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		sort    string
+		key     string
+		want    database.GetFilteredAttendeesByEventIDParams
+		wantErr error
+	}{
+		{"", "", database.GetFilteredAttendeesByEventIDParams{AfterKey: sql.NullString{Valid: true}}, nil},
+		{"email", "ann@example.com", database.GetFilteredAttendeesByEventIDParams{AfterKey: sql.NullString{String: "ann@example.com", Valid: true}}, nil},
+		{"table_no", "12", database.GetFilteredAttendeesByEventIDParams{AfterTableNo: sql.NullInt32{Int32: 12, Valid: true}}, nil},
+		{"table_no", "twelve", database.GetFilteredAttendeesByEventIDParams{}, types.ErrInvalidCursor},
+		{"checked_in_at", "2026-10-19 09:30:00", database.GetFilteredAttendeesByEventIDParams{AfterCheckedInAt: sql.NullTime{Time: checkedInAt, Valid: true}}, nil},
+		{"checked_in_at", "yesterday", database.GetFilteredAttendeesByEventIDParams{}, types.ErrInvalidCursor},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort+" "+tt.key, func(t *testing.T) {
+			params := database.GetFilteredAttendeesByEventIDParams{SortColumn: tt.sort}
+			err := setAfterKey(&params, &types.Cursor{Key: tt.key, ID: 7})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("setAfterKey() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if params.AfterID != (sql.NullInt32{Int32: 7, Valid: true}) {
+				t.Errorf("AfterID = %v, want 7", params.AfterID)
+			}
+			if params.AfterKey != tt.want.AfterKey || params.AfterTableNo != tt.want.AfterTableNo || !params.AfterCheckedInAt.Time.Equal(tt.want.AfterCheckedInAt.Time) || params.AfterCheckedInAt.Valid != tt.want.AfterCheckedInAt.Valid {
+				t.Errorf("setAfterKey() = %+v, want %+v", params, tt.want)
+			}
+		})
+	}
+}
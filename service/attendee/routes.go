@@ -1,43 +1,71 @@
 package attendee
 
 import (
+	"bytes"
+	"context"
 	"database/sql"
 	"encoding/csv"
 	"errors"
 	"fmt"
 	"log"
+	"reflect"
+	"slices"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 
+	"github.com/jayden1905/event-registration-software/service/audit"
 	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/service/checkin"
 	"github.com/jayden1905/event-registration-software/service/email"
+	"github.com/jayden1905/event-registration-software/service/group"
+	"github.com/jayden1905/event-registration-software/service/live"
+	"github.com/jayden1905/event-registration-software/service/plan"
+	"github.com/jayden1905/event-registration-software/service/suppression"
+	"github.com/jayden1905/event-registration-software/service/ticket"
+	"github.com/jayden1905/event-registration-software/service/tracking"
 	"github.com/jayden1905/event-registration-software/types"
 	"github.com/jayden1905/event-registration-software/utils"
 )
 
 type Handler struct {
-	store      types.AttendeeStore
-	eventStore types.EventStore
-	userStore  types.UserStore
-	emailStore types.EmailTempalteStore
-	mailer     email.Mailer
+	store           types.AttendeeStore
+	eventStore      types.EventStore
+	userStore       types.UserStore
+	emailStore      types.EmailTempalteStore
+	deliveryStore   types.EmailDeliveryStore
+	suppressions    types.SuppressionStore
+	seatingStore    types.SeatingStore
+	checkInStore    types.CheckInStore
+	sessionStore    types.SessionStore
+	ticketTypeStore types.TicketTypeStore
+	enforcer        *plan.Enforcer
+	mailer          email.Mailer
+	hub             *live.Hub
 }
 
-func NewHandler(store types.AttendeeStore, eventStore types.EventStore, userStore types.UserStore, emailStore types.EmailTempalteStore, mailer email.Mailer) *Handler {
-	return &Handler{store: store, eventStore: eventStore, userStore: userStore, emailStore: emailStore, mailer: mailer}
+func NewHandler(store types.AttendeeStore, eventStore types.EventStore, userStore types.UserStore, emailStore types.EmailTempalteStore, deliveryStore types.EmailDeliveryStore, suppressions types.SuppressionStore, seatingStore types.SeatingStore, checkInStore types.CheckInStore, sessionStore types.SessionStore, ticketTypeStore types.TicketTypeStore, enforcer *plan.Enforcer, mailer email.Mailer, hub *live.Hub) *Handler {
+	return &Handler{store: store, eventStore: eventStore, userStore: userStore, emailStore: emailStore, deliveryStore: deliveryStore, suppressions: suppressions, seatingStore: seatingStore, checkInStore: checkInStore, sessionStore: sessionStore, ticketTypeStore: ticketTypeStore, enforcer: enforcer, mailer: mailer, hub: hub}
 }
 
 func (h *Handler) RegisterRoutes(router fiber.Router) {
 	router.Get("/event/:event_id/attendees", auth.WithJWTAuth(h.handleGetAttendeesPaginated, h.userStore))
 	router.Get("/event/:event_id/attendees/count", auth.WithJWTAuth(h.handleGetAttendeesRowCount, h.userStore))
+	router.Get("/event/:event_id/attendees/live", auth.WithJWTAuth(h.handleStreamAttendance, h.userStore))
 	router.Get("/attendees/:attendee_id", auth.WithJWTAuth(h.handleGetAttendeeByID, h.userStore))
 	router.Get("/event/:event_id/attendees/all", auth.WithJWTAuth(h.handleGetAllAttendees, h.userStore))
+	router.Get("/event/:event_id/attendees/export", auth.WithJWTAuth(h.handleExportAttendees, h.userStore))
 	router.Post("/event/add_attendee", auth.WithJWTAuth(h.handleCreateNewAttendee, h.userStore))
 	router.Delete("/event/:event_id/attendees/:attendee_id", auth.WithJWTAuth(h.handleDeleteAttendeeByID, h.userStore))
 	router.Delete("/event/:event_id/attendees", auth.WithJWTAuth(h.handleDeleteAllAttendeesByEventID, h.userStore))
+	router.Get("/event/:event_id/attendees/deleted", auth.WithJWTAuth(h.handleGetDeletedAttendees, h.userStore))
+	router.Post("/event/:event_id/attendees/restore", auth.WithJWTAuth(h.handleRestoreAllAttendees, h.userStore))
+	router.Post("/event/:event_id/attendees/:attendee_id/restore", auth.WithJWTAuth(h.handleRestoreAttendeeByID, h.userStore))
 	router.Put("/event/attendees/:attendee_id", auth.WithJWTAuth(h.handleUpdateAttendeeByID, h.userStore))
+	router.Patch("/event/:event_id/attendees", auth.WithJWTAuth(h.handleBulkUpdateAttendees, h.userStore))
 	router.Post("/event/:event_id/attendees/import", auth.WithJWTAuth(h.handleImportAttendeesFromCSV, h.userStore))
 	router.Post("/event/:event_id/attendees/send_invitation", auth.WithJWTAuth(h.handleSendInvitationEmails, h.userStore))
 	router.Post("/attendees/send_invitation/:attendee_id", auth.WithJWTAuth(h.handleSendInvitationEmailbyID, h.userStore))
@@ -68,21 +96,9 @@ func (h *Handler) handleGetAttendeeByID(c *fiber.Ctx) error {
 	}
 
 	// Check if the user is the owner of the event
-	event, err := h.eventStore.GetEventByID(attendee.EventID)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "Event not found",
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to get event",
-		})
-	}
-
-	if event.UserID != userID {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Unauthorized",
+	if _, ferr := utils.GetOwnedEventByID(attendee.EventID, userID, h.eventStore); ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
 		})
 	}
 
@@ -108,20 +124,68 @@ func (h *Handler) handleCreateNewAttendee(c *fiber.Ctx) error {
 	}
 
 	// Check if the user is the owner of the event
-	event, err := h.eventStore.GetEventByID(payload.EventID)
+	event, ferr := utils.GetOwnedEventByID(payload.EventID, userID, h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
+		})
+	}
+
+	// Check that the table is part of the seating plan, if the event has one
+	planTables, err := h.getPlanTables(event.EventID)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to get event",
+			"error": "Failed to get seating plan",
 		})
 	}
+	if planTables != nil && payload.TableNo != 0 && !planTables[payload.TableNo] {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("Table %d is not in the seating plan", payload.TableNo),
+		})
+	}
+
+	// Check that the ticket type belongs to the event
+	if payload.TicketTypeID != 0 {
+		if ferr := h.checkTicketType(event.EventID, payload.TicketTypeID); ferr != nil {
+			return c.Status(ferr.Code).JSON(fiber.Map{
+				"error": ferr.Message,
+			})
+		}
+	}
+
+	// Check that a guest joins an attendee of the event that can bring guests
+	if payload.PrimaryAttendeeID != 0 {
+		primary, err := h.store.GetAttendeeByID(payload.PrimaryAttendeeID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to get primary attendee",
+			})
+		}
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fmt.Sprintf("Primary attendee %d not found", payload.PrimaryAttendeeID),
+			})
+		}
+		if err := group.CheckPrimary(primary, event.EventID); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		if payload.TableNo == 0 {
+			payload.TableNo = primary.TableNo
+		}
+	}
 
-	if event.UserID != userID {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Unauthorized",
+	// Check that the plan of the event owner allows another attendee
+	if ferr := h.enforcer.CheckAttendees(event, 1); ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
 		})
 	}
 
 	// Check if the attendee with same email already exists
+	payload.Email = utils.NormalizeEmail(payload.Email)
 	atte, err := h.store.GetAttendeeByEmail(payload.Email)
 	if atte != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -154,24 +218,34 @@ func (h *Handler) handleCreateNewAttendee(c *fiber.Ctx) error {
 	case qrCodeURL := <-qrCodeChannel:
 		// Proceed if QR code upload was successful
 		attendee := &types.Attendee{
-			FirstName:   payload.FirstName,
-			LastName:    payload.LastName,
-			Email:       payload.Email,
-			EventID:     payload.EventID,
-			QrCode:      qrCodeURL,
-			CompanyName: payload.CompanyName,
-			Title:       payload.Title,
-			TableNo:     payload.TableNo,
-			Role:        payload.Role,
-			Attendance:  false,
+			FirstName:         payload.FirstName,
+			LastName:          payload.LastName,
+			Email:             payload.Email,
+			EventID:           payload.EventID,
+			QrCode:            qrCodeURL,
+			CompanyName:       payload.CompanyName,
+			Title:             payload.Title,
+			TableNo:           payload.TableNo,
+			Role:              payload.Role,
+			Attendance:        false,
+			TicketTypeID:      payload.TicketTypeID,
+			PrimaryAttendeeID: payload.PrimaryAttendeeID,
 		}
 
 		if err := h.store.CreateAttendee(c.Context(), attendee); err != nil {
+			if errors.Is(err, types.ErrTicketTypeSoldOut) {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+					"error": "Ticket type is sold out",
+				})
+			}
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 				"error": "Failed to create attendee",
 			})
 		}
 
+		audit.Note(c, audit.Mutation{Action: "attendee.create", TargetType: "attendee", TargetID: attendee.ID, EventID: event.EventID, EventOwnerID: event.UserID, After: attendee})
+		h.publishUpdate(live.UpdateRegistration, attendee.EventID, attendee)
+
 		return c.Status(fiber.StatusCreated).JSON(attendee)
 
 	case err := <-errorChannel:
@@ -201,15 +275,10 @@ func (h *Handler) handleImportAttendeesFromCSV(c *fiber.Ctx) error {
 		})
 	}
 
-	event, err := h.eventStore.GetEventByID(int32(eventID))
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to get event",
-		})
-	}
-	if event.UserID != userID {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Unauthorized",
+	event, ferr := utils.GetOwnedEventByID(int32(eventID), userID, h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
 		})
 	}
 
@@ -244,17 +313,104 @@ func (h *Handler) handleImportAttendeesFromCSV(c *fiber.Ctx) error {
 		})
 	}
 
+	// Reject the file if any row references a table outside the seating plan
+	planTables, err := h.getPlanTables(int32(eventID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get seating plan",
+		})
+	}
+	if planTables != nil {
+		invalidRows := []fiber.Map{}
+		for i, record := range records[1:] {
+			tableNo := utils.ParseTableNo(record[5])
+			if tableNo != 0 && !planTables[tableNo] {
+				// Row numbers count the header row, as in a spreadsheet
+				invalidRows = append(invalidRows, fiber.Map{"row": i + 2, "table_no": tableNo})
+			}
+		}
+		if len(invalidRows) > 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":        "Some rows reference tables that are not in the seating plan",
+				"invalid_rows": invalidRows,
+			})
+		}
+	}
+
+	// Map the ticket type column, named by the ticket_type_column form field
+	// or "ticket_type" by default, to the ticket types of the event
+	ticketColumn := c.FormValue("ticket_type_column", "ticket_type")
+	ticketColumnIndex := ticket.ColumnIndex(records[0], ticketColumn)
+	if ticketColumnIndex < 0 && c.FormValue("ticket_type_column") != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("Column %q not found in the CSV header", ticketColumn),
+		})
+	}
+	ticketTypeIDs := make([]int32, len(records)-1)
+	if ticketColumnIndex >= 0 {
+		ticketTypes, err := h.ticketTypeStore.GetTicketTypesByEventID(int32(eventID))
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to get ticket types",
+			})
+		}
+		byName := ticket.ByName(ticketTypes)
+
+		invalidRows := []fiber.Map{}
+		for i, record := range records[1:] {
+			if ticketColumnIndex >= len(record) || strings.TrimSpace(record[ticketColumnIndex]) == "" {
+				continue
+			}
+			ticketType, ok := ticket.Lookup(byName, record[ticketColumnIndex])
+			if !ok {
+				invalidRows = append(invalidRows, fiber.Map{"row": i + 2, "ticket_type": record[ticketColumnIndex]})
+				continue
+			}
+			ticketTypeIDs[i] = ticketType.ID
+		}
+		if len(invalidRows) > 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error":        "Some rows reference ticket types that do not exist",
+				"invalid_rows": invalidRows,
+			})
+		}
+	}
+
+	// Rows sharing a value of the group column, named by the group_column form
+	// field or "group_id" by default, are imported as a group: the first row is
+	// the primary attendee and the others are their guests
+	groupColumn := c.FormValue("group_column", "group_id")
+	groupColumnIndex := ticket.ColumnIndex(records[0], groupColumn)
+	if groupColumnIndex < 0 && c.FormValue("group_column") != "" {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": fmt.Sprintf("Column %q not found in the CSV header", groupColumn),
+		})
+	}
+
+	// Check that the plan of the event owner allows every row of the file
+	if ferr := h.enforcer.CheckAttendees(event, len(records)-1); ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
+		})
+	}
+
 	// Skip the header row and insert each row as an attendee
 	errorAttendees := []types.Attendee{}
 	var wg sync.WaitGroup
 	attendeeErrorsChan := make(chan errorAttendeeResult, len(records)-1) // Buffered channel to hold error results
 
-	for _, record := range records[1:] {
+	// The attendee of each row, created or already registered, to link groups once all rows are in
+	rowAttendees := make([]*types.Attendee, len(records)-1)
+	rowCreated := make([]bool, len(records)-1)
+
+	for i, record := range records[1:] {
 		wg.Add(1) // Increment WaitGroup counter for each goroutine
 
-		go func(record []string) {
+		go func(i int, record []string, ticketTypeID int32) {
 			defer wg.Done() // Decrement the counter when the goroutine finishes
 
+			record[2] = utils.NormalizeEmail(record[2])
+
 			// Generate QR code
 			qrCode, err := utils.GenerateQRCodeImage(record[2])
 			if err != nil {
@@ -265,6 +421,7 @@ func (h *Handler) handleImportAttendeesFromCSV(c *fiber.Ctx) error {
 			// Check if the attendee with the same email already exists in the same event
 			attendeeExist, err := h.store.GetAttendeeByEmail(record[2])
 			if attendeeExist != nil && attendeeExist.EventID == int32(eventID) {
+				rowAttendees[i] = attendeeExist
 				attendeeErrorsChan <- errorAttendeeResult{attendee: *attendeeExist, err: nil}
 				return
 			}
@@ -276,16 +433,17 @@ func (h *Handler) handleImportAttendeesFromCSV(c *fiber.Ctx) error {
 
 			// Create the attendee
 			attendee := &types.Attendee{
-				FirstName:   record[0],
-				LastName:    record[1],
-				Email:       record[2],
-				EventID:     int32(eventID),
-				QrCode:      qrCode,
-				CompanyName: record[3],
-				Title:       record[4],
-				TableNo:     utils.ParseTableNo(record[5]),
-				Role:        record[6],
-				Attendance:  false,
+				FirstName:    record[0],
+				LastName:     record[1],
+				Email:        record[2],
+				EventID:      int32(eventID),
+				QrCode:       qrCode,
+				CompanyName:  record[3],
+				Title:        record[4],
+				TableNo:      utils.ParseTableNo(record[5]),
+				Role:         record[6],
+				Attendance:   false,
+				TicketTypeID: ticketTypeID,
 			}
 
 			// Insert attendee
@@ -295,8 +453,9 @@ func (h *Handler) handleImportAttendeesFromCSV(c *fiber.Ctx) error {
 			}
 
 			// Success: No error for this attendee
+			rowAttendees[i], rowCreated[i] = attendee, true
 			attendeeErrorsChan <- errorAttendeeResult{attendee: *attendee, err: nil}
-		}(record)
+		}(i, record, ticketTypeIDs[i])
 	}
 
 	// Wait for all goroutines to complete
@@ -312,12 +471,28 @@ func (h *Handler) handleImportAttendeesFromCSV(c *fiber.Ctx) error {
 		}
 	}
 
+	groupErrors := []fiber.Map{}
+	if groupColumnIndex >= 0 {
+		groupErrors = h.linkImportGroups(c.Context(), event, group.ImportGroups(records[1:], groupColumnIndex), rowAttendees, rowCreated)
+	}
+
+	audit.Note(c, audit.Mutation{
+		Action:       "attendee.import",
+		TargetType:   "event",
+		TargetID:     event.EventID,
+		EventID:      event.EventID,
+		EventOwnerID: event.UserID,
+		Details:      map[string]any{"rows": len(records) - 1, "failed": len(errorAttendees), "group_errors": len(groupErrors)},
+	})
+	h.publishUpdate(live.UpdateRegistration, int32(eventID), nil)
+
 	// Return a partial success message if there are any errors
-	if len(errorAttendees) > 0 {
+	if len(errorAttendees) > 0 || len(groupErrors) > 0 {
 		return c.Status(fiber.StatusPartialContent).JSON(fiber.Map{
-			"message":   "Attendees imported with some errors",
-			"error":     "Some attendees already exist or failed to create",
-			"attendees": errorAttendees,
+			"message":      "Attendees imported with some errors",
+			"error":        "Some attendees already exist or failed to create",
+			"attendees":    errorAttendees,
+			"group_errors": groupErrors,
 		})
 	}
 
@@ -354,29 +529,10 @@ func (h *Handler) handleUpdateAttendeeByID(c *fiber.Ctx) error {
 	}
 
 	// Check if the user is the owner of the event
-	event, err := h.eventStore.GetEventByID(attendee.EventID)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "Event not found",
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to get event",
-		})
-	}
-
-	if event.UserID != userID {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Unauthorized",
-		})
-	}
-
-	// Delete the old qr image from Cloudinary
-	err = utils.DeleteQrImageFromCloudinary(attendee.QrCode)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to delete image",
+	event, ferr := utils.GetOwnedEventByID(attendee.EventID, userID, h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
 		})
 	}
 
@@ -396,6 +552,39 @@ func (h *Handler) handleUpdateAttendeeByID(c *fiber.Ctx) error {
 		})
 	}
 
+	// Check that the ticket type belongs to the event
+	if payload.TicketTypeID != 0 && payload.TicketTypeID != attendee.TicketTypeID {
+		if ferr := h.checkTicketType(attendee.EventID, payload.TicketTypeID); ferr != nil {
+			return c.Status(ferr.Code).JSON(fiber.Map{
+				"error": ferr.Message,
+			})
+		}
+	}
+
+	// Check that a new table is part of the seating plan, if the event has one
+	if payload.TableNo != 0 && payload.TableNo != attendee.TableNo {
+		planTables, err := h.getPlanTables(attendee.EventID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to get seating plan",
+			})
+		}
+		if planTables != nil && !planTables[payload.TableNo] {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fmt.Sprintf("Table %d is not in the seating plan", payload.TableNo),
+			})
+		}
+	}
+
+	// Delete the old qr image from Cloudinary
+	err = utils.DeleteQrImageFromCloudinary(attendee.QrCode)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to delete image",
+		})
+	}
+
+	payload.Email = utils.NormalizeEmail(payload.Email)
 	if payload.Email != "" && payload.Email != attendee.Email {
 		log.Println(payload.Email)
 		// Generate QR code
@@ -407,21 +596,30 @@ func (h *Handler) handleUpdateAttendeeByID(c *fiber.Ctx) error {
 		}
 		// Update the attendee by ID
 		if err := h.store.UpdateAttendeeByID(int32(attendeeID), &types.Attendee{
-			FirstName:   payload.FirstName,
-			LastName:    payload.LastName,
-			Email:       payload.Email,
-			QrCode:      qrCode,
-			CompanyName: payload.CompanyName,
-			Title:       payload.Title,
-			TableNo:     payload.TableNo,
-			Role:        payload.Role,
-			Attendance:  payload.Attendance,
+			FirstName:    payload.FirstName,
+			LastName:     payload.LastName,
+			Email:        payload.Email,
+			QrCode:       qrCode,
+			CompanyName:  payload.CompanyName,
+			Title:        payload.Title,
+			TableNo:      payload.TableNo,
+			Role:         payload.Role,
+			Attendance:   payload.Attendance,
+			TicketTypeID: payload.TicketTypeID,
 		}); err != nil {
+			if errors.Is(err, types.ErrTicketTypeSoldOut) {
+				return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+					"error": "Ticket type is sold out",
+				})
+			}
 			log.Println(err)
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 				"error": "Failed to update attendee",
 			})
 		}
+		h.noteAttendeeUpdate(c, event, attendee)
+		h.publishUpdate(live.UpdateAttendee, attendee.EventID, nil)
+
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{
 			"message": "Attendee updated successfully with new qrcode",
 		})
@@ -429,21 +627,30 @@ func (h *Handler) handleUpdateAttendeeByID(c *fiber.Ctx) error {
 
 	// Update the attendee by ID
 	if err := h.store.UpdateAttendeeByID(int32(attendeeID), &types.Attendee{
-		FirstName:   payload.FirstName,
-		LastName:    payload.LastName,
-		Email:       payload.Email,
-		CompanyName: payload.CompanyName,
-		Title:       payload.Title,
-		TableNo:     payload.TableNo,
-		Role:        payload.Role,
-		Attendance:  payload.Attendance,
+		FirstName:    payload.FirstName,
+		LastName:     payload.LastName,
+		Email:        payload.Email,
+		CompanyName:  payload.CompanyName,
+		Title:        payload.Title,
+		TableNo:      payload.TableNo,
+		Role:         payload.Role,
+		Attendance:   payload.Attendance,
+		TicketTypeID: payload.TicketTypeID,
 	}); err != nil {
+		if errors.Is(err, types.ErrTicketTypeSoldOut) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"error": "Ticket type is sold out",
+			})
+		}
 		log.Println(err)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to update attendee",
 		})
 	}
 
+	h.noteAttendeeUpdate(c, event, attendee)
+	h.publishUpdate(live.UpdateAttendee, attendee.EventID, nil)
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Attendee updated successfully",
 	})
@@ -463,17 +670,11 @@ func (h *Handler) handleDeleteAttendeeByID(c *fiber.Ctx) error {
 		})
 	}
 
-	event, err := h.eventStore.GetEventByID(int32(eventID))
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to get event",
-		})
-	}
-
 	// Check if the user is the owner of the event
-	if event.UserID != userID {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Unauthorized",
+	event, ferr := utils.GetOwnedEventByID(int32(eventID), userID, h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
 		})
 	}
 
@@ -499,21 +700,16 @@ func (h *Handler) handleDeleteAttendeeByID(c *fiber.Ctx) error {
 		})
 	}
 
-	// Delete qr image from Cloudinary
-	err = utils.DeleteQrImageFromCloudinary(attendee.QrCode)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to delete image",
-		})
-	}
-
-	// Delete the attendee by ID
+	// Delete the attendee by ID, its qr image is kept until the attendee is purged
 	if err := h.store.DeleteAttendeeByID(int32(attendeeID)); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to delete attendee",
 		})
 	}
 
+	audit.Note(c, audit.Mutation{Action: "attendee.delete", TargetType: "attendee", TargetID: attendee.ID, EventID: event.EventID, EventOwnerID: event.UserID, Before: attendee})
+	h.publishUpdate(live.UpdateRemoval, attendee.EventID, attendee)
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Attendee deleted successfully",
 	})
@@ -532,82 +728,71 @@ func (h *Handler) handleDeleteAllAttendeesByEventID(c *fiber.Ctx) error {
 		})
 	}
 
-	// Retrieve the event by its ID
-	event, err := h.eventStore.GetEventByID(int32(eventID))
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "Event not found",
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to retrieve event",
+	// Check if the user is the owner of the event
+	event, ferr := utils.GetOwnedEventByID(int32(eventID), userID, h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
 		})
 	}
 
-	// Check if the user is the owner of the event
-	if event.UserID != userID {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Unauthorized",
+	// Get the attendees of the event that match the filter in the query
+	filter, invalid := parseFilter(c, "all")
+	if invalid != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(invalid)
+	}
+	if matchesAll(filter) && !c.QueryBool("all") {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Add a filter, or all=true to delete every attendee of the event",
 		})
 	}
 
-	// Get all attendees by event ID
-	attendees, err := h.store.GetAllAttendees(int32(eventID))
+	attendees, err := h.store.GetAllFilteredAttendees(int32(eventID), filter)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to get attendees",
 		})
 	}
 
-	// Channel to capture image deletion errors
-	errChan := make(chan error, len(attendees))
-
-	// Delete qr images from Cloudinary concurrently
-	for _, attendee := range attendees {
-		go func(attendee types.Attendee) {
-			if deleteErr := utils.DeleteQrImageFromCloudinary(attendee.QrCode); deleteErr != nil {
-				errChan <- fmt.Errorf("failed to delete image for attendee %d: %v", attendee.ID, deleteErr)
-			} else {
-				errChan <- nil
+	// Delete the attendees, their qr images are kept until the attendees are purged
+	if matchesAll(filter) {
+		err = h.store.DeleteAllAttendeesByEventID(int32(eventID))
+	} else {
+		for _, attendee := range attendees {
+			if err = h.store.DeleteAttendeeByID(attendee.ID); err != nil {
+				break
 			}
-		}(*attendee)
-	}
-
-	// Wait for all image deletion results
-	var deletionErrors []string
-	for i := 0; i < len(attendees); i++ {
-		if deleteErr := <-errChan; deleteErr != nil {
-			deletionErrors = append(deletionErrors, deleteErr.Error())
 		}
 	}
-
-	// If there were any errors deleting images, log and proceed
-	if len(deletionErrors) > 0 {
-		log.Printf("Image deletion errors: %v", deletionErrors)
-	}
-
-	// Delete all attendees by event ID
-	if err := h.store.DeleteAllAttendeesByEventID(int32(eventID)); err != nil {
+	if err != nil {
 		log.Printf("Error deleting attendees for event ID: %d: %v", eventID, err)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to delete attendees",
 		})
 	}
 
-	// Provide a response with success
-	responseMessage := "Attendees deleted successfully"
-	if len(deletionErrors) > 0 {
-		// Include a message about image deletion issues
-		responseMessage = fmt.Sprintf("%s, but some images were not deleted: %v", responseMessage, deletionErrors)
-	}
+	// Keep who was on the list for the audit log
+	deleted := make([]map[string]any, 0, len(attendees))
+	for _, attendee := range attendees {
+		deleted = append(deleted, map[string]any{"id": attendee.ID, "email": attendee.Email, "first_name": attendee.FirstName, "last_name": attendee.LastName})
+	}
+	audit.Note(c, audit.Mutation{
+		Action:       "attendee.delete_all",
+		TargetType:   "event",
+		TargetID:     event.EventID,
+		EventID:      event.EventID,
+		EventOwnerID: event.UserID,
+		Details:      map[string]any{"count": len(attendees), "attendees": deleted, "filter": filter},
+	})
+	h.publishUpdate(live.UpdateRemoval, int32(eventID), nil)
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message": responseMessage,
+		"message": "Attendees deleted successfully",
+		"deleted": len(attendees),
 	})
 }
 
-// Handler to get all the attendees paginated from database
+// Handler to get a page of the attendees that match the filter in the query
 func (h *Handler) handleGetAttendeesPaginated(c *fiber.Ctx) error {
 	userID := auth.GetUserIDFromContext(c)
 
@@ -620,45 +805,77 @@ func (h *Handler) handleGetAttendeesPaginated(c *fiber.Ctx) error {
 		})
 	}
 
-	event, err := h.eventStore.GetEventByID(int32(eventID))
+	if _, ferr := utils.GetOwnedEventByID(int32(eventID), userID, h.eventStore); ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
+		})
+	}
+
+	page, ferr := utils.ParsePage(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
+		})
+	}
+
+	filter, invalid := parseFilter(c, pageKeys...)
+	if invalid != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(invalid)
+	}
+
+	attendees, next, err := h.store.GetFilteredAttendees(int32(eventID), filter, page)
+	if errors.Is(err, types.ErrInvalidCursor) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid cursor",
+		})
+	}
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to get event",
+			"error": "Failed to get attendees",
 		})
 	}
 
-	if event.UserID != userID {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Unauthorized",
-		})
+	var total *int64
+	if page.WithTotal {
+		count, err := h.store.CountFilteredAttendees(int32(eventID), filter)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to count attendees",
+			})
+		}
+		total = &count
 	}
 
-	const (
-		defaultPageSize = 10
-		maxPageSize     = 100
-	)
+	return c.Status(fiber.StatusOK).JSON(utils.NewPage(attendees, next, total, utils.AppliedFilters(filter)))
+}
 
-	pageStr := c.Query("page")
-	pageSizeStr := c.Query("page_size")
+// Handler to get every attendee of an event that matches the filter in the
+// query, unpaginated for the clients that expect the whole list
+func (h *Handler) handleGetAllAttendees(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
 
-	page := 1
-	pageSize := defaultPageSize
+	// check if the user is the owner of the event
+	eventIDString := c.Params("event_id")
+	eventID, err := strconv.Atoi(eventIDString)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid event ID",
+		})
+	}
 
-	// Parse page if provided
-	if pageStr != "" {
-		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
-			page = p
-		}
+	event, ferr := utils.GetOwnedEventByID(int32(eventID), userID, h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
+		})
 	}
 
-	// Parse pageSize if provided
-	if pageSizeStr != "" {
-		if ps, err := strconv.Atoi(pageSizeStr); err == nil && ps > 0 && ps <= maxPageSize {
-			pageSize = ps
-		}
+	filter, invalid := parseFilter(c)
+	if invalid != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(invalid)
 	}
 
-	attendees, err := h.store.GetAllAttendeesPaginated(int32(page), int32(pageSize), int32(eventID))
+	attendees, err := h.store.GetAllFilteredAttendees(event.EventID, filter)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to get attendees",
@@ -668,40 +885,154 @@ func (h *Handler) handleGetAttendeesPaginated(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusOK).JSON(attendees)
 }
 
-// Handler to get all the attendees from database
-func (h *Handler) handleGetAllAttendees(c *fiber.Ctx) error {
+// Handler to set one field of every attendee that matches the filter in the query
+func (h *Handler) handleBulkUpdateAttendees(c *fiber.Ctx) error {
 	userID := auth.GetUserIDFromContext(c)
 
-	// check if the user is the owner of the event
-	eventIDString := c.Params("event_id")
-	eventID, err := strconv.Atoi(eventIDString)
+	eventID, err := strconv.Atoi(c.Params("event_id"))
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"error": "Invalid event ID",
 		})
 	}
 
-	event, err := h.eventStore.GetEventByID(int32(eventID))
+	event, ferr := utils.GetOwnedEventByID(int32(eventID), userID, h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
+		})
+	}
+
+	filter, invalid := parseFilter(c, "all")
+	if invalid != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(invalid)
+	}
+	if matchesAll(filter) && !c.QueryBool("all") {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Add a filter, or all=true to update every attendee of the event",
+		})
+	}
+
+	var payload types.BulkUpdateAttendeesPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid request payload",
+		})
+	}
+
+	if invalidFields, err := utils.ValidatePayload(payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	// Tables have to be in the seating plan, as when attendees are added one by one
+	var tableNo int32
+	if payload.Field == "table_no" {
+		tableNo = utils.ParseTableNo(payload.Value)
+		planTables, err := h.getPlanTables(event.EventID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to get seating plan",
+			})
+		}
+		if tableNo != 0 && planTables != nil && !planTables[tableNo] {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fmt.Sprintf("Table %d is not in the seating plan", tableNo),
+			})
+		}
+	}
+
+	attendees, err := h.store.GetAllFilteredAttendees(event.EventID, filter)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to get event",
+			"error": "Failed to get attendees",
+		})
+	}
+
+	// Either every attendee is updated or none is
+	changed := make([]*types.Attendee, 0, len(attendees))
+	updated := make([]int32, 0, len(attendees))
+	for _, attendee := range attendees {
+		data := *attendee
+		switch payload.Field {
+		case "role":
+			data.Role = payload.Value
+		case "company_name":
+			data.CompanyName = payload.Value
+		case "title":
+			data.Title = payload.Value
+		case "table_no":
+			data.TableNo = tableNo
+		}
+		changed = append(changed, &data)
+		updated = append(updated, attendee.ID)
+	}
+
+	if err := h.store.UpdateAttendeesByID(c.Context(), changed); err != nil {
+		log.Printf("Error updating attendees of event ID %d: %v", event.EventID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to update attendees",
+		})
+	}
+
+	audit.Note(c, audit.Mutation{
+		Action:       "attendee.bulk_update",
+		TargetType:   "event",
+		TargetID:     event.EventID,
+		EventID:      event.EventID,
+		EventOwnerID: event.UserID,
+		Details:      map[string]any{"field": payload.Field, "value": payload.Value, "filter": filter, "attendee_ids": updated},
+	})
+	h.publishUpdate(live.UpdateAttendee, event.EventID, nil)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Attendees updated successfully",
+		"updated": len(updated),
+	})
+}
+
+// Handler to export the attendees that match the filter in the query as a CSV file in the import format
+func (h *Handler) handleExportAttendees(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	eventID, err := strconv.Atoi(c.Params("event_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid event ID",
 		})
 	}
 
-	if event.UserID != userID {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Unauthorized",
+	event, ferr := utils.GetOwnedEventByID(int32(eventID), userID, h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
 		})
 	}
 
-	attendees, err := h.store.GetAllAttendees(int32(eventID))
+	filter, invalid := parseFilter(c)
+	if invalid != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(invalid)
+	}
+
+	attendees, err := h.store.GetAllFilteredAttendees(event.EventID, filter)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to get attendees",
 		})
 	}
 
-	return c.Status(fiber.StatusOK).JSON(attendees)
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, attendees); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to export attendees",
+		})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/csv")
+	c.Attachment(fmt.Sprintf("event-%d-attendees.csv", event.EventID))
+	return c.Status(fiber.StatusOK).Send(buf.Bytes())
 }
 
 // Handler to get attendees row count from database
@@ -717,28 +1048,34 @@ func (h *Handler) handleGetAttendeesRowCount(c *fiber.Ctx) error {
 		})
 	}
 
-	event, err := h.eventStore.GetEventByID(int32(eventID))
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to get event",
+	if _, ferr := utils.GetOwnedEventByID(int32(eventID), userID, h.eventStore); ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
 		})
 	}
 
-	if event.UserID != userID {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Unauthorized",
-		})
+	filter, invalid := parseFilter(c)
+	if invalid != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(invalid)
 	}
 
-	rowCount, err := h.store.GetAttendeeRowCount(int32(eventID))
+	rowCount, err := h.store.CountFilteredAttendees(int32(eventID), filter)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to get attendees row count",
 		})
 	}
 
+	rsvp, err := h.store.GetRSVPCounts(int32(eventID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get RSVP counts",
+		})
+	}
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"row_count": rowCount,
+		"rsvp":      rsvp,
 	})
 }
 
@@ -769,35 +1106,25 @@ func (h *Handler) handleSendInvitationEmailbyID(c *fiber.Ctx) error {
 	}
 
 	// Check if the user is the owner of the event
-	event, err := h.eventStore.GetEventByID(attendee.EventID)
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "Event not found",
-			})
-		}
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to get event",
+	event, ferr := utils.GetOwnedEventByID(attendee.EventID, userID, h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
 		})
 	}
 
-	if event.UserID != userID {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Unauthorized",
-		})
-	}
-
-	// Get the email template by event ID
-	emailTemplate, err := h.emailStore.GetEmailTemplateByEventID(c.Context(), attendee.EventID)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to get email template",
+	// Get the email template of the event for the purpose in the query
+	emailTemplate, ferr := h.getEmailTemplate(c, attendee.EventID)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
 		})
 	}
 
 	emailTmp := &types.EmailTemplate{
 		ID:          emailTemplate.ID,
 		EventID:     emailTemplate.EventID,
+		Purpose:     emailTemplate.Purpose,
 		HeaderImage: emailTemplate.HeaderImage,
 		Content:     emailTemplate.Content,
 		FooterImage: emailTemplate.FooterImage,
@@ -806,8 +1133,60 @@ func (h *Handler) handleSendInvitationEmailbyID(c *fiber.Ctx) error {
 		Message:     emailTemplate.Message,
 	}
 
+	// No email is sent to a suppressed address
+	suppressed, err := h.suppressions.GetSuppression(c.Context(), attendee.Email, event.UserID)
+	if err == nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":  "Attendee email is suppressed",
+			"reason": suppression.Reason(suppressed),
+		})
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get suppressions",
+		})
+	}
+
+	// Drop the branding the plan does not include and count the email against the monthly quota
+	emailTmp, ferr = h.enforcer.Brand(userID, emailTmp)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
+		})
+	}
+	if ferr := h.enforcer.ReserveEmails(c.Context(), userID, 1); ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
+		})
+	}
+
+	// With by_group, a primary attendee receives the invitation of their whole group
+	var guests []*types.Attendee
+	if c.QueryBool("by_group") {
+		guests, err = h.store.GetGuests(attendee.ID)
+		if err != nil {
+			h.enforcer.ReleaseEmails(userID, 1)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to get guests",
+			})
+		}
+	}
+
+	// Track the email unless the organizer opted out of tracking
+	tracked, err := h.deliveryStore.IsTrackingEnabled(c.Context(), event.EventID)
+	if err != nil {
+		h.enforcer.ReleaseEmails(userID, 1)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get tracking settings",
+		})
+	}
+	trackingID := tracking.NewID(tracked)
+
 	// Send invitation email to the attendee
-	if err := h.mailer.SendInvitationEmail(attendee, emailTmp); err != nil {
+	err = h.mailer.SendGroupInvitationEmail(attendee, guests, emailTmp, trackingID)
+	h.recordDelivery(attendee, emailTmp, trackingID, err, guests...)
+	if err != nil {
+		h.enforcer.ReleaseEmails(userID, 1)
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to send invitation email",
 		})
@@ -831,29 +1210,27 @@ func (h *Handler) handleSendInvitationEmails(c *fiber.Ctx) error {
 		})
 	}
 
-	event, err := h.eventStore.GetEventByID(int32(eventID))
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to get event",
+	if _, ferr := utils.GetOwnedEventByID(int32(eventID), userID, h.eventStore); ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
 		})
 	}
 
-	if event.UserID != userID {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Unauthorized",
+	// Get the email template of the event for the purpose in the query
+	emailTemplate, ferr := h.getEmailTemplate(c, int32(eventID))
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
 		})
 	}
 
-	// Get the email template by event ID
-	emailTemplate, err := h.emailStore.GetEmailTemplateByEventID(c.Context(), int32(eventID))
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to get email template",
-		})
+	// Get the attendees of the event that match the filter in the query
+	filter, invalid := parseFilter(c, "purpose", "by_group")
+	if invalid != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(invalid)
 	}
 
-	// Get all the attendees by event ID
-	attendees, err := h.store.GetAllAttendees(int32(eventID))
+	attendees, err := h.store.GetAllFilteredAttendees(int32(eventID), filter)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
 			"error": "Failed to get attendees",
@@ -863,6 +1240,7 @@ func (h *Handler) handleSendInvitationEmails(c *fiber.Ctx) error {
 	emailTmp := &types.EmailTemplate{
 		ID:          emailTemplate.ID,
 		EventID:     emailTemplate.EventID,
+		Purpose:     emailTemplate.Purpose,
 		HeaderImage: emailTemplate.HeaderImage,
 		Content:     emailTemplate.Content,
 		FooterImage: emailTemplate.FooterImage,
@@ -871,17 +1249,65 @@ func (h *Handler) handleSendInvitationEmails(c *fiber.Ctx) error {
 		Message:     emailTemplate.Message,
 	}
 
+	// Invitations go out once the registration is paid
+	recipients := []*types.Attendee{}
+	for _, attendee := range attendees {
+		if attendee.RegistrationStatus != types.RegistrationStatusPendingPayment {
+			recipients = append(recipients, attendee)
+		}
+	}
+
+	// Attendees with a suppressed address are skipped, before grouping so the
+	// guests of a suppressed primary attendee still get their own invitation
+	suppressions, err := h.suppressions.GetSuppressionsByEventID(c.Context(), int32(eventID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get suppressions",
+		})
+	}
+	recipients, skipped := suppression.Filter(recipients, suppressions)
+
+	// With by_group, guests are left out when their primary attendee is a
+	// recipient and listed in the invitation of the primary attendee instead
+	guestsOf := map[int32][]*types.Attendee{}
+	if c.QueryBool("by_group") {
+		recipients, guestsOf = groupRecipients(recipients)
+	}
+
+	// Track the emails unless the organizer opted out of tracking
+	tracked, err := h.deliveryStore.IsTrackingEnabled(c.Context(), int32(eventID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get tracking settings",
+		})
+	}
+
+	// Drop the branding the plan does not include and count the emails against the monthly quota
+	emailTmp, ferr = h.enforcer.Brand(userID, emailTmp)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
+		})
+	}
+	if ferr := h.enforcer.ReserveEmails(c.Context(), userID, len(recipients)); ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
+		})
+	}
+
+	h.recordSkipped(int32(eventID), emailTmp, skipped)
+
 	// Use a wait group to synchronize goroutines
 	var wg sync.WaitGroup
 	// Create a channel to handle errors from goroutines
-	errorChannel := make(chan error, len(attendees))
+	errorChannel := make(chan error, len(recipients))
 
 	// Limit the number of concurrent goroutines
 	concurrencyLimit := 10
 	semaphore := make(chan struct{}, concurrencyLimit)
 
 	// Send invitation email to each attendee concurrently
-	for _, attendee := range attendees {
+	for _, attendee := range recipients {
 		// Increment wait group counter
 		wg.Add(1)
 
@@ -893,7 +1319,9 @@ func (h *Handler) handleSendInvitationEmails(c *fiber.Ctx) error {
 			defer func() { <-semaphore }() // Release the spot in the semaphore
 
 			// Send email
-			err := h.mailer.SendInvitationEmail(att, emailTmp)
+			trackingID := tracking.NewID(tracked)
+			err := h.mailer.SendGroupInvitationEmail(att, guestsOf[att.ID], emailTmp, trackingID)
+			h.recordDelivery(att, emailTmp, trackingID, err, guestsOf[att.ID]...)
 			if err != nil {
 				log.Println("Error sending email to:", att.Email, err)
 				errorChannel <- err
@@ -906,16 +1334,21 @@ func (h *Handler) handleSendInvitationEmails(c *fiber.Ctx) error {
 	wg.Wait()
 	close(errorChannel)
 
+	// Emails that failed to send do not count against the quota
+	h.enforcer.ReleaseEmails(userID, len(errorChannel))
+
 	// If there were any errors in sending emails
 	if len(errorChannel) > 0 {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Some emails failed to send",
+			"error":   "Some emails failed to send",
+			"skipped": skipped,
 		})
 	}
 
 	// Successfully sent all emails
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
 		"message": "Invitation emails sent successfully",
+		"skipped": skipped,
 	})
 }
 
@@ -938,51 +1371,560 @@ func (h *Handler) handleMarkAttendeeAttendance(c *fiber.Ctx) error {
 		})
 	}
 
-	// Check if the attendee has already been marked
-	if attendee.Attendance {
+	// Parse where the scan happened, the body is optional
+	var scan types.ScanPayload
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&scan); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": "Invalid payload",
+			})
+		}
+	}
+
+	// Check if the user is the owner of the event
+	event, ferr := utils.GetOwnedEventByID(attendee.EventID, userID, h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
+		})
+	}
+
+	// Guests whose payment has not come through are not let in yet
+	if attendee.RegistrationStatus == types.RegistrationStatusPendingPayment {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+			"error": "Registration is awaiting payment",
+		})
+	}
+
+	// Check that the session belongs to the event
+	if scan.SessionID != 0 {
+		session, err := h.sessionStore.GetSessionByID(scan.SessionID)
+		if err != nil || session.EventID != event.EventID {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": "Session not found",
+			})
+		}
+	}
+
+	// Check if the attendee is checked in already, the last scan decides so
+	// an attendee who checked out can check back in
+	history, err := h.checkInStore.GetCheckInsByAttendeeID(attendee.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get check-in history",
+		})
+	}
+	if checkin.IsCheckedIn(history, scan.SessionID) {
+		message := "Attendance already marked"
+		if scan.SessionID != 0 {
+			message = "Attendance already marked for this session"
+		}
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "Attendance already marked",
+			"error": message,
 		})
 	}
 
-	// Check if the user is the owner of the event
-	event, err := h.eventStore.GetEventByID(attendee.EventID)
+	// Record the check-in, which marks the attendance of the attendee
+	checkIn := &types.CheckIn{
+		AttendeeID: attendee.ID,
+		EventID:    attendee.EventID,
+		SessionID:  scan.SessionID,
+		Type:       types.CheckInTypeCheckIn,
+		ScannedBy:  userID,
+		Entrance:   scan.Entrance,
+		DeviceID:   scan.DeviceID,
+		ScannedAt:  time.Now(),
+	}
+	if err := h.checkInStore.RecordCheckIn(c.Context(), checkIn); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to mark attendance",
+		})
+	}
+
+	// Return the attendee with the attendance refreshed from the history
+	attendee, err = h.store.GetAttendeeByID(attendee.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get attendee",
+		})
+	}
+
+	h.publishUpdate(live.UpdateCheckIn, attendee.EventID, attendee)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message":  "Attendance marked successfully",
+		"check_in": checkIn,
+	})
+}
+
+// Handler to stream live check-in and registration updates of an event
+func (h *Handler) handleStreamAttendance(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// check if the user is the owner of the event
+	eventIDString := c.Params("event_id")
+	eventID, err := strconv.Atoi(eventIDString)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid event ID",
+		})
+	}
+
+	if _, ferr := utils.GetOwnedEventByID(int32(eventID), userID, h.eventStore); ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
+		})
+	}
+
+	// Send the current totals first so the dashboard renders immediately
+	stats, err := h.store.GetAttendanceStats(int32(eventID))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"error": "Failed to get attendance stats",
+		})
+	}
+
+	return live.ServeSSE(c, h.hub, int32(eventID), &live.Update{
+		Type:    live.UpdateSnapshot,
+		EventID: int32(eventID),
+		Stats:   stats,
+	})
+}
+
+// recordDelivery stores the outcome of an email sent to an attendee for delivery
+// reports, and for the guests listed in it, who received it through the
+// attendee. Opens and clicks of the email count for the attendee. A permanent
+// SMTP error suppresses the address of the attendee.
+func (h *Handler) recordDelivery(attendee *types.Attendee, template *types.EmailTemplate, trackingID string, sendErr error, guests ...*types.Attendee) {
+	for _, recipient := range append([]*types.Attendee{attendee}, guests...) {
+		delivery := &types.EmailDelivery{
+			EventID:    recipient.EventID,
+			AttendeeID: recipient.ID,
+			TemplateID: template.ID,
+			Email:      attendee.Email,
+			Status:     types.DeliveryStatusSent,
+		}
+		if recipient == attendee {
+			delivery.TrackingID = trackingID
+		}
+		if sendErr != nil {
+			delivery.Status = types.DeliveryStatusFailed
+			delivery.Error = sendErr.Error()
+			delivery.TrackingID = ""
+		}
+
+		if err := h.deliveryStore.CreateEmailDelivery(context.Background(), delivery); err != nil {
+			log.Printf("Error recording email delivery to %s: %v", attendee.Email, err)
+		}
+	}
+
+	if sendErr != nil {
+		suppression.SuppressFailed(context.Background(), h.suppressions, attendee.Email, sendErr)
+	}
+}
+
+// recordSkipped records the attendees a bulk send left out with the reason why,
+// failures are only logged
+func (h *Handler) recordSkipped(eventID int32, template *types.EmailTemplate, skipped []*types.SkippedRecipient) {
+	for _, recipient := range skipped {
+		delivery := &types.EmailDelivery{
+			EventID:    eventID,
+			AttendeeID: recipient.AttendeeID,
+			TemplateID: template.ID,
+			Email:      recipient.Email,
+			Status:     types.DeliveryStatusSkipped,
+			Error:      recipient.Reason,
+		}
+
+		if err := h.deliveryStore.CreateEmailDelivery(context.Background(), delivery); err != nil {
+			log.Printf("Error recording skipped email delivery to %s: %v", recipient.Email, err)
+		}
+	}
+}
+
+// groupRecipients folds the guests whose primary attendee is also a recipient
+// into the invitation of the primary attendee. It returns the attendees to
+// email and the guests to list in the email of each primary attendee.
+func groupRecipients(recipients []*types.Attendee) ([]*types.Attendee, map[int32][]*types.Attendee) {
+	primaries := make(map[int32]bool, len(recipients))
+	for _, attendee := range recipients {
+		primaries[attendee.ID] = true
+	}
+
+	emailed := []*types.Attendee{}
+	guestsOf := map[int32][]*types.Attendee{}
+	for _, attendee := range recipients {
+		if attendee.PrimaryAttendeeID != 0 && primaries[attendee.PrimaryAttendeeID] {
+			guestsOf[attendee.PrimaryAttendeeID] = append(guestsOf[attendee.PrimaryAttendeeID], attendee)
+			continue
+		}
+		emailed = append(emailed, attendee)
+	}
+
+	return emailed, guestsOf
+}
+
+// filterKeys are the query keys of the attendee filter
+var filterKeys = queryKeys(types.AttendeeFilter{})
+
+// pageKeys are the query keys of utils.ParsePage
+var pageKeys = []string{"limit", "cursor", "total"}
+
+// parseFilter reads the attendee filter from the query string, the returned
+// map is the body of the bad request response when the filter is invalid.
+// Keys other than the filter's and the given ones are rejected, so that a
+// misspelt filter cannot widen a bulk action to every attendee.
+func parseFilter(c *fiber.Ctx, allowed ...string) (*types.AttendeeFilter, fiber.Map) {
+	unknown := []string{}
+	c.Context().QueryArgs().VisitAll(func(key []byte, _ []byte) {
+		if !filterKeys[string(key)] && !slices.Contains(allowed, string(key)) {
+			unknown = append(unknown, string(key))
+		}
+	})
+	if len(unknown) > 0 {
+		return nil, fiber.Map{
+			"error":          "Invalid filter",
+			"unknown_fields": unknown,
+		}
+	}
+
+	filter := &types.AttendeeFilter{}
+	if err := c.QueryParser(filter); err != nil {
+		return nil, fiber.Map{"error": "Invalid filter"}
+	}
+
+	if invalidFields, err := utils.ValidatePayload(filter); err != nil {
+		return nil, fiber.Map{
+			"error":          "Invalid filter",
+			"invalid_fields": invalidFields,
+		}
+	}
+
+	return filter, nil
+}
+
+// queryKeys lists the query tags of the fields of a struct
+func queryKeys(v any) map[string]bool {
+	keys := map[string]bool{}
+	t := reflect.TypeOf(v)
+	for i := 0; i < t.NumField(); i++ {
+		if key := t.Field(i).Tag.Get("query"); key != "" {
+			keys[key] = true
+		}
+	}
+
+	return keys
+}
+
+// matchesAll tells whether a filter leaves in every attendee of the event,
+// whatever order it sorts them in
+func matchesAll(filter *types.AttendeeFilter) bool {
+	unsorted := *filter
+	unsorted.Sort = ""
+
+	return unsorted == types.AttendeeFilter{}
+}
+
+// linkImportGroups makes the other rows of each group of a CSV import guests of
+// its first row, seating new guests without a table with their primary
+// attendee. It returns the rows that could not join their group.
+func (h *Handler) linkImportGroups(ctx context.Context, event *types.Event, groups [][]int, rowAttendees []*types.Attendee, rowCreated []bool) []fiber.Map {
+	groupErrors := []fiber.Map{}
+	fail := func(row int, err error) {
+		// Row numbers count the header row, as in a spreadsheet
+		groupErrors = append(groupErrors, fiber.Map{"row": row + 2, "error": err.Error()})
+	}
+
+	for _, rows := range groups {
+		primary := rowAttendees[rows[0]]
+		if primary == nil {
+			fail(rows[0], fmt.Errorf("the primary attendee of the group failed to import"))
+			continue
+		}
+		if err := group.CheckPrimary(primary, event.EventID); err != nil {
+			fail(rows[0], err)
+			continue
+		}
+
+		for _, row := range rows[1:] {
+			guest := rowAttendees[row]
+			if guest == nil || guest.ID == primary.ID {
+				continue
+			}
+
+			hasGuests := false
+			if !rowCreated[row] {
+				guests, err := h.store.GetGuests(guest.ID)
+				if err != nil {
+					fail(row, fmt.Errorf("failed to get guests: %v", err))
+					continue
+				}
+				hasGuests = len(guests) > 0
+			}
+			if err := group.CheckGuest(primary, guest, hasGuests); err != nil {
+				fail(row, err)
+				continue
+			}
+
+			if err := h.store.UpdatePrimaryAttendee(ctx, guest.ID, primary.ID); err != nil {
+				fail(row, fmt.Errorf("failed to join the group: %v", err))
+				continue
+			}
+
+			if rowCreated[row] && guest.TableNo == 0 && primary.TableNo != 0 {
+				if err := h.seatingStore.AssignTable(ctx, event.EventID, guest.ID, primary.TableNo); err != nil {
+					fail(row, fmt.Errorf("failed to seat with the primary attendee: %v", err))
+				}
+			}
+		}
+	}
+
+	return groupErrors
+}
+
+// checkTicketType checks that a ticket type exists and belongs to the event
+func (h *Handler) checkTicketType(eventID int32, ticketTypeID int32) *fiber.Error {
+	ticketType, err := h.ticketTypeStore.GetTicketTypeByID(ticketTypeID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to get ticket type")
+	}
+	if err != nil || ticketType.EventID != eventID {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Ticket type %d does not belong to the event", ticketTypeID))
+	}
+
+	return nil
+}
+
+// getPlanTables returns the table numbers of the seating plan of an event, or
+// nil when the event has no plan and table numbers are free
+func (h *Handler) getPlanTables(eventID int32) (map[int32]bool, error) {
+	tables, err := h.seatingStore.GetEventTables(eventID)
+	if err != nil {
+		return nil, err
+	}
+	if len(tables) == 0 {
+		return nil, nil
+	}
+
+	planTables := make(map[int32]bool, len(tables))
+	for _, table := range tables {
+		planTables[table.TableNo] = true
+	}
+
+	return planTables, nil
+}
+
+// getEmailTemplate fetches the email template of an event for the purpose in
+// the query of the request, the invitation when no purpose is given
+func (h *Handler) getEmailTemplate(c *fiber.Ctx, eventID int32) (*types.EmailTemplate, *fiber.Error) {
+	purpose := c.Query("purpose", types.EmailPurposeInvitation)
+	if !slices.Contains(types.EmailPurposes, purpose) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid email purpose")
+	}
+
+	emailTemplate, err := h.emailStore.GetEmailTemplateByPurpose(c.Context(), eventID, purpose)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-				"error": "Event not found",
-			})
+			return nil, fiber.NewError(fiber.StatusNotFound, fmt.Sprintf("The event has no %s email template", purpose))
 		}
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to get email template")
+	}
+
+	return emailTemplate, nil
+}
+
+// noteAttendeeUpdate notes the change to an attendee for the audit log
+func (h *Handler) noteAttendeeUpdate(c *fiber.Ctx, event *types.Event, before *types.Attendee) {
+	mutation := audit.Mutation{Action: "attendee.update", TargetType: "attendee", TargetID: before.ID, EventID: event.EventID, EventOwnerID: event.UserID, Before: before}
+	if after, err := h.store.GetAttendeeByID(before.ID); err == nil {
+		mutation.After = after
+	}
+
+	audit.Note(c, mutation)
+}
+
+// publishUpdate pushes an attendee change together with fresh totals to the live dashboards of an event
+func (h *Handler) publishUpdate(updateType string, eventID int32, attendee *types.Attendee) {
+	if !h.hub.HasSubscribers(eventID) {
+		return
+	}
+
+	stats, err := h.store.GetAttendanceStats(eventID)
+	if err != nil {
+		log.Printf("Error getting attendance stats for event ID %d: %v", eventID, err)
+		return
+	}
+
+	h.hub.Publish(live.Update{
+		Type:     updateType,
+		EventID:  eventID,
+		Attendee: attendee,
+		Stats:    stats,
+	})
+}
+
+// Handler to get the deleted attendees of an event that can still be restored
+func (h *Handler) handleGetDeletedAttendees(c *fiber.Ctx) error {
+	page, ferr := utils.ParsePage(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
+		})
+	}
+
+	userID := auth.GetUserIDFromContext(c)
+
+	eventID, err := strconv.Atoi(c.Params("event_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid event ID",
+		})
+	}
+
+	event, ferr := utils.GetOwnedEventByID(int32(eventID), userID, h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
+		})
+	}
+
+	attendees, next, err := h.store.GetDeletedAttendeesPaginated(event.EventID, page)
+	if errors.Is(err, types.ErrInvalidCursor) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid cursor",
+		})
+	}
+	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to get event",
+			"error": "Failed to get deleted attendees",
+		})
+	}
+
+	var total *int64
+	if page.WithTotal {
+		count, err := h.store.CountDeletedAttendees(event.EventID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+				"error": "Failed to count deleted attendees",
+			})
+		}
+		total = &count
+	}
+
+	return c.Status(fiber.StatusOK).JSON(utils.NewPage(attendees, next, total, nil))
+}
+
+// Handler to restore a deleted attendee before it is purged
+func (h *Handler) handleRestoreAttendeeByID(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	eventID, err := strconv.Atoi(c.Params("event_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid event ID",
+		})
+	}
+
+	attendeeID, err := strconv.Atoi(c.Params("attendee_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid attendee ID",
 		})
 	}
 
-	if event.UserID != userID {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
-			"error": "Unauthorized",
+	event, ferr := utils.GetOwnedEventByID(int32(eventID), userID, h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
 		})
 	}
 
-	// Mark the attendance of the attendee
-	if err := h.store.UpdateAttendeeByID(attendee.ID, &types.Attendee{
-		FirstName:   attendee.FirstName,
-		LastName:    attendee.LastName,
-		Email:       attendee.Email,
-		EventID:     attendee.EventID,
-		QrCode:      attendee.QrCode,
-		CompanyName: attendee.CompanyName,
-		Title:       attendee.Title,
-		TableNo:     attendee.TableNo,
-		Role:        attendee.Role,
-		Attendance:  true,
-	}); err != nil {
+	attendee, err := h.store.GetDeletedAttendeeByID(int32(attendeeID))
+	if err != nil || attendee.EventID != event.EventID {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+			"error": "Deleted attendee not found",
+		})
+	}
+
+	// A restored attendee counts towards the plan again
+	if ferr := h.enforcer.CheckAttendees(event, 1); ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
+		})
+	}
+
+	if ferr := h.restoreAttendee(c, event, attendee); ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
+		})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(attendee)
+}
+
+// Handler to restore every deleted attendee of an event, attendees that
+// cannot be restored are reported and the rest are restored
+func (h *Handler) handleRestoreAllAttendees(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	eventID, err := strconv.Atoi(c.Params("event_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error": "Invalid event ID",
+		})
+	}
+
+	event, ferr := utils.GetOwnedEventByID(int32(eventID), userID, h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
+		})
+	}
+
+	attendees, err := h.store.GetDeletedAttendees(event.EventID)
+	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "Failed to mark attendance",
+			"error": "Failed to get deleted attendees",
 		})
 	}
 
+	if ferr := h.enforcer.CheckAttendees(event, len(attendees)); ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{
+			"error": ferr.Message,
+		})
+	}
+
+	restored := 0
+	failed := []fiber.Map{}
+	for _, attendee := range attendees {
+		if ferr := h.restoreAttendee(c, event, attendee); ferr != nil {
+			failed = append(failed, fiber.Map{"attendee_id": attendee.ID, "email": attendee.Email, "error": ferr.Message})
+			continue
+		}
+		restored++
+	}
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"message": "Attendance marked successfully",
+		"restored": restored,
+		"failed":   failed,
 	})
 }
+
+// restoreAttendee restores a deleted attendee and notes it for the audit log and the live dashboards
+func (h *Handler) restoreAttendee(c *fiber.Ctx, event *types.Event, attendee *types.Attendee) *fiber.Error {
+	if err := h.store.RestoreAttendeeByID(c.Context(), attendee.ID); err != nil {
+		if errors.Is(err, types.ErrTicketTypeSoldOut) {
+			return fiber.NewError(fiber.StatusConflict, "Ticket type is sold out")
+		}
+		if strings.Contains(err.Error(), "Email_Event_UNIQUE") {
+			return fiber.NewError(fiber.StatusConflict, "Attendee with same email already exists")
+		}
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to restore attendee")
+	}
+	attendee.DeletedAt = nil
+
+	audit.Note(c, audit.Mutation{Action: "attendee.restore", TargetType: "attendee", TargetID: attendee.ID, EventID: event.EventID, EventOwnerID: event.UserID, After: attendee})
+	h.publishUpdate(live.UpdateRegistration, attendee.EventID, attendee)
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package attendee
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/service/live"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+// attendeeStore keeps the attendance of its attendee once any check-in is
+// recorded, as the attendance refresh does
+type attendeeStore struct {
+	types.AttendeeStore
+	attendee *types.Attendee
+	checkIns *checkInStore
+}
+
+func (s *attendeeStore) GetAttendeeByEmail(email string) (*types.Attendee, error) {
+	return s.GetAttendeeByID(s.attendee.ID)
+}
+
+func (s *attendeeStore) GetAttendeeByID(attendeeID int32) (*types.Attendee, error) {
+	attendee := *s.attendee
+	for _, checkIn := range s.checkIns.history {
+		if checkIn.Type == types.CheckInTypeCheckIn {
+			attendee.Attendance = true
+		}
+	}
+	return &attendee, nil
+}
+
+type eventStore struct {
+	types.EventStore
+}
+
+func (eventStore) GetEventByID(eventID int32) (*types.Event, error) {
+	return &types.Event{EventID: eventID, UserID: 3}, nil
+}
+
+type checkInStore struct {
+	types.CheckInStore
+	history []*types.CheckIn
+}
+
+func (s *checkInStore) RecordCheckIn(ctx context.Context, checkIn *types.CheckIn) error {
+	checkIn.ID = int32(len(s.history) + 1)
+	s.history = append(s.history, checkIn)
+	return nil
+}
+
+func (s *checkInStore) GetCheckInsByAttendeeID(attendeeID int32) ([]*types.CheckIn, error) {
+	return s.history, nil
+}
+
+func TestMarkAttendanceAfterCheckOut(t *testing.T) {
+	checkIns := &checkInStore{}
+	attendees := &attendeeStore{attendee: &types.Attendee{ID: 1, EventID: 7, Email: "ada@example.com"}, checkIns: checkIns}
+	h := NewHandler(attendees, eventStore{}, nil, nil, nil, nil, nil, checkIns, nil, nil, nil, nil, live.NewHub())
+
+	app := fiber.New()
+	app.Post("/attendees/mark_attendance/:attendee_email", func(c *fiber.Ctx) error {
+		c.Locals(auth.UserKey, int32(3))
+		return c.Next()
+	}, h.handleMarkAttendeeAttendance)
+
+	markAttendance := func() int {
+		resp, err := app.Test(httptest.NewRequest(fiber.MethodPost, "/attendees/mark_attendance/ada@example.com", nil))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return resp.StatusCode
+	}
+
+	if status := markAttendance(); status != fiber.StatusOK {
+		t.Fatalf("expected the first check-in to succeed, got %d", status)
+	}
+	if status := markAttendance(); status != fiber.StatusBadRequest {
+		t.Fatalf("expected a second check-in to be refused, got %d", status)
+	}
+
+	checkIns.RecordCheckIn(context.Background(), &types.CheckIn{AttendeeID: 1, EventID: 7, Type: types.CheckInTypeCheckOut, ScannedAt: time.Now()})
+
+	if status := markAttendance(); status != fiber.StatusOK {
+		t.Fatalf("expected a check-in after the check-out to succeed, got %d", status)
+	}
+	if len(checkIns.history) != 3 || checkIns.history[2].Type != types.CheckInTypeCheckIn {
+		t.Errorf("expected the history to end with a check-in, got %d entries", len(checkIns.history))
+	}
+}
@@ -3,44 +3,64 @@ package attendee
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/jayden1905/event-registration-software/cmd/pkg/database"
+	"github.com/jayden1905/event-registration-software/db"
 	"github.com/jayden1905/event-registration-software/types"
 )
 
 type Store struct {
-	db *database.Queries
+	db   *database.Queries
+	conn *sql.DB
 }
 
-func NewStore(db *database.Queries) *Store {
-	return &Store{db: db}
+func NewStore(db *database.Queries, conn *sql.DB) *Store {
+	return &Store{db: db, conn: conn}
 }
 
-// CreateAttendee creates a new attendee in the database
+// CreateAttendee creates a new attendee in the database. When the attendee
+// has a ticket type its quota is taken first, so concurrent registrations
+// cannot oversell it.
 func (s *Store) CreateAttendee(ctx context.Context, attendee *types.Attendee) error {
 	attendanceValue := database.AttendeesAttendanceNo
 	if attendee.Attendance {
 		attendanceValue = database.AttendeesAttendanceYes
 	}
 
-	err := s.db.CreateAttendee(ctx, database.CreateAttendeeParams{
-		FirstName:   attendee.FirstName,
-		LastName:    attendee.LastName,
-		Email:       attendee.Email,
-		EventID:     attendee.EventID,
-		QrCode:      sql.NullString{String: attendee.QrCode, Valid: true},
-		CompanyName: sql.NullString{String: attendee.CompanyName, Valid: true},
-		Title:       sql.NullString{String: attendee.Title, Valid: true},
-		TableNo:     sql.NullInt32{Int32: attendee.TableNo, Valid: true},
-		Role:        sql.NullString{String: attendee.Role, Valid: true},
-		Attendance: database.NullAttendeesAttendance{
-			AttendeesAttendance: attendanceValue,
-			Valid:               attendee.Attendance,
-		},
+	if err := s.reserveTicket(ctx, attendee.TicketTypeID); err != nil {
+		return err
+	}
+
+	registrationStatus := database.AttendeesRegistrationStatusConfirmed
+	if attendee.RegistrationStatus == types.RegistrationStatusPendingPayment {
+		registrationStatus = database.AttendeesRegistrationStatusPendingPayment
+	}
+
+	id, err := s.db.CreateAttendee(ctx, database.CreateAttendeeParams{
+		FirstName:          attendee.FirstName,
+		LastName:           attendee.LastName,
+		Email:              attendee.Email,
+		EventID:            attendee.EventID,
+		QrCode:             sql.NullString{String: attendee.QrCode, Valid: true},
+		CompanyName:        sql.NullString{String: attendee.CompanyName, Valid: true},
+		Title:              sql.NullString{String: attendee.Title, Valid: true},
+		TableNo:            sql.NullInt32{Int32: attendee.TableNo, Valid: true},
+		Role:               sql.NullString{String: attendee.Role, Valid: true},
+		Attendance:         attendanceValue,
+		TicketTypeID:       sql.NullInt32{Int32: attendee.TicketTypeID, Valid: attendee.TicketTypeID != 0},
+		RegistrationStatus: registrationStatus,
+		PrimaryAttendeeID:  sql.NullInt32{Int32: attendee.PrimaryAttendeeID, Valid: attendee.PrimaryAttendeeID != 0},
 	})
 	if err != nil {
-		return err
+		return errors.Join(err, s.releaseTicket(ctx, attendee.TicketTypeID))
 	}
+	attendee.ID = int32(id)
+	attendee.RegistrationStatus = string(registrationStatus)
 
 	return nil
 }
@@ -53,17 +73,60 @@ func (s *Store) GetAttendeeByEmail(email string) (*types.Attendee, error) {
 	}
 
 	return &types.Attendee{
-		ID:          attendee.ID,
-		FirstName:   attendee.FirstName,
-		LastName:    attendee.LastName,
-		Email:       attendee.Email,
-		EventID:     attendee.EventID,
-		QrCode:      attendee.QrCode.String,
-		CompanyName: attendee.CompanyName.String,
-		Title:       attendee.Title.String,
-		TableNo:     attendee.TableNo.Int32,
-		Role:        attendee.Role.String,
-		Attendance:  attendee.Attendance.Valid,
+		ID:                 attendee.ID,
+		FirstName:          attendee.FirstName,
+		LastName:           attendee.LastName,
+		Email:              attendee.Email,
+		EventID:            attendee.EventID,
+		QrCode:             attendee.QrCode.String,
+		CompanyName:        attendee.CompanyName.String,
+		Title:              attendee.Title.String,
+		TableNo:            attendee.TableNo.Int32,
+		Role:               attendee.Role.String,
+		Attendance:         attendee.Attendance == database.AttendeesAttendanceYes,
+		FirstCheckedInAt:   timePtr(attendee.CheckedInAt),
+		SessionsAttended:   attendee.SessionsAttended,
+		TicketTypeID:       attendee.TicketTypeID.Int32,
+		RegistrationStatus: string(attendee.RegistrationStatus),
+		RSVPStatus:         string(attendee.RsvpStatus),
+		RSVPReason:         attendee.RsvpReason.String,
+		RSVPPlusOnes:       attendee.RsvpPlusOnes,
+		RSVPRespondedAt:    timePtr(attendee.RsvpRespondedAt),
+		PrimaryAttendeeID:  attendee.PrimaryAttendeeID.Int32,
+	}, nil
+}
+
+// GetAttendeeByEmailAndEventID fetches the attendee of an event with an email
+func (s *Store) GetAttendeeByEmailAndEventID(email string, eventID int32) (*types.Attendee, error) {
+	attendee, err := s.db.GetAttendeeByEmailAndEventID(context.Background(), database.GetAttendeeByEmailAndEventIDParams{
+		Email:   email,
+		EventID: eventID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Attendee{
+		ID:                 attendee.ID,
+		FirstName:          attendee.FirstName,
+		LastName:           attendee.LastName,
+		Email:              attendee.Email,
+		EventID:            attendee.EventID,
+		QrCode:             attendee.QrCode.String,
+		CompanyName:        attendee.CompanyName.String,
+		Title:              attendee.Title.String,
+		TableNo:            attendee.TableNo.Int32,
+		Role:               attendee.Role.String,
+		Attendance:         attendee.Attendance == database.AttendeesAttendanceYes,
+		FirstCheckedInAt:   timePtr(attendee.CheckedInAt),
+		SessionsAttended:   attendee.SessionsAttended,
+		TicketTypeID:       attendee.TicketTypeID.Int32,
+		RegistrationStatus: string(attendee.RegistrationStatus),
+		RSVPStatus:         string(attendee.RsvpStatus),
+		RSVPReason:         attendee.RsvpReason.String,
+		RSVPPlusOnes:       attendee.RsvpPlusOnes,
+		RSVPRespondedAt:    timePtr(attendee.RsvpRespondedAt),
+		PrimaryAttendeeID:  attendee.PrimaryAttendeeID.Int32,
 	}, nil
 }
 
@@ -74,64 +137,157 @@ func (s *Store) GetAttendeeByID(id int32) (*types.Attendee, error) {
 	}
 
 	return &types.Attendee{
-		ID:          attendee.ID,
-		FirstName:   attendee.FirstName,
-		LastName:    attendee.LastName,
-		Email:       attendee.Email,
-		EventID:     attendee.EventID,
-		QrCode:      attendee.QrCode.String,
-		CompanyName: attendee.CompanyName.String,
-		Title:       attendee.Title.String,
-		TableNo:     attendee.TableNo.Int32,
-		Role:        attendee.Role.String,
-		Attendance:  attendee.Attendance.Valid,
+		ID:                 attendee.ID,
+		FirstName:          attendee.FirstName,
+		LastName:           attendee.LastName,
+		Email:              attendee.Email,
+		EventID:            attendee.EventID,
+		QrCode:             attendee.QrCode.String,
+		CompanyName:        attendee.CompanyName.String,
+		Title:              attendee.Title.String,
+		TableNo:            attendee.TableNo.Int32,
+		Role:               attendee.Role.String,
+		Attendance:         attendee.Attendance == database.AttendeesAttendanceYes,
+		FirstCheckedInAt:   timePtr(attendee.CheckedInAt),
+		SessionsAttended:   attendee.SessionsAttended,
+		TicketTypeID:       attendee.TicketTypeID.Int32,
+		RegistrationStatus: string(attendee.RegistrationStatus),
+		RSVPStatus:         string(attendee.RsvpStatus),
+		RSVPReason:         attendee.RsvpReason.String,
+		RSVPPlusOnes:       attendee.RsvpPlusOnes,
+		RSVPRespondedAt:    timePtr(attendee.RsvpRespondedAt),
+		PrimaryAttendeeID:  attendee.PrimaryAttendeeID.Int32,
 	}, nil
 }
 
-// DeleteAttendeeByID deletes an attendee from the database by ID
+// DeleteAttendeeByID deletes an attendee from the database by ID. Its guests
+// are made standalone in the same transaction, so no attendee is left in the
+// group of a deleted one.
 func (s *Store) DeleteAttendeeByID(attendeeID int32) error {
-	err := s.db.DeleteAttendeeByID(context.Background(), attendeeID)
+	ctx := context.Background()
+	attendee, err := s.db.GetAttendeeByID(ctx, attendeeID)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return db.WithTx(ctx, s.conn, s.db, func(q *database.Queries) error {
+		// Remember the attendee so offline check-in devices can drop it on their next sync
+		err := q.CreateDeletedAttendee(ctx, attendeeID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteAttendeeByID(ctx, attendeeID)
+		if err != nil {
+			return err
+		}
+
+		err = q.ClearGuestsByPrimaryAttendeeID(ctx, sql.NullInt32{Int32: attendeeID, Valid: true})
+		if err != nil {
+			return err
+		}
+
+		// Free the session seats and the ticket the attendee had taken
+		err = q.RefreshSessionSignupCountsByEventID(ctx, attendee.EventID)
+		if err != nil {
+			return err
+		}
+
+		return q.RefreshTicketSoldCountsByEventID(ctx, attendee.EventID)
+	})
 }
 
-// DeleteAllAttendeesByEventID deletes all attendees from the database by event ID
+// DeleteAllAttendeesByEventID deletes all attendees from the database by
+// event ID, refreshing the session and ticket counts in the same transaction
 func (s *Store) DeleteAllAttendeesByEventID(eventID int32) error {
-	err := s.db.DeleteAllAttendeesByEventID(context.Background(), eventID)
+	ctx := context.Background()
+
+	return db.WithTx(ctx, s.conn, s.db, func(q *database.Queries) error {
+		// Remember the attendees so offline check-in devices can drop them on their next sync
+		err := q.CreateDeletedAttendeesByEventID(ctx, eventID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteAllAttendeesByEventID(ctx, eventID)
+		if err != nil {
+			return err
+		}
+
+		err = q.RefreshSessionSignupCountsByEventID(ctx, eventID)
+		if err != nil {
+			return err
+		}
+
+		return q.RefreshTicketSoldCountsByEventID(ctx, eventID)
+	})
+}
+
+// UpdateAttendeeByID updates an attendee in the database by ID. Moving the
+// attendee to another ticket type takes a ticket of the new type and gives
+// back the old one.
+func (s *Store) UpdateAttendeeByID(attendeeID int32, data *types.Attendee) error {
+	current, err := s.db.GetAttendeeByID(context.Background(), attendeeID)
+	if err != nil {
+		return err
+	}
+	oldTicketTypeID := current.TicketTypeID.Int32
+	ticketChanged := oldTicketTypeID != data.TicketTypeID
+	if ticketChanged {
+		if err := s.reserveTicket(context.Background(), data.TicketTypeID); err != nil {
+			return err
+		}
+	}
+
+	err = s.db.UpdateAttendeeByID(context.Background(), updateAttendeeParams(attendeeID, data))
 	if err != nil {
+		if ticketChanged {
+			return errors.Join(err, s.releaseTicket(context.Background(), data.TicketTypeID))
+		}
 		return err
 	}
 
+	if ticketChanged {
+		return s.releaseTicket(context.Background(), oldTicketTypeID)
+	}
+
 	return nil
 }
 
-// UpdateAttendeeByID updates an attendee in the database by ID
-func (s *Store) UpdateAttendeeByID(attendeeID int32, data *types.Attendee) error {
-	attendanceValue := database.AttendeesAttendanceNo
+// UpdateAttendeesByID updates attendees in one transaction, so either all of
+// them are updated or none is. Their ticket types are not reserved or given
+// back and must stay as they are.
+func (s *Store) UpdateAttendeesByID(ctx context.Context, attendees []*types.Attendee) error {
+	return db.WithTx(ctx, s.conn, s.db, func(q *database.Queries) error {
+		for _, attendee := range attendees {
+			if err := q.UpdateAttendeeByID(ctx, updateAttendeeParams(attendee.ID, attendee)); err != nil {
+				return err
+			}
+		}
 
-	err := s.db.UpdateAttendeeByID(context.Background(), database.UpdateAttendeeByIDParams{
-		ID:          attendeeID,
-		FirstName:   data.FirstName,
-		LastName:    data.LastName,
-		Email:       data.Email,
-		QrCode:      sql.NullString{String: data.QrCode, Valid: true},
-		CompanyName: sql.NullString{String: data.CompanyName, Valid: true},
-		Title:       sql.NullString{String: data.Title, Valid: true},
-		TableNo:     sql.NullInt32{Int32: data.TableNo, Valid: true},
-		Role:        sql.NullString{String: data.Role, Valid: true},
-		Attendance: database.NullAttendeesAttendance{
-			AttendeesAttendance: attendanceValue,
-			Valid:               data.Attendance,
-		},
+		return nil
 	})
-	if err != nil {
-		return err
+}
+
+func updateAttendeeParams(attendeeID int32, data *types.Attendee) database.UpdateAttendeeByIDParams {
+	attendanceValue := database.AttendeesAttendanceNo
+	if data.Attendance {
+		attendanceValue = database.AttendeesAttendanceYes
 	}
 
-	return nil
+	return database.UpdateAttendeeByIDParams{
+		ID:           attendeeID,
+		FirstName:    data.FirstName,
+		LastName:     data.LastName,
+		Email:        data.Email,
+		QrCode:       sql.NullString{String: data.QrCode, Valid: true},
+		CompanyName:  sql.NullString{String: data.CompanyName, Valid: true},
+		Title:        sql.NullString{String: data.Title, Valid: true},
+		TableNo:      sql.NullInt32{Int32: data.TableNo, Valid: true},
+		Role:         sql.NullString{String: data.Role, Valid: true},
+		Attendance:   attendanceValue,
+		TicketTypeID: sql.NullInt32{Int32: data.TicketTypeID, Valid: data.TicketTypeID != 0},
+	}
 }
 
 // GetAllAttendeesPaginated fetches all attendees from the database with pagination
@@ -151,17 +307,26 @@ func (s *Store) GetAllAttendeesPaginated(page int32, pageSize int32, eventID int
 
 	for _, attendee := range attendees {
 		allAttendees = append(allAttendees, &types.Attendee{
-			ID:          attendee.ID,
-			FirstName:   attendee.FirstName,
-			LastName:    attendee.LastName,
-			Email:       attendee.Email,
-			EventID:     attendee.EventID,
-			QrCode:      attendee.QrCode.String,
-			CompanyName: attendee.CompanyName.String,
-			Title:       attendee.Title.String,
-			TableNo:     attendee.TableNo.Int32,
-			Role:        attendee.Role.String,
-			Attendance:  attendee.Attendance.Valid,
+			ID:                 attendee.ID,
+			FirstName:          attendee.FirstName,
+			LastName:           attendee.LastName,
+			Email:              attendee.Email,
+			EventID:            attendee.EventID,
+			QrCode:             attendee.QrCode.String,
+			CompanyName:        attendee.CompanyName.String,
+			Title:              attendee.Title.String,
+			TableNo:            attendee.TableNo.Int32,
+			Role:               attendee.Role.String,
+			Attendance:         attendee.Attendance == database.AttendeesAttendanceYes,
+			FirstCheckedInAt:   timePtr(attendee.CheckedInAt),
+			SessionsAttended:   attendee.SessionsAttended,
+			TicketTypeID:       attendee.TicketTypeID.Int32,
+			RegistrationStatus: string(attendee.RegistrationStatus),
+			RSVPStatus:         string(attendee.RsvpStatus),
+			RSVPReason:         attendee.RsvpReason.String,
+			RSVPPlusOnes:       attendee.RsvpPlusOnes,
+			RSVPRespondedAt:    timePtr(attendee.RsvpRespondedAt),
+			PrimaryAttendeeID:  attendee.PrimaryAttendeeID.Int32,
 		})
 	}
 
@@ -179,23 +344,240 @@ func (s *Store) GetAllAttendees(eventID int32) ([]*types.Attendee, error) {
 
 	for _, attendee := range attendees {
 		allAttendees = append(allAttendees, &types.Attendee{
-			ID:          attendee.ID,
-			FirstName:   attendee.FirstName,
-			LastName:    attendee.LastName,
-			Email:       attendee.Email,
-			EventID:     attendee.EventID,
-			QrCode:      attendee.QrCode.String,
-			CompanyName: attendee.CompanyName.String,
-			Title:       attendee.Title.String,
-			TableNo:     attendee.TableNo.Int32,
-			Role:        attendee.Role.String,
-			Attendance:  attendee.Attendance.Valid,
+			ID:                 attendee.ID,
+			FirstName:          attendee.FirstName,
+			LastName:           attendee.LastName,
+			Email:              attendee.Email,
+			EventID:            attendee.EventID,
+			QrCode:             attendee.QrCode.String,
+			CompanyName:        attendee.CompanyName.String,
+			Title:              attendee.Title.String,
+			TableNo:            attendee.TableNo.Int32,
+			Role:               attendee.Role.String,
+			Attendance:         attendee.Attendance == database.AttendeesAttendanceYes,
+			FirstCheckedInAt:   timePtr(attendee.CheckedInAt),
+			SessionsAttended:   attendee.SessionsAttended,
+			TicketTypeID:       attendee.TicketTypeID.Int32,
+			RegistrationStatus: string(attendee.RegistrationStatus),
+			RSVPStatus:         string(attendee.RsvpStatus),
+			RSVPReason:         attendee.RsvpReason.String,
+			RSVPPlusOnes:       attendee.RsvpPlusOnes,
+			RSVPRespondedAt:    timePtr(attendee.RsvpRespondedAt),
+			PrimaryAttendeeID:  attendee.PrimaryAttendeeID.Int32,
 		})
 	}
 
 	return allAttendees, nil
 }
 
+// GetGuests fetches the guests registered with a primary attendee
+func (s *Store) GetGuests(primaryAttendeeID int32) ([]*types.Attendee, error) {
+	attendees, err := s.db.GetGuestsByPrimaryAttendeeID(context.Background(), sql.NullInt32{Int32: primaryAttendeeID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	guests := []*types.Attendee{}
+
+	for _, attendee := range attendees {
+		guests = append(guests, &types.Attendee{
+			ID:                 attendee.ID,
+			FirstName:          attendee.FirstName,
+			LastName:           attendee.LastName,
+			Email:              attendee.Email,
+			EventID:            attendee.EventID,
+			QrCode:             attendee.QrCode.String,
+			CompanyName:        attendee.CompanyName.String,
+			Title:              attendee.Title.String,
+			TableNo:            attendee.TableNo.Int32,
+			Role:               attendee.Role.String,
+			Attendance:         attendee.Attendance == database.AttendeesAttendanceYes,
+			FirstCheckedInAt:   timePtr(attendee.CheckedInAt),
+			SessionsAttended:   attendee.SessionsAttended,
+			TicketTypeID:       attendee.TicketTypeID.Int32,
+			RegistrationStatus: string(attendee.RegistrationStatus),
+			RSVPStatus:         string(attendee.RsvpStatus),
+			RSVPReason:         attendee.RsvpReason.String,
+			RSVPPlusOnes:       attendee.RsvpPlusOnes,
+			RSVPRespondedAt:    timePtr(attendee.RsvpRespondedAt),
+			PrimaryAttendeeID:  attendee.PrimaryAttendeeID.Int32,
+		})
+	}
+
+	return guests, nil
+}
+
+// UpdatePrimaryAttendee makes an attendee a guest of a primary attendee, or
+// standalone again when primaryAttendeeID is 0
+func (s *Store) UpdatePrimaryAttendee(ctx context.Context, attendeeID int32, primaryAttendeeID int32) error {
+	return s.db.UpdatePrimaryAttendeeByID(ctx, database.UpdatePrimaryAttendeeByIDParams{
+		PrimaryAttendeeID: sql.NullInt32{Int32: primaryAttendeeID, Valid: primaryAttendeeID != 0},
+		ID:                attendeeID,
+	})
+}
+
+// ClearGuests makes every guest of a primary attendee standalone, dissolving the group
+func (s *Store) ClearGuests(ctx context.Context, primaryAttendeeID int32) error {
+	return s.db.ClearGuestsByPrimaryAttendeeID(ctx, sql.NullInt32{Int32: primaryAttendeeID, Valid: true})
+}
+
+// GetFilteredAttendees fetches the page of the attendees of an event that match a filter after a cursor,
+// in the order the filter asks for, and the cursor of the next page when there is one
+func (s *Store) GetFilteredAttendees(eventID int32, filter *types.AttendeeFilter, page *types.PageRequest) ([]*types.Attendee, *types.Cursor, error) {
+	attendees, keys, err := s.getFilteredAttendees(eventID, filter, page.After, page.Limit+1)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The row past the limit only tells that there is a next page
+	if len(attendees) <= int(page.Limit) {
+		return attendees, nil, nil
+	}
+	attendees = attendees[:page.Limit]
+	last := attendees[len(attendees)-1]
+
+	return attendees, &types.Cursor{Key: keys[len(attendees)-1], ID: int64(last.ID)}, nil
+}
+
+// GetAllFilteredAttendees fetches every attendee of an event that matches a filter, for bulk actions
+func (s *Store) GetAllFilteredAttendees(eventID int32, filter *types.AttendeeFilter) ([]*types.Attendee, error) {
+	attendees, _, err := s.getFilteredAttendees(eventID, filter, nil, math.MaxInt32)
+	return attendees, err
+}
+
+// getFilteredAttendees fetches up to limit attendees that match a filter after a
+// cursor, together with the keys they are sorted by
+func (s *Store) getFilteredAttendees(eventID int32, filter *types.AttendeeFilter, after *types.Cursor, limit int32) ([]*types.Attendee, []string, error) {
+	where := filterParams(eventID, filter)
+	params := database.GetFilteredAttendeesByEventIDParams{
+		SortColumn:  strings.TrimPrefix(filter.Sort, "-"),
+		EventID:     where.EventID,
+		Role:        where.Role,
+		CompanyName: where.CompanyName,
+		TableNo:     where.TableNo,
+		Attendance:  where.Attendance,
+		RsvpStatus:  where.RsvpStatus,
+		Invited:     where.Invited,
+		FieldName:   where.FieldName,
+		FieldValue:  where.FieldValue,
+		Search:      where.Search,
+		Descending:  strings.HasPrefix(filter.Sort, "-"),
+		Limit:       limit,
+	}
+	if after != nil {
+		if err := setAfterKey(&params, after); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	rows, err := s.db.GetFilteredAttendeesByEventID(context.Background(), params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	filteredAttendees := []*types.Attendee{}
+	keys := []string{}
+
+	for _, attendee := range rows {
+		filteredAttendees = append(filteredAttendees, &types.Attendee{
+			ID:                 attendee.ID,
+			FirstName:          attendee.FirstName,
+			LastName:           attendee.LastName,
+			Email:              attendee.Email,
+			EventID:            attendee.EventID,
+			QrCode:             attendee.QrCode.String,
+			CompanyName:        attendee.CompanyName.String,
+			Title:              attendee.Title.String,
+			TableNo:            attendee.TableNo.Int32,
+			Role:               attendee.Role.String,
+			Attendance:         attendee.Attendance == database.AttendeesAttendanceYes,
+			FirstCheckedInAt:   timePtr(attendee.CheckedInAt),
+			SessionsAttended:   attendee.SessionsAttended,
+			TicketTypeID:       attendee.TicketTypeID.Int32,
+			RegistrationStatus: string(attendee.RegistrationStatus),
+			RSVPStatus:         string(attendee.RsvpStatus),
+			RSVPReason:         attendee.RsvpReason.String,
+			RSVPPlusOnes:       attendee.RsvpPlusOnes,
+			RSVPRespondedAt:    timePtr(attendee.RsvpRespondedAt),
+			PrimaryAttendeeID:  attendee.PrimaryAttendeeID.Int32,
+		})
+		keys = append(keys, attendee.SortKey)
+	}
+
+	return filteredAttendees, keys, nil
+}
+
+// sortKeyLayout is the format MySQL turns the checked_in_at sort key into
+const sortKeyLayout = "2006-01-02 15:04:05"
+
+// setAfterKey sets the keyset parameters of a cursor, the key is compared as
+// the type of the column the attendees are sorted by
+func setAfterKey(params *database.GetFilteredAttendeesByEventIDParams, after *types.Cursor) error {
+	params.AfterID = sql.NullInt32{Int32: int32(after.ID), Valid: true}
+
+	switch params.SortColumn {
+	case "table_no":
+		tableNo, err := strconv.ParseInt(after.Key, 10, 32)
+		if err != nil {
+			return types.ErrInvalidCursor
+		}
+		params.AfterTableNo = sql.NullInt32{Int32: int32(tableNo), Valid: true}
+	case "checked_in_at":
+		checkedInAt, err := time.Parse(sortKeyLayout, after.Key)
+		if err != nil {
+			return types.ErrInvalidCursor
+		}
+		params.AfterCheckedInAt = sql.NullTime{Time: checkedInAt, Valid: true}
+	default:
+		params.AfterKey = sql.NullString{String: after.Key, Valid: true}
+	}
+
+	return nil
+}
+
+// CountFilteredAttendees counts the attendees of an event that match a filter
+func (s *Store) CountFilteredAttendees(eventID int32, filter *types.AttendeeFilter) (int64, error) {
+	return s.db.CountFilteredAttendeesByEventID(context.Background(), filterParams(eventID, filter))
+}
+
+// filterParams converts an attendee filter into query parameters, leaving the
+// parameters of empty fields NULL so they match every attendee
+func filterParams(eventID int32, filter *types.AttendeeFilter) database.CountFilteredAttendeesByEventIDParams {
+	params := database.CountFilteredAttendeesByEventIDParams{
+		EventID:     eventID,
+		Role:        sql.NullString{String: filter.Role, Valid: filter.Role != ""},
+		CompanyName: sql.NullString{String: filter.CompanyName, Valid: filter.CompanyName != ""},
+		FieldName:   sql.NullString{String: filter.FieldName, Valid: filter.FieldName != ""},
+		FieldValue:  sql.NullString{String: filter.FieldValue, Valid: filter.FieldValue != ""},
+	}
+	if filter.TableNo != nil {
+		params.TableNo = sql.NullInt32{Int32: *filter.TableNo, Valid: true}
+	}
+	if filter.Attendance != nil {
+		params.Attendance = database.NullAttendeesAttendance{AttendeesAttendance: database.AttendeesAttendanceNo, Valid: true}
+		if *filter.Attendance {
+			params.Attendance.AttendeesAttendance = database.AttendeesAttendanceYes
+		}
+	}
+	if filter.RSVPStatus != "" {
+		params.RsvpStatus = database.NullAttendeesRsvpStatus{AttendeesRsvpStatus: database.AttendeesRsvpStatus(filter.RSVPStatus), Valid: true}
+	}
+	if filter.Invited != nil {
+		params.Invited = sql.NullInt32{Valid: true}
+		if *filter.Invited {
+			params.Invited.Int32 = 1
+		}
+	}
+	if filter.Search != "" {
+		params.Search = sql.NullString{String: "%" + likeEscaper.Replace(filter.Search) + "%", Valid: true}
+	}
+
+	return params
+}
+
+// likeEscaper escapes the wildcards of a LIKE pattern so a search matches them literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
 // GetAttendeeRowCount fetches the total number of attendees from the database
 func (s *Store) GetAttendeeRowCount(eventID int32) (int64, error) {
 	count, err := s.db.GetAttendeesRowCountByEventID(context.Background(), eventID)
@@ -205,3 +587,269 @@ func (s *Store) GetAttendeeRowCount(eventID int32) (int64, error) {
 
 	return count, nil
 }
+
+// GetAttendanceStats fetches the check-in totals of an event grouped by role and table
+func (s *Store) GetAttendanceStats(eventID int32) (*types.AttendanceStats, error) {
+	summary, err := s.db.GetAttendanceSummaryByEventID(context.Background(), eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	byRole, err := s.db.GetAttendanceByRoleByEventID(context.Background(), eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	byTable, err := s.db.GetAttendanceByTableByEventID(context.Background(), eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &types.AttendanceStats{
+		EventID:   eventID,
+		Expected:  summary.Expected,
+		CheckedIn: summary.CheckedIn,
+		ByRole:    []types.AttendanceGroupStats{},
+		ByTable:   []types.AttendanceGroupStats{},
+	}
+
+	stats.RSVP, err = s.GetRSVPCounts(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range byRole {
+		stats.ByRole = append(stats.ByRole, types.AttendanceGroupStats{
+			Role:      row.Role,
+			Expected:  row.Expected,
+			CheckedIn: row.CheckedIn,
+		})
+	}
+
+	for _, row := range byTable {
+		stats.ByTable = append(stats.ByTable, types.AttendanceGroupStats{
+			TableNo:   row.TableNo,
+			Expected:  row.Expected,
+			CheckedIn: row.CheckedIn,
+		})
+	}
+
+	return stats, nil
+}
+
+// GetRSVPCounts counts the attendees of an event by their RSVP response
+func (s *Store) GetRSVPCounts(eventID int32) (*types.RSVPCounts, error) {
+	rows, err := s.db.GetRsvpCountsByEventID(context.Background(), eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := &types.RSVPCounts{}
+	for _, row := range rows {
+		switch string(row.RsvpStatus) {
+		case types.RSVPStatusPending:
+			counts.Pending = row.Attendees
+		case types.RSVPStatusAccepted:
+			counts.Accepted = row.Attendees
+			counts.PlusOnes = row.PlusOnes
+		case types.RSVPStatusDeclined:
+			counts.Declined = row.Attendees
+		case types.RSVPStatusMaybe:
+			counts.Maybe = row.Attendees
+		}
+	}
+
+	return counts, nil
+}
+
+// UpdateAttendeeRSVP records the response of an attendee to their invitation
+func (s *Store) UpdateAttendeeRSVP(ctx context.Context, attendeeID int32, status string, reason string, plusOnes int32) error {
+	return s.db.UpdateAttendeeRsvpByID(ctx, database.UpdateAttendeeRsvpByIDParams{
+		RsvpStatus:   database.AttendeesRsvpStatus(status),
+		RsvpReason:   sql.NullString{String: reason, Valid: reason != ""},
+		RsvpPlusOnes: plusOnes,
+		ID:           attendeeID,
+	})
+}
+
+// timePtr converts a nullable time into a pointer that encodes as null
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
+
+// reserveTicket takes one ticket of a ticket type with a single conditional
+// update, returning ErrTicketTypeSoldOut when its quantity is used up
+func (s *Store) reserveTicket(ctx context.Context, ticketTypeID int32) error {
+	if ticketTypeID == 0 {
+		return nil
+	}
+
+	result, err := s.db.ReserveTicket(ctx, ticketTypeID)
+	if err != nil {
+		return err
+	}
+	reserved, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if reserved == 0 {
+		return types.ErrTicketTypeSoldOut
+	}
+
+	return nil
+}
+
+// releaseTicket gives a ticket of a ticket type back
+func (s *Store) releaseTicket(ctx context.Context, ticketTypeID int32) error {
+	if ticketTypeID == 0 {
+		return nil
+	}
+
+	return s.db.ReleaseTicket(ctx, ticketTypeID)
+}
+
+// GetDeletedAttendees fetches the soft-deleted attendees of an event that can still be restored
+func (s *Store) GetDeletedAttendees(eventID int32) ([]*types.Attendee, error) {
+	attendees, err := s.db.GetDeletedAttendeesByEventID(context.Background(), eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDeletedAttendees(attendees), nil
+}
+
+// GetDeletedAttendeesPaginated fetches the page of the deleted attendees of an event after a cursor,
+// latest deleted first, and the cursor of the next page when there is one
+func (s *Store) GetDeletedAttendeesPaginated(eventID int32, page *types.PageRequest) ([]*types.Attendee, *types.Cursor, error) {
+	params := database.GetDeletedAttendeesPaginatedParams{
+		EventID: eventID,
+		Limit:   page.Limit + 1,
+	}
+	if page.After != nil {
+		deletedAt, err := time.Parse(time.RFC3339Nano, page.After.Key)
+		if err != nil {
+			return nil, nil, types.ErrInvalidCursor
+		}
+		params.AfterID = sql.NullInt32{Int32: int32(page.After.ID), Valid: true}
+		params.AfterDeletedAt = sql.NullTime{Time: deletedAt, Valid: true}
+	}
+
+	attendees, err := s.db.GetDeletedAttendeesPaginated(context.Background(), params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The row past the limit only tells that there is a next page
+	deletedAttendees := toDeletedAttendees(attendees)
+	if len(deletedAttendees) <= int(page.Limit) {
+		return deletedAttendees, nil, nil
+	}
+	deletedAttendees = deletedAttendees[:page.Limit]
+	last := deletedAttendees[len(deletedAttendees)-1]
+
+	return deletedAttendees, &types.Cursor{Key: last.DeletedAt.Format(time.RFC3339Nano), ID: int64(last.ID)}, nil
+}
+
+// CountDeletedAttendees counts the deleted attendees of an event that can still be restored
+func (s *Store) CountDeletedAttendees(eventID int32) (int64, error) {
+	return s.db.CountDeletedAttendeesByEventID(context.Background(), eventID)
+}
+
+func toDeletedAttendees(attendees []database.Attendee) []*types.Attendee {
+	deletedAttendees := []*types.Attendee{}
+	for _, attendee := range attendees {
+		deletedAttendees = append(deletedAttendees, &types.Attendee{
+			ID:                 attendee.ID,
+			FirstName:          attendee.FirstName,
+			LastName:           attendee.LastName,
+			Email:              attendee.Email,
+			EventID:            attendee.EventID,
+			QrCode:             attendee.QrCode.String,
+			CompanyName:        attendee.CompanyName.String,
+			Title:              attendee.Title.String,
+			TableNo:            attendee.TableNo.Int32,
+			Role:               attendee.Role.String,
+			Attendance:         attendee.Attendance == database.AttendeesAttendanceYes,
+			FirstCheckedInAt:   timePtr(attendee.CheckedInAt),
+			SessionsAttended:   attendee.SessionsAttended,
+			TicketTypeID:       attendee.TicketTypeID.Int32,
+			RegistrationStatus: string(attendee.RegistrationStatus),
+			RSVPStatus:         string(attendee.RsvpStatus),
+			RSVPReason:         attendee.RsvpReason.String,
+			RSVPPlusOnes:       attendee.RsvpPlusOnes,
+			RSVPRespondedAt:    timePtr(attendee.RsvpRespondedAt),
+			PrimaryAttendeeID:  attendee.PrimaryAttendeeID.Int32,
+			DeletedAt:          timePtr(attendee.DeletedAt),
+		})
+	}
+
+	return deletedAttendees
+}
+
+// GetDeletedAttendeeByID fetches a soft-deleted attendee by ID
+func (s *Store) GetDeletedAttendeeByID(attendeeID int32) (*types.Attendee, error) {
+	attendee, err := s.db.GetDeletedAttendeeByID(context.Background(), attendeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Attendee{
+		ID:                 attendee.ID,
+		FirstName:          attendee.FirstName,
+		LastName:           attendee.LastName,
+		Email:              attendee.Email,
+		EventID:            attendee.EventID,
+		QrCode:             attendee.QrCode.String,
+		CompanyName:        attendee.CompanyName.String,
+		Title:              attendee.Title.String,
+		TableNo:            attendee.TableNo.Int32,
+		Role:               attendee.Role.String,
+		Attendance:         attendee.Attendance == database.AttendeesAttendanceYes,
+		FirstCheckedInAt:   timePtr(attendee.CheckedInAt),
+		SessionsAttended:   attendee.SessionsAttended,
+		TicketTypeID:       attendee.TicketTypeID.Int32,
+		RegistrationStatus: string(attendee.RegistrationStatus),
+		RSVPStatus:         string(attendee.RsvpStatus),
+		RSVPReason:         attendee.RsvpReason.String,
+		RSVPPlusOnes:       attendee.RsvpPlusOnes,
+		RSVPRespondedAt:    timePtr(attendee.RsvpRespondedAt),
+		PrimaryAttendeeID:  attendee.PrimaryAttendeeID.Int32,
+		DeletedAt:          timePtr(attendee.DeletedAt),
+	}, nil
+}
+
+// RestoreAttendeeByID brings a soft-deleted attendee back. The attendee takes
+// its ticket again, so a restore fails with ErrTicketTypeSoldOut when the
+// ticket type has been sold out in the meantime.
+func (s *Store) RestoreAttendeeByID(ctx context.Context, attendeeID int32) error {
+	attendee, err := s.db.GetDeletedAttendeeByID(ctx, attendeeID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.reserveTicket(ctx, attendee.TicketTypeID.Int32); err != nil {
+		return err
+	}
+
+	err = s.db.RestoreAttendeeByID(ctx, attendeeID)
+	if err != nil {
+		return errors.Join(err, s.releaseTicket(ctx, attendee.TicketTypeID.Int32))
+	}
+
+	// Check-in devices pick the attendee up again as an update on their next sync
+	err = s.db.DeleteDeletedAttendee(ctx, attendeeID)
+	if err != nil {
+		return err
+	}
+
+	err = s.db.RefreshSessionSignupCountsByEventID(ctx, attendee.EventID)
+	if err != nil {
+		return err
+	}
+
+	return s.db.RefreshTicketSoldCountsByEventID(ctx, attendee.EventID)
+}
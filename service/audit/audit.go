@@ -0,0 +1,210 @@
+package audit
+
+import (
+	"encoding/json"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+// mutationsKey holds the mutations noted by a handler until the request succeeds
+const mutationsKey = "auditMutations"
+
+// maxUserAgent is the size of the user_agent column in characters
+const maxUserAgent = 255
+
+// redacted are fields never copied into the audit log
+var redacted = map[string]bool{"password": true, "updated_at": true}
+
+// Mutation describes a change made by a handler. Before and After are the
+// target as it was and as it is; either is nil when it was created or deleted.
+type Mutation struct {
+	Action       string
+	TargetType   string
+	TargetID     int32
+	EventID      int32
+	EventOwnerID int32
+	Before       any
+	After        any
+	Details      map[string]any
+}
+
+// Change is the old and new value of a field
+type Change struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// Note tells the middleware what a handler changed, to be recorded once the request succeeds
+func Note(c *fiber.Ctx, mutation Mutation) {
+	mutations, _ := c.Locals(mutationsKey).([]Mutation)
+	c.Locals(mutationsKey, append(mutations, mutation))
+}
+
+// Middleware records every successful POST, PUT, PATCH and DELETE request in
+// the audit log. Requests whose handler noted its mutations are recorded with
+// them; any other request is recorded with the target taken from its route.
+// prefix is the path of the group the middleware is used in.
+func Middleware(store types.AuditStore, eventStore types.EventStore, prefix string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		switch c.Method() {
+		case fiber.MethodPost, fiber.MethodPut, fiber.MethodPatch, fiber.MethodDelete:
+		default:
+			return c.Next()
+		}
+
+		if err := c.Next(); err != nil || c.Response().StatusCode() >= fiber.StatusBadRequest {
+			return err
+		}
+
+		mutations, _ := c.Locals(mutationsKey).([]Mutation)
+		if len(mutations) == 0 {
+			mutations = []Mutation{FromRoute(c.Method(), strings.TrimPrefix(c.Route().Path, prefix), c.AllParams())}
+		}
+
+		for _, mutation := range mutations {
+			record(c, store, eventStore, mutation)
+		}
+
+		return nil
+	}
+}
+
+// FromRoute describes a request that did not note its mutations. The action is
+// the method and route, the target is named after the last route parameter and
+// the event is the :event_id parameter, or :id under /event.
+func FromRoute(method string, route string, params map[string]string) Mutation {
+	mutation := Mutation{Action: method + " " + route}
+
+	segments := strings.Split(strings.Trim(route, "/"), "/")
+	for i, segment := range segments {
+		name, ok := strings.CutPrefix(segment, ":")
+		if !ok {
+			continue
+		}
+
+		if name == "id" && i > 0 {
+			mutation.TargetType = strings.TrimSuffix(segments[i-1], "s")
+		} else if cut := strings.LastIndex(name, "_"); cut > 0 {
+			mutation.TargetType = name[:cut]
+		} else {
+			mutation.TargetType = name
+		}
+
+		id, _ := strconv.Atoi(params[name])
+		mutation.TargetID = int32(id)
+
+		if name == "event_id" || (name == "id" && i == 1 && segments[0] == "event") {
+			mutation.EventID = int32(id)
+		}
+	}
+
+	if mutation.TargetType == "" {
+		mutation.TargetType = strings.TrimSuffix(segments[0], "s")
+	}
+
+	return mutation
+}
+
+// Changes returns the fields that differ between the JSON encodings of before and after
+func Changes(before any, after any) map[string]Change {
+	from, to := fields(before), fields(after)
+
+	changes := map[string]Change{}
+	for key, value := range to {
+		if !reflect.DeepEqual(from[key], value) {
+			changes[key] = Change{From: from[key], To: value}
+		}
+	}
+	for key, value := range from {
+		if _, ok := to[key]; !ok {
+			changes[key] = Change{From: value}
+		}
+	}
+
+	return changes
+}
+
+// fields decodes the JSON encoding of v into its fields, leaving out redacted ones
+func fields(v any) map[string]any {
+	fields := map[string]any{}
+	if v == nil {
+		return fields
+	}
+
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fields
+	}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return map[string]any{}
+	}
+	for key := range redacted {
+		delete(fields, key)
+	}
+
+	return fields
+}
+
+func record(c *fiber.Ctx, store types.AuditStore, eventStore types.EventStore, mutation Mutation) {
+	entry := &types.AuditEntry{
+		ActorID:        auth.GetUserIDFromContext(c),
+		ImpersonatorID: auth.GetImpersonatorIDFromContext(c),
+		Action:         mutation.Action,
+		TargetType:     mutation.TargetType,
+		TargetID:       mutation.TargetID,
+		EventID:        mutation.EventID,
+		EventOwnerID:   mutation.EventOwnerID,
+		IP:             c.IP(),
+		UserAgent:      truncate(c.Get(fiber.HeaderUserAgent), maxUserAgent),
+	}
+
+	// Keep the owner with the entry so that it stays visible to them after the event is deleted
+	if entry.EventID != 0 && entry.EventOwnerID == 0 {
+		if event, err := eventStore.GetEventByID(entry.EventID); err == nil {
+			entry.EventOwnerID = event.UserID
+		}
+	}
+
+	details := map[string]any{}
+	for key, value := range mutation.Details {
+		details[key] = value
+	}
+	switch {
+	case mutation.Before != nil && mutation.After != nil:
+		details["changes"] = Changes(mutation.Before, mutation.After)
+	case mutation.Before != nil:
+		details["before"] = fields(mutation.Before)
+	case mutation.After != nil:
+		details["after"] = fields(mutation.After)
+	}
+
+	if len(details) > 0 {
+		raw, err := json.Marshal(details)
+		if err != nil {
+			log.Printf("Error encoding audit details of %s: %v", entry.Action, err)
+		} else {
+			entry.Details = raw
+		}
+	}
+
+	if err := store.CreateAuditEntry(c.Context(), entry); err != nil {
+		log.Printf("Error recording %s of %s %d by user ID %d: %v", entry.Action, entry.TargetType, entry.TargetID, entry.ActorID, err)
+	}
+}
+
+// truncate cuts value to at most size characters, never in the middle of one
+func truncate(value string, size int) string {
+	runes := []rune(value)
+	if len(runes) <= size {
+		return value
+	}
+
+	return string(runes[:size])
+}
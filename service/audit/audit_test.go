@@ -0,0 +1,190 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+type memoryStore struct {
+	entries []*types.AuditEntry
+}
+
+func (s *memoryStore) CreateAuditEntry(ctx context.Context, entry *types.AuditEntry) error {
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *memoryStore) GetAuditEntries(filter types.AuditFilter, page *types.PageRequest) ([]*types.AuditEntry, *types.Cursor, error) {
+	return s.entries, nil, nil
+}
+
+func (s *memoryStore) CountAuditEntries(filter types.AuditFilter) (int64, error) {
+	return int64(len(s.entries)), nil
+}
+
+// eventStore only knows the owner of event 7
+type eventStore struct {
+	types.EventStore
+}
+
+func (eventStore) GetEventByID(eventID int32) (*types.Event, error) {
+	if eventID != 7 {
+		return nil, fiber.ErrNotFound
+	}
+	return &types.Event{EventID: 7, UserID: 3}, nil
+}
+
+func newTestApp(store *memoryStore) *fiber.App {
+	app := fiber.New()
+	v1 := app.Group("/api/v1")
+	v1.Use(Middleware(store, eventStore{}, "/api/v1"))
+
+	v1.Delete("/event/:event_id/attendees", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+	v1.Put("/event/update/:id", func(c *fiber.Ctx) error {
+		Note(c, Mutation{
+			Action:     "event.update",
+			TargetType: "event",
+			TargetID:   7,
+			EventID:    7,
+			Before:     &types.Event{EventID: 7, Title: "Launch", Location: "Hall A"},
+			After:      &types.Event{EventID: 7, Title: "Launch", Location: "Hall B"},
+		})
+		return c.SendStatus(fiber.StatusOK)
+	})
+	v1.Post("/event/create", func(c *fiber.Ctx) error {
+		Note(c, Mutation{Action: "event.create", TargetType: "event"})
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid payload"})
+	})
+	v1.Get("/event/:id", func(c *fiber.Ctx) error {
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	return app
+}
+
+func TestMiddlewareRecordsRoutesWithoutNotes(t *testing.T) {
+	store := &memoryStore{}
+	app := newTestApp(store)
+
+	req := httptest.NewRequest(fiber.MethodDelete, "/api/v1/event/7/attendees", nil)
+	req.Header.Set(fiber.HeaderUserAgent, "test-agent")
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(store.entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(store.entries))
+	}
+	entry := store.entries[0]
+	if entry.Action != "DELETE /event/:event_id/attendees" {
+		t.Errorf("unexpected action %q", entry.Action)
+	}
+	if entry.TargetType != "event" || entry.TargetID != 7 || entry.EventID != 7 || entry.EventOwnerID != 3 {
+		t.Errorf("unexpected target %+v", entry)
+	}
+	if entry.UserAgent != "test-agent" {
+		t.Errorf("expected the user agent to be recorded, got %q", entry.UserAgent)
+	}
+}
+
+func TestMiddlewareTruncatesUserAgentByCharacter(t *testing.T) {
+	store := &memoryStore{}
+	app := newTestApp(store)
+
+	req := httptest.NewRequest(fiber.MethodDelete, "/api/v1/event/7/attendees", nil)
+	req.Header.Set(fiber.HeaderUserAgent, strings.Repeat("é", maxUserAgent+10))
+	if _, err := app.Test(req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	userAgent := store.entries[0].UserAgent
+	if !utf8.ValidString(userAgent) || utf8.RuneCountInString(userAgent) != maxUserAgent {
+		t.Errorf("expected %d whole characters, got %q", maxUserAgent, userAgent)
+	}
+}
+
+func TestMiddlewareRecordsNotedChanges(t *testing.T) {
+	store := &memoryStore{}
+	app := newTestApp(store)
+
+	if _, err := app.Test(httptest.NewRequest(fiber.MethodPut, "/api/v1/event/update/7", nil)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(store.entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(store.entries))
+	}
+
+	var details struct {
+		Changes map[string]Change `json:"changes"`
+	}
+	if err := json.Unmarshal(store.entries[0].Details, &details); err != nil {
+		t.Fatalf("unexpected details %s: %v", store.entries[0].Details, err)
+	}
+	if len(details.Changes) != 1 || details.Changes["location"].From != "Hall A" || details.Changes["location"].To != "Hall B" {
+		t.Errorf("expected only the location to change, got %+v", details.Changes)
+	}
+}
+
+func TestMiddlewareSkipsFailedAndReadOnlyRequests(t *testing.T) {
+	store := &memoryStore{}
+	app := newTestApp(store)
+
+	for _, req := range []struct{ method, path string }{
+		{fiber.MethodPost, "/api/v1/event/create"},
+		{fiber.MethodGet, "/api/v1/event/7"},
+	} {
+		if _, err := app.Test(httptest.NewRequest(req.method, req.path, nil)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if len(store.entries) != 0 {
+		t.Errorf("expected no entries, got %d", len(store.entries))
+	}
+}
+
+func TestFromRoute(t *testing.T) {
+	tests := []struct {
+		route      string
+		params     map[string]string
+		targetType string
+		targetID   int32
+		eventID    int32
+	}{
+		{"/event/:id/sessions/:session_id", map[string]string{"id": "4", "session_id": "9"}, "session", 9, 4},
+		{"/event/:event_id/attendees/:attendee_id", map[string]string{"event_id": "4", "attendee_id": "12"}, "attendee", 12, 4},
+		{"/check_ins/:check_in_id", map[string]string{"check_in_id": "5"}, "check_in", 5, 0},
+		{"/plans/:id", map[string]string{"id": "2"}, "plan", 2, 0},
+		{"/attendees/mark_attendance/:attendee_email", map[string]string{"attendee_email": "a@b.c"}, "attendee", 0, 0},
+		{"/events/delete-all", nil, "event", 0, 0},
+	}
+
+	for _, test := range tests {
+		mutation := FromRoute(fiber.MethodPost, test.route, test.params)
+		if mutation.TargetType != test.targetType || mutation.TargetID != test.targetID || mutation.EventID != test.eventID {
+			t.Errorf("%s: expected %s %d of event %d, got %s %d of event %d", test.route, test.targetType, test.targetID, test.eventID, mutation.TargetType, mutation.TargetID, mutation.EventID)
+		}
+	}
+}
+
+func TestChangesLeavesOutPasswords(t *testing.T) {
+	changes := Changes(&types.User{Email: "a@example.com", Password: "old"}, &types.User{Email: "b@example.com", Password: "new"})
+
+	if _, ok := changes["password"]; ok {
+		t.Error("expected the password to be left out")
+	}
+	if changes["email"].To != "b@example.com" {
+		t.Errorf("expected the email change, got %+v", changes)
+	}
+}
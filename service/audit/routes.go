@@ -0,0 +1,93 @@
+package audit
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+type Handler struct {
+	store     types.AuditStore
+	userStore types.UserStore
+}
+
+func NewHandler(store types.AuditStore, userStore types.UserStore) *Handler {
+	return &Handler{store: store, userStore: userStore}
+}
+
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/audit", auth.WithJWTAuth(h.handleGetAuditEntries, h.userStore))
+}
+
+// Handler to search the audit log. Super users see every entry, anyone else
+// only the entries about their own events.
+func (h *Handler) handleGetAuditEntries(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	page, ferr := utils.ParsePage(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	filter := types.AuditFilter{
+		Action:     c.Query("action"),
+		TargetType: c.Query("target_type"),
+	}
+
+	for key, field := range map[string]*int32{
+		"actor_id":  &filter.ActorID,
+		"target_id": &filter.TargetID,
+		"event_id":  &filter.EventID,
+	} {
+		str := c.Query(key)
+		if str == "" {
+			continue
+		}
+		value, err := strconv.Atoi(str)
+		if err != nil || value <= 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid " + key})
+		}
+		*field = int32(value)
+	}
+
+	for key, field := range map[string]**time.Time{"from": &filter.From, "to": &filter.To} {
+		str := c.Query(key)
+		if str == "" {
+			continue
+		}
+		value, err := time.Parse(time.RFC3339, str)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid " + key + ", expected RFC 3339"})
+		}
+		*field = &value
+	}
+
+	superUser, err := utils.IsSuperUser(userID, h.userStore)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get user role"})
+	}
+	if !superUser {
+		filter.EventOwnerID = userID
+	}
+
+	entries, next, err := h.store.GetAuditEntries(filter, page)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get audit log"})
+	}
+
+	var total *int64
+	if page.WithTotal {
+		count, err := h.store.CountAuditEntries(filter)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to count audit log"})
+		}
+		total = &count
+	}
+
+	return c.Status(fiber.StatusOK).JSON(utils.NewPage(entries, next, total, utils.AppliedFilters(filter)))
+}
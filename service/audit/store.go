@@ -0,0 +1,111 @@
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/cmd/pkg/database"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// CreateAuditEntry appends an entry to the audit log
+func (s *Store) CreateAuditEntry(ctx context.Context, entry *types.AuditEntry) error {
+	return s.db.CreateAuditEntry(ctx, database.CreateAuditEntryParams{
+		ActorID:        nullInt32(entry.ActorID),
+		ImpersonatorID: nullInt32(entry.ImpersonatorID),
+		Action:         entry.Action,
+		TargetType:     entry.TargetType,
+		TargetID:       nullInt32(entry.TargetID),
+		EventID:        nullInt32(entry.EventID),
+		EventOwnerID:   nullInt32(entry.EventOwnerID),
+		Ip:             entry.IP,
+		UserAgent:      entry.UserAgent,
+		Details:        entry.Details,
+	})
+}
+
+// GetAuditEntries fetches the page of the entries matching the filter after a cursor, newest first,
+// and the cursor of the next page when there is one
+func (s *Store) GetAuditEntries(filter types.AuditFilter, page *types.PageRequest) ([]*types.AuditEntry, *types.Cursor, error) {
+	params := database.GetAuditEntriesParams{
+		ActorID:       nullInt32(filter.ActorID),
+		ActionPattern: sql.NullString{String: filter.Action + "%", Valid: filter.Action != ""},
+		TargetType:    sql.NullString{String: filter.TargetType, Valid: filter.TargetType != ""},
+		TargetID:      nullInt32(filter.TargetID),
+		EventID:       nullInt32(filter.EventID),
+		EventOwnerID:  nullInt32(filter.EventOwnerID),
+		FromTime:      nullTime(filter.From),
+		ToTime:        nullTime(filter.To),
+		Limit:         page.Limit + 1,
+	}
+	if page.After != nil {
+		params.AfterID = sql.NullInt64{Int64: page.After.ID, Valid: true}
+	}
+
+	rows, err := s.db.GetAuditEntries(context.Background(), params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries := []*types.AuditEntry{}
+	for _, row := range rows {
+		entries = append(entries, &types.AuditEntry{
+			ID:             row.ID,
+			ActorID:        row.ActorID.Int32,
+			ImpersonatorID: row.ImpersonatorID.Int32,
+			Action:         row.Action,
+			TargetType:     row.TargetType,
+			TargetID:       row.TargetID.Int32,
+			EventID:        row.EventID.Int32,
+			EventOwnerID:   row.EventOwnerID.Int32,
+			IP:             row.Ip,
+			UserAgent:      row.UserAgent,
+			Details:        row.Details,
+			CreatedAt:      row.CreatedAt,
+		})
+	}
+
+	// The row past the limit only tells that there is a next page
+	if len(entries) <= int(page.Limit) {
+		return entries, nil, nil
+	}
+	entries = entries[:page.Limit]
+
+	return entries, &types.Cursor{ID: entries[len(entries)-1].ID}, nil
+}
+
+// CountAuditEntries counts the entries matching the filter
+func (s *Store) CountAuditEntries(filter types.AuditFilter) (int64, error) {
+	return s.db.CountAuditEntries(context.Background(), database.CountAuditEntriesParams{
+		ActorID:       nullInt32(filter.ActorID),
+		ActionPattern: sql.NullString{String: filter.Action + "%", Valid: filter.Action != ""},
+		TargetType:    sql.NullString{String: filter.TargetType, Valid: filter.TargetType != ""},
+		TargetID:      nullInt32(filter.TargetID),
+		EventID:       nullInt32(filter.EventID),
+		EventOwnerID:  nullInt32(filter.EventOwnerID),
+		FromTime:      nullTime(filter.From),
+		ToTime:        nullTime(filter.To),
+	})
+}
+
+func nullInt32(i int32) sql.NullInt32 {
+	return sql.NullInt32{Int32: i, Valid: i != 0}
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: *t, Valid: true}
+}
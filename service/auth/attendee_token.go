@@ -0,0 +1,112 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v4"
+
+	"github.com/jayden1905/event-registration-software/config"
+)
+
+// AttendeeKey is where the attendee of a link token is kept in the request
+const AttendeeKey contextKey = "attendeeID"
+
+// Scopes of the tokens put in the links sent to attendees. A token only works
+// for the scope it was signed for.
+const (
+	ScopePortal = "attendee_portal"
+	ScopeRSVP   = "attendee_rsvp"
+)
+
+// attendeeTokenSecret derives the signing key of a scope from the JWT secret.
+// Link tokens never share a key with organizer tokens, so neither passes for the other.
+func attendeeTokenSecret(scope string) []byte {
+	mac := hmac.New(sha256.New, []byte(config.Envs.JWTSecret))
+	mac.Write([]byte("attendee:" + scope))
+	return mac.Sum(nil)
+}
+
+// CreateAttendeeToken generates a token letting whoever holds a link act for one attendee within a scope
+func CreateAttendeeToken(scope string, attendeeID int32, expiresAt time.Time) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"scope":      scope,
+		"attendeeID": strconv.Itoa(int(attendeeID)),
+		"exp":        expiresAt.Unix(),
+	})
+
+	return token.SignedString(attendeeTokenSecret(scope))
+}
+
+// ValidateAttendeeToken returns the attendee a token was signed for, when it is valid for the scope
+func ValidateAttendeeToken(scope string, tokenString string) (int32, error) {
+	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+		return attendeeTokenSecret(scope), nil
+	})
+	if err != nil {
+		if ve, ok := err.(*jwt.ValidationError); ok && ve.Errors&jwt.ValidationErrorExpired != 0 {
+			return 0, fmt.Errorf("link has expired")
+		}
+		return 0, fmt.Errorf("link is invalid")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || claims["scope"] != scope {
+		return 0, fmt.Errorf("link is invalid")
+	}
+
+	// The expiry is required, a link must not work forever
+	if _, ok := claims["exp"]; !ok {
+		return 0, fmt.Errorf("link is invalid")
+	}
+
+	str, _ := claims["attendeeID"].(string)
+	attendeeID, err := strconv.ParseUint(str, 10, 32)
+	if err != nil || attendeeID == 0 {
+		return 0, fmt.Errorf("link is invalid")
+	}
+
+	return int32(attendeeID), nil
+}
+
+// WithAttendeeToken is a middleware for Fiber that validates the link token of
+// an attendee for a scope, from the token query parameter or the Authorization header
+func WithAttendeeToken(scope string, handlerFunc fiber.Handler) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		tokenString := c.Query("token")
+		if tokenString == "" {
+			tokenString = c.Get("Authorization")
+		}
+
+		if tokenString == "" {
+			return permissionDenied(c)
+		}
+
+		attendeeID, err := ValidateAttendeeToken(scope, tokenString)
+		if err != nil {
+			return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+				"error": err.Error(),
+			})
+		}
+
+		c.Locals(AttendeeKey, attendeeID)
+
+		return handlerFunc(c)
+	}
+}
+
+// GetAttendeeIDFromContext extracts the attendee of a link token from Fiber's context
+func GetAttendeeIDFromContext(c *fiber.Ctx) int32 {
+	attendeeID, ok := c.Locals(AttendeeKey).(int32)
+	if !ok {
+		return 0
+	}
+	return attendeeID
+}
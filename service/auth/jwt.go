@@ -14,11 +14,18 @@ import (
 
 	"github.com/jayden1905/event-registration-software/config"
 	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
 )
 
 type contextKey string
 
-const UserKey contextKey = "userID"
+const (
+	UserKey         contextKey = "userID"
+	ImpersonatorKey contextKey = "impersonatorID"
+)
+
+// ImpersonationExpiration is how long a super user may act as another user with one token
+const ImpersonationExpiration = time.Hour
 
 // CreateJWT generates a new JWT token with the given secret and userID.
 func CreateJWT(secret []byte, userID int) (string, error) {
@@ -37,6 +44,18 @@ func CreateJWT(secret []byte, userID int) (string, error) {
 	return tokenString, nil
 }
 
+// CreateImpersonationJWT generates a short-lived JWT token letting a super user act as userID.
+// The impersonator is kept in the token so that everything done with it can be traced back.
+func CreateImpersonationJWT(secret []byte, userID int, impersonatorID int) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"userID":         strconv.Itoa(userID),
+		"impersonatorID": strconv.Itoa(impersonatorID),
+		"exp":            time.Now().Add(ImpersonationExpiration).Unix(),
+	})
+
+	return token.SignedString(secret)
+}
+
 // CreateVerificationToken generates a new JWT token with the given secret and userID.
 func GenerateVerificationToken(email string) (string, error) {
 	claims := jwt.MapClaims{
@@ -88,6 +107,26 @@ func WithJWTAuth(handlerFunc fiber.Handler, store types.UserStore) fiber.Handler
 			return permissionDenied(c)
 		}
 
+		// Disabled and locked accounts lose access straight away, not when their token expires
+		if reason := AccountBlockedReason(u, time.Now()); reason != "" {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{
+				"error": reason,
+			})
+		}
+
+		// An impersonation token is only good while its impersonator is still a super user
+		if str, ok := claims["impersonatorID"].(string); ok {
+			impersonatorID, err := strconv.ParseUint(str, 10, 32)
+			if err != nil {
+				return permissionDenied(c)
+			}
+			superUser, err := utils.IsSuperUser(int32(impersonatorID), store)
+			if err != nil || !superUser {
+				return permissionDenied(c)
+			}
+			c.Locals(ImpersonatorKey, int32(impersonatorID))
+		}
+
 		// Set userID in context (using Fiber's Locals)
 		c.Locals(UserKey, u.ID)
 
@@ -96,6 +135,33 @@ func WithJWTAuth(handlerFunc fiber.Handler, store types.UserStore) fiber.Handler
 	}
 }
 
+// WithSuperUser is a middleware for Fiber that validates the JWT token and only lets super users through.
+func WithSuperUser(handlerFunc fiber.Handler, store types.UserStore) fiber.Handler {
+	return WithJWTAuth(func(c *fiber.Ctx) error {
+		superUser, err := utils.IsSuperUser(GetUserIDFromContext(c), store)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting user role by id: %v", err)})
+		}
+		if !superUser {
+			return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Access denied"})
+		}
+
+		return handlerFunc(c)
+	}, store)
+}
+
+// AccountBlockedReason explains why a user may not sign in at now, or returns "" when they may
+func AccountBlockedReason(u *types.User, now time.Time) string {
+	if u.Disabled {
+		return "Your account has been disabled"
+	}
+	if u.LockedUntil != nil && u.LockedUntil.After(now) {
+		return fmt.Sprintf("Your account is locked until %s", u.LockedUntil.UTC().Format(time.RFC3339))
+	}
+
+	return ""
+}
+
 // BlockIfAuthenticated is a middleware for Fiber that blocks the request if the user is authenticated.
 func BlockIfAuthenticated(handlerFunc fiber.Handler) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -194,6 +260,15 @@ func permissionDenied(c *fiber.Ctx) error {
 	return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Permission denied"})
 }
 
+// GetImpersonatorIDFromContext extracts the super user impersonating the current user, or 0 when nobody is
+func GetImpersonatorIDFromContext(c *fiber.Ctx) int32 {
+	impersonatorID, ok := c.Locals(ImpersonatorKey).(int32)
+	if !ok {
+		return 0
+	}
+	return impersonatorID
+}
+
 // GetUserIDFromContext extracts the userID from Fiber's context
 func GetUserIDFromContext(c *fiber.Ctx) int32 {
 	userID, ok := c.Locals(UserKey).(int32)
@@ -2,6 +2,10 @@ package auth
 
 import (
 	"testing"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/config"
+	"github.com/jayden1905/event-registration-software/types"
 )
 
 func TestCreateJWT(t *testing.T) {
@@ -16,3 +20,62 @@ func TestCreateJWT(t *testing.T) {
 		t.Error("expected token to be not empty")
 	}
 }
+
+func TestAccountBlockedReason(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Minute)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		user    *types.User
+		blocked bool
+	}{
+		{"active", &types.User{}, false},
+		{"disabled", &types.User{Disabled: true}, true},
+		{"locked", &types.User{LockedUntil: &future}, true},
+		{"lock expired", &types.User{LockedUntil: &past}, false},
+	}
+
+	for _, test := range tests {
+		if reason := AccountBlockedReason(test.user, now); (reason != "") != test.blocked {
+			t.Errorf("%s: expected blocked %v, got %q", test.name, test.blocked, reason)
+		}
+	}
+}
+
+func TestAttendeeToken(t *testing.T) {
+	token, err := CreateAttendeeToken(ScopePortal, 42, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("error creating attendee token: %v", err)
+	}
+
+	attendeeID, err := ValidateAttendeeToken(ScopePortal, token)
+	if err != nil || attendeeID != 42 {
+		t.Errorf("expected attendee 42, got %d (%v)", attendeeID, err)
+	}
+
+	if _, err := ValidateAttendeeToken("other_scope", token); err == nil {
+		t.Error("expected a token of another scope to be rejected")
+	}
+
+	if _, err := ValidateToken(token); err == nil {
+		t.Error("expected an attendee token to be rejected as an organizer token")
+	}
+
+	userToken, err := CreateJWT([]byte(config.Envs.JWTSecret), 42)
+	if err != nil {
+		t.Fatalf("error creating JWT: %v", err)
+	}
+	if _, err := ValidateAttendeeToken(ScopePortal, userToken); err == nil {
+		t.Error("expected an organizer token to be rejected as an attendee token")
+	}
+
+	expired, err := CreateAttendeeToken(ScopePortal, 42, time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("error creating attendee token: %v", err)
+	}
+	if _, err := ValidateAttendeeToken(ScopePortal, expired); err == nil {
+		t.Error("expected an expired token to be rejected")
+	}
+}
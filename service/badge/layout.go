@@ -0,0 +1,128 @@
+package badge
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Layout describes a sheet of equally sized badges, in millimetres
+type Layout struct {
+	Name       string  `json:"name"`
+	PageSize   string  `json:"page_size"`
+	Columns    int     `json:"columns"`
+	Rows       int     `json:"rows"`
+	Width      float64 `json:"width"`
+	Height     float64 `json:"height"`
+	MarginTop  float64 `json:"margin_top"`
+	MarginLeft float64 `json:"margin_left"`
+	GapX       float64 `json:"gap_x"`
+	GapY       float64 `json:"gap_y"`
+}
+
+// Layouts are the built-in sheet presets, modelled on common Avery name badge stock
+var Layouts = map[string]Layout{
+	// Avery 5392: 4" x 3" badges, 6 per US Letter sheet
+	"avery-5392": {Name: "avery-5392", PageSize: "Letter", Columns: 2, Rows: 3, Width: 101.6, Height: 76.2, MarginTop: 25.4, MarginLeft: 6.35, GapX: 0, GapY: 0},
+	// Avery 5395: 3 3/8" x 2 1/3" badges, 8 per US Letter sheet
+	"avery-5395": {Name: "avery-5395", PageSize: "Letter", Columns: 2, Rows: 4, Width: 85.7, Height: 59.3, MarginTop: 15.9, MarginLeft: 17.5, GapX: 9.5, GapY: 4.8},
+	// Avery L4785: 80 x 50 mm badges, 10 per A4 sheet
+	"avery-l4785": {Name: "avery-l4785", PageSize: "A4", Columns: 2, Rows: 5, Width: 80, Height: 50, MarginTop: 23.5, MarginLeft: 17, GapX: 16, GapY: 0},
+	// A6 badges, 4 per A4 sheet, for plain paper and lanyard pouches
+	"a6": {Name: "a6", PageSize: "A4", Columns: 2, Rows: 2, Width: 105, Height: 148.5, MarginTop: 0, MarginLeft: 0, GapX: 0, GapY: 0},
+}
+
+// DefaultLayout is used when no layout is requested
+const DefaultLayout = "avery-5392"
+
+var pageSizes = map[string][2]float64{
+	"A4":     {210, 297},
+	"Letter": {215.9, 279.4},
+}
+
+// PerPage returns how many badges fit on one sheet
+func (l Layout) PerPage() int {
+	return l.Columns * l.Rows
+}
+
+// Position returns the top left corner of the badge at the given index on its sheet
+func (l Layout) Position(index int) (float64, float64) {
+	slot := index % l.PerPage()
+	col, row := slot%l.Columns, slot/l.Columns
+
+	return l.MarginLeft + float64(col)*(l.Width+l.GapX), l.MarginTop + float64(row)*(l.Height+l.GapY)
+}
+
+// Validate checks that the badges fit on the page
+func (l Layout) Validate() error {
+	page, ok := pageSizes[l.PageSize]
+	if !ok {
+		return fmt.Errorf("unsupported page size %q", l.PageSize)
+	}
+	if l.Columns < 1 || l.Rows < 1 {
+		return fmt.Errorf("columns and rows must be at least 1")
+	}
+	if l.Width < 40 || l.Height < 30 {
+		return fmt.Errorf("badges must be at least 40 x 30 mm")
+	}
+
+	right := l.MarginLeft + float64(l.Columns)*l.Width + float64(l.Columns-1)*l.GapX
+	bottom := l.MarginTop + float64(l.Rows)*l.Height + float64(l.Rows-1)*l.GapY
+	if right > page[0]+0.5 || bottom > page[1]+0.5 {
+		return fmt.Errorf("badges do not fit on a %s page", l.PageSize)
+	}
+
+	return nil
+}
+
+// ParseLayout resolves a preset by name and applies any overrides given as
+// query parameters (page_size, columns, rows, width, height, margin_top,
+// margin_left, gap_x, gap_y)
+func ParseLayout(name string, query func(key string) string) (Layout, error) {
+	if name == "" {
+		name = DefaultLayout
+	}
+
+	layout, ok := Layouts[name]
+	if !ok {
+		return Layout{}, fmt.Errorf("unknown layout %q", name)
+	}
+
+	if pageSize := query("page_size"); pageSize != "" {
+		layout.PageSize = pageSize
+	}
+
+	ints := map[string]*int{"columns": &layout.Columns, "rows": &layout.Rows}
+	for key, target := range ints {
+		if value := query(key); value != "" {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return Layout{}, fmt.Errorf("invalid %s", key)
+			}
+			*target = n
+		}
+	}
+
+	floats := map[string]*float64{
+		"width":       &layout.Width,
+		"height":      &layout.Height,
+		"margin_top":  &layout.MarginTop,
+		"margin_left": &layout.MarginLeft,
+		"gap_x":       &layout.GapX,
+		"gap_y":       &layout.GapY,
+	}
+	for key, target := range floats {
+		if value := query(key); value != "" {
+			f, err := strconv.ParseFloat(value, 64)
+			if err != nil || f < 0 {
+				return Layout{}, fmt.Errorf("invalid %s", key)
+			}
+			*target = f
+		}
+	}
+
+	if err := layout.Validate(); err != nil {
+		return Layout{}, err
+	}
+
+	return layout, nil
+}
@@ -0,0 +1,39 @@
+package badge
+
+import (
+	"testing"
+)
+
+func TestPresetLayoutsFitTheirPage(t *testing.T) {
+	for name, layout := range Layouts {
+		if err := layout.Validate(); err != nil {
+			t.Errorf("layout %s: %v", name, err)
+		}
+	}
+}
+
+func TestParseLayoutOverrides(t *testing.T) {
+	query := map[string]string{"rows": "2", "margin_top": "10"}
+
+	layout, err := ParseLayout("avery-5392", func(key string) string { return query[key] })
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if layout.Rows != 2 || layout.MarginTop != 10 {
+		t.Errorf("expected overrides to be applied, got %+v", layout)
+	}
+
+	x, y := layout.Position(3)
+	if x != layout.MarginLeft+layout.Width || y != layout.MarginTop+layout.Height {
+		t.Errorf("unexpected position of badge 3: %v, %v", x, y)
+	}
+}
+
+func TestParseLayoutRejectsOverflow(t *testing.T) {
+	query := map[string]string{"columns": "3"}
+
+	if _, err := ParseLayout("avery-5392", func(key string) string { return query[key] }); err == nil {
+		t.Error("expected an error for badges that do not fit on the page")
+	}
+}
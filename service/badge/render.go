@@ -0,0 +1,224 @@
+package badge
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/jung-kurt/gofpdf"
+
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+// RenderBadges renders one badge per attendee on sheets of the given layout.
+// ticketTypes maps ticket type IDs to the names printed on the badges.
+func RenderBadges(event *types.Event, attendees []*types.Attendee, ticketTypes map[int32]string, layout Layout) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", layout.PageSize, "")
+	pdf.SetTitle(fmt.Sprintf("%s badges", event.Title), true)
+	pdf.SetAutoPageBreak(false, 0)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	if len(attendees) == 0 {
+		pdf.AddPage()
+	}
+
+	for i, attendee := range attendees {
+		if i%layout.PerPage() == 0 {
+			pdf.AddPage()
+		}
+
+		x, y := layout.Position(i)
+		if err := drawBadge(pdf, tr, event, attendee, ticketTypes[attendee.TicketTypeID], x, y, layout.Width, layout.Height); err != nil {
+			return nil, err
+		}
+	}
+
+	return output(pdf)
+}
+
+// drawBadge draws a single badge with its cut line at (x, y)
+func drawBadge(pdf *gofpdf.Fpdf, tr func(string) string, event *types.Event, attendee *types.Attendee, ticketType string, x, y, w, h float64) error {
+	const padding = 4.0
+
+	pdf.SetDrawColor(200, 200, 200)
+	pdf.SetLineWidth(0.2)
+	pdf.Rect(x, y, w, h, "D")
+
+	// Event name along the top
+	pdf.SetTextColor(90, 90, 90)
+	pdf.SetFont("Helvetica", "", 8)
+	pdf.SetXY(x+padding, y+padding)
+	pdf.CellFormat(w-2*padding, 4, fit(pdf, tr(event.Title), w-2*padding), "", 0, "C", false, 0, "")
+
+	// QR code on the right, sized to the badge
+	qrSize := min(h*0.45, w*0.35)
+	png, err := utils.GenerateQRCodePNG(attendee.Email)
+	if err != nil {
+		return fmt.Errorf("failed to generate QR code for attendee %d: %v", attendee.ID, err)
+	}
+	imageName := fmt.Sprintf("qr-%d", attendee.ID)
+	pdf.RegisterImageOptionsReader(imageName, gofpdf.ImageOptions{ImageType: "PNG"}, bytes.NewReader(png))
+	pdf.ImageOptions(imageName, x+w-padding-qrSize, y+h-padding-qrSize-6, qrSize, qrSize, false, gofpdf.ImageOptions{ImageType: "PNG"}, 0, "")
+
+	// Name, title and company on the left
+	textWidth := w - 3*padding - qrSize
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetXY(x+padding, y+padding+8)
+	pdf.SetFont("Helvetica", "B", fontSizeFor(h, 20))
+	pdf.CellFormat(textWidth, fontSizeFor(h, 20)*0.45, fit(pdf, tr(attendee.FirstName), textWidth), "", 2, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", fontSizeFor(h, 14))
+	pdf.CellFormat(textWidth, fontSizeFor(h, 14)*0.5, fit(pdf, tr(attendee.LastName), textWidth), "", 2, "L", false, 0, "")
+	pdf.Ln(1)
+	pdf.SetFont("Helvetica", "I", fontSizeFor(h, 10))
+	pdf.SetX(x + padding)
+	pdf.CellFormat(textWidth, 5, fit(pdf, tr(attendee.Title), textWidth), "", 2, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "B", fontSizeFor(h, 10))
+	pdf.CellFormat(textWidth, 5, fit(pdf, tr(attendee.CompanyName), textWidth), "", 2, "L", false, 0, "")
+
+	// Ticket type (or role) band and table number along the bottom
+	band := ticketType
+	if band == "" {
+		band = attendee.Role
+	}
+	if band != "" || attendee.TableNo != 0 {
+		pdf.SetFillColor(40, 40, 40)
+		pdf.Rect(x, y+h-6, w, 6, "F")
+		pdf.SetTextColor(255, 255, 255)
+		pdf.SetFont("Helvetica", "B", 8)
+		pdf.SetXY(x+padding, y+h-6)
+		pdf.CellFormat((w-2*padding)/2, 6, fit(pdf, tr(band), (w-2*padding)/2), "", 0, "L", false, 0, "")
+		if attendee.TableNo != 0 {
+			pdf.CellFormat((w-2*padding)/2, 6, "Table "+strconv.Itoa(int(attendee.TableNo)), "", 0, "R", false, 0, "")
+		}
+		pdf.SetTextColor(0, 0, 0)
+	}
+
+	return nil
+}
+
+// RenderTableCards renders one folded A4 table card per table listing its guests
+func RenderTableCards(event *types.Event, attendees []*types.Attendee) ([]byte, error) {
+	pdf := gofpdf.New("L", "mm", "A4", "")
+	pdf.SetTitle(fmt.Sprintf("%s table cards", event.Title), true)
+	pdf.SetAutoPageBreak(false, 0)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	tables := GroupByTable(attendees)
+	tableNos := make([]int32, 0, len(tables))
+	for tableNo := range tables {
+		tableNos = append(tableNos, tableNo)
+	}
+	sort.Slice(tableNos, func(i, j int) bool { return tableNos[i] < tableNos[j] })
+
+	if len(tableNos) == 0 {
+		pdf.AddPage()
+	}
+
+	for _, tableNo := range tableNos {
+		pdf.AddPage()
+		pageWidth, pageHeight := pdf.GetPageSize()
+		half := pageHeight / 2
+
+		// Fold line
+		pdf.SetDrawColor(200, 200, 200)
+		pdf.SetDashPattern([]float64{2, 2}, 0)
+		pdf.Line(0, half, pageWidth, half)
+		pdf.SetDashPattern([]float64{}, 0)
+
+		// The top half is printed upside down so the card reads from both sides
+		pdf.TransformBegin()
+		pdf.TransformRotate(180, pageWidth/2, half/2)
+		drawTableNumber(pdf, tr, event, tableNo, 0, pageWidth, half)
+		pdf.TransformEnd()
+
+		// The bottom half carries the number and the guest list
+		drawTableNumber(pdf, tr, event, tableNo, half, pageWidth, half/2)
+		drawGuestList(pdf, tr, tables[tableNo], half+half/2, pageWidth, half/2)
+	}
+
+	return output(pdf)
+}
+
+func drawTableNumber(pdf *gofpdf.Fpdf, tr func(string) string, event *types.Event, tableNo int32, y, w, h float64) {
+	pdf.SetTextColor(90, 90, 90)
+	pdf.SetFont("Helvetica", "", 12)
+	pdf.SetXY(0, y+8)
+	pdf.CellFormat(w, 6, tr(event.Title), "", 2, "C", false, 0, "")
+
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont("Helvetica", "B", 48)
+	pdf.SetXY(0, y+h/2-6)
+	pdf.CellFormat(w, 20, "Table "+strconv.Itoa(int(tableNo)), "", 0, "C", false, 0, "")
+}
+
+func drawGuestList(pdf *gofpdf.Fpdf, tr func(string) string, guests []*types.Attendee, y, w, h float64) {
+	const columns = 3
+	const lineHeight = 5.0
+	columnWidth := (w - 40) / columns
+	perColumn := max(int((h-10)/lineHeight), 1)
+
+	pdf.SetFont("Helvetica", "", 10)
+	for i, guest := range guests {
+		col, row := i/perColumn, i%perColumn
+		if col >= columns {
+			break
+		}
+		name := guest.FirstName + " " + guest.LastName
+		if guest.CompanyName != "" {
+			name += " (" + guest.CompanyName + ")"
+		}
+		pdf.SetXY(20+float64(col)*columnWidth, y+float64(row)*lineHeight)
+		pdf.CellFormat(columnWidth, lineHeight, fit(pdf, tr(name), columnWidth-2), "", 0, "L", false, 0, "")
+	}
+}
+
+// GroupByTable groups attendees by their table number, skipping unseated attendees
+func GroupByTable(attendees []*types.Attendee) map[int32][]*types.Attendee {
+	tables := make(map[int32][]*types.Attendee)
+	for _, attendee := range attendees {
+		if attendee.TableNo == 0 {
+			continue
+		}
+		tables[attendee.TableNo] = append(tables[attendee.TableNo], attendee)
+	}
+
+	for _, guests := range tables {
+		sort.Slice(guests, func(i, j int) bool {
+			if guests[i].LastName != guests[j].LastName {
+				return guests[i].LastName < guests[j].LastName
+			}
+			return guests[i].FirstName < guests[j].FirstName
+		})
+	}
+
+	return tables
+}
+
+// fontSizeFor scales a font size designed for a 76 mm tall badge
+func fontSizeFor(badgeHeight float64, size float64) float64 {
+	return max(size*badgeHeight/76.2, 6)
+}
+
+// fit shortens the text with an ellipsis until it fits the width in the current font
+func fit(pdf *gofpdf.Fpdf, text string, width float64) string {
+	if pdf.GetStringWidth(text) <= width {
+		return text
+	}
+
+	for len(text) > 0 && pdf.GetStringWidth(text+"...") > width {
+		text = text[:len(text)-1]
+	}
+
+	return text + "..."
+}
+
+func output(pdf *gofpdf.Fpdf) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
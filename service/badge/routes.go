@@ -0,0 +1,162 @@
+package badge
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+type Handler struct {
+	attendeeStore   types.AttendeeStore
+	ticketTypeStore types.TicketTypeStore
+	eventStore      types.EventStore
+	userStore       types.UserStore
+}
+
+func NewHandler(attendeeStore types.AttendeeStore, ticketTypeStore types.TicketTypeStore, eventStore types.EventStore, userStore types.UserStore) *Handler {
+	return &Handler{attendeeStore: attendeeStore, ticketTypeStore: ticketTypeStore, eventStore: eventStore, userStore: userStore}
+}
+
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/badges/layouts", auth.WithJWTAuth(h.handleGetLayouts, h.userStore))
+	router.Get("/event/:id/badges.pdf", auth.WithJWTAuth(h.handleGetEventBadges, h.userStore))
+	router.Get("/event/:id/table_cards.pdf", auth.WithJWTAuth(h.handleGetTableCards, h.userStore))
+	router.Get("/attendees/:attendee_id/badge.pdf", auth.WithJWTAuth(h.handleGetAttendeeBadge, h.userStore))
+}
+
+// Handler to list the built-in badge sheet layouts
+func (h *Handler) handleGetLayouts(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusOK).JSON(Layouts)
+}
+
+// Handler to render the badges of every attendee of an event, optionally
+// only those holding one ticket type
+func (h *Handler) handleGetEventBadges(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	layout, err := ParseLayout(c.Query("layout"), func(key string) string { return c.Query(key) })
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	ticketTypeID := c.QueryInt("ticket_type_id")
+
+	attendees, err := h.attendeeStore.GetAllAttendees(event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get attendees"})
+	}
+	if ticketTypeID != 0 {
+		filtered := []*types.Attendee{}
+		for _, attendee := range attendees {
+			if attendee.TicketTypeID == int32(ticketTypeID) {
+				filtered = append(filtered, attendee)
+			}
+		}
+		attendees = filtered
+	}
+
+	ticketTypes, err := h.getTicketTypeNames(event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get ticket types"})
+	}
+
+	pdf, err := RenderBadges(event, attendees, ticketTypes, layout)
+	if err != nil {
+		log.Printf("Error rendering badges for event ID %d: %v", event.EventID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to render badges"})
+	}
+
+	return sendPDF(c, fmt.Sprintf("event-%d-badges.pdf", event.EventID), pdf)
+}
+
+// Handler to render the table cards of an event grouped by table number
+func (h *Handler) handleGetTableCards(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	attendees, err := h.attendeeStore.GetAllAttendees(event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get attendees"})
+	}
+
+	pdf, err := RenderTableCards(event, attendees)
+	if err != nil {
+		log.Printf("Error rendering table cards for event ID %d: %v", event.EventID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to render table cards"})
+	}
+
+	return sendPDF(c, fmt.Sprintf("event-%d-table-cards.pdf", event.EventID), pdf)
+}
+
+// Handler to render the badge of a single attendee
+func (h *Handler) handleGetAttendeeBadge(c *fiber.Ctx) error {
+	attendeeID, err := strconv.Atoi(c.Params("attendee_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid attendee ID"})
+	}
+
+	attendee, err := h.attendeeStore.GetAttendeeByID(int32(attendeeID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Attendee not found"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get attendee"})
+	}
+
+	event, ferr := utils.GetOwnedEvent(strconv.Itoa(int(attendee.EventID)), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	layout, err := ParseLayout(c.Query("layout"), func(key string) string { return c.Query(key) })
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	ticketTypes, err := h.getTicketTypeNames(event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get ticket types"})
+	}
+
+	pdf, err := RenderBadges(event, []*types.Attendee{attendee}, ticketTypes, layout)
+	if err != nil {
+		log.Printf("Error rendering badge for attendee ID %d: %v", attendee.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to render badge"})
+	}
+
+	return sendPDF(c, fmt.Sprintf("attendee-%d-badge.pdf", attendee.ID), pdf)
+}
+
+// getTicketTypeNames maps the ticket type IDs of an event to their names
+func (h *Handler) getTicketTypeNames(eventID int32) (map[int32]string, error) {
+	ticketTypes, err := h.ticketTypeStore.GetTicketTypesByEventID(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[int32]string, len(ticketTypes))
+	for _, ticketType := range ticketTypes {
+		names[ticketType.ID] = ticketType.Name
+	}
+
+	return names, nil
+}
+
+func sendPDF(c *fiber.Ctx, filename string, pdf []byte) error {
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", filename))
+	return c.Status(fiber.StatusOK).Send(pdf)
+}
@@ -0,0 +1,202 @@
+package campaign
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/service/audit"
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+type Handler struct {
+	store      types.CampaignStore
+	eventStore types.EventStore
+	userStore  types.UserStore
+}
+
+func NewHandler(store types.CampaignStore, eventStore types.EventStore, userStore types.UserStore) *Handler {
+	return &Handler{store: store, eventStore: eventStore, userStore: userStore}
+}
+
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/event/:id/campaigns", auth.WithJWTAuth(h.handleGetCampaigns, h.userStore))
+	router.Post("/event/:id/campaigns", auth.WithJWTAuth(h.handleCreateCampaign, h.userStore))
+	router.Put("/event/:id/campaigns/:campaign_id", auth.WithJWTAuth(h.handleUpdateCampaign, h.userStore))
+	router.Delete("/event/:id/campaigns/:campaign_id", auth.WithJWTAuth(h.handleDeleteCampaign, h.userStore))
+}
+
+// Handler to list the campaigns of an event with the time each one is sent
+func (h *Handler) handleGetCampaigns(c *fiber.Ctx) error {
+	page, ferr := utils.ParsePage(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	campaigns, err := h.store.GetCampaignsByEventID(event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get campaigns"})
+	}
+
+	for _, campaign := range campaigns {
+		withSendAt(campaign, event)
+	}
+
+	list, ferr := utils.SlicePage(campaigns, page, func(campaign *types.EmailCampaign) int64 { return int64(campaign.ID) }, nil)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
+// Handler to schedule a campaign for an event
+func (h *Handler) handleCreateCampaign(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var payload types.CreateCampaignPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	campaign := &types.EmailCampaign{EventID: event.EventID}
+	applyPayload(campaign, &payload)
+
+	if err := h.store.CreateCampaign(c.Context(), campaign); err != nil {
+		log.Printf("Error creating campaign for event ID %d: %v", event.EventID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create campaign"})
+	}
+	withSendAt(campaign, event)
+
+	audit.Note(c, audit.Mutation{Action: "campaign.create", TargetType: "campaign", TargetID: campaign.ID, EventID: event.EventID, EventOwnerID: event.UserID, After: campaign})
+
+	return c.Status(fiber.StatusCreated).JSON(campaign)
+}
+
+// Handler to change a campaign that has not been sent yet
+func (h *Handler) handleUpdateCampaign(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	campaign, ferr := h.getCampaign(c, event)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var payload types.CreateCampaignPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	before := *campaign
+	applyPayload(campaign, &payload)
+
+	updated, err := h.store.UpdateCampaign(c.Context(), campaign)
+	if err != nil {
+		log.Printf("Error updating campaign ID %d: %v", campaign.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update campaign"})
+	}
+	if !updated {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Only scheduled campaigns can be changed"})
+	}
+	withSendAt(campaign, event)
+
+	audit.Note(c, audit.Mutation{Action: "campaign.update", TargetType: "campaign", TargetID: campaign.ID, EventID: event.EventID, EventOwnerID: event.UserID, Before: before, After: campaign})
+
+	return c.Status(fiber.StatusOK).JSON(campaign)
+}
+
+// Handler to delete a campaign, a campaign that is being sent cannot be deleted
+func (h *Handler) handleDeleteCampaign(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	campaign, ferr := h.getCampaign(c, event)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	if campaign.Status == types.CampaignStatusSending {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "The campaign is being sent"})
+	}
+
+	if err := h.store.DeleteCampaign(c.Context(), campaign.ID); err != nil {
+		log.Printf("Error deleting campaign ID %d: %v", campaign.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to delete campaign"})
+	}
+
+	audit.Note(c, audit.Mutation{Action: "campaign.delete", TargetType: "campaign", TargetID: campaign.ID, EventID: event.EventID, EventOwnerID: event.UserID, Before: campaign})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Campaign deleted successfully"})
+}
+
+// applyPayload copies a campaign payload onto a campaign, the role only
+// applies to campaigns sent to a role
+func applyPayload(campaign *types.EmailCampaign, payload *types.CreateCampaignPayload) {
+	campaign.Name = payload.Name
+	campaign.Purpose = payload.Purpose
+	campaign.Anchor = payload.Anchor
+	campaign.OffsetMinutes = payload.OffsetMinutes
+	campaign.Audience = payload.Audience
+	campaign.Role = ""
+	if payload.Audience == types.CampaignAudienceRole {
+		campaign.Role = payload.Role
+	}
+}
+
+// withSendAt fills in when a campaign is sent for the current dates of its event
+func withSendAt(campaign *types.EmailCampaign, event *types.Event) {
+	sendAt := SendAt(campaign, event)
+	campaign.SendAt = &sendAt
+}
+
+// getCampaign fetches the campaign in the route and checks that it belongs to the event
+func (h *Handler) getCampaign(c *fiber.Ctx, event *types.Event) (*types.EmailCampaign, *fiber.Error) {
+	campaignID, err := strconv.Atoi(c.Params("campaign_id"))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid campaign ID")
+	}
+
+	campaign, err := h.store.GetCampaignByID(int32(campaignID))
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to get campaign")
+	}
+	if err != nil || campaign.EventID != event.EventID {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Campaign not found")
+	}
+
+	return campaign, nil
+}
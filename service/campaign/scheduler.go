@@ -0,0 +1,292 @@
+package campaign
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/service/email"
+	"github.com/jayden1905/event-registration-software/service/plan"
+	"github.com/jayden1905/event-registration-software/service/suppression"
+	"github.com/jayden1905/event-registration-software/service/tracking"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+// missedLimit is how late a campaign may still be sent, campaigns missed by
+// more, for example while the server was down, are skipped
+const missedLimit = 24 * time.Hour
+
+// Scheduler sends the email campaigns of events once they are due. Campaigns
+// and their deliveries are stored, so a campaign interrupted by a restart is
+// resumed without emailing the attendees it already reached.
+type Scheduler struct {
+	store         types.CampaignStore
+	eventStore    types.EventStore
+	attendeeStore types.AttendeeStore
+	emailStore    types.EmailTempalteStore
+	deliveryStore types.EmailDeliveryStore
+	suppressions  types.SuppressionStore
+	enforcer      *plan.Enforcer
+	mailer        email.Mailer
+}
+
+// NewScheduler creates a Scheduler that sends campaigns through the mailer
+func NewScheduler(store types.CampaignStore, eventStore types.EventStore, attendeeStore types.AttendeeStore, emailStore types.EmailTempalteStore, deliveryStore types.EmailDeliveryStore, suppressions types.SuppressionStore, enforcer *plan.Enforcer, mailer email.Mailer) *Scheduler {
+	return &Scheduler{
+		store:         store,
+		eventStore:    eventStore,
+		attendeeStore: attendeeStore,
+		emailStore:    emailStore,
+		deliveryStore: deliveryStore,
+		suppressions:  suppressions,
+		enforcer:      enforcer,
+		mailer:        mailer,
+	}
+}
+
+// Start resumes the campaigns a previous run left sending and then sends due
+// campaigns every interval in the background
+func (s *Scheduler) Start(interval time.Duration) {
+	if requeued, err := s.store.RequeueSendingCampaigns(context.Background()); err != nil {
+		log.Printf("Error requeuing interrupted campaigns: %v", err)
+	} else if requeued > 0 {
+		log.Printf("Resuming %d interrupted campaigns", requeued)
+	}
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for now := range ticker.C {
+			if err := s.RunDue(context.Background(), now); err != nil {
+				log.Printf("Error sending due campaigns: %v", err)
+			}
+		}
+	}()
+}
+
+// RunDue sends every scheduled campaign whose send time is at or before now
+func (s *Scheduler) RunDue(ctx context.Context, now time.Time) error {
+	campaigns, err := s.store.GetDueCampaigns(now)
+	if err != nil {
+		return err
+	}
+
+	for _, campaign := range campaigns {
+		if err := s.run(ctx, campaign, now); err != nil {
+			log.Printf("Error sending campaign ID %d: %v", campaign.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// run claims a due campaign, sends it and records how it ended
+func (s *Scheduler) run(ctx context.Context, campaign *types.EmailCampaign, now time.Time) error {
+	event, err := s.eventStore.GetEventByID(campaign.EventID)
+	if err != nil {
+		return err
+	}
+
+	claimed, err := s.store.ClaimCampaign(ctx, campaign.ID)
+	if err != nil || !claimed {
+		return err
+	}
+
+	// A campaign that already started is resumed however late it is
+	sendAt := SendAt(campaign, event)
+	if campaign.StartedAt == nil && now.Sub(sendAt) > missedLimit {
+		return s.store.FinishCampaign(ctx, campaign.ID, types.CampaignStatusSkipped, fmt.Sprintf("Missed the send time of %s", sendAt.Format(time.RFC3339)))
+	}
+
+	status, lastError := s.send(ctx, campaign, event)
+	return s.store.FinishCampaign(ctx, campaign.ID, status, lastError)
+}
+
+// send emails the campaign to the attendees in its audience that have not
+// received it yet and returns the status and error to record
+func (s *Scheduler) send(ctx context.Context, campaign *types.EmailCampaign, event *types.Event) (string, string) {
+	template, err := s.emailStore.GetEmailTemplateByPurpose(ctx, event.EventID, campaign.Purpose)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return types.CampaignStatusFailed, fmt.Sprintf("The event has no %s email template", campaign.Purpose)
+		}
+		return types.CampaignStatusFailed, "Failed to get email template"
+	}
+
+	attendees, err := s.attendeeStore.GetAllAttendees(event.EventID)
+	if err != nil {
+		return types.CampaignStatusFailed, "Failed to get attendees"
+	}
+	sentIDs, err := s.store.GetCampaignSentAttendeeIDs(campaign.ID)
+	if err != nil {
+		return types.CampaignStatusFailed, "Failed to get earlier deliveries"
+	}
+
+	// Attendees with a suppressed address are skipped and recorded as such
+	suppressions, err := s.suppressions.GetSuppressionsByEventID(ctx, event.EventID)
+	if err != nil {
+		return types.CampaignStatusFailed, "Failed to get suppressions"
+	}
+	recipients, skipped := suppression.Filter(Recipients(campaign, attendees, sentIDs), suppressions)
+	for _, recipient := range skipped {
+		s.recordSkipped(campaign, template, recipient)
+	}
+
+	if len(recipients) == 0 {
+		return types.CampaignStatusSent, ""
+	}
+
+	// Drop the branding the plan does not include and count the emails against the monthly quota
+	template, ferr := s.enforcer.Brand(event.UserID, template)
+	if ferr != nil {
+		return types.CampaignStatusFailed, ferr.Message
+	}
+	if ferr := s.enforcer.ReserveEmails(ctx, event.UserID, len(recipients)); ferr != nil {
+		return types.CampaignStatusFailed, ferr.Message
+	}
+
+	// Track the emails unless the organizer opted out of tracking
+	tracked, err := s.deliveryStore.IsTrackingEnabled(ctx, event.EventID)
+	if err != nil {
+		s.enforcer.ReleaseEmails(event.UserID, len(recipients))
+		return types.CampaignStatusFailed, "Failed to get tracking settings"
+	}
+
+	var wg sync.WaitGroup
+	errorChannel := make(chan error, len(recipients))
+
+	// Limit the number of concurrent sends
+	semaphore := make(chan struct{}, 10)
+
+	for _, attendee := range recipients {
+		wg.Add(1)
+		semaphore <- struct{}{}
+
+		go func(att *types.Attendee) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			trackingID := tracking.NewID(tracked)
+			err := s.mailer.SendInvitationEmail(att, template, trackingID)
+			s.recordDelivery(campaign, att, template, trackingID, err)
+			if err != nil {
+				log.Printf("Error sending campaign ID %d to %s: %v", campaign.ID, att.Email, err)
+				errorChannel <- err
+			}
+		}(attendee)
+	}
+
+	wg.Wait()
+	close(errorChannel)
+
+	// Emails that failed to send do not count against the quota
+	failed := len(errorChannel)
+	s.enforcer.ReleaseEmails(event.UserID, failed)
+
+	if failed == 0 {
+		return types.CampaignStatusSent, ""
+	}
+	lastError := fmt.Sprintf("%d of %d emails failed to send: %v", failed, len(recipients), <-errorChannel)
+	if failed == len(recipients) {
+		return types.CampaignStatusFailed, lastError
+	}
+
+	return types.CampaignStatusSent, lastError
+}
+
+// recordDelivery stores the outcome of a campaign email so it is not sent
+// twice. A permanent SMTP error suppresses the address of the attendee.
+func (s *Scheduler) recordDelivery(campaign *types.EmailCampaign, attendee *types.Attendee, template *types.EmailTemplate, trackingID string, sendErr error) {
+	delivery := &types.EmailDelivery{
+		EventID:    attendee.EventID,
+		AttendeeID: attendee.ID,
+		TemplateID: template.ID,
+		CampaignID: campaign.ID,
+		Email:      attendee.Email,
+		Status:     types.DeliveryStatusSent,
+		TrackingID: trackingID,
+	}
+	if sendErr != nil {
+		delivery.Status = types.DeliveryStatusFailed
+		delivery.Error = sendErr.Error()
+		delivery.TrackingID = ""
+	}
+
+	if err := s.deliveryStore.CreateEmailDelivery(context.Background(), delivery); err != nil {
+		log.Printf("Error recording email delivery to %s: %v", attendee.Email, err)
+	}
+
+	if sendErr != nil {
+		suppression.SuppressFailed(context.Background(), s.suppressions, attendee.Email, sendErr)
+	}
+}
+
+// recordSkipped stores an attendee of the audience a campaign left out with the reason why
+func (s *Scheduler) recordSkipped(campaign *types.EmailCampaign, template *types.EmailTemplate, recipient *types.SkippedRecipient) {
+	delivery := &types.EmailDelivery{
+		EventID:    campaign.EventID,
+		AttendeeID: recipient.AttendeeID,
+		TemplateID: template.ID,
+		CampaignID: campaign.ID,
+		Email:      recipient.Email,
+		Status:     types.DeliveryStatusSkipped,
+		Error:      recipient.Reason,
+	}
+
+	if err := s.deliveryStore.CreateEmailDelivery(context.Background(), delivery); err != nil {
+		log.Printf("Error recording skipped email delivery to %s: %v", recipient.Email, err)
+	}
+}
+
+// SendAt returns when a campaign is due for an event
+func SendAt(campaign *types.EmailCampaign, event *types.Event) time.Time {
+	anchor := event.StartDate
+	if campaign.Anchor == types.CampaignAnchorEnd {
+		anchor = event.EndDate
+	}
+
+	return anchor.Add(time.Duration(campaign.OffsetMinutes) * time.Minute)
+}
+
+// InAudience reports whether an attendee belongs to the audience of a campaign
+func InAudience(campaign *types.EmailCampaign, attendee *types.Attendee) bool {
+	switch campaign.Audience {
+	case types.CampaignAudienceAll:
+		return true
+	case types.CampaignAudienceNotCheckedIn:
+		return !attendee.Attendance
+	case types.CampaignAudienceCheckedIn:
+		return attendee.Attendance
+	case types.CampaignAudienceRole:
+		return strings.EqualFold(attendee.Role, campaign.Role)
+	}
+
+	return false
+}
+
+// Recipients returns the confirmed attendees in the audience of a campaign
+// that are not among the attendees it was already sent to
+func Recipients(campaign *types.EmailCampaign, attendees []*types.Attendee, sentIDs []int32) []*types.Attendee {
+	sent := make(map[int32]bool, len(sentIDs))
+	for _, id := range sentIDs {
+		sent[id] = true
+	}
+
+	recipients := []*types.Attendee{}
+	for _, attendee := range attendees {
+		if attendee.RegistrationStatus == types.RegistrationStatusPendingPayment || sent[attendee.ID] {
+			continue
+		}
+		if InAudience(campaign, attendee) {
+			recipients = append(recipients, attendee)
+		}
+	}
+
+	return recipients
+}
@@ -0,0 +1,73 @@
+package campaign
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+func TestSendAt(t *testing.T) {
+	event := &types.Event{
+		StartDate: time.Date(2026, 11, 20, 9, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2026, 11, 21, 17, 0, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name     string
+		campaign *types.EmailCampaign
+		want     time.Time
+	}{
+		{"7 days before", &types.EmailCampaign{Anchor: types.CampaignAnchorStart, OffsetMinutes: -7 * 24 * 60}, time.Date(2026, 11, 13, 9, 0, 0, 0, time.UTC)},
+		{"1 day before", &types.EmailCampaign{Anchor: types.CampaignAnchorStart, OffsetMinutes: -24 * 60}, time.Date(2026, 11, 19, 9, 0, 0, 0, time.UTC)},
+		{"at the start", &types.EmailCampaign{Anchor: types.CampaignAnchorStart}, event.StartDate},
+		{"1 day after", &types.EmailCampaign{Anchor: types.CampaignAnchorEnd, OffsetMinutes: 24 * 60}, time.Date(2026, 11, 22, 17, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		if got := SendAt(test.campaign, event); !got.Equal(test.want) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestRecipients(t *testing.T) {
+	attendees := []*types.Attendee{
+		{ID: 1, Role: "Speaker", Attendance: true, RegistrationStatus: types.RegistrationStatusConfirmed},
+		{ID: 2, Role: "Guest", Attendance: false, RegistrationStatus: types.RegistrationStatusConfirmed},
+		{ID: 3, Role: "speaker", Attendance: false, RegistrationStatus: types.RegistrationStatusConfirmed},
+		{ID: 4, Role: "Guest", Attendance: false, RegistrationStatus: types.RegistrationStatusPendingPayment},
+	}
+
+	tests := []struct {
+		name     string
+		campaign *types.EmailCampaign
+		sentIDs  []int32
+		want     []int32
+	}{
+		{"all", &types.EmailCampaign{Audience: types.CampaignAudienceAll}, nil, []int32{1, 2, 3}},
+		{"not checked in", &types.EmailCampaign{Audience: types.CampaignAudienceNotCheckedIn}, nil, []int32{2, 3}},
+		{"checked in", &types.EmailCampaign{Audience: types.CampaignAudienceCheckedIn}, nil, []int32{1}},
+		{"role ignores case", &types.EmailCampaign{Audience: types.CampaignAudienceRole, Role: "Speaker"}, nil, []int32{1, 3}},
+		{"resumed after a restart", &types.EmailCampaign{Audience: types.CampaignAudienceAll}, []int32{1, 3}, []int32{2}},
+	}
+
+	for _, test := range tests {
+		recipients := Recipients(test.campaign, attendees, test.sentIDs)
+
+		var got []int32
+		for _, attendee := range recipients {
+			got = append(got, attendee.ID)
+		}
+		if len(got) != len(test.want) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+			continue
+		}
+		for i := range got {
+			if got[i] != test.want[i] {
+				t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+				break
+			}
+		}
+	}
+}
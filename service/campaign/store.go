@@ -0,0 +1,192 @@
+package campaign
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/cmd/pkg/database"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// CreateCampaign schedules a campaign for an event
+func (s *Store) CreateCampaign(ctx context.Context, campaign *types.EmailCampaign) error {
+	id, err := s.db.CreateEmailCampaign(ctx, database.CreateEmailCampaignParams{
+		EventID:       campaign.EventID,
+		Name:          campaign.Name,
+		Purpose:       database.EmailCampaignsPurpose(campaign.Purpose),
+		Anchor:        database.EmailCampaignsAnchor(campaign.Anchor),
+		OffsetMinutes: campaign.OffsetMinutes,
+		Audience:      database.EmailCampaignsAudience(campaign.Audience),
+		Role:          sql.NullString{String: campaign.Role, Valid: campaign.Role != ""},
+	})
+	if err != nil {
+		return err
+	}
+	campaign.ID = int32(id)
+	campaign.Status = types.CampaignStatusScheduled
+
+	return nil
+}
+
+// UpdateCampaign changes a campaign that has not been sent yet, it reports
+// false when the campaign is no longer scheduled
+func (s *Store) UpdateCampaign(ctx context.Context, campaign *types.EmailCampaign) (bool, error) {
+	result, err := s.db.UpdateEmailCampaign(ctx, database.UpdateEmailCampaignParams{
+		Name:          campaign.Name,
+		Purpose:       database.EmailCampaignsPurpose(campaign.Purpose),
+		Anchor:        database.EmailCampaignsAnchor(campaign.Anchor),
+		OffsetMinutes: campaign.OffsetMinutes,
+		Audience:      database.EmailCampaignsAudience(campaign.Audience),
+		Role:          sql.NullString{String: campaign.Role, Valid: campaign.Role != ""},
+		ID:            campaign.ID,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	// MySQL counts matched rows that did not change as unaffected, so check the status again
+	if affected, err := result.RowsAffected(); err == nil && affected > 0 {
+		return true, nil
+	}
+	current, err := s.GetCampaignByID(campaign.ID)
+	if err != nil {
+		return false, err
+	}
+
+	return current.Status == types.CampaignStatusScheduled, nil
+}
+
+// DeleteCampaign deletes a campaign, the deliveries it sent are kept
+func (s *Store) DeleteCampaign(ctx context.Context, id int32) error {
+	return s.db.DeleteEmailCampaign(ctx, id)
+}
+
+// GetCampaignsByEventID fetches the campaigns of an event
+func (s *Store) GetCampaignsByEventID(eventID int32) ([]*types.EmailCampaign, error) {
+	rows, err := s.db.GetEmailCampaignsByEventID(context.Background(), eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	campaigns := []*types.EmailCampaign{}
+	for _, row := range rows {
+		campaigns = append(campaigns, toCampaign(row))
+	}
+
+	return campaigns, nil
+}
+
+// GetCampaignByID fetches a campaign by its ID
+func (s *Store) GetCampaignByID(id int32) (*types.EmailCampaign, error) {
+	row, err := s.db.GetEmailCampaignByID(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCampaign(row), nil
+}
+
+// GetDueCampaigns fetches the scheduled campaigns of events that are not
+// deleted whose send time is at or before now
+func (s *Store) GetDueCampaigns(now time.Time) ([]*types.EmailCampaign, error) {
+	rows, err := s.db.GetDueEmailCampaigns(context.Background(), now)
+	if err != nil {
+		return nil, err
+	}
+
+	var campaigns []*types.EmailCampaign
+	for _, row := range rows {
+		campaigns = append(campaigns, toCampaign(row))
+	}
+
+	return campaigns, nil
+}
+
+// ClaimCampaign moves a scheduled campaign to sending, it reports false when
+// the campaign was already claimed
+func (s *Store) ClaimCampaign(ctx context.Context, id int32) (bool, error) {
+	result, err := s.db.ClaimEmailCampaign(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// FinishCampaign records the final status of a campaign and counts its deliveries
+func (s *Store) FinishCampaign(ctx context.Context, id int32, status string, lastError string) error {
+	return s.db.FinishEmailCampaign(ctx, database.FinishEmailCampaignParams{
+		Status:    database.EmailCampaignsStatus(status),
+		LastError: sql.NullString{String: lastError, Valid: lastError != ""},
+		ID:        id,
+	})
+}
+
+// RequeueSendingCampaigns schedules the campaigns that were interrupted while
+// sending again
+func (s *Store) RequeueSendingCampaigns(ctx context.Context) (int64, error) {
+	result, err := s.db.RequeueSendingEmailCampaigns(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// GetCampaignSentAttendeeIDs fetches the attendees a campaign was already sent to
+func (s *Store) GetCampaignSentAttendeeIDs(campaignID int32) ([]int32, error) {
+	rows, err := s.db.GetCampaignSentAttendeeIDs(context.Background(), sql.NullInt32{Int32: campaignID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int32
+	for _, row := range rows {
+		ids = append(ids, row.Int32)
+	}
+
+	return ids, nil
+}
+
+// toCampaign converts a database email campaign into the campaign type
+func toCampaign(row database.EmailCampaign) *types.EmailCampaign {
+	campaign := &types.EmailCampaign{
+		ID:            row.ID,
+		EventID:       row.EventID,
+		Name:          row.Name,
+		Purpose:       string(row.Purpose),
+		Anchor:        string(row.Anchor),
+		OffsetMinutes: row.OffsetMinutes,
+		Audience:      string(row.Audience),
+		Role:          row.Role.String,
+		Status:        string(row.Status),
+		SentCount:     row.SentCount,
+		FailedCount:   row.FailedCount,
+		LastError:     row.LastError.String,
+		CreatedAt:     row.CreatedAt,
+		UpdatedAt:     row.UpdatedAt,
+	}
+	if row.StartedAt.Valid {
+		campaign.StartedAt = &row.StartedAt.Time
+	}
+	if row.FinishedAt.Valid {
+		campaign.FinishedAt = &row.FinishedAt.Time
+	}
+
+	return campaign
+}
@@ -0,0 +1,345 @@
+package checkin
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/service/live"
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+type Handler struct {
+	store         types.CheckInSyncStore
+	checkInStore  types.CheckInStore
+	sessionStore  types.SessionStore
+	attendeeStore types.AttendeeStore
+	eventStore    types.EventStore
+	userStore     types.UserStore
+	hub           *live.Hub
+}
+
+func NewHandler(store types.CheckInSyncStore, checkInStore types.CheckInStore, sessionStore types.SessionStore, attendeeStore types.AttendeeStore, eventStore types.EventStore, userStore types.UserStore, hub *live.Hub) *Handler {
+	return &Handler{store: store, checkInStore: checkInStore, sessionStore: sessionStore, attendeeStore: attendeeStore, eventStore: eventStore, userStore: userStore, hub: hub}
+}
+
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/event/:id/checkin/manifest", auth.WithJWTAuth(h.handleGetManifest, h.userStore))
+	router.Post("/event/:id/checkin/sync", auth.WithJWTAuth(h.handleSyncCheckIns, h.userStore))
+	router.Get("/event/:id/check_ins", auth.WithJWTAuth(h.handleGetEventCheckIns, h.userStore))
+	router.Get("/attendees/:attendee_id/check_ins", auth.WithJWTAuth(h.handleGetAttendeeCheckIns, h.userStore))
+	router.Post("/attendees/:attendee_id/check_out", auth.WithJWTAuth(h.handleCheckOut, h.userStore))
+	router.Delete("/check_ins/:check_in_id", auth.WithJWTAuth(h.handleUndoCheckIn, h.userStore))
+}
+
+// Handler to download the attendee manifest of an event, or the changes since a version
+func (h *Handler) handleGetManifest(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var since int64
+	if sinceStr := c.Query("since"); sinceStr != "" {
+		s, err := strconv.ParseInt(sinceStr, 10, 64)
+		if err != nil || s < 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid version"})
+		}
+		since = s
+	}
+
+	// Take the version before reading so changes made during the download are sent again next time
+	version, err := h.store.GetSyncVersion(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get manifest"})
+	}
+
+	attendees, err := h.store.GetManifestAttendees(c.Context(), event.EventID, since)
+	if err != nil {
+		log.Printf("Error getting manifest for event ID %d: %v", event.EventID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get manifest"})
+	}
+
+	deleted := []types.DeletedManifestAttendee{}
+	if since > 0 {
+		deleted, err = h.store.GetDeletedManifestAttendees(c.Context(), event.EventID, since)
+		if err != nil {
+			log.Printf("Error getting deleted attendees for event ID %d: %v", event.EventID, err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get manifest"})
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(types.CheckInManifest{
+		EventID:   event.EventID,
+		Version:   version,
+		Since:     since,
+		Full:      since == 0,
+		Attendees: attendees,
+		Deleted:   deleted,
+	})
+}
+
+// Handler to upload a batch of check-ins recorded offline by a device
+func (h *Handler) handleSyncCheckIns(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var payload types.SyncCheckInsPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	attendees, err := h.store.GetManifestAttendees(c.Context(), event.EventID, 0)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get attendees"})
+	}
+
+	deleted, err := h.store.GetDeletedManifestAttendees(c.Context(), event.EventID, 0)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get deleted attendees"})
+	}
+
+	results, marks := Resolve(payload.CheckIns, attendees, deleted, time.Now())
+
+	userID := auth.GetUserIDFromContext(c)
+	for attendeeID, checkedInAt := range marks {
+		if err := h.checkInStore.RecordCheckIn(c.Context(), &types.CheckIn{
+			AttendeeID: attendeeID,
+			EventID:    event.EventID,
+			Type:       types.CheckInTypeCheckIn,
+			ScannedBy:  userID,
+			DeviceID:   payload.DeviceID,
+			ScannedAt:  checkedInAt,
+		}); err != nil {
+			log.Printf("Error syncing check-in of attendee ID %d from device %s: %v", attendeeID, payload.DeviceID, err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to store check-ins"})
+		}
+	}
+
+	summary := map[string]int{}
+	for _, result := range results {
+		summary[result.Status]++
+	}
+
+	if len(marks) > 0 {
+		h.publishUpdate(live.UpdateCheckIn, event.EventID, nil)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"device_id": payload.DeviceID,
+		"summary":   summary,
+		"results":   results,
+	})
+}
+
+// Handler to get the check-in history of an event, optionally of one session
+func (h *Handler) handleGetEventCheckIns(c *fiber.Ctx) error {
+	page, ferr := utils.ParsePage(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	sessionID := c.QueryInt("session_id", 0)
+	if sessionID != 0 {
+		session, err := h.sessionStore.GetSessionByID(int32(sessionID))
+		if err != nil || session.EventID != event.EventID {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Session not found"})
+		}
+	}
+
+	checkIns, next, err := h.checkInStore.GetCheckInsByEventID(event.EventID, int32(sessionID), page)
+	if errors.Is(err, types.ErrInvalidCursor) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid cursor"})
+	}
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get check-ins"})
+	}
+
+	var total *int64
+	if page.WithTotal {
+		count, err := h.checkInStore.CountCheckInsByEventID(event.EventID, int32(sessionID))
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to count check-ins"})
+		}
+		total = &count
+	}
+
+	filters := map[string]any{}
+	if sessionID != 0 {
+		filters["session_id"] = sessionID
+	}
+
+	return c.Status(fiber.StatusOK).JSON(utils.NewPage(checkIns, next, total, filters))
+}
+
+// Handler to get the check-in history of an attendee
+func (h *Handler) handleGetAttendeeCheckIns(c *fiber.Ctx) error {
+	attendee, ferr := h.getOwnedAttendee(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	checkIns, err := h.checkInStore.GetCheckInsByAttendeeID(attendee.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get check-ins"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"attendee":  attendee,
+		"check_ins": checkIns,
+	})
+}
+
+// Handler to record that a checked in attendee left the event or a session
+func (h *Handler) handleCheckOut(c *fiber.Ctx) error {
+	attendee, ferr := h.getOwnedAttendee(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var scan types.ScanPayload
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(&scan); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+		}
+	}
+
+	if scan.SessionID != 0 {
+		session, err := h.sessionStore.GetSessionByID(scan.SessionID)
+		if err != nil || session.EventID != attendee.EventID {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Session not found"})
+		}
+	}
+
+	history, err := h.checkInStore.GetCheckInsByAttendeeID(attendee.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get check-in history"})
+	}
+	if !IsCheckedIn(history, scan.SessionID) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Attendee is not checked in"})
+	}
+
+	checkOut := &types.CheckIn{
+		AttendeeID: attendee.ID,
+		EventID:    attendee.EventID,
+		SessionID:  scan.SessionID,
+		Type:       types.CheckInTypeCheckOut,
+		ScannedBy:  auth.GetUserIDFromContext(c),
+		Entrance:   scan.Entrance,
+		DeviceID:   scan.DeviceID,
+		ScannedAt:  time.Now(),
+	}
+	if err := h.checkInStore.RecordCheckIn(c.Context(), checkOut); err != nil {
+		log.Printf("Error checking out attendee ID %d: %v", attendee.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to check out attendee"})
+	}
+
+	h.publishUpdate(live.UpdateCheckOut, attendee.EventID, attendee)
+
+	return c.Status(fiber.StatusCreated).JSON(checkOut)
+}
+
+// Handler to undo a check-in or check-out recorded by mistake
+func (h *Handler) handleUndoCheckIn(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	checkInID, err := strconv.Atoi(c.Params("check_in_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid check-in ID"})
+	}
+
+	checkIn, err := h.checkInStore.GetCheckInByID(int32(checkInID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Check-in not found"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get check-in"})
+	}
+
+	if _, ferr := utils.GetOwnedEventByID(checkIn.EventID, userID, h.eventStore); ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	if err := h.checkInStore.UndoCheckIn(c.Context(), checkIn); err != nil {
+		log.Printf("Error undoing check-in ID %d: %v", checkIn.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to undo check-in"})
+	}
+
+	// Return the attendee with the attendance recomputed from the remaining history
+	attendee, err := h.attendeeStore.GetAttendeeByID(checkIn.AttendeeID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get attendee"})
+	}
+
+	h.publishUpdate(live.UpdateAttendee, attendee.EventID, attendee)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message":  "Check-in undone successfully",
+		"attendee": attendee,
+	})
+}
+
+// getOwnedAttendee loads the attendee of the request and checks that the current user owns its event
+func (h *Handler) getOwnedAttendee(c *fiber.Ctx) (*types.Attendee, *fiber.Error) {
+	userID := auth.GetUserIDFromContext(c)
+
+	attendeeID, err := strconv.Atoi(c.Params("attendee_id"))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid attendee ID")
+	}
+
+	attendee, err := h.attendeeStore.GetAttendeeByID(int32(attendeeID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Attendee not found")
+		}
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to get attendee")
+	}
+
+	if _, ferr := utils.GetOwnedEventByID(attendee.EventID, userID, h.eventStore); ferr != nil {
+		return nil, ferr
+	}
+
+	return attendee, nil
+}
+
+// publishUpdate pushes a check-in change together with fresh totals to the live dashboards of an event
+func (h *Handler) publishUpdate(updateType string, eventID int32, attendee *types.Attendee) {
+	if !h.hub.HasSubscribers(eventID) {
+		return
+	}
+
+	stats, err := h.attendeeStore.GetAttendanceStats(eventID)
+	if err != nil {
+		log.Printf("Error getting attendance stats for event ID %d: %v", eventID, err)
+		return
+	}
+
+	h.hub.Publish(live.Update{
+		Type:     updateType,
+		EventID:  eventID,
+		Attendee: attendee,
+		Stats:    stats,
+	})
+}
@@ -0,0 +1,21 @@
+package checkin
+
+import (
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+// IsCheckedIn tells whether an attendee is currently checked in, from their
+// check-in history oldest first. Without a session the last scan of the
+// event decides, whichever session it was made at; with a session only the
+// scans of that session count.
+func IsCheckedIn(history []*types.CheckIn, sessionID int32) bool {
+	checkedIn := false
+	for _, checkIn := range history {
+		if sessionID != 0 && checkIn.SessionID != sessionID {
+			continue
+		}
+		checkedIn = checkIn.Type == types.CheckInTypeCheckIn
+	}
+
+	return checkedIn
+}
@@ -0,0 +1,42 @@
+package checkin
+
+import (
+	"testing"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+func TestIsCheckedIn(t *testing.T) {
+	in := func(sessionID int32) *types.CheckIn {
+		return &types.CheckIn{SessionID: sessionID, Type: types.CheckInTypeCheckIn}
+	}
+	out := func(sessionID int32) *types.CheckIn {
+		return &types.CheckIn{SessionID: sessionID, Type: types.CheckInTypeCheckOut}
+	}
+
+	tests := []struct {
+		name      string
+		history   []*types.CheckIn
+		sessionID int32
+		want      bool
+	}{
+		{"never scanned", nil, 0, false},
+		{"checked in", []*types.CheckIn{in(0)}, 0, true},
+		{"checked out", []*types.CheckIn{in(0), out(0)}, 0, false},
+		{"checked out twice", []*types.CheckIn{in(0), out(0), out(0)}, 0, false},
+		{"checked in again", []*types.CheckIn{in(0), out(0), in(0)}, 0, true},
+		{"checked in at a session", []*types.CheckIn{in(3)}, 0, true},
+		{"left a session", []*types.CheckIn{in(0), out(3)}, 0, false},
+		{"session not scanned", []*types.CheckIn{in(0)}, 3, false},
+		{"checked in at the session", []*types.CheckIn{in(0), in(3), out(0)}, 3, true},
+		{"checked out of the session", []*types.CheckIn{in(3), out(3), in(4)}, 3, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsCheckedIn(tt.history, tt.sessionID); got != tt.want {
+				t.Errorf("IsCheckedIn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
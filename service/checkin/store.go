@@ -0,0 +1,211 @@
+package checkin
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/cmd/pkg/database"
+	"github.com/jayden1905/event-registration-software/db"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+type Store struct {
+	db   *database.Queries
+	conn *sql.DB
+}
+
+// NewStore initializes the Store with the database queries and the connection to run transactions on
+func NewStore(db *database.Queries, conn *sql.DB) *Store {
+	return &Store{db: db, conn: conn}
+}
+
+// GetSyncVersion returns the current version to hand out as a manifest cursor
+func (s *Store) GetSyncVersion(ctx context.Context) (int64, error) {
+	return s.db.GetSyncVersion(ctx)
+}
+
+// GetManifestAttendees fetches the attendees of an event changed since the given version
+func (s *Store) GetManifestAttendees(ctx context.Context, eventID int32, since int64) ([]*types.ManifestAttendee, error) {
+	attendees, err := s.db.GetAttendeesUpdatedSince(ctx, database.GetAttendeesUpdatedSinceParams{
+		EventID: eventID,
+		Since:   since,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := []*types.ManifestAttendee{}
+	for _, attendee := range attendees {
+		entry := &types.ManifestAttendee{
+			ID:        attendee.ID,
+			FirstName: attendee.FirstName,
+			LastName:  attendee.LastName,
+			TokenHash: TokenHash(attendee.EventID, attendee.Email),
+			TableNo:   attendee.TableNo.Int32,
+			Role:      attendee.Role.String,
+			CheckedIn: attendee.Attendance == database.AttendeesAttendanceYes,
+		}
+		if attendee.CheckedInAt.Valid {
+			entry.CheckedInAt = &attendee.CheckedInAt.Time
+		}
+		manifest = append(manifest, entry)
+	}
+
+	return manifest, nil
+}
+
+// GetDeletedManifestAttendees fetches the attendees of an event deleted since the given version
+func (s *Store) GetDeletedManifestAttendees(ctx context.Context, eventID int32, since int64) ([]types.DeletedManifestAttendee, error) {
+	deleted, err := s.db.GetDeletedAttendeesSince(ctx, database.GetDeletedAttendeesSinceParams{
+		EventID: eventID,
+		Since:   since,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tombstones := []types.DeletedManifestAttendee{}
+	for _, attendee := range deleted {
+		tombstones = append(tombstones, types.DeletedManifestAttendee{
+			ID:        attendee.AttendeeID,
+			TokenHash: TokenHash(attendee.EventID, attendee.Email),
+			DeletedAt: attendee.DeletedAt,
+		})
+	}
+
+	return tombstones, nil
+}
+
+// RecordCheckIn adds a scan to the check-in history and refreshes the
+// attendance of the attendee in the same transaction
+func (s *Store) RecordCheckIn(ctx context.Context, checkIn *types.CheckIn) error {
+	return db.WithTx(ctx, s.conn, s.db, func(q *database.Queries) error {
+		id, err := q.CreateCheckIn(ctx, database.CreateCheckInParams{
+			AttendeeID: checkIn.AttendeeID,
+			EventID:    checkIn.EventID,
+			SessionID:  sql.NullInt32{Int32: checkIn.SessionID, Valid: checkIn.SessionID != 0},
+			Type:       database.CheckInsType(checkIn.Type),
+			ScannedBy:  sql.NullInt32{Int32: checkIn.ScannedBy, Valid: checkIn.ScannedBy != 0},
+			Entrance:   sql.NullString{String: checkIn.Entrance, Valid: checkIn.Entrance != ""},
+			DeviceID:   sql.NullString{String: checkIn.DeviceID, Valid: checkIn.DeviceID != ""},
+			ScannedAt:  checkIn.ScannedAt,
+		})
+		if err != nil {
+			return err
+		}
+		checkIn.ID = int32(id)
+
+		return q.RefreshAttendeeAttendance(ctx, checkIn.AttendeeID)
+	})
+}
+
+// UndoCheckIn removes a scan from the history and refreshes the attendance
+// of the attendee in the same transaction
+func (s *Store) UndoCheckIn(ctx context.Context, checkIn *types.CheckIn) error {
+	return db.WithTx(ctx, s.conn, s.db, func(q *database.Queries) error {
+		if err := q.DeleteCheckIn(ctx, checkIn.ID); err != nil {
+			return err
+		}
+
+		return q.RefreshAttendeeAttendance(ctx, checkIn.AttendeeID)
+	})
+}
+
+// GetCheckInByID fetches a check-in history entry by ID
+func (s *Store) GetCheckInByID(checkInID int32) (*types.CheckIn, error) {
+	checkIn, err := s.db.GetCheckInByID(context.Background(), checkInID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCheckIn(checkIn), nil
+}
+
+// GetCheckInsByAttendeeID fetches the check-in history of an attendee, oldest first
+func (s *Store) GetCheckInsByAttendeeID(attendeeID int32) ([]*types.CheckIn, error) {
+	checkIns, err := s.db.GetCheckInsByAttendeeID(context.Background(), attendeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCheckIns(checkIns), nil
+}
+
+// GetCheckInsByEventID fetches the page of the check-in history of an event, or of one of its
+// sessions, after a cursor, newest first, and the cursor of the next page when there is one
+func (s *Store) GetCheckInsByEventID(eventID int32, sessionID int32, page *types.PageRequest) ([]*types.CheckIn, *types.Cursor, error) {
+	var afterID sql.NullInt32
+	var afterScannedAt sql.NullTime
+	if page.After != nil {
+		scannedAt, err := time.Parse(time.RFC3339Nano, page.After.Key)
+		if err != nil {
+			return nil, nil, types.ErrInvalidCursor
+		}
+		afterID = sql.NullInt32{Int32: int32(page.After.ID), Valid: true}
+		afterScannedAt = sql.NullTime{Time: scannedAt, Valid: true}
+	}
+
+	var checkIns []database.CheckIn
+	var err error
+	if sessionID != 0 {
+		checkIns, err = s.db.GetCheckInsBySessionID(context.Background(), database.GetCheckInsBySessionIDParams{
+			SessionID:      sql.NullInt32{Int32: sessionID, Valid: true},
+			AfterID:        afterID,
+			AfterScannedAt: afterScannedAt,
+			Limit:          page.Limit + 1,
+		})
+	} else {
+		checkIns, err = s.db.GetCheckInsByEventID(context.Background(), database.GetCheckInsByEventIDParams{
+			EventID:        eventID,
+			AfterID:        afterID,
+			AfterScannedAt: afterScannedAt,
+			Limit:          page.Limit + 1,
+		})
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The row past the limit only tells that there is a next page
+	history := toCheckIns(checkIns)
+	if len(history) <= int(page.Limit) {
+		return history, nil, nil
+	}
+	history = history[:page.Limit]
+	last := history[len(history)-1]
+
+	return history, &types.Cursor{Key: last.ScannedAt.Format(time.RFC3339Nano), ID: int64(last.ID)}, nil
+}
+
+// CountCheckInsByEventID counts the check-ins of an event, or of one of its sessions
+func (s *Store) CountCheckInsByEventID(eventID int32, sessionID int32) (int64, error) {
+	if sessionID != 0 {
+		return s.db.CountCheckInsBySessionID(context.Background(), sql.NullInt32{Int32: sessionID, Valid: true})
+	}
+
+	return s.db.CountCheckInsByEventID(context.Background(), eventID)
+}
+
+func toCheckIn(checkIn database.CheckIn) *types.CheckIn {
+	return &types.CheckIn{
+		ID:         checkIn.ID,
+		AttendeeID: checkIn.AttendeeID,
+		EventID:    checkIn.EventID,
+		SessionID:  checkIn.SessionID.Int32,
+		Type:       string(checkIn.Type),
+		ScannedBy:  checkIn.ScannedBy.Int32,
+		Entrance:   checkIn.Entrance.String,
+		DeviceID:   checkIn.DeviceID.String,
+		ScannedAt:  checkIn.ScannedAt,
+	}
+}
+
+func toCheckIns(checkIns []database.CheckIn) []*types.CheckIn {
+	history := []*types.CheckIn{}
+	for _, checkIn := range checkIns {
+		history = append(history, toCheckIn(checkIn))
+	}
+
+	return history
+}
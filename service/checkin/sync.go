@@ -0,0 +1,109 @@
+package checkin
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+// maxClockSkew is how far in the future a device clock may be before its scans are rejected
+const maxClockSkew = 5 * time.Minute
+
+// TokenHash hashes the QR code content of an attendee (their email) for the
+// device manifest, so devices can match scans without storing the emails.
+// Devices compute hex(sha256("<event id>:<lower-cased email>")) of a scan.
+func TokenHash(eventID int32, email string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d:%s", eventID, strings.ToLower(strings.TrimSpace(email)))))
+	return hex.EncodeToString(sum[:])
+}
+
+// Resolve decides the outcome of a batch of offline check-ins against the
+// current attendees of the event. Scans are applied in the order they were
+// made, so the earliest scan of an attendee checks them in and later ones are
+// duplicates; a scan earlier than an already stored check-in moves the
+// check-in time back. Scans of deleted attendees, unknown attendees and scans
+// from the future are not applied. It returns the results in upload order and
+// the check-in time to store per attendee.
+func Resolve(checkIns []types.OfflineCheckIn, attendees []*types.ManifestAttendee, deleted []types.DeletedManifestAttendee, now time.Time) ([]types.CheckInResult, map[int32]time.Time) {
+	byID := make(map[int32]*types.ManifestAttendee, len(attendees))
+	byHash := make(map[string]*types.ManifestAttendee, len(attendees))
+	for _, attendee := range attendees {
+		byID[attendee.ID] = attendee
+		byHash[attendee.TokenHash] = attendee
+	}
+
+	deletedIDs := make(map[int32]bool, len(deleted))
+	deletedHashes := make(map[string]bool, len(deleted))
+	for _, attendee := range deleted {
+		deletedIDs[attendee.ID] = true
+		deletedHashes[attendee.TokenHash] = true
+	}
+
+	order := make([]int, len(checkIns))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return checkIns[order[i]].ScannedAt.Before(checkIns[order[j]].ScannedAt)
+	})
+
+	// checkedInAt holds the check-in time of every attendee as the batch is applied
+	checkedInAt := make(map[int32]*time.Time, len(attendees))
+	for _, attendee := range attendees {
+		if attendee.CheckedIn {
+			checkedInAt[attendee.ID] = attendee.CheckedInAt
+		}
+	}
+
+	results := make([]types.CheckInResult, len(checkIns))
+	marks := make(map[int32]time.Time)
+	for _, i := range order {
+		checkIn := checkIns[i]
+		result := types.CheckInResult{ClientID: checkIn.ClientID, AttendeeID: checkIn.AttendeeID}
+
+		attendee := byID[checkIn.AttendeeID]
+		if checkIn.AttendeeID == 0 {
+			attendee = byHash[checkIn.TokenHash]
+		}
+
+		switch {
+		case checkIn.ScannedAt.IsZero() || checkIn.ScannedAt.After(now.Add(maxClockSkew)):
+			result.Status = types.CheckInStatusInvalid
+			result.Message = "Scan time is missing or in the future"
+		case attendee == nil && (deletedIDs[checkIn.AttendeeID] || (checkIn.AttendeeID == 0 && deletedHashes[checkIn.TokenHash])):
+			result.Status = types.CheckInStatusDeleted
+			result.Message = "Attendee was deleted"
+		case attendee == nil:
+			result.Status = types.CheckInStatusUnknown
+			result.Message = "Attendee not found"
+		default:
+			result.AttendeeID = attendee.ID
+			scannedAt := checkIn.ScannedAt
+
+			at, checkedIn := checkedInAt[attendee.ID]
+			switch {
+			case !checkedIn:
+				result.Status = types.CheckInStatusCheckedIn
+				checkedInAt[attendee.ID] = &scannedAt
+				marks[attendee.ID] = scannedAt
+			case at != nil && scannedAt.Before(*at):
+				// Another device or an online scan got there later, keep the earliest time
+				result.Status = types.CheckInStatusDuplicate
+				checkedInAt[attendee.ID] = &scannedAt
+				marks[attendee.ID] = scannedAt
+			default:
+				result.Status = types.CheckInStatusDuplicate
+			}
+			result.CheckedInAt = checkedInAt[attendee.ID]
+		}
+
+		results[i] = result
+	}
+
+	return results, marks
+}
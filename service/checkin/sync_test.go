@@ -0,0 +1,52 @@
+package checkin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+func TestResolveOfflineCheckIns(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	onlineAt := now.Add(-10 * time.Minute)
+
+	attendees := []*types.ManifestAttendee{
+		{ID: 1, TokenHash: TokenHash(7, "ada@example.com")},
+		{ID: 2, TokenHash: TokenHash(7, "bob@example.com"), CheckedIn: true, CheckedInAt: &onlineAt},
+	}
+	deleted := []types.DeletedManifestAttendee{{ID: 3, TokenHash: TokenHash(7, "cy@example.com")}}
+
+	results, marks := Resolve([]types.OfflineCheckIn{
+		{ClientID: "a", TokenHash: TokenHash(7, " Ada@Example.com"), ScannedAt: now.Add(-5 * time.Minute)},
+		{ClientID: "b", AttendeeID: 1, ScannedAt: now.Add(-30 * time.Minute)},
+		{ClientID: "c", AttendeeID: 2, ScannedAt: now.Add(-20 * time.Minute)},
+		{ClientID: "d", AttendeeID: 3, ScannedAt: now},
+		{ClientID: "e", AttendeeID: 4, ScannedAt: now},
+		{ClientID: "f", AttendeeID: 1, ScannedAt: now.Add(time.Hour)},
+	}, attendees, deleted, now)
+
+	expected := []string{
+		types.CheckInStatusDuplicate,
+		types.CheckInStatusCheckedIn,
+		types.CheckInStatusDuplicate,
+		types.CheckInStatusDeleted,
+		types.CheckInStatusUnknown,
+		types.CheckInStatusInvalid,
+	}
+	for i, status := range expected {
+		if results[i].Status != status {
+			t.Errorf("result %s: expected %s, got %s", results[i].ClientID, status, results[i].Status)
+		}
+	}
+
+	if results[0].AttendeeID != 1 {
+		t.Errorf("expected the token hash to resolve to attendee 1, got %d", results[0].AttendeeID)
+	}
+	if !marks[1].Equal(now.Add(-30 * time.Minute)) {
+		t.Errorf("expected the earliest scan to check attendee 1 in, got %v", marks[1])
+	}
+	if !marks[2].Equal(now.Add(-20 * time.Minute)) {
+		t.Errorf("expected the earlier offline scan to replace the online check-in time, got %v", marks[2])
+	}
+}
@@ -0,0 +1,242 @@
+package clone
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/service/plan"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+// options chooses what is copied from an event besides its details
+type options struct {
+	emailTemplates bool
+	customFields   bool
+	seatingPlan    bool
+}
+
+// copyOptions resolves the options of a clone, everything but the attendees
+// is copied unless it is turned off
+func copyOptions(payload types.CloneEventPayload) options {
+	enabled := func(option *bool) bool {
+		return option == nil || *option
+	}
+
+	return options{
+		emailTemplates: enabled(payload.CopyEmailTemplates),
+		customFields:   enabled(payload.CopyCustomFields),
+		seatingPlan:    enabled(payload.CopySeatingPlan),
+	}
+}
+
+// newBlueprint keeps what can be copied from an event, without the IDs,
+// timestamps and counts that belong to the original
+func newBlueprint(event *types.Event, templates []*types.EmailTemplate, fields []*types.EventCustomField, tables []*types.EventTable) *types.EventBlueprint {
+	blueprint := &types.EventBlueprint{
+		Title:        event.Title,
+		Description:  event.Description,
+		Location:     event.Location,
+		DurationSecs: int64(event.EndDate.Sub(event.StartDate) / time.Second),
+	}
+
+	for _, template := range templates {
+		blueprint.EmailTemplates = append(blueprint.EmailTemplates, &types.EmailTemplate{
+			Purpose:     template.Purpose,
+			HeaderImage: template.HeaderImage,
+			Content:     template.Content,
+			FooterImage: template.FooterImage,
+			Subject:     template.Subject,
+			BgColor:     template.BgColor,
+			Message:     template.Message,
+		})
+	}
+
+	for _, field := range fields {
+		blueprint.CustomFields = append(blueprint.CustomFields, &types.EventCustomField{
+			Name:      field.Name,
+			FieldType: field.FieldType,
+			Required:  field.Required,
+			Position:  field.Position,
+		})
+	}
+
+	for _, table := range tables {
+		blueprint.Tables = append(blueprint.Tables, &types.EventTable{
+			TableNo:  table.TableNo,
+			Name:     table.Name,
+			Capacity: table.Capacity,
+		})
+	}
+
+	return blueprint
+}
+
+// schedule works out the dates of a new event, without an end date the event
+// lasts as long as the blueprint
+func schedule(blueprint *types.EventBlueprint, start time.Time, end time.Time) (time.Time, time.Time) {
+	if end.IsZero() {
+		end = start.Add(time.Duration(blueprint.DurationSecs) * time.Second)
+	}
+
+	return start, end
+}
+
+// blueprint collects what is copied from an event
+func (h *Handler) blueprint(ctx context.Context, event *types.Event, opts options) (*types.EventBlueprint, error) {
+	var templates []*types.EmailTemplate
+	if opts.emailTemplates {
+		found, err := h.emailStore.GetEmailTemplatesByEventID(ctx, event.EventID)
+		if err != nil {
+			return nil, err
+		}
+		templates = found
+	}
+
+	var fields []*types.EventCustomField
+	if opts.customFields {
+		found, err := h.customFieldStore.GetCustomFields(event.EventID)
+		if err != nil {
+			return nil, err
+		}
+		fields = found
+	}
+
+	var tables []*types.EventTable
+	if opts.seatingPlan {
+		found, err := h.seatingStore.GetEventTables(event.EventID)
+		if err != nil {
+			return nil, err
+		}
+		tables = found
+	}
+
+	return newBlueprint(event, templates, fields, tables), nil
+}
+
+// createEvent creates an event for the user from a blueprint. When a part of
+// the blueprint cannot be created the new event is deleted again.
+func (h *Handler) createEvent(ctx context.Context, userID int32, blueprint *types.EventBlueprint, title string, start time.Time, end time.Time) (*types.Event, error) {
+	event := &types.Event{
+		Title:       title,
+		Description: blueprint.Description,
+		StartDate:   start,
+		EndDate:     end,
+		Location:    blueprint.Location,
+		UserID:      userID,
+	}
+	if err := h.eventStore.CreateNewEvent(ctx, event); err != nil {
+		return nil, err
+	}
+
+	if err := h.applyBlueprint(ctx, event, blueprint); err != nil {
+		return nil, errors.Join(err, h.eventStore.DeleteEvent(ctx, event.EventID))
+	}
+
+	return h.eventStore.GetEventByID(event.EventID)
+}
+
+// applyBlueprint creates the email templates, custom fields and tables of a blueprint for an event
+func (h *Handler) applyBlueprint(ctx context.Context, event *types.Event, blueprint *types.EventBlueprint) error {
+	for _, emailTemplate := range blueprint.EmailTemplates {
+		template := *emailTemplate
+		template.EventID = event.EventID
+
+		// Branding is only kept when the plan of the user still includes it
+		if template.HeaderImage != "" || template.FooterImage != "" || template.BgColor != "" {
+			if ferr := h.enforcer.CheckBranding(event.UserID); ferr != nil {
+				template = *plan.Unbranded(&template)
+			}
+		}
+
+		if err := h.emailStore.CreateEmailTemplate(ctx, &template); err != nil {
+			return fmt.Errorf("failed to copy %s email template: %w", template.Purpose, err)
+		}
+	}
+
+	for _, field := range blueprint.CustomFields {
+		copied := *field
+		copied.EventID = event.EventID
+		if err := h.customFieldStore.CreateCustomField(ctx, &copied); err != nil {
+			return fmt.Errorf("failed to copy custom field %q: %w", field.Name, err)
+		}
+	}
+
+	for _, table := range blueprint.Tables {
+		copied := *table
+		copied.EventID = event.EventID
+		if err := h.seatingStore.CreateEventTable(ctx, &copied); err != nil {
+			return fmt.Errorf("failed to copy table %d: %w", table.TableNo, err)
+		}
+	}
+
+	return nil
+}
+
+// copyAttendees registers the attendees of one event for another with their
+// attendance reset and a new qr code each. Table numbers are kept only with
+// the seating plan. The attendees that could not be copied are returned.
+func (h *Handler) copyAttendees(ctx context.Context, attendees []*types.Attendee, to *types.Event, keepTables bool) (int, []*types.Attendee) {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	copied := 0
+	failed := []*types.Attendee{}
+
+	for _, attendee := range attendees {
+		wg.Add(1)
+
+		go func(original *types.Attendee) {
+			defer wg.Done()
+
+			err := h.copyAttendee(ctx, original, to, keepTables)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				log.Printf("Error copying attendee ID %d to event ID %d: %v", original.ID, to.EventID, err)
+				failed = append(failed, original)
+				return
+			}
+			copied++
+		}(attendee)
+	}
+
+	wg.Wait()
+
+	return copied, failed
+}
+
+// copyAttendee registers a copy of an attendee for an event
+func (h *Handler) copyAttendee(ctx context.Context, original *types.Attendee, to *types.Event, keepTables bool) error {
+	qrCode, err := h.generateQRCode(original.Email)
+	if err != nil {
+		return fmt.Errorf("failed to generate QR code: %w", err)
+	}
+
+	attendee := &types.Attendee{
+		FirstName:   original.FirstName,
+		LastName:    original.LastName,
+		Email:       original.Email,
+		EventID:     to.EventID,
+		QrCode:      qrCode,
+		CompanyName: original.CompanyName,
+		Title:       original.Title,
+		Role:        original.Role,
+		Attendance:  false,
+	}
+	if keepTables {
+		attendee.TableNo = original.TableNo
+	}
+
+	if err := h.attendeeStore.CreateAttendee(ctx, attendee); err != nil {
+		if deleteErr := h.deleteQRCode(qrCode); deleteErr != nil {
+			log.Printf("Error deleting QR code of failed copy of attendee ID %d: %v", original.ID, deleteErr)
+		}
+		return err
+	}
+
+	return h.customFieldStore.CopyAttendeeCustomFields(ctx, original.ID, attendee.ID)
+}
@@ -0,0 +1,111 @@
+package clone
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+func TestCopyOptionsDefaultToCopying(t *testing.T) {
+	opts := copyOptions(types.CloneEventPayload{Title: "Dinner"})
+	if !opts.emailTemplates || !opts.customFields || !opts.seatingPlan {
+		t.Errorf("expected everything to be copied by default, got %+v", opts)
+	}
+
+	off := false
+	opts = copyOptions(types.CloneEventPayload{Title: "Dinner", CopySeatingPlan: &off})
+	if !opts.emailTemplates || !opts.customFields || opts.seatingPlan {
+		t.Errorf("expected only the seating plan to be left out, got %+v", opts)
+	}
+}
+
+func TestNewBlueprintDropsWhatBelongsToTheOriginal(t *testing.T) {
+	start := time.Date(2026, 9, 1, 18, 0, 0, 0, time.UTC)
+	event := &types.Event{
+		EventID:     7,
+		Title:       "Quarterly dinner",
+		Description: "Dinner with the partners",
+		Location:    "Main hall",
+		StartDate:   start,
+		EndDate:     start.Add(4 * time.Hour),
+		UserID:      3,
+	}
+	templates := []*types.EmailTemplate{
+		{ID: 11, EventID: 7, Purpose: types.EmailPurposeInvitation, Subject: "You are invited", Content: "<p>Hi</p>", BgColor: "#fff"},
+		{ID: 12, EventID: 7, Purpose: types.EmailPurposeReminder, Subject: "See you tomorrow", Content: "<p>Soon</p>"},
+	}
+	fields := []*types.EventCustomField{{ID: 5, EventID: 7, Name: "Dietary needs", FieldType: "text", Position: 1}}
+	tables := []*types.EventTable{{ID: 9, EventID: 7, TableNo: 1, Name: "Head table", Capacity: 10, Seated: 8, OverCapacity: false}}
+
+	blueprint := newBlueprint(event, templates, fields, tables)
+
+	if blueprint.Title != event.Title || blueprint.Location != event.Location || blueprint.DurationSecs != 4*60*60 {
+		t.Errorf("expected the event details to be kept, got %+v", blueprint)
+	}
+	if len(blueprint.EmailTemplates) != 2 {
+		t.Fatalf("expected both email templates, got %d", len(blueprint.EmailTemplates))
+	}
+	if template := blueprint.EmailTemplates[0]; template.ID != 0 || template.EventID != 0 || template.Purpose != types.EmailPurposeInvitation || template.Subject != "You are invited" || template.BgColor != "#fff" {
+		t.Errorf("expected the template content without its IDs, got %+v", template)
+	}
+	if template := blueprint.EmailTemplates[1]; template.Purpose != types.EmailPurposeReminder {
+		t.Errorf("expected the reminder to keep its purpose, got %+v", template)
+	}
+	if field := blueprint.CustomFields[0]; field.ID != 0 || field.EventID != 0 || field.Name != "Dietary needs" {
+		t.Errorf("expected the custom field without its IDs, got %+v", field)
+	}
+	if table := blueprint.Tables[0]; table.ID != 0 || table.EventID != 0 || table.Seated != 0 || table.Capacity != 10 {
+		t.Errorf("expected the table without its IDs and occupancy, got %+v", table)
+	}
+
+	if blueprint := newBlueprint(event, nil, nil, nil); blueprint.EmailTemplates != nil || blueprint.CustomFields != nil || blueprint.Tables != nil {
+		t.Errorf("expected only the event details, got %+v", blueprint)
+	}
+}
+
+func TestScheduleKeepsTheLengthOfTheEvent(t *testing.T) {
+	blueprint := &types.EventBlueprint{DurationSecs: 3 * 60 * 60}
+	start := time.Date(2026, 12, 1, 18, 0, 0, 0, time.UTC)
+
+	_, end := schedule(blueprint, start, time.Time{})
+	if want := start.Add(3 * time.Hour); !end.Equal(want) {
+		t.Errorf("expected end %v, got %v", want, end)
+	}
+
+	chosen := start.Add(time.Hour)
+	if _, end := schedule(blueprint, start, chosen); !end.Equal(chosen) {
+		t.Errorf("expected the chosen end %v, got %v", chosen, end)
+	}
+}
+
+// failingAttendeeStore rejects every attendee
+type failingAttendeeStore struct {
+	types.AttendeeStore
+}
+
+func (failingAttendeeStore) CreateAttendee(ctx context.Context, attendee *types.Attendee) error {
+	return errors.New("duplicate email")
+}
+
+func TestCopyAttendeeDeletesQRCodeWhenCreateFails(t *testing.T) {
+	var deleted []string
+	h := &Handler{
+		attendeeStore:  failingAttendeeStore{},
+		generateQRCode: func(data string) (string, error) { return "https://example.com/qr/" + data, nil },
+		deleteQRCode: func(url string) error {
+			deleted = append(deleted, url)
+			return nil
+		},
+	}
+
+	err := h.copyAttendee(context.Background(), &types.Attendee{ID: 4, Email: "ada@example.com"}, &types.Event{EventID: 9}, false)
+	if err == nil {
+		t.Fatal("expected the copy to fail")
+	}
+	if len(deleted) != 1 || deleted[0] != "https://example.com/qr/ada@example.com" {
+		t.Errorf("expected the uploaded QR code to be deleted, got %v", deleted)
+	}
+}
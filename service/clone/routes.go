@@ -0,0 +1,331 @@
+package clone
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/service/audit"
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/service/plan"
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+type Handler struct {
+	store            types.EventTemplateStore
+	eventStore       types.EventStore
+	emailStore       types.EmailTempalteStore
+	customFieldStore types.CustomFieldStore
+	seatingStore     types.SeatingStore
+	attendeeStore    types.AttendeeStore
+	userStore        types.UserStore
+	enforcer         *plan.Enforcer
+	// generateQRCode creates and stores the qr code of a copied attendee
+	generateQRCode func(data string) (string, error)
+	// deleteQRCode removes a stored qr code no attendee was created with
+	deleteQRCode func(url string) error
+}
+
+func NewHandler(store types.EventTemplateStore, eventStore types.EventStore, emailStore types.EmailTempalteStore, customFieldStore types.CustomFieldStore, seatingStore types.SeatingStore, attendeeStore types.AttendeeStore, userStore types.UserStore, enforcer *plan.Enforcer) *Handler {
+	return &Handler{
+		store:            store,
+		eventStore:       eventStore,
+		emailStore:       emailStore,
+		customFieldStore: customFieldStore,
+		seatingStore:     seatingStore,
+		attendeeStore:    attendeeStore,
+		userStore:        userStore,
+		enforcer:         enforcer,
+		generateQRCode:   utils.GenerateQRCodeImage,
+		deleteQRCode:     utils.DeleteQrImageFromCloudinary,
+	}
+}
+
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Post("/event/:id/clone", auth.WithJWTAuth(h.handleCloneEvent, h.userStore))
+	router.Post("/event/:id/save_as_template", auth.WithJWTAuth(h.handleSaveEventTemplate, h.userStore))
+	router.Get("/event_templates", auth.WithJWTAuth(h.handleGetEventTemplates, h.userStore))
+	router.Get("/event_templates/:id", auth.WithJWTAuth(h.handleGetEventTemplate, h.userStore))
+	router.Delete("/event_templates/:id", auth.WithJWTAuth(h.handleDeleteEventTemplate, h.userStore))
+	router.Post("/event_templates/:id/create_event", auth.WithJWTAuth(h.handleCreateEventFromTemplate, h.userStore))
+}
+
+// Handler to create a copy of an event, optionally with its attendees
+func (h *Handler) handleCloneEvent(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	source, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var payload types.CloneEventPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	if _, err := h.eventStore.GetEventByTitle(payload.Title); err == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Event with the same title already exists"})
+	}
+
+	if ferr := h.enforcer.CheckEvents(userID); ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var attendees []*types.Attendee
+	if payload.CopyAttendees {
+		found, err := h.attendeeStore.GetAllAttendees(source.EventID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get attendees"})
+		}
+		attendees = found
+
+		// The copy is a new event, so its attendees count from zero
+		if ferr := h.enforcer.CheckAttendees(&types.Event{UserID: userID}, len(attendees)); ferr != nil {
+			return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+		}
+	}
+
+	opts := copyOptions(payload)
+	blueprint, err := h.blueprint(c.Context(), source, opts)
+	if err != nil {
+		log.Printf("Error reading event ID %d to clone: %v", source.EventID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to read the event to clone"})
+	}
+
+	start, end := source.StartDate, source.EndDate
+	if payload.StartDate != nil {
+		start, end = *payload.StartDate, *payload.EndDate
+	}
+
+	event, err := h.createEvent(c.Context(), userID, blueprint, payload.Title, start, end)
+	if err != nil {
+		log.Printf("Error cloning event ID %d: %v", source.EventID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to clone event"})
+	}
+
+	copied, failed := 0, []*types.Attendee{}
+	if len(attendees) > 0 {
+		copied, failed = h.copyAttendees(c.Context(), attendees, event, opts.seatingPlan)
+	}
+
+	audit.Note(c, audit.Mutation{
+		Action:       "event.clone",
+		TargetType:   "event",
+		TargetID:     event.EventID,
+		EventID:      event.EventID,
+		EventOwnerID: userID,
+		After:        event,
+		Details: map[string]any{
+			"source_event_id":  source.EventID,
+			"email_templates":  len(blueprint.EmailTemplates),
+			"custom_fields":    len(blueprint.CustomFields),
+			"tables":           len(blueprint.Tables),
+			"attendees_copied": copied,
+			"attendees_failed": len(failed),
+		},
+	})
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"event":            event,
+		"attendees_copied": copied,
+		"attendees_failed": failed,
+	})
+}
+
+// Handler to save an event as a template to create new events from
+func (h *Handler) handleSaveEventTemplate(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var payload types.SaveEventTemplatePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	blueprint, err := h.blueprint(c.Context(), event, options{emailTemplates: true, customFields: true, seatingPlan: true})
+	if err != nil {
+		log.Printf("Error reading event ID %d to save as a template: %v", event.EventID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to read the event"})
+	}
+
+	template := &types.EventTemplate{
+		UserID:    userID,
+		Name:      payload.Name,
+		Blueprint: *blueprint,
+	}
+	if err := h.store.CreateEventTemplate(c.Context(), template); err != nil {
+		if isDuplicateName(err) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "An event template with this name already exists"})
+		}
+		log.Printf("Error saving event ID %d as a template: %v", event.EventID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to save event template"})
+	}
+
+	audit.Note(c, audit.Mutation{
+		Action:       "event_template.create",
+		TargetType:   "event_template",
+		TargetID:     template.ID,
+		EventID:      event.EventID,
+		EventOwnerID: userID,
+		After:        template,
+	})
+
+	return c.Status(fiber.StatusCreated).JSON(template)
+}
+
+// Handler to list the event templates of the user
+func (h *Handler) handleGetEventTemplates(c *fiber.Ctx) error {
+	page, ferr := utils.ParsePage(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	userID := auth.GetUserIDFromContext(c)
+
+	templates, err := h.store.GetEventTemplates(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get event templates"})
+	}
+
+	list, ferr := utils.SlicePage(templates, page, func(template *types.EventTemplate) int64 { return int64(template.ID) }, nil)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
+// Handler to get an event template
+func (h *Handler) handleGetEventTemplate(c *fiber.Ctx) error {
+	template, ferr := h.getOwnedTemplate(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(template)
+}
+
+// Handler to delete an event template
+func (h *Handler) handleDeleteEventTemplate(c *fiber.Ctx) error {
+	template, ferr := h.getOwnedTemplate(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	if err := h.store.DeleteEventTemplate(c.Context(), template.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to delete event template"})
+	}
+
+	audit.Note(c, audit.Mutation{Action: "event_template.delete", TargetType: "event_template", TargetID: template.ID, EventOwnerID: template.UserID, Before: template})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Event template deleted successfully"})
+}
+
+// Handler to create an event from an event template
+func (h *Handler) handleCreateEventFromTemplate(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	template, ferr := h.getOwnedTemplate(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var payload types.CreateEventFromTemplatePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	title := payload.Title
+	if title == "" {
+		title = template.Blueprint.Title
+	}
+	if _, err := h.eventStore.GetEventByTitle(title); err == nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Event with the same title already exists"})
+	}
+
+	if ferr := h.enforcer.CheckEvents(userID); ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	start, end := schedule(&template.Blueprint, payload.StartDate, payload.EndDate)
+	event, err := h.createEvent(c.Context(), userID, &template.Blueprint, title, start, end)
+	if err != nil {
+		log.Printf("Error creating an event from template ID %d: %v", template.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create event"})
+	}
+
+	audit.Note(c, audit.Mutation{
+		Action:       "event.create",
+		TargetType:   "event",
+		TargetID:     event.EventID,
+		EventID:      event.EventID,
+		EventOwnerID: userID,
+		After:        event,
+		Details:      map[string]any{"event_template_id": template.ID},
+	})
+
+	return c.Status(fiber.StatusCreated).JSON(event)
+}
+
+// getOwnedTemplate fetches the event template in the route and checks that it belongs to the user
+func (h *Handler) getOwnedTemplate(c *fiber.Ctx) (*types.EventTemplate, *fiber.Error) {
+	userID := auth.GetUserIDFromContext(c)
+
+	templateID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid event template ID")
+	}
+
+	template, err := h.store.GetEventTemplateByID(int32(templateID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Event template not found")
+		}
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to get event template")
+	}
+
+	if template.UserID != userID {
+		return nil, fiber.NewError(fiber.StatusUnauthorized, "Unauthorized")
+	}
+
+	return template, nil
+}
+
+// isDuplicateName reports whether an insert hit the unique event template name of a user
+func isDuplicateName(err error) bool {
+	return strings.Contains(err.Error(), "event_templates_user_id_name_unique")
+}
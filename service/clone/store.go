@@ -0,0 +1,88 @@
+package clone
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jayden1905/event-registration-software/cmd/pkg/database"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// CreateEventTemplate saves an event template with its blueprint
+func (s *Store) CreateEventTemplate(ctx context.Context, template *types.EventTemplate) error {
+	blueprint, err := json.Marshal(template.Blueprint)
+	if err != nil {
+		return err
+	}
+
+	id, err := s.db.CreateEventTemplate(ctx, database.CreateEventTemplateParams{
+		UserID:    template.UserID,
+		Name:      template.Name,
+		Blueprint: blueprint,
+	})
+	if err != nil {
+		return err
+	}
+	template.ID = int32(id)
+
+	return nil
+}
+
+// GetEventTemplates fetches the event templates of a user by name
+func (s *Store) GetEventTemplates(userID int32) ([]*types.EventTemplate, error) {
+	rows, err := s.db.GetEventTemplatesByUserID(context.Background(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var templates []*types.EventTemplate
+	for _, row := range rows {
+		template, err := toEventTemplate(row)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
+
+// GetEventTemplateByID fetches an event template by its ID
+func (s *Store) GetEventTemplateByID(id int32) (*types.EventTemplate, error) {
+	row, err := s.db.GetEventTemplateByID(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toEventTemplate(row)
+}
+
+// DeleteEventTemplate deletes an event template, events created from it are kept
+func (s *Store) DeleteEventTemplate(ctx context.Context, id int32) error {
+	return s.db.DeleteEventTemplate(ctx, id)
+}
+
+// toEventTemplate converts a database event template into the event template type
+func toEventTemplate(row database.EventTemplate) (*types.EventTemplate, error) {
+	template := &types.EventTemplate{
+		ID:        row.ID,
+		UserID:    row.UserID,
+		Name:      row.Name,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+	if err := json.Unmarshal(row.Blueprint, &template.Blueprint); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
@@ -0,0 +1,127 @@
+package customfield
+
+import (
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/service/audit"
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+type Handler struct {
+	store      types.CustomFieldStore
+	eventStore types.EventStore
+	userStore  types.UserStore
+}
+
+func NewHandler(store types.CustomFieldStore, eventStore types.EventStore, userStore types.UserStore) *Handler {
+	return &Handler{store: store, eventStore: eventStore, userStore: userStore}
+}
+
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/event/:id/custom_fields", auth.WithJWTAuth(h.handleGetCustomFields, h.userStore))
+	router.Post("/event/:id/custom_fields", auth.WithJWTAuth(h.handleCreateCustomField, h.userStore))
+	router.Delete("/event/:id/custom_fields/:field_id", auth.WithJWTAuth(h.handleDeleteCustomField, h.userStore))
+}
+
+// Handler to list the custom fields of an event
+func (h *Handler) handleGetCustomFields(c *fiber.Ctx) error {
+	page, ferr := utils.ParsePage(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	fields, err := h.store.GetCustomFields(event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get custom fields"})
+	}
+
+	list, ferr := utils.SlicePage(fields, page, func(field *types.EventCustomField) int64 { return int64(field.ID) }, nil)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
+// Handler to add a custom field to an event
+func (h *Handler) handleCreateCustomField(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var payload types.CreateCustomFieldPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	field := &types.EventCustomField{
+		EventID:   event.EventID,
+		Name:      payload.Name,
+		FieldType: payload.FieldType,
+		Required:  payload.Required,
+		Position:  payload.Position,
+	}
+	if err := h.store.CreateCustomField(c.Context(), field); err != nil {
+		if isDuplicateName(err) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "A custom field with this name already exists"})
+		}
+		log.Printf("Error creating custom field for event ID %d: %v", event.EventID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create custom field"})
+	}
+
+	audit.Note(c, audit.Mutation{Action: "custom_field.create", TargetType: "custom_field", TargetID: field.ID, EventID: event.EventID, EventOwnerID: event.UserID, After: field})
+
+	return c.Status(fiber.StatusCreated).JSON(field)
+}
+
+// Handler to remove a custom field from an event
+func (h *Handler) handleDeleteCustomField(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	fieldID, err := strconv.Atoi(c.Params("field_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid custom field ID"})
+	}
+
+	field, err := h.store.GetCustomFieldByID(int32(fieldID))
+	if err != nil || field.EventID != event.EventID {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Custom field not found"})
+	}
+
+	if err := h.store.DeleteCustomField(c.Context(), field.ID); err != nil {
+		log.Printf("Error deleting custom field ID %d: %v", field.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to delete custom field"})
+	}
+
+	audit.Note(c, audit.Mutation{Action: "custom_field.delete", TargetType: "custom_field", TargetID: field.ID, EventID: event.EventID, EventOwnerID: event.UserID, Before: field})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Custom field deleted successfully"})
+}
+
+// isDuplicateName reports whether an insert hit the unique custom field name of an event
+func isDuplicateName(err error) bool {
+	return strings.Contains(err.Error(), "event_custom_fields_event_id_name_unique")
+}
@@ -0,0 +1,130 @@
+package customfield
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jayden1905/event-registration-software/cmd/pkg/database"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// CreateCustomField adds a custom field to an event
+func (s *Store) CreateCustomField(ctx context.Context, field *types.EventCustomField) error {
+	if field.FieldType == "" {
+		field.FieldType = "text"
+	}
+
+	id, err := s.db.CreateEventCustomField(ctx, database.CreateEventCustomFieldParams{
+		EventID:   field.EventID,
+		Name:      field.Name,
+		FieldType: field.FieldType,
+		Required:  field.Required,
+		Position:  field.Position,
+	})
+	if err != nil {
+		return err
+	}
+	field.ID = int32(id)
+
+	return nil
+}
+
+// GetCustomFields fetches the custom fields of an event in the order they are shown
+func (s *Store) GetCustomFields(eventID int32) ([]*types.EventCustomField, error) {
+	rows, err := s.db.GetEventCustomFieldsByEventID(context.Background(), eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []*types.EventCustomField
+	for _, row := range rows {
+		fields = append(fields, toCustomField(row))
+	}
+
+	return fields, nil
+}
+
+// GetCustomFieldByID fetches a custom field by its ID
+func (s *Store) GetCustomFieldByID(id int32) (*types.EventCustomField, error) {
+	row, err := s.db.GetEventCustomFieldByID(context.Background(), id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toCustomField(row), nil
+}
+
+// DeleteCustomField removes a custom field from an event
+func (s *Store) DeleteCustomField(ctx context.Context, id int32) error {
+	return s.db.DeleteEventCustomField(ctx, id)
+}
+
+// CopyAttendeeCustomFields copies the custom field values of one attendee to another
+func (s *Store) CopyAttendeeCustomFields(ctx context.Context, fromAttendeeID int32, toAttendeeID int32) error {
+	return s.db.CopyAttendeeCustomFields(ctx, database.CopyAttendeeCustomFieldsParams{
+		ToAttendeeID:   toAttendeeID,
+		FromAttendeeID: fromAttendeeID,
+	})
+}
+
+// GetAttendeeCustomFieldValues fetches the custom field values of an attendee
+func (s *Store) GetAttendeeCustomFieldValues(attendeeID int32) ([]*types.AttendeeCustomFieldValue, error) {
+	rows, err := s.db.GetAttendeeCustomFieldValues(context.Background(), attendeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []*types.AttendeeCustomFieldValue
+	for _, row := range rows {
+		values = append(values, &types.AttendeeCustomFieldValue{
+			Name:      row.FieldName.String,
+			FieldType: row.FieldType.String,
+			Value:     row.FieldValue.String,
+		})
+	}
+
+	return values, nil
+}
+
+// SetAttendeeCustomFieldValue replaces the value an attendee gave for a custom field
+func (s *Store) SetAttendeeCustomFieldValue(ctx context.Context, attendeeID int32, value *types.AttendeeCustomFieldValue) error {
+	name := sql.NullString{String: value.Name, Valid: true}
+
+	err := s.db.DeleteAttendeeCustomFieldValue(ctx, database.DeleteAttendeeCustomFieldValueParams{
+		AttendeeID: attendeeID,
+		FieldName:  name,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.db.CreateAttendeeCustomFieldValue(ctx, database.CreateAttendeeCustomFieldValueParams{
+		AttendeeID: attendeeID,
+		FieldName:  name,
+		FieldValue: sql.NullString{String: value.Value, Valid: true},
+		FieldType:  sql.NullString{String: value.FieldType, Valid: true},
+	})
+}
+
+// toCustomField converts a database custom field into the custom field type
+func toCustomField(row database.EventCustomField) *types.EventCustomField {
+	return &types.EventCustomField{
+		ID:        row.ID,
+		EventID:   row.EventID,
+		Name:      row.Name,
+		FieldType: row.FieldType,
+		Required:  row.Required,
+		Position:  row.Position,
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+}
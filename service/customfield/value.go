@@ -0,0 +1,39 @@
+package customfield
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+// ValidateValue checks a value given for a custom field against its type,
+// and that a required field is not left blank
+func ValidateValue(field *types.EventCustomField, value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		if field.Required {
+			return fmt.Errorf("%s is required", field.Name)
+		}
+		return nil
+	}
+
+	switch field.FieldType {
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("%s must be a number", field.Name)
+		}
+	case "date":
+		if _, err := time.Parse(time.DateOnly, value); err != nil {
+			return fmt.Errorf("%s must be a date like 2006-01-02", field.Name)
+		}
+	case "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%s must be true or false", field.Name)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package customfield
+
+import (
+	"testing"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+func TestValidateValue(t *testing.T) {
+	tests := []struct {
+		field types.EventCustomField
+		value string
+		valid bool
+	}{
+		{types.EventCustomField{Name: "Notes", FieldType: "text"}, "", true},
+		{types.EventCustomField{Name: "Notes", FieldType: "text", Required: true}, "  ", false},
+		{types.EventCustomField{Name: "Age", FieldType: "number"}, "42.5", true},
+		{types.EventCustomField{Name: "Age", FieldType: "number"}, "forty", false},
+		{types.EventCustomField{Name: "Arrival", FieldType: "date"}, "2026-10-19", true},
+		{types.EventCustomField{Name: "Arrival", FieldType: "date"}, "19/10/2026", false},
+		{types.EventCustomField{Name: "Vegan", FieldType: "boolean"}, "true", true},
+		{types.EventCustomField{Name: "Vegan", FieldType: "boolean"}, "maybe", false},
+	}
+
+	for _, test := range tests {
+		if err := ValidateValue(&test.field, test.value); (err == nil) != test.valid {
+			t.Errorf("%s %q: expected valid %v, got %v", test.field.Name, test.value, test.valid, err)
+		}
+	}
+}
@@ -0,0 +1,306 @@
+package dedup
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+const (
+	// nameThreshold is how alike two names must be to match when the companies match too
+	nameThreshold = 0.92
+	// companyThreshold is how alike two companies must be to count as the same
+	companyThreshold = 0.88
+	// nameOnlyThreshold is how alike two names must be to match when a company is missing
+	nameOnlyThreshold = 0.97
+	// nameOnlyWeight lowers the score of a match on the name alone
+	nameOnlyWeight = 0.85
+)
+
+// companySuffixes are left out when comparing companies, "Acme Inc." is "Acme"
+var companySuffixes = map[string]bool{
+	"inc": true, "incorporated": true, "ltd": true, "limited": true, "llc": true,
+	"plc": true, "corp": true, "corporation": true, "co": true, "company": true,
+	"gmbh": true, "ag": true, "pte": true, "pty": true, "sa": true, "bv": true, "the": true,
+}
+
+// CanonicalEmail reduces an email to the mailbox it delivers to: lowercased,
+// without a +tag, and without the dots Gmail ignores
+func CanonicalEmail(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return email
+	}
+
+	local, _, _ = strings.Cut(local, "+")
+	if domain == "gmail.com" || domain == "googlemail.com" {
+		local = strings.ReplaceAll(local, ".", "")
+		domain = "gmail.com"
+	}
+
+	return local + "@" + domain
+}
+
+// words lowercases a value and splits it into its letters and digits
+func words(value string) []string {
+	return strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// NameKey is the name of an attendee as it is compared
+func NameKey(firstName string, lastName string) string {
+	return strings.Join(words(firstName+" "+lastName), " ")
+}
+
+// CompanyKey is the company of an attendee as it is compared
+func CompanyKey(company string) string {
+	kept := []string{}
+	for _, word := range words(company) {
+		if !companySuffixes[word] {
+			kept = append(kept, word)
+		}
+	}
+
+	return strings.Join(kept, " ")
+}
+
+// Similarity is the Jaro-Winkler similarity of two values, 1 when they are equal
+func Similarity(a string, b string) float64 {
+	if a == b {
+		return 1
+	}
+
+	s, t := []rune(a), []rune(b)
+	if len(s) == 0 || len(t) == 0 {
+		return 0
+	}
+
+	window := max(len(s), len(t))/2 - 1
+	window = max(window, 0)
+
+	sMatched := make([]bool, len(s))
+	tMatched := make([]bool, len(t))
+	matches := 0
+	for i := range s {
+		for j := max(0, i-window); j < min(len(t), i+window+1); j++ {
+			if !tMatched[j] && s[i] == t[j] {
+				sMatched[i], tMatched[j] = true, true
+				matches++
+				break
+			}
+		}
+	}
+	if matches == 0 {
+		return 0
+	}
+
+	transpositions := 0
+	j := 0
+	for i := range s {
+		if !sMatched[i] {
+			continue
+		}
+		for !tMatched[j] {
+			j++
+		}
+		if s[i] != t[j] {
+			transpositions++
+		}
+		j++
+	}
+
+	m := float64(matches)
+	jaro := (m/float64(len(s)) + m/float64(len(t)) + (m-float64(transpositions)/2)/m) / 3
+
+	prefix := 0
+	for prefix < min(4, len(s), len(t)) && s[prefix] == t[prefix] {
+		prefix++
+	}
+
+	return jaro + float64(prefix)*0.1*(1-jaro)
+}
+
+// candidate is an attendee with the keys it is compared by
+type candidate struct {
+	attendee *types.Attendee
+	email    string
+	name     string
+	swapped  string
+	company  string
+}
+
+func newCandidate(attendee *types.Attendee) *candidate {
+	return &candidate{
+		attendee: attendee,
+		email:    CanonicalEmail(attendee.Email),
+		name:     NameKey(attendee.FirstName, attendee.LastName),
+		swapped:  NameKey(attendee.LastName, attendee.FirstName),
+		company:  CompanyKey(attendee.CompanyName),
+	}
+}
+
+// block groups the candidates worth comparing by name, those with the same
+// initials in either order. Names that differ in their first letter are not
+// compared, which keeps large events fast.
+func (c *candidate) block() string {
+	first, last := words(c.attendee.FirstName), words(c.attendee.LastName)
+	if len(first) == 0 || len(last) == 0 {
+		return ""
+	}
+
+	initials := []rune{[]rune(first[0])[0], []rune(last[0])[0]}
+	sort.Slice(initials, func(i, j int) bool { return initials[i] < initials[j] })
+	return string(initials)
+}
+
+// Match tells whether two attendees look like the same person and why
+func Match(a *types.Attendee, b *types.Attendee) (string, float64, bool) {
+	return match(newCandidate(a), newCandidate(b))
+}
+
+func match(a *candidate, b *candidate) (string, float64, bool) {
+	if a.email != "" && a.email == b.email {
+		return types.DuplicateReasonEmail, 1, true
+	}
+
+	name := max(Similarity(a.name, b.name), Similarity(a.name, b.swapped))
+	if a.company != "" && b.company != "" {
+		company := Similarity(a.company, b.company)
+		if name >= nameThreshold && company >= companyThreshold {
+			return types.DuplicateReasonNameCompany, name * company, true
+		}
+		return "", 0, false
+	}
+
+	if name >= nameOnlyThreshold {
+		return types.DuplicateReasonName, name * nameOnlyWeight, true
+	}
+
+	return "", 0, false
+}
+
+// FindDuplicates groups the attendees that look like the same person, surest groups first
+func FindDuplicates(attendees []*types.Attendee) []*types.DuplicateGroup {
+	candidates := make([]*candidate, len(attendees))
+	byEmail := map[string][]int{}
+	byBlock := map[string][]int{}
+	for i, attendee := range attendees {
+		candidates[i] = newCandidate(attendee)
+		if email := candidates[i].email; email != "" {
+			byEmail[email] = append(byEmail[email], i)
+		}
+		if block := candidates[i].block(); block != "" {
+			byBlock[block] = append(byBlock[block], i)
+		}
+	}
+
+	parent := make([]int, len(attendees))
+	for i := range parent {
+		parent[i] = i
+	}
+	var root func(i int) int
+	root = func(i int) int {
+		if parent[i] != i {
+			parent[i] = root(parent[i])
+		}
+		return parent[i]
+	}
+
+	matches := map[[2]int]types.DuplicateMatch{}
+	link := func(i int, j int, reason string, score float64) {
+		if i > j {
+			i, j = j, i
+		}
+		if _, ok := matches[[2]int{i, j}]; ok {
+			return
+		}
+		matches[[2]int{i, j}] = types.DuplicateMatch{
+			AttendeeID: attendees[i].ID,
+			OtherID:    attendees[j].ID,
+			Reason:     reason,
+			Score:      score,
+		}
+		parent[root(i)] = root(j)
+	}
+
+	for _, same := range byEmail {
+		for _, j := range same[1:] {
+			link(same[0], j, types.DuplicateReasonEmail, 1)
+		}
+	}
+	for _, block := range byBlock {
+		for x, i := range block {
+			for _, j := range block[x+1:] {
+				if reason, score, ok := match(candidates[i], candidates[j]); ok {
+					link(i, j, reason, score)
+				}
+			}
+		}
+	}
+
+	groups := map[int]*types.DuplicateGroup{}
+	for pair, m := range matches {
+		group := groups[root(pair[0])]
+		if group == nil {
+			group = &types.DuplicateGroup{}
+			groups[root(pair[0])] = group
+		}
+		group.Matches = append(group.Matches, m)
+		group.Score = max(group.Score, m.Score)
+	}
+	for i, attendee := range attendees {
+		if group := groups[root(i)]; group != nil {
+			group.Attendees = append(group.Attendees, attendee)
+		}
+	}
+
+	result := make([]*types.DuplicateGroup, 0, len(groups))
+	for _, group := range groups {
+		sort.Slice(group.Attendees, func(i, j int) bool { return group.Attendees[i].ID < group.Attendees[j].ID })
+		sort.Slice(group.Matches, func(i, j int) bool {
+			if group.Matches[i].AttendeeID != group.Matches[j].AttendeeID {
+				return group.Matches[i].AttendeeID < group.Matches[j].AttendeeID
+			}
+			return group.Matches[i].OtherID < group.Matches[j].OtherID
+		})
+		group.KeepID = KeepCandidate(group.Attendees).ID
+		result = append(result, group)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score != result[j].Score {
+			return result[i].Score > result[j].Score
+		}
+		return result[i].Attendees[0].ID < result[j].Attendees[0].ID
+	})
+
+	return result
+}
+
+// KeepCandidate suggests which attendee of a group to keep: one that checked
+// in, then one that is confirmed, then the first registered
+func KeepCandidate(attendees []*types.Attendee) *types.Attendee {
+	rank := func(attendee *types.Attendee) int {
+		rank := 0
+		if attendee.Attendance {
+			rank += 2
+		}
+		if attendee.RegistrationStatus != types.RegistrationStatusPendingPayment {
+			rank++
+		}
+		return rank
+	}
+
+	keep := attendees[0]
+	for _, attendee := range attendees[1:] {
+		if r, k := rank(attendee), rank(keep); r > k || (r == k && attendee.ID < keep.ID) {
+			keep = attendee
+		}
+	}
+
+	return keep
+}
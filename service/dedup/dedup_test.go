@@ -0,0 +1,104 @@
+package dedup
+
+import (
+	"testing"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+func TestCanonicalEmail(t *testing.T) {
+	tests := map[string]string{
+		" Ann.Smith@Example.com ":       "ann.smith@example.com",
+		"ann+events@example.com":        "ann@example.com",
+		"Ann.Smith+rsvp@googlemail.com": "annsmith@gmail.com",
+		"not an email":                  "not an email",
+	}
+
+	for email, want := range tests {
+		if got := CanonicalEmail(email); got != want {
+			t.Errorf("%q: expected %q, got %q", email, want, got)
+		}
+	}
+}
+
+func TestSimilarity(t *testing.T) {
+	if got := Similarity("martha", "marhta"); got < 0.96 || got > 0.97 {
+		t.Errorf("expected the classic 0.961 for martha and marhta, got %.3f", got)
+	}
+	if got := Similarity("ann", "ann"); got != 1 {
+		t.Errorf("expected equal values to be 1, got %.3f", got)
+	}
+	if got := Similarity("ann", ""); got != 0 {
+		t.Errorf("expected an empty value to be 0, got %.3f", got)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		name   string
+		a, b   types.Attendee
+		reason string
+	}{
+		{
+			"same mailbox",
+			types.Attendee{FirstName: "Ann", LastName: "Smith", Email: "Ann.Smith@gmail.com"},
+			types.Attendee{FirstName: "A", LastName: "S", Email: "annsmith+conf@gmail.com"},
+			types.DuplicateReasonEmail,
+		},
+		{
+			"typo in the name at the same company",
+			types.Attendee{FirstName: "Jonathan", LastName: "Miller", Email: "jon@acme.com", CompanyName: "Acme Inc."},
+			types.Attendee{FirstName: "Jonathon", LastName: "Miller", Email: "jmiller@gmail.com", CompanyName: "ACME"},
+			types.DuplicateReasonNameCompany,
+		},
+		{
+			"name in the wrong columns without a company",
+			types.Attendee{FirstName: "Priya", LastName: "Raman", Email: "priya@a.com"},
+			types.Attendee{FirstName: "Raman", LastName: "Priya", Email: "praman@b.com"},
+			types.DuplicateReasonName,
+		},
+		{
+			"same name at another company",
+			types.Attendee{FirstName: "John", LastName: "Smith", Email: "john@acme.com", CompanyName: "Acme"},
+			types.Attendee{FirstName: "John", LastName: "Smith", Email: "john@globex.com", CompanyName: "Globex"},
+			"",
+		},
+	}
+
+	for _, test := range tests {
+		reason, _, ok := Match(&test.a, &test.b)
+		if ok != (test.reason != "") || reason != test.reason {
+			t.Errorf("%s: expected %q, got %q", test.name, test.reason, reason)
+		}
+	}
+}
+
+func TestFindDuplicates(t *testing.T) {
+	attendees := []*types.Attendee{
+		{ID: 1, FirstName: "Ann", LastName: "Smith", Email: "ann@example.com", CompanyName: "Acme"},
+		{ID: 2, FirstName: "Bob", LastName: "Jones", Email: "bob@example.com"},
+		{ID: 3, FirstName: "Anne", LastName: "Smith", Email: "asmith@acme.com", CompanyName: "Acme Ltd", Attendance: true},
+		{ID: 4, FirstName: "Ann", LastName: "Smith", Email: "Ann+vip@example.com"},
+		{ID: 5, FirstName: "Carla", LastName: "Diaz", Email: "carla@example.com"},
+	}
+
+	groups := FindDuplicates(attendees)
+	if len(groups) != 1 {
+		t.Fatalf("expected 1 group, got %d", len(groups))
+	}
+
+	group := groups[0]
+	ids := []int32{}
+	for _, attendee := range group.Attendees {
+		ids = append(ids, attendee.ID)
+	}
+	if len(ids) != 3 || ids[0] != 1 || ids[1] != 3 || ids[2] != 4 {
+		t.Errorf("expected attendees 1, 3 and 4, got %v", ids)
+	}
+	if group.Score != 1 {
+		t.Errorf("expected the email match to score 1, got %.3f", group.Score)
+	}
+	if group.KeepID != 3 {
+		t.Errorf("expected the checked in attendee to be kept, got %d", group.KeepID)
+	}
+}
@@ -0,0 +1,172 @@
+package dedup
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"slices"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/service/audit"
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/service/live"
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+type Handler struct {
+	store         types.MergeStore
+	attendeeStore types.AttendeeStore
+	eventStore    types.EventStore
+	userStore     types.UserStore
+	hub           *live.Hub
+}
+
+func NewHandler(store types.MergeStore, attendeeStore types.AttendeeStore, eventStore types.EventStore, userStore types.UserStore, hub *live.Hub) *Handler {
+	return &Handler{store: store, attendeeStore: attendeeStore, eventStore: eventStore, userStore: userStore, hub: hub}
+}
+
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/event/:event_id/attendees/duplicates", auth.WithJWTAuth(h.handleGetDuplicates, h.userStore))
+	router.Post("/event/:event_id/attendees/merge", auth.WithJWTAuth(h.handleMergeAttendees, h.userStore))
+}
+
+// Handler to list the groups of attendees of an event that look like the same
+// person, by email or by name and company
+func (h *Handler) handleGetDuplicates(c *fiber.Ctx) error {
+	page, ferr := utils.ParsePage(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	event, ferr := utils.GetOwnedEvent(c.Params("event_id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	attendees, err := h.attendeeStore.GetAllAttendees(event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get attendees"})
+	}
+
+	groups := FindDuplicates(attendees)
+	list, ferr := utils.SlicePage(groups, page, func(group *types.DuplicateGroup) int64 { return int64(group.Attendees[0].ID) }, nil)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
+// Handler to merge attendees into the one kept. The merged attendees and their
+// QR codes are deleted once their history is moved over.
+func (h *Handler) handleMergeAttendees(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("event_id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var payload types.MergeAttendeesPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid payload"})
+	}
+	if invalidFields, err := utils.ValidatePayload(payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	slices.Sort(payload.MergeIDs)
+	payload.MergeIDs = slices.Compact(payload.MergeIDs)
+	if slices.Contains(payload.MergeIDs, payload.KeepID) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "The attendee kept cannot also be merged"})
+	}
+
+	keep, ferr := h.getEventAttendee(event, payload.KeepID)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	merged := make([]*types.Attendee, 0, len(payload.MergeIDs))
+	for _, id := range payload.MergeIDs {
+		attendee, ferr := h.getEventAttendee(event, id)
+		if ferr != nil {
+			return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+		}
+		merged = append(merged, attendee)
+	}
+
+	for _, attendee := range merged {
+		if err := h.store.MergeAttendee(c.Context(), event.EventID, keep.ID, attendee.ID); err != nil {
+			log.Printf("Error merging attendee ID %d into attendee ID %d: %v", attendee.ID, keep.ID, err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to merge attendees"})
+		}
+
+		if err := utils.DeleteQrImageFromCloudinary(attendee.QrCode); err != nil {
+			log.Printf("Error deleting QR code of attendee ID %d: %v", attendee.ID, err)
+		}
+		h.publishUpdate(live.UpdateRemoval, attendee)
+	}
+
+	result, err := h.attendeeStore.GetAttendeeByID(keep.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get attendee"})
+	}
+
+	audit.Note(c, audit.Mutation{
+		Action:       "attendee.merge",
+		TargetType:   "attendee",
+		TargetID:     keep.ID,
+		EventID:      event.EventID,
+		EventOwnerID: event.UserID,
+		Before:       keep,
+		After:        result,
+		Details:      map[string]any{"merged": merged},
+	})
+	h.publishUpdate(live.UpdateAttendee, result)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"attendee": result,
+		"merged":   payload.MergeIDs,
+	})
+}
+
+// getEventAttendee loads an attendee and checks that it belongs to the event
+func (h *Handler) getEventAttendee(event *types.Event, attendeeID int32) (*types.Attendee, *fiber.Error) {
+	attendee, err := h.attendeeStore.GetAttendeeByID(attendeeID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Attendee "+strconv.Itoa(int(attendeeID))+" not found")
+		}
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to get attendee")
+	}
+
+	if attendee.EventID != event.EventID {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Attendee "+strconv.Itoa(int(attendeeID))+" not found")
+	}
+
+	return attendee, nil
+}
+
+// publishUpdate pushes a registration change to the live attendance stream of the event
+func (h *Handler) publishUpdate(updateType string, attendee *types.Attendee) {
+	if !h.hub.HasSubscribers(attendee.EventID) {
+		return
+	}
+
+	stats, err := h.attendeeStore.GetAttendanceStats(attendee.EventID)
+	if err != nil {
+		log.Printf("Error getting attendance stats for event ID %d: %v", attendee.EventID, err)
+		return
+	}
+
+	h.hub.Publish(live.Update{
+		Type:     updateType,
+		EventID:  attendee.EventID,
+		Attendee: attendee,
+		Stats:    stats,
+	})
+}
@@ -0,0 +1,94 @@
+package dedup
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jayden1905/event-registration-software/cmd/pkg/database"
+	"github.com/jayden1905/event-registration-software/db"
+)
+
+type Store struct {
+	db   *database.Queries
+	conn *sql.DB
+}
+
+// NewStore initializes the Store with the database queries and the connection
+// its merges run their transactions on
+func NewStore(db *database.Queries, conn *sql.DB) *Store {
+	return &Store{db: db, conn: conn}
+}
+
+// MergeAttendee folds an attendee into the one kept: the kept attendee takes
+// the details it is missing, the latest RSVP, the guests, the check-in
+// history, custom field values, session sign-ups, emails and orders, then the
+// merged attendee is deleted. Either the whole merge is applied or none of it.
+func (s *Store) MergeAttendee(ctx context.Context, eventID int32, keepID int32, mergeID int32) error {
+	return db.WithTx(ctx, s.conn, s.db, func(q *database.Queries) error {
+		err := q.MergeAttendeeDetails(ctx, database.MergeAttendeeDetailsParams{KeepID: keepID, MergeID: mergeID})
+		if err != nil {
+			return err
+		}
+
+		err = q.MoveGuests(ctx, database.MoveGuestsParams{KeepID: keepID, MergeID: sql.NullInt32{Int32: mergeID, Valid: true}})
+		if err != nil {
+			return err
+		}
+
+		err = q.MoveCheckIns(ctx, database.MoveCheckInsParams{KeepID: keepID, MergeID: mergeID})
+		if err != nil {
+			return err
+		}
+
+		// A value of the merged attendee wins over a blank one of the kept attendee
+		err = q.DeleteBlankCustomFieldValues(ctx, database.DeleteBlankCustomFieldValuesParams{KeepID: keepID, MergeID: mergeID})
+		if err != nil {
+			return err
+		}
+
+		err = q.MoveCustomFieldValues(ctx, database.MoveCustomFieldValuesParams{KeepID: keepID, MergeID: mergeID})
+		if err != nil {
+			return err
+		}
+
+		err = q.MoveSessionSignups(ctx, database.MoveSessionSignupsParams{KeepID: keepID, MergeID: mergeID})
+		if err != nil {
+			return err
+		}
+
+		err = q.MoveEmailDeliveries(ctx, database.MoveEmailDeliveriesParams{
+			KeepID:  sql.NullInt32{Int32: keepID, Valid: true},
+			MergeID: sql.NullInt32{Int32: mergeID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		err = q.MoveOrders(ctx, database.MoveOrdersParams{
+			KeepID:  sql.NullInt32{Int32: keepID, Valid: true},
+			MergeID: sql.NullInt32{Int32: mergeID, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		// Remember the attendee so offline check-in devices can drop it on their next sync
+		err = q.CreateDeletedAttendee(ctx, mergeID)
+		if err != nil {
+			return err
+		}
+
+		err = q.DeleteMergedAttendee(ctx, mergeID)
+		if err != nil {
+			return err
+		}
+
+		// Free the session seats and the ticket only the merged attendee had taken
+		err = q.RefreshSessionSignupCountsByEventID(ctx, eventID)
+		if err != nil {
+			return err
+		}
+
+		return q.RefreshTicketSoldCountsByEventID(ctx, eventID)
+	})
+}
@@ -4,5 +4,7 @@ import "github.com/jayden1905/event-registration-software/types"
 
 type Mailer interface {
 	SendVerificationEmail(toEmail string, token string) error
-	SendInvitationEmail(attendee *types.Attendee, template *types.EmailTemplate) error
+	SendInvitationEmail(attendee *types.Attendee, template *types.EmailTemplate, trackingID string) error
+	SendGroupInvitationEmail(primary *types.Attendee, guests []*types.Attendee, template *types.EmailTemplate, trackingID string) error
+	SendReceiptEmail(receipt *types.Receipt) error
 }
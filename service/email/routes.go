@@ -1,10 +1,15 @@
 package email
 
 import (
+	"database/sql"
+	"errors"
 	"strconv"
+	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/jayden1905/event-registration-software/service/audit"
 	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/service/plan"
 	"github.com/jayden1905/event-registration-software/types"
 	"github.com/jayden1905/event-registration-software/utils"
 )
@@ -13,19 +18,24 @@ type Handler struct {
 	store      types.EmailTempalteStore
 	eventStore types.EventStore
 	userStore  types.UserStore
+	enforcer   *plan.Enforcer
 }
 
-func NewHandler(store types.EmailTempalteStore, eventStore types.EventStore, userStore types.UserStore) *Handler {
-	return &Handler{store: store, eventStore: eventStore, userStore: userStore}
+func NewHandler(store types.EmailTempalteStore, eventStore types.EventStore, userStore types.UserStore, enforcer *plan.Enforcer) *Handler {
+	return &Handler{store: store, eventStore: eventStore, userStore: userStore, enforcer: enforcer}
 }
 
 func (h *Handler) RegisterRoutes(router fiber.Router) {
 	router.Get("/email_templates/:event_id", auth.WithJWTAuth(h.handleGetEmailTempalteByID, h.userStore))
+	router.Get("/event/:id/email_templates", auth.WithJWTAuth(h.handleGetEmailTemplates, h.userStore))
 	router.Post("/email_templates", auth.WithJWTAuth(h.handleCreateEmailTemplate, h.userStore))
 	router.Put("/email_templates", auth.WithJWTAuth(h.handleUpdateEmailTemplate, h.userStore))
+	router.Delete("/email_templates/:id", auth.WithJWTAuth(h.handleDeleteEmailTemplate, h.userStore))
+	router.Post("/email_templates/:id/restore", auth.WithJWTAuth(h.handleRestoreEmailTemplate, h.userStore))
 }
 
-// Handler for getting an email template by its ID
+// Handler for getting the email template of an event for one purpose, its
+// invitation unless the purpose query says otherwise
 func (h *Handler) handleGetEmailTempalteByID(c *fiber.Ctx) error {
 	userID := auth.GetUserIDFromContext(c)
 
@@ -36,21 +46,38 @@ func (h *Handler) handleGetEmailTempalteByID(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid event ID"})
 	}
 
-	event, err := h.eventStore.GetEventByID(int32(eventID))
+	if _, ferr := utils.GetOwnedEventByID(int32(eventID), userID, h.eventStore); ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	purpose := c.Query("purpose", types.EmailPurposeInvitation)
+	emailTemplate, err := h.store.GetEmailTemplateByPurpose(c.Context(), int32(eventID), purpose)
 	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Event not found"})
+		if errors.Is(err, sql.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Email template not found"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get email template"})
 	}
 
-	if event.UserID != userID {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	return c.Status(fiber.StatusOK).JSON(emailTemplate)
+}
+
+// Handler for listing the email templates of an event, one for each purpose
+func (h *Handler) handleGetEmailTemplates(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
 	}
 
-	emailTemplate, err := h.store.GetEmailTemplateByEventID(c.Context(), int32(eventID))
+	emailTemplates, err := h.store.GetEmailTemplatesByEventID(c.Context(), event.EventID)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get email template"})
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get email templates"})
+	}
+	if emailTemplates == nil {
+		emailTemplates = []*types.EmailTemplate{}
 	}
 
-	return c.Status(fiber.StatusOK).JSON(emailTemplate)
+	return c.Status(fiber.StatusOK).JSON(emailTemplates)
 }
 
 // Handler for creating an email template
@@ -70,17 +97,21 @@ func (h *Handler) handleCreateEmailTemplate(c *fiber.Ctx) error {
 		})
 	}
 
-	event, err := h.eventStore.GetEventByID(payload.EventID)
-	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Event not found"})
+	event, ferr := utils.GetOwnedEventByID(payload.EventID, userID, h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
 	}
 
-	if event.UserID != userID {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	// Header and footer images and the background color are custom branding
+	if payload.HeaderImage != "" || payload.FooterImage != "" || payload.BgColor != "" {
+		if ferr := h.enforcer.CheckBranding(userID); ferr != nil {
+			return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+		}
 	}
 
 	emailTemplate := &types.EmailTemplate{
 		EventID:     payload.EventID,
+		Purpose:     payload.Purpose,
 		HeaderImage: payload.HeaderImage,
 		Content:     payload.Content,
 		FooterImage: payload.FooterImage,
@@ -89,16 +120,25 @@ func (h *Handler) handleCreateEmailTemplate(c *fiber.Ctx) error {
 		Message:     payload.Message,
 	}
 
-	// check if the email template already exists
-	if _, err := h.store.GetEmailTemplateByEventID(c.Context(), payload.EventID); err == nil {
+	if emailTemplate.Purpose == "" {
+		emailTemplate.Purpose = types.EmailPurposeInvitation
+	}
+
+	// check if the event already has an email template for the purpose
+	if _, err := h.store.GetEmailTemplateByPurpose(c.Context(), payload.EventID, emailTemplate.Purpose); err == nil {
 		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Email template already exists"})
 	}
 
 	if err := h.store.CreateEmailTemplate(c.Context(), emailTemplate); err != nil {
+		if isDuplicatePurpose(err) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Email template already exists"})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create email template"})
 	}
 
-	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"message": "Email template created successfully"})
+	audit.Note(c, audit.Mutation{Action: "email_template.create", TargetType: "email_template", TargetID: emailTemplate.ID, EventID: event.EventID, EventOwnerID: event.UserID, After: emailTemplate})
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"message": "Email template created successfully", "email_template": emailTemplate})
 }
 
 // Handler for updating an email template
@@ -118,18 +158,28 @@ func (h *Handler) handleUpdateEmailTemplate(c *fiber.Ctx) error {
 		})
 	}
 
-	event, err := h.eventStore.GetEventByID(payload.EventID)
-	if err != nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Event not found"})
+	event, ferr := utils.GetOwnedEventByID(payload.EventID, userID, h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	// Header and footer images and the background color are custom branding
+	if payload.HeaderImage != "" || payload.FooterImage != "" || payload.BgColor != "" {
+		if ferr := h.enforcer.CheckBranding(userID); ferr != nil {
+			return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+		}
 	}
 
-	if event.UserID != userID {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Unauthorized"})
+	// Keep the template as it was for the audit log
+	before, err := h.store.GetEmailTemplateByID(c.Context(), payload.ID)
+	if err != nil || before.EventID != event.EventID {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Email template not found"})
 	}
 
 	emailTemplate := &types.EmailTemplate{
 		ID:          payload.ID,
 		EventID:     payload.EventID,
+		Purpose:     payload.Purpose,
 		HeaderImage: payload.HeaderImage,
 		Content:     payload.Content,
 		FooterImage: payload.FooterImage,
@@ -137,10 +187,83 @@ func (h *Handler) handleUpdateEmailTemplate(c *fiber.Ctx) error {
 		BgColor:     payload.BgColor,
 		Message:     payload.Message,
 	}
+	if emailTemplate.Purpose == "" {
+		emailTemplate.Purpose = before.Purpose
+	}
 
 	if err := h.store.UpdateEmailTemplate(c.Context(), emailTemplate); err != nil {
+		if isDuplicatePurpose(err) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "The event already has an email template for this purpose"})
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update email template"})
 	}
 
+	audit.Note(c, audit.Mutation{Action: "email_template.update", TargetType: "email_template", TargetID: emailTemplate.ID, EventID: event.EventID, EventOwnerID: event.UserID, Before: before, After: emailTemplate})
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Email template updated successfully"})
 }
+
+// Handler for deleting an email template, it can be restored until it is purged
+func (h *Handler) handleDeleteEmailTemplate(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid email template ID"})
+	}
+
+	emailTemplate, err := h.store.GetEmailTemplateByID(c.Context(), int32(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Email template not found"})
+	}
+
+	event, ferr := utils.GetOwnedEventByID(emailTemplate.EventID, userID, h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	if err := h.store.DeleteEmailTemplate(c.Context(), emailTemplate.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to delete email template"})
+	}
+
+	audit.Note(c, audit.Mutation{Action: "email_template.delete", TargetType: "email_template", TargetID: emailTemplate.ID, EventID: event.EventID, EventOwnerID: event.UserID, Before: emailTemplate})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Email template deleted successfully"})
+}
+
+// Handler for restoring a deleted email template before it is purged
+func (h *Handler) handleRestoreEmailTemplate(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid email template ID"})
+	}
+
+	emailTemplate, err := h.store.GetDeletedEmailTemplateByID(c.Context(), int32(id))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Deleted email template not found"})
+	}
+
+	event, ferr := utils.GetOwnedEventByID(emailTemplate.EventID, userID, h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	if err := h.store.RestoreEmailTemplate(c.Context(), emailTemplate.ID); err != nil {
+		if isDuplicatePurpose(err) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Email template already exists"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to restore email template"})
+	}
+	emailTemplate.DeletedAt = nil
+
+	audit.Note(c, audit.Mutation{Action: "email_template.restore", TargetType: "email_template", TargetID: emailTemplate.ID, EventID: event.EventID, EventOwnerID: event.UserID, After: emailTemplate})
+
+	return c.Status(fiber.StatusOK).JSON(emailTemplate)
+}
+
+// isDuplicatePurpose reports whether an insert or update hit the unique template purpose of an event
+func isDuplicatePurpose(err error) bool {
+	return strings.Contains(err.Error(), "email_template_event_id_purpose_unique")
+}
@@ -3,14 +3,20 @@ package email
 import (
 	"bytes"
 	"fmt"
+	"html"
 	"html/template"
 	"log"
 	"net/smtp"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/jayden1905/event-registration-software/config"
+	"github.com/jayden1905/event-registration-software/service/portal"
+	"github.com/jayden1905/event-registration-software/service/rsvp"
+	"github.com/jayden1905/event-registration-software/service/tracking"
 	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
 )
 
 // EmailService holds the SMTP server information for sending emails
@@ -84,8 +90,15 @@ func (es *EmailService) SendVerificationEmail(toEmail string, token string) erro
 	return nil
 }
 
-// SendInvitationEmail sends the invitation email
-func (es *EmailService) SendInvitationEmail(attendee *types.Attendee, template *types.EmailTemplate) error {
+// SendInvitationEmail sends the invitation email, tracked when it has a tracking ID
+func (es *EmailService) SendInvitationEmail(attendee *types.Attendee, template *types.EmailTemplate, trackingID string) error {
+	return es.SendGroupInvitationEmail(attendee, nil, template, trackingID)
+}
+
+// SendGroupInvitationEmail sends the invitation email of a group to its primary
+// attendee, listing the guests with their QR codes in place of {{guests}} or
+// after the content when the template has no such placeholder
+func (es *EmailService) SendGroupInvitationEmail(attendee *types.Attendee, guests []*types.Attendee, template *types.EmailTemplate, trackingID string) error {
 	auth := smtp.PlainAuth("", es.SMTPUsername, es.SMTPPassword, es.SMTPHost)
 
 	// Replace template variables with attendee data
@@ -93,6 +106,34 @@ func (es *EmailService) SendInvitationEmail(attendee *types.Attendee, template *
 	content = strings.Replace(content, "{{first_name}}", attendee.FirstName, -1)
 	content = strings.Replace(content, "{{last_name}}", attendee.LastName, -1)
 	content = strings.Replace(content, "{{qr_code}}", attendee.QrCode, -1)
+	if strings.Contains(content, "{{portal_link}}") {
+		link, err := portal.Link(attendee.ID, time.Now())
+		if err != nil {
+			log.Printf("Error creating portal link for attendee ID %d: %v", attendee.ID, err)
+			return err
+		}
+		content = strings.Replace(content, "{{portal_link}}", link.Link, -1)
+	}
+	if strings.Contains(content, "{{rsvp_") {
+		links, err := rsvp.Links(attendee.ID, time.Now())
+		if err != nil {
+			log.Printf("Error creating RSVP links for attendee ID %d: %v", attendee.ID, err)
+			return err
+		}
+		content = strings.Replace(content, "{{rsvp_accept_link}}", links[types.RSVPStatusAccepted], -1)
+		content = strings.Replace(content, "{{rsvp_decline_link}}", links[types.RSVPStatusDeclined], -1)
+		content = strings.Replace(content, "{{rsvp_maybe_link}}", links[types.RSVPStatusMaybe], -1)
+	}
+	if len(guests) > 0 && !strings.Contains(content, "{{guests}}") {
+		content += "{{guests}}"
+	}
+	content = strings.Replace(content, "{{guests}}", guestList(guests), -1)
+
+	// Templates without custom branding are sent on white without images
+	bgColor := template.BgColor
+	if bgColor == "" {
+		bgColor = "#ffffff"
+	}
 
 	// Prepare the email body
 	body := fmt.Sprintf(`
@@ -126,25 +167,22 @@ func (es *EmailService) SendInvitationEmail(attendee *types.Attendee, template *
 			</head>
 		<body>
 		<table style="background-color: %s; color: black;" class="container" role="presentation" cellspacing="0" cellpadding="0">
-			<tr>
-				<td class="img-container">
-					<img src="%s" alt="Header" />
-				</td>
-			</tr>
+			%s
 			<tr>
 				<td>
 					<div>%s</div>
 				</td>
 			</tr>
-			<tr>
-				<td class="img-container">
-					<img src="%s" alt="Footer" />
-				</td>
-			</tr>
+			%s
 		</table>
 		</body>
 		</html>
-	`, template.Message, template.BgColor, template.HeaderImage, content, template.FooterImage)
+	`, template.Message, bgColor, imageRow(template.HeaderImage, "Header"), content, imageRow(template.FooterImage, "Footer"))
+
+	// Tracked emails get a tracking pixel and links that go through the click endpoint
+	if trackingID != "" {
+		body = tracking.Instrument(body, trackingID)
+	}
 
 	subject := fmt.Sprintf("Subject: %s\r\n", template.Subject)
 	contentType := "MIME-Version: 1.0\r\nContent-Type: text/html; charset=\"UTF-8\"\r\n"
@@ -159,3 +197,99 @@ func (es *EmailService) SendInvitationEmail(attendee *types.Attendee, template *
 
 	return nil
 }
+
+// imageRow renders a full width image row of the invitation email, or nothing without an image
+func imageRow(src string, alt string) string {
+	if src == "" {
+		return ""
+	}
+
+	return fmt.Sprintf(`<tr>
+				<td class="img-container">
+					<img src="%s" alt="%s" />
+				</td>
+			</tr>`, src, alt)
+}
+
+// guestList renders the guests of a group invitation with their QR codes, or nothing without guests
+func guestList(guests []*types.Attendee) string {
+	if len(guests) == 0 {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(`<div><p>Your guests:</p>`)
+	for _, guest := range guests {
+		name := html.EscapeString(strings.TrimSpace(guest.FirstName + " " + guest.LastName))
+		fmt.Fprintf(&b, `<div><p>%s</p><img src="%s" alt="QR code of %s" width="200" /></div>`, name, guest.QrCode, name)
+	}
+	b.WriteString(`</div>`)
+
+	return b.String()
+}
+
+// SendReceiptEmail sends the receipt of a paid order in HTML format
+func (es *EmailService) SendReceiptEmail(receipt *types.Receipt) error {
+	auth := smtp.PlainAuth("", es.SMTPUsername, es.SMTPPassword, es.SMTPHost)
+
+	// Load the HTML template
+	tmplPath := "templates/receipt_email.html"
+	tmplContent, err := os.ReadFile(tmplPath)
+	if err != nil {
+		log.Printf("Error reading email template: %v", err)
+		return err
+	}
+
+	// Parse the template
+	tmpl, err := template.New("receipt_email").Parse(string(tmplContent))
+	if err != nil {
+		log.Printf("Error parsing email template: %v", err)
+		return err
+	}
+
+	// Prepare template data
+	data := struct {
+		Name           string
+		EventTitle     string
+		EventDate      string
+		EventLocation  string
+		TicketType     string
+		ReceiptNumber  string
+		OrderReference string
+		PaidAt         string
+		Amount         string
+		ReceiptLink    string
+	}{
+		Name:           receipt.Name,
+		EventTitle:     receipt.EventTitle,
+		EventDate:      receipt.EventStartDate.Format("2 January 2006"),
+		EventLocation:  receipt.EventLocation,
+		TicketType:     receipt.TicketType,
+		ReceiptNumber:  receipt.ReceiptNumber,
+		OrderReference: receipt.OrderReference,
+		PaidAt:         receipt.PaidAt.Format("2 January 2006 15:04 MST"),
+		Amount:         utils.FormatAmount(receipt.AmountCents, receipt.Currency),
+		ReceiptLink:    fmt.Sprintf("%s/api/v1/orders/%s/receipt", config.Envs.BackendHost, receipt.OrderReference),
+	}
+
+	// Render the template
+	var renderedBody bytes.Buffer
+	if err := tmpl.Execute(&renderedBody, data); err != nil {
+		log.Printf("Error executing email template: %v", err)
+		return err
+	}
+
+	// Create the email content
+	subject := fmt.Sprintf("Subject: Your receipt for %s\r\n", receipt.EventTitle)
+	contentType := "MIME-Version: 1.0\r\nContent-Type: text/html; charset=\"UTF-8\";\r\n"
+	msg := []byte(subject + contentType + "\r\n" + renderedBody.String())
+
+	// Send the email
+	err = smtp.SendMail(es.SMTPHost+":"+es.SMTPPort, auth, es.FromEmail, []string{receipt.Email}, msg)
+	if err != nil {
+		log.Printf("Error sending email to %s: %v", receipt.Email, err)
+		return err
+	}
+
+	return nil
+}
@@ -17,29 +17,43 @@ func NewStore(db *database.Queries) *Store {
 	return &Store{db: db}
 }
 
-// GetEmailTemplateByEventID fetches an email template by its event ID from the database
-func (s *Store) GetEmailTemplateByEventID(c context.Context, eventID int32) (*types.EmailTemplate, error) {
-	emailTemplate, err := s.db.GetEmailTemplateByEventID(c, eventID)
+// GetEmailTemplatesByEventID fetches the email templates of an event ordered by purpose
+func (s *Store) GetEmailTemplatesByEventID(ctx context.Context, eventID int32) ([]*types.EmailTemplate, error) {
+	emailTemplates, err := s.db.GetEmailTemplatesByEventID(ctx, eventID)
 	if err != nil {
 		return nil, err
 	}
 
-	return &types.EmailTemplate{
-		ID:          int32(emailTemplate.ID),
-		EventID:     int32(emailTemplate.EventID),
-		HeaderImage: emailTemplate.HeaderImage.String,
-		Content:     emailTemplate.Content.String,
-		FooterImage: emailTemplate.FooterImage.String,
-		Subject:     emailTemplate.Subject.String,
-		BgColor:     emailTemplate.BgColor.String,
-		Message:     emailTemplate.Message.String,
-	}, nil
+	var templates []*types.EmailTemplate
+	for _, emailTemplate := range emailTemplates {
+		templates = append(templates, toEmailTemplate(emailTemplate))
+	}
+
+	return templates, nil
 }
 
-// CreateEmailTemplate creates an email template in the database
+// GetEmailTemplateByPurpose fetches the email template of an event for a purpose
+func (s *Store) GetEmailTemplateByPurpose(ctx context.Context, eventID int32, purpose string) (*types.EmailTemplate, error) {
+	emailTemplate, err := s.db.GetEmailTemplateByPurpose(ctx, database.GetEmailTemplateByPurposeParams{
+		EventID: eventID,
+		Purpose: database.EmailTemplatePurpose(purpose),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toEmailTemplate(emailTemplate), nil
+}
+
+// CreateEmailTemplate creates an email template in the database, an invitation when it has no purpose
 func (s *Store) CreateEmailTemplate(ctx context.Context, emailTemplate *types.EmailTemplate) error {
-	err := s.db.CreateEmailTemplate(ctx, database.CreateEmailTemplateParams{
+	if emailTemplate.Purpose == "" {
+		emailTemplate.Purpose = types.EmailPurposeInvitation
+	}
+
+	id, err := s.db.CreateEmailTemplate(ctx, database.CreateEmailTemplateParams{
 		EventID:     emailTemplate.EventID,
+		Purpose:     database.EmailTemplatePurpose(emailTemplate.Purpose),
 		HeaderImage: sql.NullString{String: emailTemplate.HeaderImage, Valid: emailTemplate.HeaderImage != ""},
 		Content:     sql.NullString{String: emailTemplate.Content, Valid: emailTemplate.Content != ""},
 		FooterImage: sql.NullString{String: emailTemplate.FooterImage, Valid: emailTemplate.FooterImage != ""},
@@ -47,10 +61,10 @@ func (s *Store) CreateEmailTemplate(ctx context.Context, emailTemplate *types.Em
 		BgColor:     sql.NullString{String: emailTemplate.BgColor, Valid: emailTemplate.BgColor != ""},
 		Message:     sql.NullString{String: emailTemplate.Message, Valid: emailTemplate.Message != ""},
 	})
-
 	if err != nil {
 		return err
 	}
+	emailTemplate.ID = int32(id)
 
 	return nil
 }
@@ -60,6 +74,7 @@ func (s *Store) UpdateEmailTemplate(ctx context.Context, emailTemplate *types.Em
 	err := s.db.UpdateEmailTemplateByID(ctx, database.UpdateEmailTemplateByIDParams{
 		ID:          emailTemplate.ID,
 		EventID:     emailTemplate.EventID,
+		Purpose:     database.EmailTemplatePurpose(emailTemplate.Purpose),
 		HeaderImage: sql.NullString{String: emailTemplate.HeaderImage, Valid: emailTemplate.HeaderImage != ""},
 		Content:     sql.NullString{String: emailTemplate.Content, Valid: emailTemplate.Content != ""},
 		FooterImage: sql.NullString{String: emailTemplate.FooterImage, Valid: emailTemplate.FooterImage != ""},
@@ -74,3 +89,98 @@ func (s *Store) UpdateEmailTemplate(ctx context.Context, emailTemplate *types.Em
 
 	return nil
 }
+
+// GetEmailTemplateByID fetches an email template by its ID from the database
+func (s *Store) GetEmailTemplateByID(ctx context.Context, id int32) (*types.EmailTemplate, error) {
+	emailTemplate, err := s.db.GetEmailTemplateByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toEmailTemplate(emailTemplate), nil
+}
+
+// DeleteEmailTemplate soft deletes an email template, it can be restored until it is purged
+func (s *Store) DeleteEmailTemplate(ctx context.Context, id int32) error {
+	return s.db.DeleteEmailTemplateByID(ctx, id)
+}
+
+// GetDeletedEmailTemplateByID fetches a soft-deleted email template by its ID
+func (s *Store) GetDeletedEmailTemplateByID(ctx context.Context, id int32) (*types.EmailTemplate, error) {
+	emailTemplate, err := s.db.GetDeletedEmailTemplateByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toEmailTemplate(emailTemplate), nil
+}
+
+// RestoreEmailTemplate brings a soft-deleted email template back
+func (s *Store) RestoreEmailTemplate(ctx context.Context, id int32) error {
+	return s.db.RestoreEmailTemplateByID(ctx, id)
+}
+
+// CreateEmailDelivery records the outcome of an email sent to an attendee
+func (s *Store) CreateEmailDelivery(ctx context.Context, delivery *types.EmailDelivery) error {
+	err := s.db.CreateEmailDelivery(ctx, database.CreateEmailDeliveryParams{
+		EventID:    delivery.EventID,
+		AttendeeID: sql.NullInt32{Int32: delivery.AttendeeID, Valid: delivery.AttendeeID != 0},
+		TemplateID: sql.NullInt32{Int32: delivery.TemplateID, Valid: delivery.TemplateID != 0},
+		Email:      delivery.Email,
+		Status:     database.EmailDeliveriesStatus(delivery.Status),
+		Error:      sql.NullString{String: delivery.Error, Valid: delivery.Error != ""},
+		CampaignID: sql.NullInt32{Int32: delivery.CampaignID, Valid: delivery.CampaignID != 0},
+		TrackingID: sql.NullString{String: delivery.TrackingID, Valid: delivery.TrackingID != ""},
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetEmailDeliveryStats fetches the delivery totals of an event from the database
+func (s *Store) GetEmailDeliveryStats(ctx context.Context, eventID int32) (*types.EmailDeliveryStats, error) {
+	stats, err := s.db.GetEmailDeliveryStatsByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.EmailDeliveryStats{
+		Total:      stats.Total,
+		Sent:       stats.Sent,
+		Failed:     stats.Failed,
+		Skipped:    stats.Skipped,
+		Recipients: stats.Recipients,
+	}, nil
+}
+
+// IsTrackingEnabled tells whether the emails of an event are tracked, unless its organizer opted out
+func (s *Store) IsTrackingEnabled(ctx context.Context, eventID int32) (bool, error) {
+	optOuts, err := s.db.GetEmailTrackingOptOut(ctx, eventID)
+	if err != nil {
+		return false, err
+	}
+
+	return optOuts == 0, nil
+}
+
+// toEmailTemplate converts a database email template into the email template type
+func toEmailTemplate(emailTemplate database.EmailTemplate) *types.EmailTemplate {
+	template := &types.EmailTemplate{
+		ID:          emailTemplate.ID,
+		EventID:     emailTemplate.EventID,
+		Purpose:     string(emailTemplate.Purpose),
+		HeaderImage: emailTemplate.HeaderImage.String,
+		Content:     emailTemplate.Content.String,
+		FooterImage: emailTemplate.FooterImage.String,
+		Subject:     emailTemplate.Subject.String,
+		BgColor:     emailTemplate.BgColor.String,
+		Message:     emailTemplate.Message.String,
+	}
+	if emailTemplate.DeletedAt.Valid {
+		template.DeletedAt = &emailTemplate.DeletedAt.Time
+	}
+
+	return template
+}
@@ -6,7 +6,9 @@ import (
 
 	"github.com/gofiber/fiber/v2"
 
+	"github.com/jayden1905/event-registration-software/service/audit"
 	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/service/plan"
 	"github.com/jayden1905/event-registration-software/types"
 	"github.com/jayden1905/event-registration-software/utils"
 )
@@ -14,10 +16,11 @@ import (
 type Handler struct {
 	store     types.EventStore
 	userStore types.UserStore
+	enforcer  *plan.Enforcer
 }
 
-func NewHandler(store types.EventStore, userStore types.UserStore) *Handler {
-	return &Handler{store: store, userStore: userStore}
+func NewHandler(store types.EventStore, userStore types.UserStore, enforcer *plan.Enforcer) *Handler {
+	return &Handler{store: store, userStore: userStore, enforcer: enforcer}
 }
 
 func (h *Handler) RegisterRoutes(router fiber.Router) {
@@ -27,19 +30,35 @@ func (h *Handler) RegisterRoutes(router fiber.Router) {
 	router.Put("/event/update/:id", auth.WithJWTAuth(h.handleUpdateEvent, h.userStore))
 	router.Delete("/event/delete/:id", auth.WithJWTAuth(h.handleDeleteEventByEventID, h.userStore))
 	router.Delete("/events/delete-all", auth.WithJWTAuth(h.handleDeleteAllEvents, h.userStore))
+	router.Get("/events/deleted", auth.WithJWTAuth(h.handleGetDeletedEvents, h.userStore))
+	router.Post("/event/:id/restore", auth.WithJWTAuth(h.handleRestoreEvent, h.userStore))
 }
 
-// handleGetAllEvents fetches all events from the database
+// handleGetAllEvents fetches a page of the user's events, latest start first
 func (h *Handler) handleGetAllEvents(c *fiber.Ctx) error {
 	// get user id from the context
 	userID := auth.GetUserIDFromContext(c)
 
-	events, err := h.store.GetAllEvents(userID)
+	page, ferr := utils.ParsePage(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	events, next, err := h.store.GetEventsPaginated(userID, page)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
 
-	return c.Status(fiber.StatusOK).JSON(events)
+	var total *int64
+	if page.WithTotal {
+		count, err := h.store.CountEvents(userID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		total = &count
+	}
+
+	return c.Status(fiber.StatusOK).JSON(utils.NewPage(events, next, total, nil))
 }
 
 // handleGetEventByID fetches an event by its ID from the database
@@ -55,14 +74,10 @@ func (h *Handler) handleGetEventByID(c *fiber.Ctx) error {
 	}
 	eventID := int32(eventIDInt)
 
-	event, err := h.store.GetEventByID(eventID)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
-	}
-
-	// check if the user is the owner of the event
-	if userID != event.UserID {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "You are not authorized to view this event"})
+	// Check if the user is the owner of the event
+	event, ferr := utils.GetOwnedEventByID(eventID, userID, h.store)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
 	}
 
 	return c.Status(fiber.StatusOK).JSON(event)
@@ -95,18 +110,26 @@ func (h *Handler) handleCreateEvent(c *fiber.Ctx) error {
 	// get user id from the context
 	userID := auth.GetUserIDFromContext(c)
 
+	// Check that the plan of the user allows another event
+	if ferr := h.enforcer.CheckEvents(userID); ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
 	// Create a new event
-	if err := h.store.CreateNewEvent(c.Context(), &types.Event{
+	event := &types.Event{
 		Title:       payload.Title,
 		Description: payload.Description,
 		StartDate:   payload.StartDate,
 		EndDate:     payload.EndDate,
 		Location:    payload.Location,
 		UserID:      userID,
-	}); err != nil {
+	}
+	if err := h.store.CreateNewEvent(c.Context(), event); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
 
+	audit.Note(c, audit.Mutation{Action: "event.create", TargetType: "event", TargetID: event.EventID, EventID: event.EventID, EventOwnerID: userID, After: event})
+
 	return c.Status(fiber.StatusCreated).JSON(payload)
 }
 
@@ -140,19 +163,14 @@ func (h *Handler) handleUpdateEvent(c *fiber.Ctx) error {
 	}
 	eventID := int32(eventIDInt)
 
-	// Check if the event exists
-	event, err := h.store.GetEventByID(eventID)
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Event not found"})
-	}
-
-	// check if the user is the owner of the event
-	if userID != event.UserID {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "You are not authorized to update this event"})
+	// Check if the user is the owner of the event
+	event, ferr := utils.GetOwnedEventByID(eventID, userID, h.store)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
 	}
 
 	// Update the event
-	if err := h.store.UpdateEvent(c.Context(), &types.Event{
+	updated := &types.Event{
 		EventID:     eventID,
 		Title:       payload.Title,
 		Description: payload.Description,
@@ -160,10 +178,14 @@ func (h *Handler) handleUpdateEvent(c *fiber.Ctx) error {
 		EndDate:     payload.EndDate,
 		Location:    payload.Location,
 		UserID:      userID,
-	}); err != nil {
+		CreatedAt:   event.CreatedAt,
+	}
+	if err := h.store.UpdateEvent(c.Context(), updated); err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
 
+	audit.Note(c, audit.Mutation{Action: "event.update", TargetType: "event", TargetID: eventID, EventID: eventID, EventOwnerID: userID, Before: event, After: updated})
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Event updated successfully"})
 }
 
@@ -180,15 +202,10 @@ func (h *Handler) handleDeleteEventByEventID(c *fiber.Ctx) error {
 	}
 	eventID := int32(eventIDInt)
 
-	// Check if the event exists
-	event, err := h.store.GetEventByID(eventID)
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Event not found"})
-	}
-
-	// check if the user is the owner of the event
-	if userID != event.UserID {
-		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "You are not authorized to delete this event"})
+	// Check if the user is the owner of the event
+	event, ferr := utils.GetOwnedEventByID(eventID, userID, h.store)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
 	}
 
 	// Delete the event
@@ -196,6 +213,8 @@ func (h *Handler) handleDeleteEventByEventID(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
 
+	audit.Note(c, audit.Mutation{Action: "event.delete", TargetType: "event", TargetID: eventID, EventID: eventID, EventOwnerID: userID, Before: event})
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Event deleted successfully"})
 }
 
@@ -204,10 +223,86 @@ func (h *Handler) handleDeleteAllEvents(c *fiber.Ctx) error {
 	// get user id from the context
 	userID := auth.GetUserIDFromContext(c)
 
-	err := h.store.DeleteAllEvents(c.Context(), userID)
+	// Keep the events for the audit log
+	events, err := h.store.GetAllEvents(userID)
 	if err != nil {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
 
+	err = h.store.DeleteAllEvents(c.Context(), userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	for _, event := range events {
+		audit.Note(c, audit.Mutation{Action: "event.delete", TargetType: "event", TargetID: event.EventID, EventID: event.EventID, EventOwnerID: userID, Before: event})
+	}
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "All events deleted successfully"})
 }
+
+// handleGetDeletedEvents fetches the deleted events of the user that can still be restored
+func (h *Handler) handleGetDeletedEvents(c *fiber.Ctx) error {
+	page, ferr := utils.ParsePage(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	// get user id from the context
+	userID := auth.GetUserIDFromContext(c)
+
+	events, err := h.store.GetDeletedEvents(userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	list, ferr := utils.SlicePage(events, page, func(event *types.Event) int64 { return int64(event.EventID) }, nil)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
+// handleRestoreEvent brings back a deleted event before it is purged
+func (h *Handler) handleRestoreEvent(c *fiber.Ctx) error {
+	// get user id from the context
+	userID := auth.GetUserIDFromContext(c)
+
+	// get event id from the context
+	eventIDString := c.Params("id")
+	eventIDInt, err := strconv.Atoi(eventIDString)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid event id"})
+	}
+	eventID := int32(eventIDInt)
+
+	event, err := h.store.GetDeletedEventByID(eventID)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Deleted event not found"})
+	}
+
+	// check if the user is the owner of the event
+	if userID != event.UserID {
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "You are not authorized to restore this event"})
+	}
+
+	// Another event may have taken the title since this one was deleted
+	if _, err := h.store.GetEventByTitle(event.Title); err == nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Event with the same title already exists"})
+	}
+
+	// A restored event counts towards the plan again
+	if ferr := h.enforcer.CheckEvents(userID); ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	if err := h.store.RestoreEvent(c.Context(), eventID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+	}
+	event.DeletedAt = nil
+
+	audit.Note(c, audit.Mutation{Action: "event.restore", TargetType: "event", TargetID: eventID, EventID: eventID, EventOwnerID: userID, After: event})
+
+	return c.Status(fiber.StatusOK).JSON(event)
+}
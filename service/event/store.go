@@ -2,6 +2,8 @@ package event
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/jayden1905/event-registration-software/cmd/pkg/database"
 	"github.com/jayden1905/event-registration-software/types"
@@ -45,7 +47,7 @@ func (s *Store) GetAllEvents(userID int32) ([]*types.Event, error) {
 
 // CreateEvent creates a new event in the database
 func (s *Store) CreateNewEvent(ctx context.Context, event *types.Event) error {
-	err := s.db.CreateEvent(ctx, database.CreateEventParams{
+	id, err := s.db.CreateEvent(ctx, database.CreateEventParams{
 		Title:       event.Title,
 		Description: event.Description,
 		StartDate:   event.StartDate,
@@ -56,6 +58,7 @@ func (s *Store) CreateNewEvent(ctx context.Context, event *types.Event) error {
 	if err != nil {
 		return err
 	}
+	event.EventID = int32(id)
 
 	return nil
 }
@@ -97,6 +100,59 @@ func (s *Store) DeleteAllEvents(ctx context.Context, userID int32) error {
 	return nil
 }
 
+// GetEventsPaginated fetches the page of the events of every user, or of userID when it is not 0,
+// after a cursor, latest start first, and the cursor of the next page when there is one
+func (s *Store) GetEventsPaginated(userID int32, page *types.PageRequest) ([]*types.Event, *types.Cursor, error) {
+	params := database.GetEventsPaginatedParams{
+		UserID: sql.NullInt32{Int32: userID, Valid: userID != 0},
+		Limit:  page.Limit + 1,
+	}
+	if page.After != nil {
+		startDate, err := time.Parse(time.RFC3339Nano, page.After.Key)
+		if err != nil {
+			return nil, nil, err
+		}
+		params.AfterID = sql.NullInt32{Int32: int32(page.After.ID), Valid: true}
+		params.AfterDate = sql.NullTime{Time: startDate, Valid: true}
+	}
+
+	events, err := s.db.GetEventsPaginated(context.Background(), params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	allEvents := []*types.Event{}
+	for _, event := range events {
+		allEvents = append(allEvents, &types.Event{
+			EventID:     event.EventID,
+			Title:       event.Title,
+			Description: event.Description,
+			StartDate:   event.StartDate,
+			EndDate:     event.EndDate,
+			Location:    event.Location,
+			UserID:      event.UserID,
+			CreatedAt:   event.CreatedAt,
+			UpdatedAt:   event.UpdatedAt,
+		})
+	}
+
+	// The row past the limit only tells that there is a next page
+	if len(allEvents) <= int(page.Limit) {
+		return allEvents, nil, nil
+	}
+	allEvents = allEvents[:page.Limit]
+	last := allEvents[len(allEvents)-1]
+
+	return allEvents, &types.Cursor{Key: last.StartDate.Format(time.RFC3339Nano), ID: int64(last.EventID)}, nil
+}
+
+// CountEvents counts the events of every user, or of userID when it is not 0
+func (s *Store) CountEvents(userID int32) (int64, error) {
+	return s.db.CountEvents(context.Background(), database.CountEventsParams{
+		UserID: sql.NullInt32{Int32: userID, Valid: userID != 0},
+	})
+}
+
 // GetEventByTitle fetches an event by its title
 func (s *Store) GetEventByTitle(title string) (*types.Event, error) {
 	event, err := s.db.GetEventByTitle(context.Background(), title)
@@ -136,3 +192,56 @@ func (s *Store) GetEventByID(eventID int32) (*types.Event, error) {
 		UpdatedAt:   event.UpdatedAt,
 	}, nil
 }
+
+// GetDeletedEvents fetches the soft-deleted events of a user that can still be restored
+func (s *Store) GetDeletedEvents(userID int32) ([]*types.Event, error) {
+	events, err := s.db.GetDeletedEventsByUserID(context.Background(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var deletedEvents []*types.Event
+
+	for _, event := range events {
+		deletedEvents = append(deletedEvents, &types.Event{
+			EventID:     int32(event.EventID),
+			Title:       event.Title,
+			Description: event.Description,
+			StartDate:   event.StartDate,
+			EndDate:     event.EndDate,
+			Location:    event.Location,
+			UserID:      int32(event.UserID),
+			CreatedAt:   event.CreatedAt,
+			UpdatedAt:   event.UpdatedAt,
+			DeletedAt:   &event.DeletedAt.Time,
+		})
+	}
+
+	return deletedEvents, nil
+}
+
+// GetDeletedEventByID fetches a soft-deleted event by its ID
+func (s *Store) GetDeletedEventByID(eventID int32) (*types.Event, error) {
+	event, err := s.db.GetDeletedEventByID(context.Background(), eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Event{
+		EventID:     int32(event.EventID),
+		Title:       event.Title,
+		Description: event.Description,
+		StartDate:   event.StartDate,
+		EndDate:     event.EndDate,
+		Location:    event.Location,
+		UserID:      int32(event.UserID),
+		CreatedAt:   event.CreatedAt,
+		UpdatedAt:   event.UpdatedAt,
+		DeletedAt:   &event.DeletedAt.Time,
+	}, nil
+}
+
+// RestoreEvent brings a soft-deleted event back
+func (s *Store) RestoreEvent(ctx context.Context, eventID int32) error {
+	return s.db.RestoreEventByID(ctx, eventID)
+}
@@ -0,0 +1,69 @@
+package group
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+// CheckPrimary checks that an attendee can bring guests to an event. Groups
+// are one level deep, so a guest cannot have guests of their own.
+func CheckPrimary(primary *types.Attendee, eventID int32) error {
+	if primary.EventID != eventID {
+		return fmt.Errorf("attendee %d does not belong to the event", primary.ID)
+	}
+	if primary.PrimaryAttendeeID != 0 {
+		return fmt.Errorf("attendee %d is a guest and cannot bring guests", primary.ID)
+	}
+
+	return nil
+}
+
+// CheckGuest checks that an attendee can join the group of a primary attendee
+// of the same event. An attendee that brings guests cannot be a guest.
+func CheckGuest(primary *types.Attendee, guest *types.Attendee, guestHasGuests bool) error {
+	if guest.ID == primary.ID {
+		return fmt.Errorf("attendee %d cannot be their own guest", guest.ID)
+	}
+	if guest.EventID != primary.EventID {
+		return fmt.Errorf("attendee %d does not belong to the event", guest.ID)
+	}
+	if guest.PrimaryAttendeeID != 0 && guest.PrimaryAttendeeID != primary.ID {
+		return fmt.Errorf("attendee %d is already a guest of attendee %d", guest.ID, guest.PrimaryAttendeeID)
+	}
+	if guestHasGuests {
+		return fmt.Errorf("attendee %d brings guests and cannot be a guest", guest.ID)
+	}
+
+	return nil
+}
+
+// ImportGroups groups the rows of a CSV import, header excluded, that share a
+// value in the group column. Groups come in the order of their first row,
+// which is the primary attendee of the group; rows without a value, or alone
+// with theirs, are left out.
+func ImportGroups(rows [][]string, column int) [][]int {
+	byValue := make(map[string]int)
+	groups := [][]int{}
+	for i, row := range rows {
+		if column >= len(row) {
+			continue
+		}
+		value := strings.ToLower(strings.TrimSpace(row[column]))
+		if value == "" {
+			continue
+		}
+
+		index, ok := byValue[value]
+		if !ok {
+			index = len(groups)
+			byValue[value] = index
+			groups = append(groups, nil)
+		}
+		groups[index] = append(groups[index], i)
+	}
+
+	return slices.DeleteFunc(groups, func(group []int) bool { return len(group) < 2 })
+}
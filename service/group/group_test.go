@@ -0,0 +1,67 @@
+package group
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+func TestCheckPrimary(t *testing.T) {
+	if err := CheckPrimary(&types.Attendee{ID: 1, EventID: 1}, 1); err != nil {
+		t.Errorf("expected a standalone attendee to bring guests, got %v", err)
+	}
+	if err := CheckPrimary(&types.Attendee{ID: 1, EventID: 2}, 1); err == nil {
+		t.Error("expected an attendee of another event to be rejected")
+	}
+	if err := CheckPrimary(&types.Attendee{ID: 1, EventID: 1, PrimaryAttendeeID: 2}, 1); err == nil {
+		t.Error("expected a guest to be rejected as a primary attendee")
+	}
+}
+
+func TestCheckGuest(t *testing.T) {
+	primary := &types.Attendee{ID: 1, EventID: 1}
+
+	tests := []struct {
+		name      string
+		guest     *types.Attendee
+		hasGuests bool
+		ok        bool
+	}{
+		{"standalone", &types.Attendee{ID: 2, EventID: 1}, false, true},
+		{"already a guest of the primary", &types.Attendee{ID: 2, EventID: 1, PrimaryAttendeeID: 1}, false, true},
+		{"the primary itself", &types.Attendee{ID: 1, EventID: 1}, false, false},
+		{"another event", &types.Attendee{ID: 2, EventID: 2}, false, false},
+		{"guest of someone else", &types.Attendee{ID: 2, EventID: 1, PrimaryAttendeeID: 3}, false, false},
+		{"brings guests", &types.Attendee{ID: 2, EventID: 1}, true, false},
+	}
+
+	for _, tt := range tests {
+		err := CheckGuest(primary, tt.guest, tt.hasGuests)
+		if (err == nil) != tt.ok {
+			t.Errorf("%s: unexpected result %v", tt.name, err)
+		}
+	}
+}
+
+func TestImportGroups(t *testing.T) {
+	rows := [][]string{
+		{"Ann", "A1"},
+		{"Bob", ""},
+		{"Cat", "a1 "},
+		{"Dan", "B2"},
+		{"Eve"},
+		{"Fay", "C3"},
+		{"Gus", "C3"},
+		{"Hal", "A1"},
+	}
+
+	groups := ImportGroups(rows, 1)
+
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %v", groups)
+	}
+	if !slices.Equal(groups[0], []int{0, 2, 7}) || !slices.Equal(groups[1], []int{5, 6}) {
+		t.Errorf("unexpected groups %v", groups)
+	}
+}
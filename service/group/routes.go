@@ -0,0 +1,261 @@
+package group
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"slices"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/service/audit"
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/service/live"
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+type Handler struct {
+	attendeeStore types.AttendeeStore
+	seatingStore  types.SeatingStore
+	eventStore    types.EventStore
+	userStore     types.UserStore
+	hub           *live.Hub
+}
+
+func NewHandler(attendeeStore types.AttendeeStore, seatingStore types.SeatingStore, eventStore types.EventStore, userStore types.UserStore, hub *live.Hub) *Handler {
+	return &Handler{attendeeStore: attendeeStore, seatingStore: seatingStore, eventStore: eventStore, userStore: userStore, hub: hub}
+}
+
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/event/:event_id/attendees/:attendee_id/group", auth.WithJWTAuth(h.handleGetGroup, h.userStore))
+	router.Post("/event/:event_id/attendees/:attendee_id/guests", auth.WithJWTAuth(h.handleLinkGuests, h.userStore))
+	router.Delete("/event/:event_id/attendees/:attendee_id/group", auth.WithJWTAuth(h.handleLeaveGroup, h.userStore))
+}
+
+// Handler to get the group of an attendee, whether they are its primary attendee or one of its guests
+func (h *Handler) handleGetGroup(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("event_id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	attendee, ferr := h.getParamAttendee(c, event)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	primary := attendee
+	if attendee.PrimaryAttendeeID != 0 {
+		primary, ferr = h.getEventAttendee(event, attendee.PrimaryAttendeeID)
+		if ferr != nil && ferr.Code != fiber.StatusNotFound {
+			return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+		}
+		// The primary attendee was deleted, the guest is on their own
+		if ferr != nil {
+			primary = attendee
+		}
+	}
+
+	group, ferr := h.getGroup(primary)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(group)
+}
+
+// Handler to add attendees of the event as guests of a primary attendee,
+// optionally seating them at the table of the primary attendee
+func (h *Handler) handleLinkGuests(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("event_id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var payload types.LinkGuestsPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid payload"})
+	}
+	if invalidFields, err := utils.ValidatePayload(payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	primary, ferr := h.getParamAttendee(c, event)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+	if err := CheckPrimary(primary, event.EventID); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	slices.Sort(payload.GuestIDs)
+	payload.GuestIDs = slices.Compact(payload.GuestIDs)
+
+	// Validate every guest before linking anyone
+	guests := make([]*types.Attendee, 0, len(payload.GuestIDs))
+	for _, id := range payload.GuestIDs {
+		guest, ferr := h.getEventAttendee(event, id)
+		if ferr != nil {
+			return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+		}
+
+		guestGuests, err := h.attendeeStore.GetGuests(guest.ID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get guests"})
+		}
+		if err := CheckGuest(primary, guest, len(guestGuests) > 0); err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+		guests = append(guests, guest)
+	}
+
+	for _, guest := range guests {
+		if err := h.attendeeStore.UpdatePrimaryAttendee(c.Context(), guest.ID, primary.ID); err != nil {
+			log.Printf("Error linking attendee ID %d to attendee ID %d: %v", guest.ID, primary.ID, err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to link guests"})
+		}
+
+		if payload.SeatWithPrimary && guest.TableNo != primary.TableNo {
+			if err := h.seatingStore.AssignTable(c.Context(), event.EventID, guest.ID, primary.TableNo); err != nil {
+				log.Printf("Error seating attendee ID %d: %v", guest.ID, err)
+				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to seat guests"})
+			}
+		}
+	}
+
+	group, ferr := h.getGroup(primary)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	audit.Note(c, audit.Mutation{
+		Action:       "attendee.group_link",
+		TargetType:   "attendee",
+		TargetID:     primary.ID,
+		EventID:      event.EventID,
+		EventOwnerID: event.UserID,
+		Before:       guests,
+		After:        group,
+	})
+	for _, guest := range group.Guests {
+		if slices.Contains(payload.GuestIDs, guest.ID) {
+			h.publishUpdate(guest)
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(group)
+}
+
+// Handler to take a guest out of their group, or to dissolve the group when
+// the attendee is its primary attendee. Nobody is deleted.
+func (h *Handler) handleLeaveGroup(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("event_id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	attendee, ferr := h.getParamAttendee(c, event)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var released []*types.Attendee
+	if attendee.PrimaryAttendeeID != 0 {
+		if err := h.attendeeStore.UpdatePrimaryAttendee(c.Context(), attendee.ID, 0); err != nil {
+			log.Printf("Error unlinking attendee ID %d: %v", attendee.ID, err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to leave group"})
+		}
+		released = []*types.Attendee{attendee}
+	} else {
+		guests, err := h.attendeeStore.GetGuests(attendee.ID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get guests"})
+		}
+		if len(guests) == 0 {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "The attendee is not part of a group"})
+		}
+
+		if err := h.attendeeStore.ClearGuests(c.Context(), attendee.ID); err != nil {
+			log.Printf("Error dissolving the group of attendee ID %d: %v", attendee.ID, err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to dissolve group"})
+		}
+		released = guests
+	}
+
+	audit.Note(c, audit.Mutation{
+		Action:       "attendee.group_leave",
+		TargetType:   "attendee",
+		TargetID:     attendee.ID,
+		EventID:      event.EventID,
+		EventOwnerID: event.UserID,
+		Before:       released,
+	})
+	for _, guest := range released {
+		guest.PrimaryAttendeeID = 0
+		h.publishUpdate(guest)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Group updated successfully"})
+}
+
+// getGroup loads the guests of a primary attendee
+func (h *Handler) getGroup(primary *types.Attendee) (*types.AttendeeGroup, *fiber.Error) {
+	guests, err := h.attendeeStore.GetGuests(primary.ID)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to get guests")
+	}
+
+	return &types.AttendeeGroup{Primary: primary, Guests: guests}, nil
+}
+
+// getParamAttendee loads the attendee of the request and checks that it belongs to the event
+func (h *Handler) getParamAttendee(c *fiber.Ctx, event *types.Event) (*types.Attendee, *fiber.Error) {
+	attendeeID, err := strconv.Atoi(c.Params("attendee_id"))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid attendee ID")
+	}
+
+	return h.getEventAttendee(event, int32(attendeeID))
+}
+
+// getEventAttendee loads an attendee and checks that it belongs to the event
+func (h *Handler) getEventAttendee(event *types.Event, attendeeID int32) (*types.Attendee, *fiber.Error) {
+	attendee, err := h.attendeeStore.GetAttendeeByID(attendeeID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Attendee "+strconv.Itoa(int(attendeeID))+" not found")
+		}
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to get attendee")
+	}
+
+	if attendee.EventID != event.EventID {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Attendee "+strconv.Itoa(int(attendeeID))+" not found")
+	}
+
+	return attendee, nil
+}
+
+// publishUpdate pushes a group change to the live attendance stream of the event
+func (h *Handler) publishUpdate(attendee *types.Attendee) {
+	if !h.hub.HasSubscribers(attendee.EventID) {
+		return
+	}
+
+	stats, err := h.attendeeStore.GetAttendanceStats(attendee.EventID)
+	if err != nil {
+		log.Printf("Error getting attendance stats for event ID %d: %v", attendee.EventID, err)
+		return
+	}
+
+	h.hub.Publish(live.Update{
+		Type:     live.UpdateAttendee,
+		EventID:  attendee.EventID,
+		Attendee: attendee,
+		Stats:    stats,
+	})
+}
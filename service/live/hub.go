@@ -0,0 +1,95 @@
+package live
+
+import (
+	"sync"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+// Update types pushed to the dashboards of an event
+const (
+	UpdateSnapshot     = "snapshot"
+	UpdateCheckIn      = "check_in"
+	UpdateCheckOut     = "check_out"
+	UpdateRegistration = "registration"
+	UpdateAttendee     = "attendee_updated"
+	UpdateRemoval      = "removal"
+)
+
+// subscriberBuffer is how many updates a slow subscriber may lag behind before updates are dropped
+const subscriberBuffer = 16
+
+// Update is a message pushed to every subscriber of an event
+type Update struct {
+	Type     string                 `json:"type"`
+	EventID  int32                  `json:"event_id"`
+	Attendee *types.Attendee        `json:"attendee,omitempty"`
+	Stats    *types.AttendanceStats `json:"stats,omitempty"`
+	SentAt   time.Time              `json:"sent_at"`
+}
+
+// Hub is an in-process pub/sub hub keyed by event ID
+type Hub struct {
+	mu          sync.RWMutex
+	subscribers map[int32]map[chan Update]struct{}
+}
+
+// NewHub creates an empty Hub
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[int32]map[chan Update]struct{})}
+}
+
+// Subscribe registers a new subscriber for the event and returns its channel
+// together with a function that must be called to unsubscribe
+func (h *Hub) Subscribe(eventID int32) (<-chan Update, func()) {
+	ch := make(chan Update, subscriberBuffer)
+
+	h.mu.Lock()
+	if h.subscribers[eventID] == nil {
+		h.subscribers[eventID] = make(map[chan Update]struct{})
+	}
+	h.subscribers[eventID][ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			delete(h.subscribers[eventID], ch)
+			if len(h.subscribers[eventID]) == 0 {
+				delete(h.subscribers, eventID)
+			}
+			h.mu.Unlock()
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// HasSubscribers reports whether anyone is listening to the event
+func (h *Hub) HasSubscribers(eventID int32) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	return len(h.subscribers[eventID]) > 0
+}
+
+// Publish sends the update to every subscriber of its event without blocking.
+// Subscribers whose buffer is full miss the update.
+func (h *Hub) Publish(update Update) {
+	if update.SentAt.IsZero() {
+		update.SentAt = time.Now()
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subscribers[update.EventID] {
+		select {
+		case ch <- update:
+		default:
+		}
+	}
+}
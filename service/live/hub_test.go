@@ -0,0 +1,58 @@
+package live
+
+import (
+	"testing"
+)
+
+func TestHubPublishToEventSubscribers(t *testing.T) {
+	hub := NewHub()
+
+	updates, unsubscribe := hub.Subscribe(1)
+	defer unsubscribe()
+
+	other, unsubscribeOther := hub.Subscribe(2)
+	defer unsubscribeOther()
+
+	hub.Publish(Update{Type: UpdateCheckIn, EventID: 1})
+
+	select {
+	case update := <-updates:
+		if update.Type != UpdateCheckIn {
+			t.Errorf("expected %q update, got %q", UpdateCheckIn, update.Type)
+		}
+		if update.SentAt.IsZero() {
+			t.Error("expected SentAt to be set")
+		}
+	default:
+		t.Fatal("expected subscriber of event 1 to receive the update")
+	}
+
+	select {
+	case <-other:
+		t.Error("expected subscriber of event 2 not to receive the update")
+	default:
+	}
+}
+
+func TestHubUnsubscribe(t *testing.T) {
+	hub := NewHub()
+
+	updates, unsubscribe := hub.Subscribe(1)
+	if !hub.HasSubscribers(1) {
+		t.Fatal("expected event 1 to have subscribers")
+	}
+
+	unsubscribe()
+	unsubscribe()
+
+	if hub.HasSubscribers(1) {
+		t.Error("expected event 1 to have no subscribers")
+	}
+
+	if _, ok := <-updates; ok {
+		t.Error("expected channel to be closed")
+	}
+
+	// Publishing without subscribers must not block or panic
+	hub.Publish(Update{Type: UpdateCheckIn, EventID: 1})
+}
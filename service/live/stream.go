@@ -0,0 +1,74 @@
+package live
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/valyala/fasthttp"
+)
+
+// heartbeatInterval keeps idle streams open through proxies and detects closed clients
+const heartbeatInterval = 15 * time.Second
+
+// ServeSSE streams the updates of an event to the client as Server-Sent Events.
+// The snapshot, if any, is sent first so the dashboard can render without waiting.
+func ServeSSE(c *fiber.Ctx, hub *Hub, eventID int32, snapshot *Update) error {
+	c.Set("Content-Type", "text/event-stream")
+	c.Set("Cache-Control", "no-cache")
+	c.Set("Connection", "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	updates, unsubscribe := hub.Subscribe(eventID)
+
+	c.Context().SetBodyStreamWriter(fasthttp.StreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+
+		if snapshot != nil {
+			if err := writeEvent(w, *snapshot); err != nil {
+				return
+			}
+		}
+
+		ticker := time.NewTicker(heartbeatInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case update, ok := <-updates:
+				if !ok {
+					return
+				}
+				if err := writeEvent(w, update); err != nil {
+					return
+				}
+			case <-ticker.C:
+				// A failed flush means the client has gone away
+				if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+					return
+				}
+				if err := w.Flush(); err != nil {
+					return
+				}
+			}
+		}
+	}))
+
+	return nil
+}
+
+// writeEvent writes a single SSE frame and flushes it to the client
+func writeEvent(w *bufio.Writer, update Update) error {
+	data, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", update.Type, data); err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
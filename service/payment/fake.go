@@ -0,0 +1,158 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/config"
+)
+
+const (
+	FakeProviderName = "fake"
+	// FakeSignatureHeader carries the hex HMAC-SHA256 of a fake webhook body
+	FakeSignatureHeader = "X-Fake-Signature"
+)
+
+type fakeCheckout struct {
+	request  CheckoutRequest
+	status   string
+	refunded int64
+}
+
+// FakeProvider is an in-memory payment provider for tests and local
+// development. Checkouts are never paid on their own: call Complete or Fail to
+// get the signed webhook the real provider would have sent. Like a real
+// provider it no longer takes the payment once the checkout has expired.
+type FakeProvider struct {
+	secret    string
+	mu        sync.Mutex
+	next      int
+	checkouts map[string]*fakeCheckout
+}
+
+// NewFakeProvider creates a FakeProvider signing its webhooks with secret
+func NewFakeProvider(secret string) *FakeProvider {
+	return &FakeProvider{secret: secret, checkouts: make(map[string]*fakeCheckout)}
+}
+
+func (p *FakeProvider) Name() string {
+	return FakeProviderName
+}
+
+// CreateCheckout records a checkout that waits for Complete or Fail
+func (p *FakeProvider) CreateCheckout(ctx context.Context, request CheckoutRequest) (*Checkout, error) {
+	if request.AmountCents <= 0 {
+		return nil, fmt.Errorf("checkout amount must be positive")
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.next++
+	id := fmt.Sprintf("fake_cs_%d", p.next)
+	p.checkouts[id] = &fakeCheckout{request: request, status: "open"}
+
+	return &Checkout{
+		ID:  id,
+		URL: fmt.Sprintf("%s/api/v1/payments/fake/%s", config.Envs.BackendHost, id),
+	}, nil
+}
+
+// ParseWebhook checks the signature of a webhook built by Complete, Fail or
+// Refund and decodes it
+func (p *FakeProvider) ParseWebhook(payload []byte, header func(key string) string) (*WebhookEvent, error) {
+	if !hmac.Equal([]byte(header(FakeSignatureHeader)), []byte(p.sign(payload))) {
+		return nil, ErrInvalidSignature
+	}
+
+	var body struct {
+		Type       string `json:"type"`
+		CheckoutID string `json:"checkout_id"`
+	}
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return nil, fmt.Errorf("invalid webhook payload: %v", err)
+	}
+
+	return &WebhookEvent{Type: body.Type, CheckoutID: body.CheckoutID}, nil
+}
+
+// Refund gives back part or all of a completed checkout
+func (p *FakeProvider) Refund(ctx context.Context, checkoutID string, amountCents int64, currency string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	checkout, ok := p.checkouts[checkoutID]
+	if !ok {
+		return fmt.Errorf("checkout %s not found", checkoutID)
+	}
+	if checkout.status != "paid" {
+		return fmt.Errorf("checkout %s is not paid", checkoutID)
+	}
+	if currency != checkout.request.Currency {
+		return fmt.Errorf("refund currency %s does not match checkout currency %s", currency, checkout.request.Currency)
+	}
+	if checkout.refunded+amountCents > checkout.request.AmountCents {
+		return fmt.Errorf("refund exceeds the amount paid")
+	}
+	checkout.refunded += amountCents
+
+	return nil
+}
+
+// Complete marks a checkout as paid and returns the webhook body and signature reporting it
+func (p *FakeProvider) Complete(checkoutID string) ([]byte, string, error) {
+	return p.settle(checkoutID, "paid", EventCheckoutCompleted)
+}
+
+// Fail marks a checkout as failed and returns the webhook body and signature reporting it
+func (p *FakeProvider) Fail(checkoutID string) ([]byte, string, error) {
+	return p.settle(checkoutID, "failed", EventCheckoutFailed)
+}
+
+// Refunded returns the amount refunded on a checkout
+func (p *FakeProvider) Refunded(checkoutID string) int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if checkout, ok := p.checkouts[checkoutID]; ok {
+		return checkout.refunded
+	}
+	return 0
+}
+
+func (p *FakeProvider) settle(checkoutID string, status string, eventType string) ([]byte, string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	checkout, ok := p.checkouts[checkoutID]
+	if !ok {
+		return nil, "", fmt.Errorf("checkout %s not found", checkoutID)
+	}
+	if checkout.status != "open" {
+		return nil, "", fmt.Errorf("checkout %s is already %s", checkoutID, checkout.status)
+	}
+	if expiresAt := checkout.request.ExpiresAt; !expiresAt.IsZero() && time.Now().After(expiresAt) {
+		checkout.status = "expired"
+		return nil, "", fmt.Errorf("checkout %s has expired", checkoutID)
+	}
+	checkout.status = status
+
+	payload, err := json.Marshal(map[string]string{"type": eventType, "checkout_id": checkoutID})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return payload, p.sign(payload), nil
+}
+
+func (p *FakeProvider) sign(payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(p.secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,136 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestCheckout(t *testing.T, provider *FakeProvider) *Checkout {
+	t.Helper()
+
+	checkout, err := provider.CreateCheckout(context.Background(), CheckoutRequest{
+		OrderReference: "abc123",
+		AmountCents:    2500,
+		Currency:       "USD",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating checkout: %v", err)
+	}
+
+	return checkout
+}
+
+func header(signature string) func(key string) string {
+	return func(key string) string {
+		if key == FakeSignatureHeader {
+			return signature
+		}
+		return ""
+	}
+}
+
+func TestFakeCheckoutRejectsFreeAmount(t *testing.T) {
+	provider := NewFakeProvider("secret")
+
+	if _, err := provider.CreateCheckout(context.Background(), CheckoutRequest{Currency: "USD"}); err == nil {
+		t.Error("expected an error for a checkout without an amount")
+	}
+}
+
+func TestFakeCompleteProducesVerifiableWebhook(t *testing.T) {
+	provider := NewFakeProvider("secret")
+	checkout := newTestCheckout(t, provider)
+
+	payload, signature, err := provider.Complete(checkout.ID)
+	if err != nil {
+		t.Fatalf("unexpected error completing checkout: %v", err)
+	}
+
+	event, err := provider.ParseWebhook(payload, header(signature))
+	if err != nil {
+		t.Fatalf("unexpected error parsing webhook: %v", err)
+	}
+	if event.Type != EventCheckoutCompleted || event.CheckoutID != checkout.ID {
+		t.Errorf("expected %s for %s, got %s for %s", EventCheckoutCompleted, checkout.ID, event.Type, event.CheckoutID)
+	}
+
+	// A checkout is settled once
+	if _, _, err := provider.Fail(checkout.ID); err == nil {
+		t.Error("expected an error failing a completed checkout")
+	}
+}
+
+func TestFakeWebhookSignature(t *testing.T) {
+	provider := NewFakeProvider("secret")
+	checkout := newTestCheckout(t, provider)
+
+	payload, signature, err := provider.Fail(checkout.ID)
+	if err != nil {
+		t.Fatalf("unexpected error failing checkout: %v", err)
+	}
+
+	tampered := append([]byte{}, payload...)
+	tampered[len(tampered)-2] = 'x'
+	if _, err := provider.ParseWebhook(tampered, header(signature)); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature for a tampered payload, got %v", err)
+	}
+
+	other := NewFakeProvider("other secret")
+	if _, err := other.ParseWebhook(payload, header(signature)); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature for another secret, got %v", err)
+	}
+
+	if _, err := provider.ParseWebhook(payload, header("")); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature without a signature, got %v", err)
+	}
+}
+
+func TestFakeRefund(t *testing.T) {
+	provider := NewFakeProvider("secret")
+	checkout := newTestCheckout(t, provider)
+	ctx := context.Background()
+
+	if err := provider.Refund(ctx, checkout.ID, 2500, "USD"); err == nil {
+		t.Error("expected an error refunding an unpaid checkout")
+	}
+
+	if _, _, err := provider.Complete(checkout.ID); err != nil {
+		t.Fatalf("unexpected error completing checkout: %v", err)
+	}
+
+	if err := provider.Refund(ctx, checkout.ID, 1000, "EUR"); err == nil {
+		t.Error("expected an error refunding in another currency")
+	}
+	if err := provider.Refund(ctx, checkout.ID, 1000, "USD"); err != nil {
+		t.Fatalf("unexpected error refunding: %v", err)
+	}
+	if err := provider.Refund(ctx, checkout.ID, 2000, "USD"); err == nil {
+		t.Error("expected an error refunding more than was paid")
+	}
+	if refunded := provider.Refunded(checkout.ID); refunded != 1000 {
+		t.Errorf("expected 1000 refunded, got %d", refunded)
+	}
+}
+
+func TestFakeCheckoutExpires(t *testing.T) {
+	provider := NewFakeProvider("secret")
+
+	checkout, err := provider.CreateCheckout(context.Background(), CheckoutRequest{
+		OrderReference: "abc123",
+		AmountCents:    2500,
+		Currency:       "USD",
+		ExpiresAt:      time.Now().Add(-time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error creating checkout: %v", err)
+	}
+
+	if _, _, err := provider.Complete(checkout.ID); err == nil {
+		t.Error("expected an error completing an expired checkout")
+	}
+	if _, _, err := provider.Fail(checkout.ID); err == nil {
+		t.Error("expected an expired checkout to stay expired")
+	}
+}
@@ -0,0 +1,73 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Types of webhook events reported by a payment provider
+const (
+	EventCheckoutCompleted = "checkout.completed"
+	EventCheckoutFailed    = "checkout.failed"
+	EventRefunded          = "charge.refunded"
+)
+
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// CheckoutRequest describes the payment a guest is asked to make
+type CheckoutRequest struct {
+	OrderReference string
+	Description    string
+	Email          string
+	AmountCents    int64
+	Currency       string
+	SuccessURL     string
+	CancelURL      string
+	// ExpiresAt is when the provider stops taking the payment
+	ExpiresAt time.Time
+}
+
+// Checkout is a payment session created at the provider. The guest pays on
+// the provider's page at URL; ID identifies the payment in webhooks and refunds.
+type Checkout struct {
+	ID  string
+	URL string
+}
+
+// WebhookEvent is a payment update pushed by the provider
+type WebhookEvent struct {
+	Type       string
+	CheckoutID string
+}
+
+// Provider takes payments for orders. Implementations wrap a payment service
+// such as Stripe; FakeProvider keeps everything in memory.
+type Provider interface {
+	// Name identifies the provider in stored orders
+	Name() string
+	CreateCheckout(ctx context.Context, request CheckoutRequest) (*Checkout, error)
+	// ParseWebhook verifies and decodes a webhook request body
+	ParseWebhook(payload []byte, header func(key string) string) (*WebhookEvent, error)
+	Refund(ctx context.Context, checkoutID string, amountCents int64, currency string) error
+}
+
+// NewProvider returns the payment provider configured by name. Webhooks
+// cannot be verified without a secret, and the fake provider lets anyone mark
+// a checkout as paid, so neither is accepted in production.
+func NewProvider(name string, webhookSecret string, production bool) (Provider, error) {
+	if webhookSecret == "" {
+		return nil, errors.New("PAYMENT_WEBHOOK_SECRET is required to verify payment webhooks")
+	}
+
+	switch name {
+	case "", FakeProviderName:
+		if production {
+			return nil, errors.New("the fake payment provider cannot be used in production")
+		}
+		return NewFakeProvider(webhookSecret), nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", name)
+	}
+}
@@ -0,0 +1,32 @@
+package payment
+
+import "testing"
+
+func TestNewProvider(t *testing.T) {
+	tests := []struct {
+		name       string
+		provider   string
+		secret     string
+		production bool
+		valid      bool
+	}{
+		{"fake in development", FakeProviderName, "secret", false, true},
+		{"default in development", "", "secret", false, true},
+		{"fake in production", FakeProviderName, "secret", true, false},
+		{"default in production", "", "secret", true, false},
+		{"no webhook secret", FakeProviderName, "", false, false},
+		{"unknown provider", "paypal", "secret", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewProvider(tt.provider, tt.secret, tt.production)
+			if tt.valid && (err != nil || provider == nil) {
+				t.Errorf("expected a provider, got %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,576 @@
+package payment
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/config"
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/service/email"
+	"github.com/jayden1905/event-registration-software/service/live"
+	"github.com/jayden1905/event-registration-software/service/plan"
+	"github.com/jayden1905/event-registration-software/service/ticket"
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+type Handler struct {
+	store           types.OrderStore
+	provider        Provider
+	attendeeStore   types.AttendeeStore
+	ticketTypeStore types.TicketTypeStore
+	eventStore      types.EventStore
+	userStore       types.UserStore
+	enforcer        *plan.Enforcer
+	mailer          email.Mailer
+	hub             *live.Hub
+}
+
+func NewHandler(store types.OrderStore, provider Provider, attendeeStore types.AttendeeStore, ticketTypeStore types.TicketTypeStore, eventStore types.EventStore, userStore types.UserStore, enforcer *plan.Enforcer, mailer email.Mailer, hub *live.Hub) *Handler {
+	return &Handler{store: store, provider: provider, attendeeStore: attendeeStore, ticketTypeStore: ticketTypeStore, eventStore: eventStore, userStore: userStore, enforcer: enforcer, mailer: mailer, hub: hub}
+}
+
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Post("/event/:id/register", h.handleRegister)
+	router.Post("/payments/webhook", h.handleWebhook)
+	router.Get("/orders/:reference", h.handleGetOrderByReference)
+	router.Get("/orders/:reference/receipt", h.handleGetReceipt)
+	router.Get("/event/:id/orders", auth.WithJWTAuth(h.handleGetOrders, h.userStore))
+	router.Get("/event/:id/orders/:order_id", auth.WithJWTAuth(h.handleGetOrder, h.userStore))
+	router.Post("/event/:id/orders/:order_id/refund", auth.WithJWTAuth(h.handleRefundOrder, h.userStore))
+
+	// The fake provider has no payment page, these routes stand in for it during development
+	if fake, ok := h.provider.(*FakeProvider); ok && !config.Envs.ISProduction {
+		router.Post("/payments/fake/:checkout_id/complete", h.handleSettleFakeCheckout(fake.Complete))
+		router.Post("/payments/fake/:checkout_id/fail", h.handleSettleFakeCheckout(fake.Fail))
+	}
+}
+
+// Handler for a guest to register for an event with a ticket. Free tickets
+// confirm the registration straight away; paid tickets keep it pending and
+// return the checkout to pay at.
+func (h *Handler) handleRegister(c *fiber.Ctx) error {
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid event ID"})
+	}
+
+	event, err := h.eventStore.GetEventByID(int32(eventID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Event not found"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get event"})
+	}
+
+	var payload types.RegisterPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	// Hidden ticket types are only handed out by the organizer
+	ticketType, err := h.ticketTypeStore.GetTicketTypeByID(payload.TicketTypeID)
+	if err != nil || ticketType.EventID != event.EventID || !ticketType.Visible {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Ticket type not found"})
+	}
+	if !ticket.OnSale(ticketType, time.Now()) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Ticket type is not on sale"})
+	}
+	if ticketType.PriceCents > 0 && h.provider == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "Paid registration is not available"})
+	}
+
+	// The event counts against the attendee limit of its owner's plan
+	if ferr := h.enforcer.CheckAttendees(event, 1); ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	payload.Email = utils.NormalizeEmail(payload.Email)
+	_, err = h.attendeeStore.GetAttendeeByEmailAndEventID(payload.Email, event.EventID)
+	if err == nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Email is already registered for this event"})
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get attendee"})
+	}
+
+	qrCode, err := utils.GenerateQRCodeImage(payload.Email)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Failed to generate or upload QR code: %v", err)})
+	}
+
+	attendee := &types.Attendee{
+		FirstName:          payload.FirstName,
+		LastName:           payload.LastName,
+		Email:              payload.Email,
+		EventID:            event.EventID,
+		QrCode:             qrCode,
+		CompanyName:        payload.CompanyName,
+		Title:              payload.Title,
+		TicketTypeID:       ticketType.ID,
+		RegistrationStatus: types.RegistrationStatusConfirmed,
+	}
+	if ticketType.PriceCents > 0 {
+		attendee.RegistrationStatus = types.RegistrationStatusPendingPayment
+	}
+
+	// The ticket is taken here, so a pending registration holds its place until the payment settles
+	if err := h.attendeeStore.CreateAttendee(c.Context(), attendee); err != nil {
+		if deleteErr := utils.DeleteQrImageFromCloudinary(qrCode); deleteErr != nil {
+			log.Printf("Error deleting QR code of failed registration: %v", deleteErr)
+		}
+		if errors.Is(err, types.ErrTicketTypeSoldOut) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Ticket type is sold out"})
+		}
+		// Two registrations with the same email can pass the check above at once
+		if isDuplicateEntry(err) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Email is already registered for this event"})
+		}
+		log.Printf("Error registering %s for event ID %d: %v", payload.Email, event.EventID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to register"})
+	}
+
+	if attendee.RegistrationStatus == types.RegistrationStatusConfirmed {
+		h.publishUpdate(live.UpdateRegistration, attendee)
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"attendee": attendee})
+	}
+
+	reference, err := newReference()
+	if err != nil {
+		h.removeAttendee(attendee.ID)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create order"})
+	}
+
+	order := &types.Order{
+		Reference:    reference,
+		EventID:      event.EventID,
+		AttendeeID:   attendee.ID,
+		TicketTypeID: ticketType.ID,
+		Email:        attendee.Email,
+		FirstName:    attendee.FirstName,
+		LastName:     attendee.LastName,
+		AmountCents:  ticketType.PriceCents,
+		Currency:     ticketType.Currency,
+		Provider:     h.provider.Name(),
+	}
+	if err := h.store.CreateOrder(c.Context(), order); err != nil {
+		log.Printf("Error creating order for attendee ID %d: %v", attendee.ID, err)
+		h.removeAttendee(attendee.ID)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create order"})
+	}
+
+	checkout, err := h.provider.CreateCheckout(c.Context(), CheckoutRequest{
+		OrderReference: order.Reference,
+		Description:    fmt.Sprintf("%s - %s", event.Title, ticketType.Name),
+		Email:          order.Email,
+		AmountCents:    order.AmountCents,
+		Currency:       order.Currency,
+		SuccessURL:     fmt.Sprintf("%s/orders/%s", config.Envs.PublicHost, order.Reference),
+		CancelURL:      fmt.Sprintf("%s/events/%d", config.Envs.PublicHost, event.EventID),
+		ExpiresAt:      time.Now().Add(checkoutExpiry()),
+	})
+	if err == nil {
+		order.ProviderRef = checkout.ID
+		order.CheckoutURL = checkout.URL
+		err = h.store.SetOrderCheckout(c.Context(), order)
+	}
+	if err != nil {
+		log.Printf("Error creating checkout for order ID %d: %v", order.ID, err)
+		if failErr := h.store.MarkOrderFailed(c.Context(), order); failErr != nil {
+			log.Printf("Error failing order ID %d: %v", order.ID, failErr)
+		}
+		h.removeAttendee(attendee.ID)
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": "Failed to create checkout"})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"attendee":     attendee,
+		"order":        order,
+		"checkout_url": order.CheckoutURL,
+	})
+}
+
+// Handler for the payment updates pushed by the payment provider
+func (h *Handler) handleWebhook(c *fiber.Ctx) error {
+	if h.provider == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "Payments are not configured"})
+	}
+
+	event, err := h.provider.ParseWebhook(c.Body(), func(key string) string { return c.Get(key) })
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+	}
+
+	if err := h.applyWebhookEvent(c.Context(), event); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Order not found"})
+		}
+		log.Printf("Error handling %s webhook for checkout %s: %v", event.Type, event.CheckoutID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to handle webhook"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"received": true})
+}
+
+// handleSettleFakeCheckout pays or fails a fake checkout and feeds the resulting webhook back in
+func (h *Handler) handleSettleFakeCheckout(settle func(checkoutID string) ([]byte, string, error)) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		payload, signature, err := settle(c.Params("checkout_id"))
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": err.Error()})
+		}
+
+		event, err := h.provider.ParseWebhook(payload, func(key string) string { return signature })
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
+		}
+		if err := h.applyWebhookEvent(c.Context(), event); err != nil {
+			log.Printf("Error handling fake %s webhook for checkout %s: %v", event.Type, event.CheckoutID, err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to handle webhook"})
+		}
+
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"checkout_id": event.CheckoutID, "type": event.Type})
+	}
+}
+
+// Handler for a guest to follow their order after the checkout
+func (h *Handler) handleGetOrderByReference(c *fiber.Ctx) error {
+	order, err := h.store.GetOrderByReference(c.Params("reference"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Order not found"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(order)
+}
+
+// Handler for a guest to get the receipt of a paid order
+func (h *Handler) handleGetReceipt(c *fiber.Ctx) error {
+	order, err := h.store.GetOrderByReference(c.Params("reference"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Order not found"})
+	}
+	if order.PaidAt == nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Order is not paid"})
+	}
+
+	receipt, err := h.buildReceipt(order)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get receipt"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(receipt)
+}
+
+// Handler to list the orders of an event
+func (h *Handler) handleGetOrders(c *fiber.Ctx) error {
+	page, ferr := utils.ParsePage(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	orders, next, err := h.store.GetOrdersByEventID(event.EventID, page)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get orders"})
+	}
+
+	var total *int64
+	if page.WithTotal {
+		count, err := h.store.CountOrdersByEventID(event.EventID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to count orders"})
+		}
+		total = &count
+	}
+
+	return c.Status(fiber.StatusOK).JSON(utils.NewPage(orders, next, total, nil))
+}
+
+// Handler to get an order of an event
+func (h *Handler) handleGetOrder(c *fiber.Ctx) error {
+	order, ferr := h.getOwnedOrder(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(order)
+}
+
+// Handler to refund a paid order in full and cancel its registration
+func (h *Handler) handleRefundOrder(c *fiber.Ctx) error {
+	order, ferr := h.getOwnedOrder(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	if order.Status != types.OrderStatusPaid {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": types.ErrOrderNotPaid.Error()})
+	}
+
+	if h.provider == nil {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{"error": "Payments are not configured"})
+	}
+
+	if err := h.provider.Refund(c.Context(), order.ProviderRef, order.AmountCents, order.Currency); err != nil {
+		log.Printf("Error refunding order ID %d: %v", order.ID, err)
+		return c.Status(fiber.StatusBadGateway).JSON(fiber.Map{"error": "Failed to refund payment"})
+	}
+
+	attendeeID := order.AttendeeID
+	if err := h.store.MarkOrderRefunded(c.Context(), order); err != nil {
+		log.Printf("Error marking order ID %d refunded: %v", order.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to refund order"})
+	}
+	h.removeAttendee(attendeeID)
+
+	return c.Status(fiber.StatusOK).JSON(order)
+}
+
+// applyWebhookEvent settles the order of a checkout. Events for orders that
+// were already settled are ignored, as providers may deliver them twice.
+func (h *Handler) applyWebhookEvent(ctx context.Context, event *WebhookEvent) error {
+	order, err := h.store.GetOrderByProviderRef(h.provider.Name(), event.CheckoutID)
+	if err != nil {
+		return err
+	}
+	attendeeID := order.AttendeeID
+
+	switch event.Type {
+	case EventCheckoutCompleted:
+		err = h.store.MarkOrderPaid(ctx, order)
+		if errors.Is(err, types.ErrOrderNotPending) {
+			// The ticket of an expired order may already be someone else's,
+			// a payment that still went through is given back
+			if order.Status == types.OrderStatusExpired {
+				return h.provider.Refund(ctx, event.CheckoutID, order.AmountCents, order.Currency)
+			}
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if attendee, err := h.attendeeStore.GetAttendeeByID(attendeeID); err == nil {
+			h.publishUpdate(live.UpdateRegistration, attendee)
+		}
+		h.sendReceipt(order)
+
+	case EventCheckoutFailed:
+		err = h.store.MarkOrderFailed(ctx, order)
+		if errors.Is(err, types.ErrOrderNotPending) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		h.removeAttendee(attendeeID)
+
+	case EventRefunded:
+		err = h.store.MarkOrderRefunded(ctx, order)
+		if errors.Is(err, types.ErrOrderNotPaid) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		h.removeAttendee(attendeeID)
+	}
+
+	return nil
+}
+
+// StartCheckoutExpiry expires the checkouts left unpaid every interval in the background
+func (h *Handler) StartCheckoutExpiry(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for now := range ticker.C {
+			expired, err := h.ExpireCheckouts(context.Background(), now)
+			if err != nil {
+				log.Printf("Error expiring checkouts: %v", err)
+			}
+			if expired > 0 {
+				log.Printf("Expired %d unpaid checkouts", expired)
+			}
+		}
+	}()
+}
+
+// ExpireCheckouts expires the orders still pending for longer than the
+// checkout expiry and removes their pending attendees, which gives their
+// tickets back. Orders settled in the meantime are left as they are.
+func (h *Handler) ExpireCheckouts(ctx context.Context, now time.Time) (int, error) {
+	orders, err := h.store.GetPendingOrdersCreatedBefore(now.Add(-checkoutExpiry()))
+	if err != nil {
+		return 0, err
+	}
+
+	expired := 0
+	for _, order := range orders {
+		err := h.store.MarkOrderExpired(ctx, order)
+		if errors.Is(err, types.ErrOrderNotPending) {
+			continue
+		}
+		if err != nil {
+			return expired, err
+		}
+
+		h.removeAttendee(order.AttendeeID)
+		expired++
+	}
+
+	return expired, nil
+}
+
+// buildReceipt collects the details printed on the receipt of an order
+func (h *Handler) buildReceipt(order *types.Order) (*types.Receipt, error) {
+	event, err := h.eventStore.GetEventByID(order.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	ticketTypeName := ""
+	if order.TicketTypeID != 0 {
+		if ticketType, err := h.ticketTypeStore.GetTicketTypeByID(order.TicketTypeID); err == nil {
+			ticketTypeName = ticketType.Name
+		}
+	}
+
+	return &types.Receipt{
+		ReceiptNumber:  order.ReceiptNumber,
+		OrderReference: order.Reference,
+		EventTitle:     event.Title,
+		EventStartDate: event.StartDate,
+		EventLocation:  event.Location,
+		Name:           order.FirstName + " " + order.LastName,
+		Email:          order.Email,
+		TicketType:     ticketTypeName,
+		AmountCents:    order.AmountCents,
+		Currency:       order.Currency,
+		PaidAt:         *order.PaidAt,
+		RefundedAt:     order.RefundedAt,
+	}, nil
+}
+
+// sendReceipt emails the receipt of a paid order. Failures are logged, the
+// receipt stays available from the order.
+func (h *Handler) sendReceipt(order *types.Order) {
+	receipt, err := h.buildReceipt(order)
+	if err == nil {
+		err = h.mailer.SendReceiptEmail(receipt)
+	}
+	if err != nil {
+		log.Printf("Error sending receipt of order ID %d: %v", order.ID, err)
+	}
+}
+
+// removeAttendee deletes the attendee of a failed, expired or refunded order,
+// which also gives their ticket back
+func (h *Handler) removeAttendee(attendeeID int32) {
+	if attendeeID == 0 {
+		return
+	}
+
+	attendee, err := h.attendeeStore.GetAttendeeByID(attendeeID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("Error getting attendee ID %d: %v", attendeeID, err)
+		}
+		return
+	}
+
+	if err := utils.DeleteQrImageFromCloudinary(attendee.QrCode); err != nil {
+		log.Printf("Error deleting QR code of attendee ID %d: %v", attendeeID, err)
+	}
+	if err := h.attendeeStore.DeleteAttendeeByID(attendeeID); err != nil {
+		log.Printf("Error deleting attendee ID %d: %v", attendeeID, err)
+		return
+	}
+
+	h.publishUpdate(live.UpdateRemoval, attendee)
+}
+
+// publishUpdate pushes a registration change to the live attendance stream of the event
+func (h *Handler) publishUpdate(updateType string, attendee *types.Attendee) {
+	if !h.hub.HasSubscribers(attendee.EventID) {
+		return
+	}
+
+	stats, err := h.attendeeStore.GetAttendanceStats(attendee.EventID)
+	if err != nil {
+		log.Printf("Error getting attendance stats for event ID %d: %v", attendee.EventID, err)
+		return
+	}
+
+	h.hub.Publish(live.Update{
+		Type:     updateType,
+		EventID:  attendee.EventID,
+		Attendee: attendee,
+		Stats:    stats,
+	})
+}
+
+// getOwnedOrder loads the order of the request and checks that it belongs to an event of the current user
+func (h *Handler) getOwnedOrder(c *fiber.Ctx) (*types.Order, *fiber.Error) {
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return nil, ferr
+	}
+
+	orderID, err := strconv.Atoi(c.Params("order_id"))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid order ID")
+	}
+
+	order, err := h.store.GetOrderByID(int32(orderID))
+	if err != nil || order.EventID != event.EventID {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Order not found")
+	}
+
+	return order, nil
+}
+
+// checkoutExpiry is how long a pending registration holds its ticket while the guest pays
+func checkoutExpiry() time.Duration {
+	return time.Duration(config.Envs.CheckoutExpiryMinutes) * time.Minute
+}
+
+// isDuplicateEntry reports whether an insert hit a unique key, here the email of the attendees of an event
+func isDuplicateEntry(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1062
+}
+
+// newReference returns a random order reference that is hard to guess, as it
+// gives access to the order and its receipt
+func newReference() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
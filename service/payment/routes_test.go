@@ -0,0 +1,78 @@
+package payment
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+type eventStore struct {
+	types.EventStore
+}
+
+func (eventStore) GetEventByID(eventID int32) (*types.Event, error) {
+	return &types.Event{EventID: eventID, UserID: 3}, nil
+}
+
+type ticketTypeStore struct {
+	types.TicketTypeStore
+}
+
+func (ticketTypeStore) GetTicketTypeByID(id int32) (*types.TicketType, error) {
+	return &types.TicketType{ID: id, EventID: 7, PriceCents: 2500, Currency: "usd", Visible: true}, nil
+}
+
+func TestPaymentsWithoutProvider(t *testing.T) {
+	app := fiber.New()
+	NewHandler(nil, nil, nil, ticketTypeStore{}, eventStore{}, nil, nil, nil, nil).RegisterRoutes(app)
+
+	tests := []struct {
+		name string
+		path string
+		body string
+	}{
+		{"paid registration", "/event/7/register", `{"first_name":"Ada","last_name":"Lovelace","email":"ada@example.com","ticket_type_id":1}`},
+		{"webhook", "/payments/webhook", `{}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodPost, tt.path, strings.NewReader(tt.body))
+			req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.StatusCode != fiber.StatusServiceUnavailable {
+				t.Errorf("expected status %d, got %d", fiber.StatusServiceUnavailable, resp.StatusCode)
+			}
+		})
+	}
+}
+
+func TestIsDuplicateEntry(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"duplicate key", &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"}, true},
+		{"joined duplicate key", errors.Join(&mysql.MySQLError{Number: 1062}, nil), true},
+		{"other mysql error", &mysql.MySQLError{Number: 1452}, false},
+		{"other error", errors.New("connection refused"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isDuplicateEntry(tt.err); got != tt.want {
+				t.Errorf("isDuplicateEntry(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
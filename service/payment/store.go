@@ -0,0 +1,257 @@
+package payment
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/cmd/pkg/database"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// CreateOrder creates a new pending order in the database
+func (s *Store) CreateOrder(ctx context.Context, order *types.Order) error {
+	id, err := s.db.CreateOrder(ctx, database.CreateOrderParams{
+		Reference:    order.Reference,
+		EventID:      order.EventID,
+		AttendeeID:   sql.NullInt32{Int32: order.AttendeeID, Valid: order.AttendeeID != 0},
+		TicketTypeID: sql.NullInt32{Int32: order.TicketTypeID, Valid: order.TicketTypeID != 0},
+		Email:        order.Email,
+		FirstName:    order.FirstName,
+		LastName:     order.LastName,
+		AmountCents:  order.AmountCents,
+		Currency:     order.Currency,
+		Provider:     order.Provider,
+	})
+	if err != nil {
+		return err
+	}
+	order.ID = int32(id)
+	order.Status = types.OrderStatusPending
+
+	return nil
+}
+
+// SetOrderCheckout stores the checkout created at the payment provider for an order
+func (s *Store) SetOrderCheckout(ctx context.Context, order *types.Order) error {
+	return s.db.SetOrderCheckout(ctx, database.SetOrderCheckoutParams{
+		ProviderRef: sql.NullString{String: order.ProviderRef, Valid: order.ProviderRef != ""},
+		CheckoutUrl: sql.NullString{String: order.CheckoutURL, Valid: order.CheckoutURL != ""},
+		ID:          order.ID,
+	})
+}
+
+// GetOrderByID fetches an order by ID
+func (s *Store) GetOrderByID(orderID int32) (*types.Order, error) {
+	order, err := s.db.GetOrderByID(context.Background(), orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOrder(order), nil
+}
+
+// GetOrderByReference fetches an order by the reference handed to the guest
+func (s *Store) GetOrderByReference(reference string) (*types.Order, error) {
+	order, err := s.db.GetOrderByReference(context.Background(), reference)
+	if err != nil {
+		return nil, err
+	}
+
+	return toOrder(order), nil
+}
+
+// GetOrderByProviderRef fetches an order by the ID of its checkout at the payment provider
+func (s *Store) GetOrderByProviderRef(provider string, providerRef string) (*types.Order, error) {
+	order, err := s.db.GetOrderByProviderRef(context.Background(), database.GetOrderByProviderRefParams{
+		Provider:    provider,
+		ProviderRef: sql.NullString{String: providerRef, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toOrder(order), nil
+}
+
+// GetOrdersByEventID fetches the page of the orders of an event after a cursor, newest first,
+// and the cursor of the next page when there is one
+func (s *Store) GetOrdersByEventID(eventID int32, page *types.PageRequest) ([]*types.Order, *types.Cursor, error) {
+	params := database.GetOrdersByEventIDParams{
+		EventID: eventID,
+		Limit:   page.Limit + 1,
+	}
+	if page.After != nil {
+		params.AfterID = sql.NullInt32{Int32: int32(page.After.ID), Valid: true}
+	}
+
+	orders, err := s.db.GetOrdersByEventID(context.Background(), params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	allOrders := []*types.Order{}
+	for _, order := range orders {
+		allOrders = append(allOrders, toOrder(order))
+	}
+
+	// The row past the limit only tells that there is a next page
+	if len(allOrders) <= int(page.Limit) {
+		return allOrders, nil, nil
+	}
+	allOrders = allOrders[:page.Limit]
+
+	return allOrders, &types.Cursor{ID: int64(allOrders[len(allOrders)-1].ID)}, nil
+}
+
+// CountOrdersByEventID counts the orders of an event
+func (s *Store) CountOrdersByEventID(eventID int32) (int64, error) {
+	return s.db.CountOrdersByEventID(context.Background(), eventID)
+}
+
+// GetPendingOrdersCreatedBefore fetches the orders still awaiting payment
+// that were created before cutoff
+func (s *Store) GetPendingOrdersCreatedBefore(cutoff time.Time) ([]*types.Order, error) {
+	orders, err := s.db.GetPendingOrdersCreatedBefore(context.Background(), cutoff)
+	if err != nil {
+		return nil, err
+	}
+
+	pendingOrders := make([]*types.Order, 0, len(orders))
+	for _, order := range orders {
+		pendingOrders = append(pendingOrders, toOrder(order))
+	}
+
+	return pendingOrders, nil
+}
+
+// MarkOrderPaid marks a pending order as paid, issues its receipt number and
+// confirms the registration of its attendee. It returns ErrOrderNotPending
+// when the order was already settled, so repeated webhooks are harmless.
+func (s *Store) MarkOrderPaid(ctx context.Context, order *types.Order) error {
+	paidAt := time.Now()
+	receiptNumber := fmt.Sprintf("R-%d-%06d", order.EventID, order.ID)
+
+	result, err := s.db.MarkOrderPaid(ctx, database.MarkOrderPaidParams{
+		ReceiptNumber: sql.NullString{String: receiptNumber, Valid: true},
+		PaidAt:        sql.NullTime{Time: paidAt, Valid: true},
+		ID:            order.ID,
+	})
+	if err != nil {
+		return err
+	}
+	if updated, err := result.RowsAffected(); err != nil {
+		return err
+	} else if updated == 0 {
+		return types.ErrOrderNotPending
+	}
+
+	order.Status = types.OrderStatusPaid
+	order.ReceiptNumber = receiptNumber
+	order.PaidAt = &paidAt
+
+	if order.AttendeeID != 0 {
+		return s.db.ConfirmAttendeeRegistration(ctx, order.AttendeeID)
+	}
+
+	return nil
+}
+
+// MarkOrderFailed marks a pending order as failed. The pending attendee is
+// left for the caller to remove.
+func (s *Store) MarkOrderFailed(ctx context.Context, order *types.Order) error {
+	result, err := s.db.MarkOrderFailed(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+	if updated, err := result.RowsAffected(); err != nil {
+		return err
+	} else if updated == 0 {
+		return types.ErrOrderNotPending
+	}
+	order.Status = types.OrderStatusFailed
+
+	return nil
+}
+
+// MarkOrderExpired marks a pending order as expired. The pending attendee is
+// left for the caller to remove.
+func (s *Store) MarkOrderExpired(ctx context.Context, order *types.Order) error {
+	result, err := s.db.MarkOrderExpired(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+	if updated, err := result.RowsAffected(); err != nil {
+		return err
+	} else if updated == 0 {
+		return types.ErrOrderNotPending
+	}
+	order.Status = types.OrderStatusExpired
+
+	return nil
+}
+
+// MarkOrderRefunded marks a paid order as refunded. The attendee is left for
+// the caller to remove.
+func (s *Store) MarkOrderRefunded(ctx context.Context, order *types.Order) error {
+	refundedAt := time.Now()
+
+	result, err := s.db.MarkOrderRefunded(ctx, database.MarkOrderRefundedParams{
+		RefundedAt: sql.NullTime{Time: refundedAt, Valid: true},
+		ID:         order.ID,
+	})
+	if err != nil {
+		return err
+	}
+	if updated, err := result.RowsAffected(); err != nil {
+		return err
+	} else if updated == 0 {
+		return types.ErrOrderNotPaid
+	}
+	order.Status = types.OrderStatusRefunded
+	order.RefundedAt = &refundedAt
+
+	return nil
+}
+
+func toOrder(order database.Order) *types.Order {
+	return &types.Order{
+		ID:            order.ID,
+		Reference:     order.Reference,
+		EventID:       order.EventID,
+		AttendeeID:    order.AttendeeID.Int32,
+		TicketTypeID:  order.TicketTypeID.Int32,
+		Email:         order.Email,
+		FirstName:     order.FirstName,
+		LastName:      order.LastName,
+		AmountCents:   order.AmountCents,
+		Currency:      order.Currency,
+		Status:        string(order.Status),
+		Provider:      order.Provider,
+		ProviderRef:   order.ProviderRef.String,
+		CheckoutURL:   order.CheckoutUrl.String,
+		ReceiptNumber: order.ReceiptNumber.String,
+		PaidAt:        timePtr(order.PaidAt),
+		RefundedAt:    timePtr(order.RefundedAt),
+		CreatedAt:     order.CreatedAt,
+		UpdatedAt:     order.UpdatedAt,
+	}
+}
+
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
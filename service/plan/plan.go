@@ -0,0 +1,200 @@
+package plan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+// unlimited is the plan of super users, who are never held to a quota
+var unlimited = &types.Plan{Name: "Unlimited", CustomBranding: true}
+
+// Period returns the first day of the month of t, which keys the monthly email usage
+func Period(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// Allows reports whether adding more to used stays within limit. A limit of 0 is unlimited.
+func Allows(limit int32, used int64, adding int64) bool {
+	return limit == 0 || used+adding <= int64(limit)
+}
+
+// Effective returns the plan whose limits apply to a user: their own plan
+// while their subscription is active or when the plan requires none, the
+// free plan otherwise
+func Effective(user *types.User, plan *types.Plan, free *types.Plan) *types.Plan {
+	if plan.RequiresSubscription && user.Subscription != "Active" {
+		return free
+	}
+
+	return plan
+}
+
+// Unbranded strips the header, footer and background of an email template
+func Unbranded(template *types.EmailTemplate) *types.EmailTemplate {
+	unbranded := *template
+	unbranded.HeaderImage = ""
+	unbranded.FooterImage = ""
+	unbranded.BgColor = ""
+
+	return &unbranded
+}
+
+// Enforcer checks the actions of a user against the limits of their plan.
+// Exceeded quotas are reported as 402 Payment Required and features missing
+// from the plan as 403 Forbidden.
+type Enforcer struct {
+	store     types.PlanStore
+	userStore types.UserStore
+}
+
+func NewEnforcer(store types.PlanStore, userStore types.UserStore) *Enforcer {
+	return &Enforcer{store: store, userStore: userStore}
+}
+
+// PlanFor returns the plan whose limits apply to a user
+func (e *Enforcer) PlanFor(userID int32) (*types.Plan, error) {
+	superUser, err := utils.IsSuperUser(userID, e.userStore)
+	if err != nil {
+		return nil, err
+	}
+	if superUser {
+		return unlimited, nil
+	}
+
+	user, err := e.userStore.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan, err := e.store.GetPlanByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if plan.ID == types.FreePlanID {
+		return plan, nil
+	}
+
+	free, err := e.store.GetPlanByID(types.FreePlanID)
+	if err != nil {
+		return nil, err
+	}
+
+	return Effective(user, plan, free), nil
+}
+
+// CheckEvents checks that a user may create another event
+func (e *Enforcer) CheckEvents(userID int32) *fiber.Error {
+	plan, err := e.PlanFor(userID)
+	if err != nil {
+		return planError(userID, err)
+	}
+	if plan.MaxEvents == 0 {
+		return nil
+	}
+
+	events, err := e.store.CountEventsByUserID(userID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to count events")
+	}
+	if !Allows(plan.MaxEvents, events, 1) {
+		return fiber.NewError(fiber.StatusPaymentRequired, fmt.Sprintf("The %s plan allows at most %d events, upgrade to create more", plan.Name, plan.MaxEvents))
+	}
+
+	return nil
+}
+
+// CheckAttendees checks that adding attendees to an event keeps it within the plan of its owner
+func (e *Enforcer) CheckAttendees(event *types.Event, adding int) *fiber.Error {
+	plan, err := e.PlanFor(event.UserID)
+	if err != nil {
+		return planError(event.UserID, err)
+	}
+	if plan.MaxAttendeesPerEvent == 0 {
+		return nil
+	}
+
+	attendees, err := e.store.CountAttendeesByEventID(event.EventID)
+	if err != nil {
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to count attendees")
+	}
+	if !Allows(plan.MaxAttendeesPerEvent, attendees, int64(adding)) {
+		return fiber.NewError(fiber.StatusPaymentRequired, fmt.Sprintf("The %s plan allows at most %d attendees per event, %d left for this event", plan.Name, plan.MaxAttendeesPerEvent, max(int64(plan.MaxAttendeesPerEvent)-attendees, 0)))
+	}
+
+	return nil
+}
+
+// CheckBranding checks that a user may customize the look of their emails
+func (e *Enforcer) CheckBranding(userID int32) *fiber.Error {
+	plan, err := e.PlanFor(userID)
+	if err != nil {
+		return planError(userID, err)
+	}
+	if !plan.CustomBranding {
+		return fiber.NewError(fiber.StatusForbidden, fmt.Sprintf("Custom branding is not included in the %s plan", plan.Name))
+	}
+
+	return nil
+}
+
+// Brand returns the template to send for a user, stripped of its branding when their plan does not include it
+func (e *Enforcer) Brand(userID int32, template *types.EmailTemplate) (*types.EmailTemplate, *fiber.Error) {
+	plan, err := e.PlanFor(userID)
+	if err != nil {
+		return nil, planError(userID, err)
+	}
+	if !plan.CustomBranding {
+		return Unbranded(template), nil
+	}
+
+	return template, nil
+}
+
+// ReserveEmails counts emails about to be sent against the monthly quota of a user
+func (e *Enforcer) ReserveEmails(ctx context.Context, userID int32, count int) *fiber.Error {
+	plan, err := e.PlanFor(userID)
+	if err != nil {
+		return planError(userID, err)
+	}
+
+	now := time.Now()
+	err = e.store.ReserveEmails(ctx, userID, int32(count), plan.EmailsPerMonth, now)
+	if errors.Is(err, types.ErrEmailQuotaExceeded) {
+		sent, err := e.store.GetEmailsSent(userID, now)
+		if err != nil {
+			return fiber.NewError(fiber.StatusInternalServerError, "Failed to count emails")
+		}
+		return fiber.NewError(fiber.StatusPaymentRequired, fmt.Sprintf("The %s plan allows %d emails per month, %d left this month", plan.Name, plan.EmailsPerMonth, max(int64(plan.EmailsPerMonth)-sent, 0)))
+	}
+	if err != nil {
+		log.Printf("Error reserving %d emails for user ID %d: %v", count, userID, err)
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to count emails")
+	}
+
+	return nil
+}
+
+// ReleaseEmails gives back reserved emails that could not be sent
+func (e *Enforcer) ReleaseEmails(userID int32, count int) {
+	if count == 0 {
+		return
+	}
+
+	if err := e.store.ReleaseEmails(context.Background(), userID, int32(count), time.Now()); err != nil {
+		log.Printf("Error releasing %d emails for user ID %d: %v", count, userID, err)
+	}
+}
+
+func planError(userID int32, err error) *fiber.Error {
+	log.Printf("Error getting plan of user ID %d: %v", userID, err)
+	return fiber.NewError(fiber.StatusInternalServerError, "Failed to get plan")
+}
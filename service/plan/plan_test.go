@@ -0,0 +1,95 @@
+package plan
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+func TestPeriodIsFirstOfMonthInUTC(t *testing.T) {
+	singapore := time.FixedZone("SGT", 8*60*60)
+
+	// Early on the first of the month in Singapore is still last month in UTC
+	period := Period(time.Date(2026, 11, 1, 3, 0, 0, 0, singapore))
+	if want := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC); !period.Equal(want) {
+		t.Errorf("expected %v, got %v", want, period)
+	}
+
+	period = Period(time.Date(2026, 10, 31, 23, 59, 59, 0, time.UTC))
+	if want := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC); !period.Equal(want) {
+		t.Errorf("expected %v, got %v", want, period)
+	}
+}
+
+func TestAllows(t *testing.T) {
+	tests := []struct {
+		name   string
+		limit  int32
+		used   int64
+		adding int64
+		want   bool
+	}{
+		{"unlimited", 0, 5000, 100, true},
+		{"below limit", 10, 4, 1, true},
+		{"reaches limit", 10, 9, 1, true},
+		{"over limit", 10, 10, 1, false},
+		{"batch over limit", 100, 90, 20, false},
+		{"nothing added at limit", 10, 10, 0, true},
+	}
+
+	for _, test := range tests {
+		if got := Allows(test.limit, test.used, test.adding); got != test.want {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestEffectiveFallsBackToFreePlan(t *testing.T) {
+	free := &types.Plan{ID: types.FreePlanID, Name: "Free"}
+	pro := &types.Plan{ID: 2, Name: "Pro", RequiresSubscription: true}
+
+	for _, status := range []string{"Inactive", "Pending", "Cancelled"} {
+		if plan := Effective(&types.User{Subscription: status}, pro, free); plan != free {
+			t.Errorf("%s subscription: expected the free plan, got %s", status, plan.Name)
+		}
+	}
+
+	if plan := Effective(&types.User{Subscription: "Active"}, pro, free); plan != pro {
+		t.Errorf("active subscription: expected the pro plan, got %s", plan.Name)
+	}
+}
+
+func TestEffectiveKeepsPlansWithoutSubscription(t *testing.T) {
+	free := &types.Plan{ID: types.FreePlanID, Name: "Free"}
+	legacy := &types.Plan{ID: 4, Name: "Legacy"}
+
+	for _, status := range []string{"Active", "Inactive", "Pending", "Cancelled"} {
+		if plan := Effective(&types.User{Subscription: status}, legacy, free); plan != legacy {
+			t.Errorf("%s subscription: expected the legacy plan, got %s", status, plan.Name)
+		}
+	}
+}
+
+func TestUnbrandedKeepsContent(t *testing.T) {
+	template := &types.EmailTemplate{
+		ID:          3,
+		Subject:     "Welcome",
+		Content:     "<p>Hi {{first_name}}</p>",
+		HeaderImage: "https://example.com/header.png",
+		FooterImage: "https://example.com/footer.png",
+		BgColor:     "#ff0000",
+	}
+
+	unbranded := Unbranded(template)
+	if unbranded.HeaderImage != "" || unbranded.FooterImage != "" || unbranded.BgColor != "" {
+		t.Errorf("expected branding to be stripped, got %+v", unbranded)
+	}
+	if unbranded.ID != 3 || unbranded.Subject != "Welcome" || unbranded.Content != template.Content {
+		t.Errorf("expected the rest of the template to be kept, got %+v", unbranded)
+	}
+	// The stored template is left alone
+	if template.HeaderImage == "" {
+		t.Error("expected the original template to keep its header image")
+	}
+}
@@ -0,0 +1,193 @@
+package plan
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/service/audit"
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+type Handler struct {
+	store     types.PlanStore
+	userStore types.UserStore
+}
+
+func NewHandler(store types.PlanStore, userStore types.UserStore) *Handler {
+	return &Handler{store: store, userStore: userStore}
+}
+
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/plans", h.handleGetPlans)
+	router.Post("/plans", auth.WithSuperUser(h.handleCreatePlan, h.userStore))
+	router.Put("/plans/:id", auth.WithSuperUser(h.handleUpdatePlan, h.userStore))
+	router.Put("/user/:id/plan", auth.WithSuperUser(h.handleUpdateUserPlan, h.userStore))
+}
+
+// Handler to list the plans and their limits
+func (h *Handler) handleGetPlans(c *fiber.Ctx) error {
+	page, ferr := utils.ParsePage(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	plans, err := h.store.GetPlans()
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get plans"})
+	}
+
+	list, ferr := utils.SlicePage(plans, page, func(plan *types.Plan) int64 { return int64(plan.ID) }, nil)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
+// Handler for a super user to create a plan
+func (h *Handler) handleCreatePlan(c *fiber.Ctx) error {
+	var payload types.CreatePlanPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	plan := &types.Plan{
+		Name:                 payload.Name,
+		MaxEvents:            payload.MaxEvents,
+		MaxAttendeesPerEvent: payload.MaxAttendeesPerEvent,
+		EmailsPerMonth:       payload.EmailsPerMonth,
+		CustomBranding:       payload.CustomBranding,
+	}
+	if err := h.store.CreatePlan(c.Context(), plan); err != nil {
+		if isDuplicateName(err) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "A plan with this name already exists"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create plan"})
+	}
+
+	audit.Note(c, audit.Mutation{Action: "plan.create", TargetType: "plan", TargetID: plan.ID, After: plan})
+
+	return c.Status(fiber.StatusCreated).JSON(plan)
+}
+
+// Handler for a super user to change the name and limits of a plan
+func (h *Handler) handleUpdatePlan(c *fiber.Ctx) error {
+	planID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid plan ID"})
+	}
+
+	plan, err := h.store.GetPlanByID(int32(planID))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Plan not found"})
+	}
+
+	var payload types.CreatePlanPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	before := *plan
+	plan.Name = payload.Name
+	plan.MaxEvents = payload.MaxEvents
+	plan.MaxAttendeesPerEvent = payload.MaxAttendeesPerEvent
+	plan.EmailsPerMonth = payload.EmailsPerMonth
+	plan.CustomBranding = payload.CustomBranding
+
+	if err := h.store.UpdatePlan(c.Context(), plan); err != nil {
+		if isDuplicateName(err) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "A plan with this name already exists"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update plan"})
+	}
+
+	audit.Note(c, audit.Mutation{Action: "plan.update", TargetType: "plan", TargetID: plan.ID, Before: before, After: plan})
+
+	return c.Status(fiber.StatusOK).JSON(plan)
+}
+
+// Handler for a super user to move a user to another plan
+func (h *Handler) handleUpdateUserPlan(c *fiber.Ctx) error {
+	intID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("Invalid id: %v", err)})
+	}
+	id := int32(intID)
+
+	user, err := h.userStore.GetUserByID(id)
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "User not found"})
+	}
+
+	var payload types.UpdateUserPlanPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	plan, err := h.store.GetPlanByID(payload.PlanID)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Plan not found"})
+	}
+
+	before := map[string]any{"subscription": user.Subscription}
+	if previous, err := h.store.GetPlanByUserID(user.ID); err == nil {
+		before["plan_id"] = previous.ID
+	}
+
+	if err := h.store.UpdateUserPlan(c.Context(), user.ID, plan.ID); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update user plan"})
+	}
+
+	if payload.SubscriptionStatus != "" {
+		if err := h.store.UpdateUserSubscriptionStatus(c.Context(), user.ID, payload.SubscriptionStatus); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update subscription status"})
+		}
+		user.Subscription = payload.SubscriptionStatus
+	}
+	user.Plan = plan
+	user.Password = ""
+
+	audit.Note(c, audit.Mutation{
+		Action:     "user.plan",
+		TargetType: "user",
+		TargetID:   user.ID,
+		Before:     before,
+		After:      map[string]any{"plan_id": plan.ID, "subscription": user.Subscription},
+	})
+
+	return c.Status(fiber.StatusOK).JSON(user)
+}
+
+// isDuplicateName reports whether an insert or update hit the unique plan name
+func isDuplicateName(err error) bool {
+	return strings.Contains(err.Error(), "plans_name_unique")
+}
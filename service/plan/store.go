@@ -0,0 +1,215 @@
+package plan
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/cmd/pkg/database"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+// subscriptionIDs maps the seeded subscription statuses to their IDs
+var subscriptionIDs = map[string]int8{
+	"Active":    1,
+	"Inactive":  2,
+	"Pending":   3,
+	"Cancelled": 4,
+}
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// GetPlans fetches all the plans
+func (s *Store) GetPlans() ([]*types.Plan, error) {
+	plans, err := s.db.GetPlans(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	allPlans := []*types.Plan{}
+	for _, plan := range plans {
+		allPlans = append(allPlans, toPlan(plan))
+	}
+
+	return allPlans, nil
+}
+
+// GetPlanByID fetches a plan by ID
+func (s *Store) GetPlanByID(planID int32) (*types.Plan, error) {
+	plan, err := s.db.GetPlanByID(context.Background(), planID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPlan(plan), nil
+}
+
+// GetPlanByUserID fetches the plan a user is on
+func (s *Store) GetPlanByUserID(userID int32) (*types.Plan, error) {
+	plan, err := s.db.GetPlanByUserID(context.Background(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPlan(plan), nil
+}
+
+// CreatePlan creates a new plan in the database. Plans created this way
+// always require an active subscription.
+func (s *Store) CreatePlan(ctx context.Context, plan *types.Plan) error {
+	id, err := s.db.CreatePlan(ctx, database.CreatePlanParams{
+		Name:                 plan.Name,
+		MaxEvents:            plan.MaxEvents,
+		MaxAttendeesPerEvent: plan.MaxAttendeesPerEvent,
+		EmailsPerMonth:       plan.EmailsPerMonth,
+		CustomBranding:       plan.CustomBranding,
+	})
+	if err != nil {
+		return err
+	}
+	plan.ID = int32(id)
+	plan.RequiresSubscription = true
+
+	return nil
+}
+
+// UpdatePlan updates the name and limits of a plan
+func (s *Store) UpdatePlan(ctx context.Context, plan *types.Plan) error {
+	return s.db.UpdatePlan(ctx, database.UpdatePlanParams{
+		Name:                 plan.Name,
+		MaxEvents:            plan.MaxEvents,
+		MaxAttendeesPerEvent: plan.MaxAttendeesPerEvent,
+		EmailsPerMonth:       plan.EmailsPerMonth,
+		CustomBranding:       plan.CustomBranding,
+		ID:                   plan.ID,
+	})
+}
+
+// UpdateUserPlan moves a user to another plan
+func (s *Store) UpdateUserPlan(ctx context.Context, userID int32, planID int32) error {
+	return s.db.UpdateUserPlan(ctx, database.UpdateUserPlanParams{
+		PlanID: planID,
+		UserID: userID,
+	})
+}
+
+// UpdateUserSubscriptionStatus sets the subscription status of a user
+func (s *Store) UpdateUserSubscriptionStatus(ctx context.Context, userID int32, status string) error {
+	subscriptionID, ok := subscriptionIDs[status]
+	if !ok {
+		return fmt.Errorf("unknown subscription status %q", status)
+	}
+
+	return s.db.UpdateUserSubscriptionStatus(ctx, database.UpdateUserSubscriptionStatusParams{
+		SubscriptionID: subscriptionID,
+		UserID:         userID,
+	})
+}
+
+// CountEventsByUserID counts the events owned by a user
+func (s *Store) CountEventsByUserID(userID int32) (int64, error) {
+	return s.db.CountEventsByUserID(context.Background(), userID)
+}
+
+// CountAttendeesByEventID counts the attendees registered for an event
+func (s *Store) CountAttendeesByEventID(eventID int32) (int64, error) {
+	return s.db.GetAttendeesRowCountByEventID(context.Background(), eventID)
+}
+
+// GetEmailsSent counts the emails a user sent in the month of now
+func (s *Store) GetEmailsSent(userID int32, now time.Time) (int64, error) {
+	return s.db.GetEmailsSent(context.Background(), database.GetEmailsSentParams{
+		UserID: userID,
+		Period: Period(now),
+	})
+}
+
+// GetUsage counts the events, attendees and emails of this month used by a user
+func (s *Store) GetUsage(userID int32, now time.Time) (*types.PlanUsage, error) {
+	events, err := s.db.GetAttendeeCountsByUserID(context.Background(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	emailsSent, err := s.GetEmailsSent(userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	usage := &types.PlanUsage{
+		Events:      int64(len(events)),
+		EmailsSent:  emailsSent,
+		EmailPeriod: Period(now).Format("2006-01"),
+		Attendees:   []types.EventUsage{},
+	}
+	for _, event := range events {
+		usage.Attendees = append(usage.Attendees, types.EventUsage{
+			EventID:   event.EventID,
+			Title:     event.Title,
+			Attendees: event.Attendees,
+		})
+	}
+
+	return usage, nil
+}
+
+// ReserveEmails counts emails about to be sent against the monthly quota of a
+// user. It returns ErrEmailQuotaExceeded, counting nothing, when the emails do
+// not fit in what is left of limit.
+func (s *Store) ReserveEmails(ctx context.Context, userID int32, count int32, limit int32, now time.Time) error {
+	period := Period(now)
+
+	if err := s.db.CreateEmailUsage(ctx, database.CreateEmailUsageParams{
+		UserID: userID,
+		Period: period,
+	}); err != nil {
+		return err
+	}
+
+	result, err := s.db.ReserveEmails(ctx, database.ReserveEmailsParams{
+		Count:   count,
+		UserID:  userID,
+		Period:  period,
+		MaxSent: limit,
+	})
+	if err != nil {
+		return err
+	}
+	if reserved, err := result.RowsAffected(); err != nil {
+		return err
+	} else if reserved == 0 {
+		return types.ErrEmailQuotaExceeded
+	}
+
+	return nil
+}
+
+// ReleaseEmails gives back reserved emails that were not sent
+func (s *Store) ReleaseEmails(ctx context.Context, userID int32, count int32, now time.Time) error {
+	return s.db.ReleaseEmails(ctx, database.ReleaseEmailsParams{
+		Count:  count,
+		UserID: userID,
+		Period: Period(now),
+	})
+}
+
+func toPlan(plan database.Plan) *types.Plan {
+	return &types.Plan{
+		ID:                   plan.ID,
+		Name:                 plan.Name,
+		MaxEvents:            plan.MaxEvents,
+		MaxAttendeesPerEvent: plan.MaxAttendeesPerEvent,
+		EmailsPerMonth:       plan.EmailsPerMonth,
+		CustomBranding:       plan.CustomBranding,
+		RequiresSubscription: plan.RequiresSubscription,
+		CreatedAt:            plan.CreatedAt,
+		UpdatedAt:            plan.UpdatedAt,
+	}
+}
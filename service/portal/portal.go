@@ -0,0 +1,101 @@
+package portal
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/config"
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+// LinkExpiration is how long a portal link sent to an attendee keeps working
+const LinkExpiration = 180 * 24 * time.Hour
+
+// Link generates the magic link that signs an attendee in to their portal
+func Link(attendeeID int32, now time.Time) (*types.PortalLink, error) {
+	expiresAt := now.Add(LinkExpiration)
+
+	token, err := auth.CreateAttendeeToken(auth.ScopePortal, attendeeID, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.PortalLink{
+		Link:      fmt.Sprintf("%s/portal?token=%s", config.Envs.PublicHost, url.QueryEscape(token)),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// Calendar renders an iCalendar file with the event an attendee registered for
+func Calendar(event *types.Event, attendee *types.Attendee, now time.Time) []byte {
+	const stamp = "20060102T150405Z"
+
+	host := "localhost"
+	if u, err := url.Parse(config.Envs.PublicHost); err == nil && u.Hostname() != "" {
+		host = u.Hostname()
+	}
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Event Registration Software//Attendee Portal//EN",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"BEGIN:VEVENT",
+		fmt.Sprintf("UID:event-%d-attendee-%d@%s", event.EventID, attendee.ID, host),
+		"DTSTAMP:" + now.UTC().Format(stamp),
+		"DTSTART:" + event.StartDate.UTC().Format(stamp),
+		"DTEND:" + event.EndDate.UTC().Format(stamp),
+		"SUMMARY:" + escapeText(event.Title),
+	}
+	if event.Description != "" {
+		lines = append(lines, "DESCRIPTION:"+escapeText(event.Description))
+	}
+	if event.Location != "" {
+		lines = append(lines, "LOCATION:"+escapeText(event.Location))
+	}
+	lines = append(lines, "END:VEVENT", "END:VCALENDAR")
+
+	var b strings.Builder
+	for _, line := range lines {
+		b.WriteString(fold(line))
+		b.WriteString("\r\n")
+	}
+
+	return []byte(b.String())
+}
+
+// escapeText escapes a value for an iCalendar TEXT property
+func escapeText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(value)
+}
+
+// fold splits a content line into lines of at most 75 octets, continued with
+// a leading space, without cutting a UTF-8 character in two
+func fold(line string) string {
+	const limit = 75
+
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > limit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+
+	return b.String()
+}
@@ -0,0 +1,86 @@
+package portal
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+func TestLink(t *testing.T) {
+	now := time.Now()
+	link, err := Link(7, now)
+	if err != nil {
+		t.Fatalf("error creating portal link: %v", err)
+	}
+
+	_, token, ok := strings.Cut(link.Link, "/portal?token=")
+	if !ok {
+		t.Fatalf("expected a portal link, got %q", link.Link)
+	}
+	if attendeeID, err := auth.ValidateAttendeeToken(auth.ScopePortal, token); err != nil || attendeeID != 7 {
+		t.Errorf("expected the link to sign in attendee 7, got %d (%v)", attendeeID, err)
+	}
+	if !link.ExpiresAt.Equal(now.Add(LinkExpiration)) {
+		t.Errorf("expected the link to expire after %s, got %s", LinkExpiration, link.ExpiresAt)
+	}
+}
+
+func TestCalendar(t *testing.T) {
+	start := time.Date(2026, 11, 3, 9, 0, 0, 0, time.UTC)
+	event := &types.Event{
+		EventID:     3,
+		Title:       "Launch; party, with friends",
+		Description: strings.Repeat("A long description ", 10) + "\nwith a second line",
+		StartDate:   start,
+		EndDate:     start.Add(8 * time.Hour),
+		Location:    "Hall 1",
+	}
+
+	ics := string(Calendar(event, &types.Attendee{ID: 9}, start))
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"DTSTART:20261103T090000Z\r\n",
+		"DTEND:20261103T170000Z\r\n",
+		`SUMMARY:Launch\; party\, with friends` + "\r\n",
+		"LOCATION:Hall 1\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("expected the calendar to contain %q", want)
+		}
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("expected lines of at most 75 octets, got %d: %q", len(line), line)
+		}
+	}
+
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	if !strings.Contains(unfolded, `with a second line`) || !strings.Contains(unfolded, `\nwith`) {
+		t.Error("expected the description to be unfolded intact with its line break escaped")
+	}
+}
+
+func TestCanCancel(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	upcoming := &types.Event{StartDate: now.Add(24 * time.Hour), EndDate: now.Add(30 * time.Hour)}
+	started := &types.Event{StartDate: now.Add(-time.Hour), EndDate: now.Add(5 * time.Hour)}
+
+	if !canCancel(&types.Attendee{}, upcoming, now) {
+		t.Error("expected a registration to be cancellable before the event")
+	}
+	if canCancel(&types.Attendee{Attendance: true}, upcoming, now) {
+		t.Error("expected a checked in attendee not to be able to cancel")
+	}
+	if canCancel(&types.Attendee{}, started, now) {
+		t.Error("expected a registration not to be cancellable once the event started")
+	}
+	if !canEdit(started, now) {
+		t.Error("expected details to be editable until the event ends")
+	}
+}
@@ -0,0 +1,380 @@
+package portal
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/service/audit"
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/service/badge"
+	"github.com/jayden1905/event-registration-software/service/customfield"
+	"github.com/jayden1905/event-registration-software/service/live"
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+type Handler struct {
+	attendeeStore    types.AttendeeStore
+	eventStore       types.EventStore
+	customFieldStore types.CustomFieldStore
+	ticketTypeStore  types.TicketTypeStore
+	userStore        types.UserStore
+	hub              *live.Hub
+}
+
+func NewHandler(attendeeStore types.AttendeeStore, eventStore types.EventStore, customFieldStore types.CustomFieldStore, ticketTypeStore types.TicketTypeStore, userStore types.UserStore, hub *live.Hub) *Handler {
+	return &Handler{attendeeStore: attendeeStore, eventStore: eventStore, customFieldStore: customFieldStore, ticketTypeStore: ticketTypeStore, userStore: userStore, hub: hub}
+}
+
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	// Attendees sign in with the token of their magic link, never with an organizer account
+	router.Get("/portal", auth.WithAttendeeToken(auth.ScopePortal, h.handleGetRegistration))
+	router.Patch("/portal", auth.WithAttendeeToken(auth.ScopePortal, h.handleUpdateRegistration))
+	router.Post("/portal/cancel", auth.WithAttendeeToken(auth.ScopePortal, h.handleCancelRegistration))
+	router.Get("/portal/calendar.ics", auth.WithAttendeeToken(auth.ScopePortal, h.handleGetCalendar))
+	router.Get("/portal/badge.pdf", auth.WithAttendeeToken(auth.ScopePortal, h.handleGetBadge))
+
+	router.Get("/event/:event_id/attendees/:attendee_id/portal_link", auth.WithJWTAuth(h.handleGetPortalLink, h.userStore))
+}
+
+// Handler to show an attendee their registration, QR code and custom field values
+func (h *Handler) handleGetRegistration(c *fiber.Ctx) error {
+	attendee, event, ferr := h.getRegistration(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	registration, err := h.buildRegistration(attendee, event)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get registration"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(registration)
+}
+
+// Handler to let an attendee correct their details and answer the custom fields of the event
+func (h *Handler) handleUpdateRegistration(c *fiber.Ctx) error {
+	attendee, event, ferr := h.getRegistration(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	if !canEdit(event, time.Now()) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "The event has ended"})
+	}
+
+	var payload types.UpdatePortalRegistrationPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid payload"})
+	}
+	if invalidFields, err := utils.ValidatePayload(payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	fields, err := h.customFieldStore.GetCustomFields(event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get custom fields"})
+	}
+
+	byName := make(map[string]*types.EventCustomField, len(fields))
+	for _, field := range fields {
+		byName[field.Name] = field
+	}
+
+	invalidFields := map[string]string{}
+	for name, value := range payload.CustomFields {
+		field, ok := byName[name]
+		if !ok {
+			invalidFields[name] = "Unknown custom field"
+			continue
+		}
+		if err := customfield.ValidateValue(field, value); err != nil {
+			invalidFields[name] = err.Error()
+		}
+	}
+	if len(invalidFields) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	updated := *attendee
+	if payload.FirstName != nil {
+		updated.FirstName = strings.TrimSpace(*payload.FirstName)
+	}
+	if payload.LastName != nil {
+		updated.LastName = strings.TrimSpace(*payload.LastName)
+	}
+	if payload.CompanyName != nil {
+		updated.CompanyName = strings.TrimSpace(*payload.CompanyName)
+	}
+	if payload.Title != nil {
+		updated.Title = strings.TrimSpace(*payload.Title)
+	}
+
+	if err := h.attendeeStore.UpdateAttendeeByID(attendee.ID, &updated); err != nil {
+		log.Printf("Error updating registration of attendee ID %d: %v", attendee.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update registration"})
+	}
+
+	for name, value := range payload.CustomFields {
+		err := h.customFieldStore.SetAttendeeCustomFieldValue(c.Context(), attendee.ID, &types.AttendeeCustomFieldValue{
+			Name:      name,
+			FieldType: byName[name].FieldType,
+			Value:     strings.TrimSpace(value),
+		})
+		if err != nil {
+			log.Printf("Error saving custom field %q of attendee ID %d: %v", name, attendee.ID, err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update registration"})
+		}
+	}
+
+	result, err := h.attendeeStore.GetAttendeeByID(attendee.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get attendee"})
+	}
+
+	audit.Note(c, audit.Mutation{
+		Action:       "attendee.portal_update",
+		TargetType:   "attendee",
+		TargetID:     attendee.ID,
+		EventID:      event.EventID,
+		EventOwnerID: event.UserID,
+		Before:       attendee,
+		After:        result,
+		Details:      map[string]any{"custom_fields": payload.CustomFields},
+	})
+	h.publishUpdate(live.UpdateAttendee, result)
+
+	registration, err := h.buildRegistration(result, event)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get registration"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(registration)
+}
+
+// Handler to let an attendee cancel their registration. It goes to the trash
+// like any deleted attendee, so the organizer can still restore it.
+func (h *Handler) handleCancelRegistration(c *fiber.Ctx) error {
+	attendee, event, ferr := h.getRegistration(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	if !canCancel(attendee, event, time.Now()) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "The registration can no longer be cancelled"})
+	}
+
+	if err := h.attendeeStore.DeleteAttendeeByID(attendee.ID); err != nil {
+		log.Printf("Error cancelling registration of attendee ID %d: %v", attendee.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to cancel registration"})
+	}
+
+	audit.Note(c, audit.Mutation{
+		Action:       "attendee.portal_cancel",
+		TargetType:   "attendee",
+		TargetID:     attendee.ID,
+		EventID:      event.EventID,
+		EventOwnerID: event.UserID,
+		Before:       attendee,
+	})
+	h.publishUpdate(live.UpdateRemoval, attendee)
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"message": "Registration cancelled successfully",
+	})
+}
+
+// Handler to download the event of an attendee as an iCalendar file
+func (h *Handler) handleGetCalendar(c *fiber.Ctx) error {
+	attendee, event, ferr := h.getRegistration(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	c.Set(fiber.HeaderContentType, "text/calendar; charset=utf-8")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("event-%d.ics", event.EventID)))
+	return c.Status(fiber.StatusOK).Send(Calendar(event, attendee, time.Now()))
+}
+
+// Handler to download the badge of an attendee
+func (h *Handler) handleGetBadge(c *fiber.Ctx) error {
+	attendee, event, ferr := h.getRegistration(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	ticketType, err := h.getTicketTypeName(attendee)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get ticket type"})
+	}
+
+	ticketTypes := map[int32]string{attendee.TicketTypeID: ticketType}
+	pdf, err := badge.RenderBadges(event, []*types.Attendee{attendee}, ticketTypes, badge.Layouts[badge.DefaultLayout])
+	if err != nil {
+		log.Printf("Error rendering badge for attendee ID %d: %v", attendee.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to render badge"})
+	}
+
+	c.Set(fiber.HeaderContentType, "application/pdf")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", fmt.Sprintf("attendee-%d-badge.pdf", attendee.ID)))
+	return c.Status(fiber.StatusOK).Send(pdf)
+}
+
+// Handler to get a fresh portal link for an attendee, for organizers to share by hand
+func (h *Handler) handleGetPortalLink(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	eventID, err := strconv.Atoi(c.Params("event_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid event ID"})
+	}
+
+	attendeeID, err := strconv.Atoi(c.Params("attendee_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid attendee ID"})
+	}
+
+	event, ferr := utils.GetOwnedEventByID(int32(eventID), userID, h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	attendee, err := h.attendeeStore.GetAttendeeByID(int32(attendeeID))
+	if err != nil || attendee.EventID != event.EventID {
+		if err == nil || errors.Is(err, sql.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Attendee not found"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get attendee"})
+	}
+
+	link, err := Link(attendee.ID, time.Now())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create portal link"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(link)
+}
+
+// getRegistration loads the attendee of the portal token and their event
+func (h *Handler) getRegistration(c *fiber.Ctx) (*types.Attendee, *types.Event, *fiber.Error) {
+	attendee, err := h.attendeeStore.GetAttendeeByID(auth.GetAttendeeIDFromContext(c))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, fiber.NewError(fiber.StatusNotFound, "Registration not found")
+		}
+		return nil, nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to get registration")
+	}
+
+	event, err := h.eventStore.GetEventByID(attendee.EventID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, fiber.NewError(fiber.StatusNotFound, "Event not found")
+		}
+		return nil, nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to get event")
+	}
+
+	return attendee, event, nil
+}
+
+// buildRegistration gathers what the portal shows of a registration
+func (h *Handler) buildRegistration(attendee *types.Attendee, event *types.Event) (*types.PortalRegistration, error) {
+	fields, err := h.customFieldStore.GetCustomFields(event.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := h.customFieldStore.GetAttendeeCustomFieldValues(attendee.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]string, len(values))
+	for _, value := range values {
+		byName[value.Name] = value.Value
+	}
+
+	customFields := make([]*types.PortalCustomField, 0, len(fields))
+	for _, field := range fields {
+		customFields = append(customFields, &types.PortalCustomField{
+			Name:      field.Name,
+			FieldType: field.FieldType,
+			Required:  field.Required,
+			Value:     byName[field.Name],
+		})
+	}
+
+	ticketType, err := h.getTicketTypeName(attendee)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.PortalRegistration{
+		Attendee:     attendee,
+		Event:        event,
+		TicketType:   ticketType,
+		CustomFields: customFields,
+		CanEdit:      canEdit(event, time.Now()),
+		CanCancel:    canCancel(attendee, event, time.Now()),
+	}, nil
+}
+
+// getTicketTypeName fetches the name of the ticket type of an attendee, or "" without one
+func (h *Handler) getTicketTypeName(attendee *types.Attendee) (string, error) {
+	if attendee.TicketTypeID == 0 {
+		return "", nil
+	}
+
+	ticketType, err := h.ticketTypeStore.GetTicketTypeByID(attendee.TicketTypeID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	return ticketType.Name, nil
+}
+
+// canEdit tells whether attendees may still change their details, until the event ends
+func canEdit(event *types.Event, now time.Time) bool {
+	return now.Before(event.EndDate)
+}
+
+// canCancel tells whether an attendee may still cancel: not once checked in
+// or once the event has started
+func canCancel(attendee *types.Attendee, event *types.Event, now time.Time) bool {
+	return !attendee.Attendance && attendee.SessionsAttended == 0 && now.Before(event.StartDate)
+}
+
+// publishUpdate pushes a registration change to the live attendance stream of the event
+func (h *Handler) publishUpdate(updateType string, attendee *types.Attendee) {
+	if !h.hub.HasSubscribers(attendee.EventID) {
+		return
+	}
+
+	stats, err := h.attendeeStore.GetAttendanceStats(attendee.EventID)
+	if err != nil {
+		log.Printf("Error getting attendance stats for event ID %d: %v", attendee.EventID, err)
+		return
+	}
+
+	h.hub.Publish(live.Update{
+		Type:     updateType,
+		EventID:  attendee.EventID,
+		Attendee: attendee,
+		Stats:    stats,
+	})
+}
@@ -0,0 +1,117 @@
+package purge
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+// Result counts what one run of the purge removed
+type Result struct {
+	Events         int
+	Attendees      int
+	EmailTemplates int64
+}
+
+// Purger permanently deletes events, attendees and email templates that have
+// been soft deleted for longer than the retention period, together with the
+// qr images stored for their attendees
+type Purger struct {
+	store     types.PurgeStore
+	retention time.Duration
+	// deleteImage removes a stored qr image, it is replaced in tests
+	deleteImage func(url string) error
+}
+
+// NewPurger creates a Purger that keeps deleted rows restorable for the retention period
+func NewPurger(store types.PurgeStore, retention time.Duration) *Purger {
+	return &Purger{store: store, retention: retention, deleteImage: utils.DeleteQrImageFromCloudinary}
+}
+
+// Start runs the purge every interval in the background
+func (p *Purger) Start(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for now := range ticker.C {
+			result, err := p.Purge(context.Background(), now)
+			if err != nil {
+				log.Printf("Error purging deleted rows: %v", err)
+			}
+			if result.Events > 0 || result.Attendees > 0 || result.EmailTemplates > 0 {
+				log.Printf("Purged %d events, %d attendees and %d email templates", result.Events, result.Attendees, result.EmailTemplates)
+			}
+		}
+	}()
+}
+
+// Purge permanently deletes the rows that were soft deleted before now minus
+// the retention period. A row whose images cannot be deleted is kept so the
+// next run tries again, the images are never left behind without their row.
+func (p *Purger) Purge(ctx context.Context, now time.Time) (Result, error) {
+	var result Result
+	cutoff := now.Add(-p.retention)
+
+	eventIDs, err := p.store.GetEventIDsDeletedBefore(cutoff)
+	if err != nil {
+		return result, err
+	}
+
+	for _, eventID := range eventIDs {
+		qrCodes, err := p.store.GetQrCodesByEventID(eventID)
+		if err != nil {
+			return result, err
+		}
+
+		if !p.deleteImages(qrCodes) {
+			log.Printf("Keeping deleted event ID %d until its images are deleted", eventID)
+			continue
+		}
+
+		if err := p.store.PurgeEvent(ctx, eventID); err != nil {
+			return result, err
+		}
+		result.Events++
+	}
+
+	attendees, err := p.store.GetAttendeesDeletedBefore(cutoff)
+	if err != nil {
+		return result, err
+	}
+
+	for _, attendee := range attendees {
+		if attendee.QrCode != "" && !p.deleteImages([]string{attendee.QrCode}) {
+			log.Printf("Keeping deleted attendee ID %d until its image is deleted", attendee.ID)
+			continue
+		}
+
+		if err := p.store.PurgeAttendee(ctx, attendee.ID); err != nil {
+			return result, err
+		}
+		result.Attendees++
+	}
+
+	result.EmailTemplates, err = p.store.PurgeEmailTemplatesDeletedBefore(ctx, cutoff)
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// deleteImages deletes the stored images and reports whether all of them are gone
+func (p *Purger) deleteImages(urls []string) bool {
+	deleted := true
+	for _, url := range urls {
+		if err := p.deleteImage(url); err != nil {
+			log.Printf("Error deleting image %s: %v", url, err)
+			deleted = false
+		}
+	}
+
+	return deleted
+}
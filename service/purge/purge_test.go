@@ -0,0 +1,147 @@
+package purge
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+type fakeStore struct {
+	events          map[int32]time.Time
+	qrCodes         map[int32][]string
+	attendees       map[int32]*types.Attendee
+	attendeeDeleted map[int32]time.Time
+	templates       []time.Time
+	cutoffs         []time.Time
+}
+
+func (s *fakeStore) GetEventIDsDeletedBefore(cutoff time.Time) ([]int32, error) {
+	s.cutoffs = append(s.cutoffs, cutoff)
+	var ids []int32
+	for id, deletedAt := range s.events {
+		if deletedAt.Before(cutoff) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (s *fakeStore) GetQrCodesByEventID(eventID int32) ([]string, error) {
+	return s.qrCodes[eventID], nil
+}
+
+func (s *fakeStore) PurgeEvent(ctx context.Context, eventID int32) error {
+	delete(s.events, eventID)
+	return nil
+}
+
+func (s *fakeStore) GetAttendeesDeletedBefore(cutoff time.Time) ([]*types.Attendee, error) {
+	var attendees []*types.Attendee
+	for id, deletedAt := range s.attendeeDeleted {
+		if deletedAt.Before(cutoff) {
+			attendees = append(attendees, s.attendees[id])
+		}
+	}
+	return attendees, nil
+}
+
+func (s *fakeStore) PurgeAttendee(ctx context.Context, attendeeID int32) error {
+	delete(s.attendees, attendeeID)
+	delete(s.attendeeDeleted, attendeeID)
+	return nil
+}
+
+func (s *fakeStore) PurgeEmailTemplatesDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	var kept []time.Time
+	for _, deletedAt := range s.templates {
+		if !deletedAt.Before(cutoff) {
+			kept = append(kept, deletedAt)
+		}
+	}
+	purged := int64(len(s.templates) - len(kept))
+	s.templates = kept
+	return purged, nil
+}
+
+func TestPurgeRemovesRowsPastRetention(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-31 * 24 * time.Hour)
+	recent := now.Add(-2 * 24 * time.Hour)
+
+	store := &fakeStore{
+		events:  map[int32]time.Time{1: old, 2: recent},
+		qrCodes: map[int32][]string{1: {"qr-1a", "qr-1b"}, 2: {"qr-2"}},
+		attendees: map[int32]*types.Attendee{
+			10: {ID: 10, QrCode: "qr-10"},
+			11: {ID: 11, QrCode: "qr-11"},
+		},
+		attendeeDeleted: map[int32]time.Time{10: old, 11: recent},
+		templates:       []time.Time{old, recent},
+	}
+
+	purger := NewPurger(store, 30*24*time.Hour)
+	var deleted []string
+	purger.deleteImage = func(url string) error {
+		deleted = append(deleted, url)
+		return nil
+	}
+
+	result, err := purger.Purge(context.Background(), now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Events != 1 || result.Attendees != 1 || result.EmailTemplates != 1 {
+		t.Errorf("expected one of each to be purged, got %+v", result)
+	}
+	if _, ok := store.events[2]; !ok {
+		t.Error("expected the recently deleted event to be kept")
+	}
+	if _, ok := store.attendees[11]; !ok {
+		t.Error("expected the recently deleted attendee to be kept")
+	}
+	if len(deleted) != 3 {
+		t.Errorf("expected the images of the purged rows to be deleted, got %v", deleted)
+	}
+	if want := now.Add(-30 * 24 * time.Hour); !store.cutoffs[0].Equal(want) {
+		t.Errorf("expected cutoff %v, got %v", want, store.cutoffs[0])
+	}
+}
+
+func TestPurgeKeepsRowsWhoseImagesRemain(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-60 * 24 * time.Hour)
+
+	store := &fakeStore{
+		events:          map[int32]time.Time{1: old},
+		qrCodes:         map[int32][]string{1: {"qr-1", "broken"}},
+		attendees:       map[int32]*types.Attendee{10: {ID: 10, QrCode: "broken"}},
+		attendeeDeleted: map[int32]time.Time{10: old},
+	}
+
+	purger := NewPurger(store, 30*24*time.Hour)
+	purger.deleteImage = func(url string) error {
+		if url == "broken" {
+			return errors.New("storage unavailable")
+		}
+		return nil
+	}
+
+	result, err := purger.Purge(context.Background(), now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Events != 0 || result.Attendees != 0 {
+		t.Errorf("expected nothing to be purged, got %+v", result)
+	}
+	if _, ok := store.events[1]; !ok {
+		t.Error("expected the event to be kept for the next run")
+	}
+	if _, ok := store.attendees[10]; !ok {
+		t.Error("expected the attendee to be kept for the next run")
+	}
+}
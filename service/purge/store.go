@@ -0,0 +1,85 @@
+package purge
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/cmd/pkg/database"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// GetEventIDsDeletedBefore fetches the IDs of the events deleted before the cutoff
+func (s *Store) GetEventIDsDeletedBefore(cutoff time.Time) ([]int32, error) {
+	return s.db.GetEventIDsDeletedBefore(context.Background(), sql.NullTime{Time: cutoff, Valid: true})
+}
+
+// GetQrCodesByEventID fetches the qr images of every attendee of an event, deleted or not
+func (s *Store) GetQrCodesByEventID(eventID int32) ([]string, error) {
+	qrCodes, err := s.db.GetQrCodesByEventID(context.Background(), eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	var urls []string
+	for _, qrCode := range qrCodes {
+		if qrCode.String != "" {
+			urls = append(urls, qrCode.String)
+		}
+	}
+
+	return urls, nil
+}
+
+// PurgeEvent permanently deletes a soft-deleted event with its attendees.
+// Everything else that belongs to the event goes with it through the foreign keys.
+func (s *Store) PurgeEvent(ctx context.Context, eventID int32) error {
+	err := s.db.PurgeAttendeesByEventID(ctx, eventID)
+	if err != nil {
+		return err
+	}
+
+	return s.db.PurgeEventByID(ctx, eventID)
+}
+
+// GetAttendeesDeletedBefore fetches the ID and qr image of the attendees deleted before the cutoff
+func (s *Store) GetAttendeesDeletedBefore(cutoff time.Time) ([]*types.Attendee, error) {
+	rows, err := s.db.GetAttendeesDeletedBefore(context.Background(), sql.NullTime{Time: cutoff, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	var attendees []*types.Attendee
+	for _, row := range rows {
+		attendees = append(attendees, &types.Attendee{
+			ID:     row.ID,
+			QrCode: row.QrCode.String,
+		})
+	}
+
+	return attendees, nil
+}
+
+// PurgeAttendee permanently deletes a soft-deleted attendee
+func (s *Store) PurgeAttendee(ctx context.Context, attendeeID int32) error {
+	return s.db.PurgeAttendeeByID(ctx, attendeeID)
+}
+
+// PurgeEmailTemplatesDeletedBefore permanently deletes the email templates deleted before the cutoff
+func (s *Store) PurgeEmailTemplatesDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result, err := s.db.PurgeEmailTemplatesDeletedBefore(ctx, sql.NullTime{Time: cutoff, Valid: true})
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
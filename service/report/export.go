@@ -0,0 +1,259 @@
+package report
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+// WriteCSV writes the report as a sequence of CSV sections separated by blank lines
+func WriteCSV(w io.Writer, report *types.EventReport) error {
+	writer := csv.NewWriter(w)
+
+	rows := [][]string{
+		{"Event", report.Event.Title},
+		{"Generated at", report.GeneratedAt.Format(time.RFC3339)},
+		{},
+		{"Registered", "Checked in", "No-shows", "Attendance rate"},
+		{
+			strconv.FormatInt(report.Totals.Registered, 10),
+			strconv.FormatInt(report.Totals.CheckedIn, 10),
+			strconv.FormatInt(report.Totals.NoShows, 10),
+			formatRate(report.Totals.AttendanceRate),
+		},
+		{},
+		{"Invitations sent", "Invitations failed", "Invitations skipped", "Invited attendees", "Not invited"},
+		{
+			strconv.FormatInt(report.Invitations.Sent, 10),
+			strconv.FormatInt(report.Invitations.Failed, 10),
+			strconv.FormatInt(report.Invitations.Skipped, 10),
+			strconv.FormatInt(report.Invitations.Recipients, 10),
+			strconv.FormatInt(report.Invitations.NotInvited, 10),
+		},
+		{},
+		{"Check-ins from", "Check-ins"},
+	}
+
+	for _, bucket := range report.CheckIns {
+		rows = append(rows, []string{bucket.Start.Format(time.RFC3339), strconv.FormatInt(bucket.CheckIns, 10)})
+	}
+
+	rows = appendBreakdown(rows, "Company", report.ByCompany)
+	rows = appendBreakdown(rows, "Role", report.ByRole)
+	rows = appendBreakdown(rows, "Ticket type", report.ByTicketType)
+	rows = appendBreakdown(rows, "Table", report.ByTable)
+
+	rows = append(rows, []string{}, []string{"No-show first name", "Last name", "Email", "Company", "Title", "Table", "Role"})
+	for _, attendee := range report.NoShows {
+		rows = append(rows, []string{
+			attendee.FirstName,
+			attendee.LastName,
+			attendee.Email,
+			attendee.CompanyName,
+			attendee.Title,
+			formatTable(attendee.TableNo),
+			attendee.Role,
+		})
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+
+	return writer.Error()
+}
+
+func appendBreakdown(rows [][]string, label string, breakdown []types.ReportBreakdown) [][]string {
+	rows = append(rows, []string{}, []string{label, "Registered", "Checked in"})
+	for _, row := range breakdown {
+		rows = append(rows, []string{
+			row.Label,
+			strconv.FormatInt(row.Registered, 10),
+			strconv.FormatInt(row.CheckedIn, 10),
+		})
+	}
+
+	return rows
+}
+
+// RenderPDF renders the report as an A4 PDF document
+func RenderPDF(report *types.EventReport) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(fmt.Sprintf("%s report", report.Event.Title), true)
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AddPage()
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(0, 10, tr(report.Event.Title), "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, tr(fmt.Sprintf("%s - %s, %s",
+		report.Event.StartDate.Format("02 Jan 2006 15:04"),
+		report.Event.EndDate.Format("02 Jan 2006 15:04"),
+		report.Event.Location)), "", 1, "L", false, 0, "")
+	pdf.CellFormat(0, 6, "Generated "+report.GeneratedAt.Format("02 Jan 2006 15:04"), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	pdfSection(pdf, "Totals")
+	pdfTable(pdf, tr, []string{"Registered", "Checked in", "No-shows", "Attendance rate"}, [][]string{{
+		strconv.FormatInt(report.Totals.Registered, 10),
+		strconv.FormatInt(report.Totals.CheckedIn, 10),
+		strconv.FormatInt(report.Totals.NoShows, 10),
+		formatRate(report.Totals.AttendanceRate),
+	}})
+
+	pdfSection(pdf, "Invitations")
+	pdfTable(pdf, tr, []string{"Sent", "Failed", "Skipped", "Invited attendees", "Not invited"}, [][]string{{
+		strconv.FormatInt(report.Invitations.Sent, 10),
+		strconv.FormatInt(report.Invitations.Failed, 10),
+		strconv.FormatInt(report.Invitations.Skipped, 10),
+		strconv.FormatInt(report.Invitations.Recipients, 10),
+		strconv.FormatInt(report.Invitations.NotInvited, 10),
+	}})
+
+	pdfSection(pdf, "Check-ins over time")
+	pdfCheckInChart(pdf, report.CheckIns)
+
+	for _, section := range []struct {
+		title     string
+		label     string
+		breakdown []types.ReportBreakdown
+	}{
+		{"By company", "Company", report.ByCompany},
+		{"By role", "Role", report.ByRole},
+		{"By ticket type", "Ticket type", report.ByTicketType},
+		{"By table", "Table", report.ByTable},
+	} {
+		rows := [][]string{}
+		for _, row := range section.breakdown {
+			label := row.Label
+			if label == "" {
+				label = "(none)"
+			}
+			rows = append(rows, []string{
+				label,
+				strconv.FormatInt(row.Registered, 10),
+				strconv.FormatInt(row.CheckedIn, 10),
+			})
+		}
+		pdfSection(pdf, section.title)
+		pdfTable(pdf, tr, []string{section.label, "Registered", "Checked in"}, rows)
+	}
+
+	rows := [][]string{}
+	for _, attendee := range report.NoShows {
+		rows = append(rows, []string{
+			attendee.FirstName + " " + attendee.LastName,
+			attendee.Email,
+			attendee.CompanyName,
+			formatTable(attendee.TableNo),
+		})
+	}
+	pdfSection(pdf, "No-shows")
+	pdfTable(pdf, tr, []string{"Name", "Email", "Company", "Table"}, rows)
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func pdfSection(pdf *gofpdf.Fpdf, title string) {
+	pdf.Ln(2)
+	pdf.SetFont("Helvetica", "B", 13)
+	pdf.CellFormat(0, 8, title, "", 1, "L", false, 0, "")
+}
+
+// pdfTable draws a table spanning the page width with equally sized columns
+func pdfTable(pdf *gofpdf.Fpdf, tr func(string) string, header []string, rows [][]string) {
+	pageWidth, _ := pdf.GetPageSize()
+	left, _, right, _ := pdf.GetMargins()
+	width := (pageWidth - left - right) / float64(len(header))
+
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetFillColor(230, 230, 230)
+	for _, h := range header {
+		pdf.CellFormat(width, 7, h, "1", 0, "L", true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 9)
+	if len(rows) == 0 {
+		pdf.CellFormat(width*float64(len(header)), 7, "No data", "1", 1, "L", false, 0, "")
+		return
+	}
+	for _, row := range rows {
+		for _, cell := range row {
+			pdf.CellFormat(width, 6, truncate(tr(cell), 40), "1", 0, "L", false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+}
+
+// pdfCheckInChart draws a bar per check-in bucket
+func pdfCheckInChart(pdf *gofpdf.Fpdf, buckets []types.CheckInBucket) {
+	if len(buckets) == 0 {
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.CellFormat(0, 7, "No check-ins recorded", "", 1, "L", false, 0, "")
+		return
+	}
+
+	const chartHeight = 40.0
+	pageWidth, _ := pdf.GetPageSize()
+	left, _, right, _ := pdf.GetMargins()
+	chartWidth := pageWidth - left - right
+
+	if pdf.GetY()+chartHeight+10 > 280 {
+		pdf.AddPage()
+	}
+
+	var peak int64 = 1
+	for _, bucket := range buckets {
+		peak = max(peak, bucket.CheckIns)
+	}
+
+	x, y := left, pdf.GetY()
+	barWidth := chartWidth / float64(len(buckets))
+	pdf.SetFillColor(66, 133, 244)
+	for i, bucket := range buckets {
+		height := chartHeight * float64(bucket.CheckIns) / float64(peak)
+		pdf.Rect(x+float64(i)*barWidth, y+chartHeight-height, barWidth*0.9, height, "F")
+	}
+	pdf.Line(x, y+chartHeight, x+chartWidth, y+chartHeight)
+
+	pdf.SetFont("Helvetica", "", 8)
+	pdf.SetXY(x, y+chartHeight+1)
+	pdf.CellFormat(chartWidth/2, 5, buckets[0].Start.Format("02 Jan 15:04"), "", 0, "L", false, 0, "")
+	pdf.CellFormat(chartWidth/2, 5, fmt.Sprintf("peak %d check-ins", peak), "", 1, "R", false, 0, "")
+	pdf.Ln(2)
+}
+
+func formatRate(rate float64) string {
+	return fmt.Sprintf("%.1f%%", rate*100)
+}
+
+func formatTable(tableNo int32) string {
+	if tableNo == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(int(tableNo))
+}
+
+func truncate(value string, length int) string {
+	if len(value) <= length {
+		return value
+	}
+
+	return value[:length-3] + "..."
+}
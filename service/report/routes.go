@@ -0,0 +1,89 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+const (
+	defaultBucketMinutes = 15
+	maxBucketMinutes     = 24 * 60
+)
+
+type Handler struct {
+	store      types.ReportStore
+	eventStore types.EventStore
+	userStore  types.UserStore
+}
+
+func NewHandler(store types.ReportStore, eventStore types.EventStore, userStore types.UserStore) *Handler {
+	return &Handler{store: store, eventStore: eventStore, userStore: userStore}
+}
+
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/event/:id/report", auth.WithJWTAuth(h.handleGetEventReport, h.userStore))
+}
+
+// Handler to get the analytics report of an event as JSON, CSV or PDF
+func (h *Handler) handleGetEventReport(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	// Check if the user is the owner of the event
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), userID, h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	// Parse the check-in bucket size if provided
+	bucketMinutes := defaultBucketMinutes
+	if bucketStr := c.Query("bucket"); bucketStr != "" {
+		b, err := strconv.Atoi(bucketStr)
+		if err != nil || b < 1 || b > maxBucketMinutes {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fmt.Sprintf("Bucket must be between 1 and %d minutes", maxBucketMinutes),
+			})
+		}
+		bucketMinutes = b
+	}
+
+	report, err := h.store.GetEventReport(c.Context(), event, time.Duration(bucketMinutes)*time.Minute)
+	if err != nil {
+		log.Printf("Error building report for event ID %d: %v", event.EventID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to build report"})
+	}
+
+	filename := fmt.Sprintf("event-%d-report", event.EventID)
+
+	switch c.Query("format", "json") {
+	case "json":
+		return c.Status(fiber.StatusOK).JSON(report)
+	case "csv":
+		var buf bytes.Buffer
+		if err := WriteCSV(&buf, report); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to export report"})
+		}
+		c.Set(fiber.HeaderContentType, "text/csv")
+		c.Attachment(filename + ".csv")
+		return c.Status(fiber.StatusOK).Send(buf.Bytes())
+	case "pdf":
+		pdf, err := RenderPDF(report)
+		if err != nil {
+			log.Printf("Error rendering report PDF for event ID %d: %v", event.EventID, err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to export report"})
+		}
+		c.Set(fiber.HeaderContentType, "application/pdf")
+		c.Attachment(filename + ".pdf")
+		return c.Status(fiber.StatusOK).Send(pdf)
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Format must be one of json, csv or pdf"})
+	}
+}
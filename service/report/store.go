@@ -0,0 +1,190 @@
+package report
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/cmd/pkg/database"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+// MaxCheckInBuckets caps the buckets of a report, a bucket size too small for
+// the span of the check-ins is widened to stay under it
+const MaxCheckInBuckets = 1000
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// GetEventReport aggregates the attendance and invitation numbers of an event
+func (s *Store) GetEventReport(ctx context.Context, event *types.Event, bucketSize time.Duration) (*types.EventReport, error) {
+	summary, err := s.db.GetAttendanceSummaryByEventID(ctx, event.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &types.EventReport{
+		Event:       event,
+		GeneratedAt: time.Now(),
+		Totals: types.ReportTotals{
+			Registered: summary.Expected,
+			CheckedIn:  summary.CheckedIn,
+			NoShows:    summary.Expected - summary.CheckedIn,
+		},
+		CheckIns:     []types.CheckInBucket{},
+		ByCompany:    []types.ReportBreakdown{},
+		ByRole:       []types.ReportBreakdown{},
+		ByTicketType: []types.ReportBreakdown{},
+		ByTable:      []types.ReportBreakdown{},
+		NoShows:      []*types.Attendee{},
+	}
+	if summary.Expected > 0 {
+		report.Totals.AttendanceRate = float64(summary.CheckedIn) / float64(summary.Expected)
+	}
+
+	// Check-ins are aggregated per minute in the database and merged into buckets here
+	perMinute, err := s.db.GetCheckInsPerMinuteByEventID(ctx, event.EventID)
+	if err != nil {
+		return nil, err
+	}
+	minutes := make([]MinuteCount, 0, len(perMinute))
+	for _, row := range perMinute {
+		minutes = append(minutes, MinuteCount{Minute: row.Minute, CheckIns: row.CheckIns})
+	}
+	report.CheckIns = BucketCheckIns(minutes, bucketSize)
+
+	byCompany, err := s.db.GetAttendanceByCompanyByEventID(ctx, event.EventID)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range byCompany {
+		report.ByCompany = append(report.ByCompany, types.ReportBreakdown{
+			Label:      row.CompanyName,
+			Registered: row.Expected,
+			CheckedIn:  row.CheckedIn,
+		})
+	}
+
+	byRole, err := s.db.GetAttendanceByRoleByEventID(ctx, event.EventID)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range byRole {
+		report.ByRole = append(report.ByRole, types.ReportBreakdown{
+			Label:      row.Role,
+			Registered: row.Expected,
+			CheckedIn:  row.CheckedIn,
+		})
+	}
+
+	byTicket, err := s.db.GetAttendanceByTicketTypeByEventID(ctx, event.EventID)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range byTicket {
+		report.ByTicketType = append(report.ByTicketType, types.ReportBreakdown{
+			Label:      row.TicketType,
+			Registered: row.Expected,
+			CheckedIn:  row.CheckedIn,
+		})
+	}
+
+	byTable, err := s.db.GetAttendanceByTableByEventID(ctx, event.EventID)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range byTable {
+		label := ""
+		if row.TableNo != 0 {
+			label = strconv.Itoa(int(row.TableNo))
+		}
+		report.ByTable = append(report.ByTable, types.ReportBreakdown{
+			Label:      label,
+			Registered: row.Expected,
+			CheckedIn:  row.CheckedIn,
+		})
+	}
+
+	deliveries, err := s.db.GetEmailDeliveryStatsByEventID(ctx, event.EventID)
+	if err != nil {
+		return nil, err
+	}
+	report.Invitations = types.InvitationStats{
+		Sent:       deliveries.Sent,
+		Failed:     deliveries.Failed,
+		Skipped:    deliveries.Skipped,
+		Recipients: deliveries.Recipients,
+		NotInvited: max(summary.Expected-deliveries.Recipients, 0),
+	}
+
+	noShows, err := s.db.GetNoShowsByEventID(ctx, event.EventID)
+	if err != nil {
+		return nil, err
+	}
+	for _, attendee := range noShows {
+		report.NoShows = append(report.NoShows, &types.Attendee{
+			ID:           attendee.ID,
+			FirstName:    attendee.FirstName,
+			LastName:     attendee.LastName,
+			Email:        attendee.Email,
+			EventID:      attendee.EventID,
+			QrCode:       attendee.QrCode.String,
+			CompanyName:  attendee.CompanyName.String,
+			Title:        attendee.Title.String,
+			TableNo:      attendee.TableNo.Int32,
+			Role:         attendee.Role.String,
+			Attendance:   attendee.Attendance == database.AttendeesAttendanceYes,
+			TicketTypeID: attendee.TicketTypeID.Int32,
+		})
+	}
+
+	return report, nil
+}
+
+// MinuteCount is the number of check-ins in one minute since the Unix epoch
+type MinuteCount struct {
+	Minute   int64
+	CheckIns int64
+}
+
+// BucketCheckIns merges per-minute check-in counts into consecutive buckets of
+// the given size. Empty buckets between the first and last check-in are kept so
+// the result can be charted directly. Buckets are widened to whole multiples
+// of the given size when it would take more than MaxCheckInBuckets.
+func BucketCheckIns(minutes []MinuteCount, bucketSize time.Duration) []types.CheckInBucket {
+	buckets := []types.CheckInBucket{}
+	if len(minutes) == 0 {
+		return buckets
+	}
+
+	size := int64(bucketSize / time.Minute)
+	if size < 1 {
+		size = 1
+	}
+
+	// The span may straddle one more bucket than it fills
+	span := minutes[len(minutes)-1].Minute - minutes[0].Minute + 1
+	if limit := size * (MaxCheckInBuckets - 1); span > limit {
+		size *= (span + limit - 1) / limit
+	}
+
+	first := minutes[0].Minute / size
+	last := minutes[len(minutes)-1].Minute / size
+	for b := first; b <= last; b++ {
+		buckets = append(buckets, types.CheckInBucket{
+			Start: time.Unix(b*size*60, 0).UTC(),
+		})
+	}
+
+	for _, m := range minutes {
+		buckets[m.Minute/size-first].CheckIns += m.CheckIns
+	}
+
+	return buckets
+}
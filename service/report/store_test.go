@@ -0,0 +1,62 @@
+package report
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBucketCheckIns(t *testing.T) {
+	base := time.Date(2026, 3, 14, 18, 0, 0, 0, time.UTC).Unix() / 60
+
+	buckets := BucketCheckIns([]MinuteCount{
+		{Minute: base, CheckIns: 3},
+		{Minute: base + 7, CheckIns: 2},
+		{Minute: base + 31, CheckIns: 1},
+	}, 15*time.Minute)
+
+	if len(buckets) != 3 {
+		t.Fatalf("expected 3 buckets, got %d", len(buckets))
+	}
+
+	expected := []int64{5, 0, 1}
+	for i, bucket := range buckets {
+		if bucket.CheckIns != expected[i] {
+			t.Errorf("bucket %d: expected %d check-ins, got %d", i, expected[i], bucket.CheckIns)
+		}
+	}
+
+	if !buckets[0].Start.Equal(time.Date(2026, 3, 14, 18, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected first bucket start: %v", buckets[0].Start)
+	}
+	if !buckets[2].Start.Equal(time.Date(2026, 3, 14, 18, 30, 0, 0, time.UTC)) {
+		t.Errorf("unexpected last bucket start: %v", buckets[2].Start)
+	}
+}
+
+func TestBucketCheckInsEmpty(t *testing.T) {
+	if buckets := BucketCheckIns(nil, 15*time.Minute); len(buckets) != 0 {
+		t.Errorf("expected no buckets, got %d", len(buckets))
+	}
+}
+
+func TestBucketCheckInsCapsBucketCount(t *testing.T) {
+	base := time.Date(2026, 3, 14, 18, 0, 0, 0, time.UTC).Unix() / 60
+
+	// A month of check-ins in one-minute buckets
+	buckets := BucketCheckIns([]MinuteCount{
+		{Minute: base, CheckIns: 1},
+		{Minute: base + 30*24*60, CheckIns: 2},
+	}, time.Minute)
+
+	if len(buckets) > MaxCheckInBuckets {
+		t.Fatalf("expected at most %d buckets, got %d", MaxCheckInBuckets, len(buckets))
+	}
+
+	var total int64
+	for _, bucket := range buckets {
+		total += bucket.CheckIns
+	}
+	if total != 3 {
+		t.Errorf("expected the buckets to hold all 3 check-ins, got %d", total)
+	}
+}
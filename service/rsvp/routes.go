@@ -0,0 +1,150 @@
+package rsvp
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/service/audit"
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/service/live"
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+type Handler struct {
+	attendeeStore types.AttendeeStore
+	eventStore    types.EventStore
+	hub           *live.Hub
+}
+
+func NewHandler(attendeeStore types.AttendeeStore, eventStore types.EventStore, hub *live.Hub) *Handler {
+	return &Handler{attendeeStore: attendeeStore, eventStore: eventStore, hub: hub}
+}
+
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	// Attendees answer with the token of the links in their invitation
+	router.Get("/rsvp", auth.WithAttendeeToken(auth.ScopeRSVP, h.handleGetInvitation))
+	router.Post("/rsvp", auth.WithAttendeeToken(auth.ScopeRSVP, h.handleRespond))
+}
+
+// Handler to show an attendee the invitation they are answering and their current response
+func (h *Handler) handleGetInvitation(c *fiber.Ctx) error {
+	attendee, event, ferr := h.getInvitation(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(toInvitation(attendee, event))
+}
+
+// Handler to record the response of an attendee to their invitation, with an
+// optional reason and the number of guests they bring
+func (h *Handler) handleRespond(c *fiber.Ctx) error {
+	attendee, event, ferr := h.getInvitation(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	if !canRespond(event, time.Now()) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "The event has already started"})
+	}
+
+	var payload types.RespondRSVPPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid payload"})
+	}
+	if invalidFields, err := utils.ValidatePayload(payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	if payload.Response == types.RSVPStatusDeclined {
+		payload.PlusOnes = 0
+	}
+
+	err := h.attendeeStore.UpdateAttendeeRSVP(c.Context(), attendee.ID, payload.Response, strings.TrimSpace(payload.Reason), payload.PlusOnes)
+	if err != nil {
+		log.Printf("Error recording the RSVP of attendee ID %d: %v", attendee.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to record response"})
+	}
+
+	result, err := h.attendeeStore.GetAttendeeByID(attendee.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get attendee"})
+	}
+
+	audit.Note(c, audit.Mutation{
+		Action:       "attendee.rsvp",
+		TargetType:   "attendee",
+		TargetID:     attendee.ID,
+		EventID:      event.EventID,
+		EventOwnerID: event.UserID,
+		Before:       attendee,
+		After:        result,
+	})
+	h.publishUpdate(result)
+
+	return c.Status(fiber.StatusOK).JSON(toInvitation(result, event))
+}
+
+// getInvitation loads the attendee of the RSVP token and their event
+func (h *Handler) getInvitation(c *fiber.Ctx) (*types.Attendee, *types.Event, *fiber.Error) {
+	attendee, err := h.attendeeStore.GetAttendeeByID(auth.GetAttendeeIDFromContext(c))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, fiber.NewError(fiber.StatusNotFound, "Invitation not found")
+		}
+		return nil, nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to get invitation")
+	}
+
+	event, err := h.eventStore.GetEventByID(attendee.EventID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, fiber.NewError(fiber.StatusNotFound, "Event not found")
+		}
+		return nil, nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to get event")
+	}
+
+	return attendee, event, nil
+}
+
+// toInvitation keeps what an attendee answering an invitation may see
+func toInvitation(attendee *types.Attendee, event *types.Event) *types.RSVPInvitation {
+	return &types.RSVPInvitation{
+		FirstName:   attendee.FirstName,
+		LastName:    attendee.LastName,
+		Event:       event,
+		Status:      attendee.RSVPStatus,
+		Reason:      attendee.RSVPReason,
+		PlusOnes:    attendee.RSVPPlusOnes,
+		RespondedAt: attendee.RSVPRespondedAt,
+		CanRespond:  canRespond(event, time.Now()),
+	}
+}
+
+// publishUpdate pushes an RSVP to the live attendance stream of the event
+func (h *Handler) publishUpdate(attendee *types.Attendee) {
+	if !h.hub.HasSubscribers(attendee.EventID) {
+		return
+	}
+
+	stats, err := h.attendeeStore.GetAttendanceStats(attendee.EventID)
+	if err != nil {
+		log.Printf("Error getting attendance stats for event ID %d: %v", attendee.EventID, err)
+		return
+	}
+
+	h.hub.Publish(live.Update{
+		Type:     live.UpdateAttendee,
+		EventID:  attendee.EventID,
+		Attendee: attendee,
+		Stats:    stats,
+	})
+}
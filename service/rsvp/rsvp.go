@@ -0,0 +1,37 @@
+package rsvp
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/config"
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+// LinkExpiration is how long the RSVP links of an invitation keep working
+const LinkExpiration = 180 * 24 * time.Hour
+
+// Links generates the one-click links of an invitation, one per response. They
+// open the RSVP page of the frontend, which records the response straight away
+// and then lets the attendee add a reason and plus-ones. Nothing is recorded
+// by merely fetching a link, so mail scanners following links change nothing.
+func Links(attendeeID int32, now time.Time) (map[string]string, error) {
+	token, err := auth.CreateAttendeeToken(auth.ScopeRSVP, attendeeID, now.Add(LinkExpiration))
+	if err != nil {
+		return nil, err
+	}
+
+	links := map[string]string{}
+	for _, response := range []string{types.RSVPStatusAccepted, types.RSVPStatusDeclined, types.RSVPStatusMaybe} {
+		links[response] = fmt.Sprintf("%s/rsvp?token=%s&response=%s", config.Envs.PublicHost, url.QueryEscape(token), response)
+	}
+
+	return links, nil
+}
+
+// canRespond tells whether an attendee may still answer their invitation, until the event starts
+func canRespond(event *types.Event, now time.Time) bool {
+	return now.Before(event.StartDate)
+}
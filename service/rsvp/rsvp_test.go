@@ -0,0 +1,46 @@
+package rsvp
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+func TestLinks(t *testing.T) {
+	links, err := Links(5, time.Now())
+	if err != nil {
+		t.Fatalf("error creating RSVP links: %v", err)
+	}
+
+	for _, response := range []string{types.RSVPStatusAccepted, types.RSVPStatusDeclined, types.RSVPStatusMaybe} {
+		link, err := url.Parse(links[response])
+		if err != nil {
+			t.Fatalf("%s: invalid link %q", response, links[response])
+		}
+		if got := link.Query().Get("response"); got != response {
+			t.Errorf("%s: expected the link to carry its response, got %q", response, got)
+		}
+
+		token := link.Query().Get("token")
+		if attendeeID, err := auth.ValidateAttendeeToken(auth.ScopeRSVP, token); err != nil || attendeeID != 5 {
+			t.Errorf("%s: expected the link to answer for attendee 5, got %d (%v)", response, attendeeID, err)
+		}
+		if _, err := auth.ValidateAttendeeToken(auth.ScopePortal, token); err == nil {
+			t.Errorf("%s: expected an RSVP token not to open the portal", response)
+		}
+	}
+}
+
+func TestCanRespond(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	if !canRespond(&types.Event{StartDate: now.Add(time.Hour)}, now) {
+		t.Error("expected attendees to respond before the event starts")
+	}
+	if canRespond(&types.Event{StartDate: now.Add(-time.Hour)}, now) {
+		t.Error("expected attendees not to respond once the event started")
+	}
+}
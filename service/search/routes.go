@@ -0,0 +1,83 @@
+package search
+
+import (
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 100
+)
+
+type Handler struct {
+	store      types.SearchStore
+	eventStore types.EventStore
+	userStore  types.UserStore
+}
+
+func NewHandler(store types.SearchStore, eventStore types.EventStore, userStore types.UserStore) *Handler {
+	return &Handler{store: store, eventStore: eventStore, userStore: userStore}
+}
+
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/event/:event_id/attendees/search", auth.WithJWTAuth(h.handleSearchEventAttendees, h.userStore))
+	router.Get("/search/attendees", auth.WithJWTAuth(h.handleSearchAttendees, h.userStore))
+}
+
+// Handler to search the attendees of an event by partial name, email or company
+func (h *Handler) handleSearchEventAttendees(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	event, ferr := utils.GetOwnedEvent(c.Params("event_id"), userID, h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	return h.search(c, func(query string, limit int32) ([]*types.AttendeeSearchResult, error) {
+		return h.store.SearchEventAttendees(event.EventID, query, limit)
+	})
+}
+
+// Handler to search the attendees of every event of the user by partial name, email or company
+func (h *Handler) handleSearchAttendees(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	return h.search(c, func(query string, limit int32) ([]*types.AttendeeSearchResult, error) {
+		return h.store.SearchUserAttendees(userID, query, limit)
+	})
+}
+
+// search runs the search in the q query parameter and responds with the
+// ranked results and their highlights
+func (h *Handler) search(c *fiber.Ctx, find func(query string, limit int32) ([]*types.AttendeeSearchResult, error)) error {
+	terms := Terms(c.Query("q"))
+	if len(terms) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Search needs at least one letter or digit"})
+	}
+
+	limit := c.QueryInt("limit", defaultLimit)
+	if limit <= 0 || limit > maxLimit {
+		limit = defaultLimit
+	}
+
+	results, err := find(BooleanQuery(terms), int32(limit))
+	if err != nil {
+		log.Printf("Error searching attendees for %q: %v", c.Query("q"), err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to search attendees"})
+	}
+
+	for _, result := range results {
+		result.Highlights = Highlights(&result.Attendee, terms)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"query":   c.Query("q"),
+		"results": results,
+	})
+}
@@ -0,0 +1,138 @@
+package search
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+const (
+	// maxTerms bounds the words of a search so a pasted paragraph stays a small query
+	maxTerms = 8
+	// minTokenSize is the InnoDB innodb_ft_min_token_size default, shorter
+	// words are not indexed so they cannot be required
+	minTokenSize = 3
+)
+
+// stopwords is the default InnoDB full-text stopword list, these words are not
+// indexed so they cannot be required either
+var stopwords = map[string]bool{
+	"a": true, "about": true, "an": true, "are": true, "as": true, "at": true,
+	"be": true, "by": true, "com": true, "de": true, "en": true, "for": true,
+	"from": true, "how": true, "i": true, "in": true, "is": true, "it": true,
+	"la": true, "of": true, "on": true, "or": true, "that": true, "the": true,
+	"this": true, "to": true, "was": true, "what": true, "when": true, "where": true,
+	"who": true, "will": true, "with": true, "und": true, "www": true,
+}
+
+// Terms splits a search into lower-case words. Anything but letters and digits
+// separates words, the way the full-text index splits names and emails.
+func Terms(query string) []string {
+	words := strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	terms := []string{}
+	for _, word := range words {
+		if len(terms) == maxTerms {
+			break
+		}
+		terms = append(terms, word)
+	}
+
+	return terms
+}
+
+// BooleanQuery builds the full-text query for the terms of a search. Every
+// term matches words it starts with, and indexed terms are required so more
+// words narrow the results. The rest only add to the score.
+func BooleanQuery(terms []string) string {
+	parts := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if len(term) >= minTokenSize && !stopwords[term] {
+			parts = append(parts, "+"+term+"*")
+		} else {
+			parts = append(parts, term+"*")
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Highlights returns the fields of an attendee that match the terms, with the
+// matching part of each word wrapped in a mark element
+func Highlights(attendee *types.Attendee, terms []string) map[string]string {
+	fields := map[string]string{
+		"first_name":   attendee.FirstName,
+		"last_name":    attendee.LastName,
+		"email":        attendee.Email,
+		"company_name": attendee.CompanyName,
+	}
+
+	highlights := map[string]string{}
+	for name, value := range fields {
+		if snippet, ok := Highlight(value, terms); ok {
+			highlights[name] = snippet
+		}
+	}
+
+	return highlights
+}
+
+// Highlight marks the start of every word of a value that begins with one of
+// the terms and reports whether any word did. The snippet is HTML escaped so
+// it can be shown as is.
+func Highlight(value string, terms []string) (string, bool) {
+	var b strings.Builder
+	matched := false
+
+	runes := []rune(value)
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			start := i
+			for i < len(runes) && !isWordRune(runes[i]) {
+				i++
+			}
+			b.WriteString(html.EscapeString(string(runes[start:i])))
+			continue
+		}
+
+		start := i
+		for i < len(runes) && isWordRune(runes[i]) {
+			i++
+		}
+		word := runes[start:i]
+
+		n := longestPrefix(word, terms)
+		if n == 0 {
+			b.WriteString(html.EscapeString(string(word)))
+			continue
+		}
+		matched = true
+		b.WriteString("<mark>")
+		b.WriteString(html.EscapeString(string(word[:n])))
+		b.WriteString("</mark>")
+		b.WriteString(html.EscapeString(string(word[n:])))
+	}
+
+	return b.String(), matched
+}
+
+// longestPrefix returns the length in runes of the longest term a word starts with
+func longestPrefix(word []rune, terms []string) int {
+	longest := 0
+	for _, term := range terms {
+		n := len([]rune(term))
+		if n > longest && n <= len(word) && strings.EqualFold(string(word[:n]), term) {
+			longest = n
+		}
+	}
+
+	return longest
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
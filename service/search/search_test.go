@@ -0,0 +1,66 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+func TestTerms(t *testing.T) {
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		{"Ann  Smith", []string{"ann", "smith"}},
+		{"ann.smith@Example.com", []string{"ann", "smith", "example", "com"}},
+		{"+ann* -(bob)", []string{"ann", "bob"}},
+		{"  ", []string{}},
+		{"a b c d e f g h i j", []string{"a", "b", "c", "d", "e", "f", "g", "h"}},
+	}
+
+	for _, test := range tests {
+		if got := Terms(test.query); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%q: expected %v, got %v", test.query, test.want, got)
+		}
+	}
+}
+
+func TestBooleanQuery(t *testing.T) {
+	got := BooleanQuery([]string{"ann", "smith", "example", "com", "jo"})
+	if want := "+ann* +smith* +example* com* jo*"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		value   string
+		terms   []string
+		want    string
+		matched bool
+	}{
+		{"Annabelle", []string{"ann"}, "<mark>Ann</mark>abelle", true},
+		{"ann.smith@example.com", []string{"smi", "example"}, "ann.<mark>smi</mark>th@<mark>example</mark>.com", true},
+		{"Joanne", []string{"ann"}, "Joanne", false},
+		{"Smith & <Sons>", []string{"sons"}, "Smith &amp; &lt;<mark>Sons</mark>&gt;", true},
+		{"Ångström", []string{"ång"}, "<mark>Ång</mark>ström", true},
+	}
+
+	for _, test := range tests {
+		got, matched := Highlight(test.value, test.terms)
+		if got != test.want || matched != test.matched {
+			t.Errorf("%q: expected %q %v, got %q %v", test.value, test.want, test.matched, got, matched)
+		}
+	}
+}
+
+func TestHighlightsOnlyMatchingFields(t *testing.T) {
+	attendee := &types.Attendee{FirstName: "Ann", LastName: "Lee", Email: "ann@acme.io", CompanyName: "Acme"}
+
+	got := Highlights(attendee, []string{"ann"})
+	want := map[string]string{"first_name": "<mark>Ann</mark>", "email": "<mark>ann</mark>@acme.io"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
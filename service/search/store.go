@@ -0,0 +1,148 @@
+package search
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/cmd/pkg/database"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+// The searches are written by hand, sqlc does not bind the parameters of
+// MATCH ... AGAINST so it cannot generate them.
+const (
+	searchColumns = `attendees.id, attendees.first_name, attendees.last_name, attendees.email,
+    attendees.qr_code, attendees.company_name, attendees.title, attendees.table_no, attendees.role,
+    attendees.event_id, attendees.attendance, attendees.checked_in_at, attendees.sessions_attended,
+    attendees.ticket_type_id, attendees.registration_status, attendees.rsvp_status,
+    attendees.rsvp_reason, attendees.rsvp_plus_ones, attendees.rsvp_responded_at,
+    attendees.primary_attendee_id`
+
+	searchMatch = `MATCH(
+        attendees.first_name,
+        attendees.last_name,
+        attendees.email,
+        attendees.company_name
+    ) AGAINST (? IN BOOLEAN MODE)`
+
+	searchAttendeesByEventID = `SELECT ` + searchColumns + `,
+    '' AS event_title,
+    ` + searchMatch + ` AS score
+FROM attendees
+WHERE attendees.event_id = ?
+    AND attendees.deleted_at IS NULL
+    AND ` + searchMatch + `
+ORDER BY score DESC,
+    attendees.id
+LIMIT ?`
+
+	searchAttendeesByUserID = `SELECT ` + searchColumns + `,
+    events.title AS event_title,
+    ` + searchMatch + ` AS score
+FROM attendees
+    JOIN events ON events.event_id = attendees.event_id
+WHERE events.user_id = ?
+    AND events.deleted_at IS NULL
+    AND attendees.deleted_at IS NULL
+    AND ` + searchMatch + `
+ORDER BY score DESC,
+    attendees.id
+LIMIT ?`
+)
+
+type Store struct {
+	conn *sql.DB
+}
+
+// NewStore initializes the Store with the database connection
+func NewStore(conn *sql.DB) *Store {
+	return &Store{conn: conn}
+}
+
+// SearchEventAttendees fetches the attendees of an event matching a full-text query, best matches first
+func (s *Store) SearchEventAttendees(eventID int32, query string, limit int32) ([]*types.AttendeeSearchResult, error) {
+	return s.search(searchAttendeesByEventID, query, eventID, query, limit)
+}
+
+// SearchUserAttendees fetches the attendees of every event of a user matching a full-text query, best matches first
+func (s *Store) SearchUserAttendees(userID int32, query string, limit int32) ([]*types.AttendeeSearchResult, error) {
+	return s.search(searchAttendeesByUserID, query, userID, query, limit)
+}
+
+func (s *Store) search(statement string, args ...interface{}) ([]*types.AttendeeSearchResult, error) {
+	rows, err := s.conn.QueryContext(context.Background(), statement, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []*types.AttendeeSearchResult{}
+	for rows.Next() {
+		var row database.Attendee
+		var result types.AttendeeSearchResult
+		if err := rows.Scan(
+			&row.ID,
+			&row.FirstName,
+			&row.LastName,
+			&row.Email,
+			&row.QrCode,
+			&row.CompanyName,
+			&row.Title,
+			&row.TableNo,
+			&row.Role,
+			&row.EventID,
+			&row.Attendance,
+			&row.CheckedInAt,
+			&row.SessionsAttended,
+			&row.TicketTypeID,
+			&row.RegistrationStatus,
+			&row.RsvpStatus,
+			&row.RsvpReason,
+			&row.RsvpPlusOnes,
+			&row.RsvpRespondedAt,
+			&row.PrimaryAttendeeID,
+			&result.EventTitle,
+			&result.Score,
+		); err != nil {
+			return nil, err
+		}
+
+		result.Attendee = types.Attendee{
+			ID:                 row.ID,
+			FirstName:          row.FirstName,
+			LastName:           row.LastName,
+			Email:              row.Email,
+			EventID:            row.EventID,
+			QrCode:             row.QrCode.String,
+			CompanyName:        row.CompanyName.String,
+			Title:              row.Title.String,
+			TableNo:            row.TableNo.Int32,
+			Role:               row.Role.String,
+			Attendance:         row.Attendance == database.AttendeesAttendanceYes,
+			FirstCheckedInAt:   timePtr(row.CheckedInAt),
+			SessionsAttended:   row.SessionsAttended,
+			TicketTypeID:       row.TicketTypeID.Int32,
+			RegistrationStatus: string(row.RegistrationStatus),
+			RSVPStatus:         string(row.RsvpStatus),
+			RSVPReason:         row.RsvpReason.String,
+			RSVPPlusOnes:       row.RsvpPlusOnes,
+			RSVPRespondedAt:    timePtr(row.RsvpRespondedAt),
+			PrimaryAttendeeID:  row.PrimaryAttendeeID.Int32,
+		}
+		results = append(results, &result)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
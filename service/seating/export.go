@@ -0,0 +1,173 @@
+package seating
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/jung-kurt/gofpdf"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+// ChartTable is one table of the seating chart with the guests sat at it
+type ChartTable struct {
+	TableNo      int32             `json:"table_no"`
+	Name         string            `json:"name"`
+	Capacity     int32             `json:"capacity"`
+	InPlan       bool              `json:"in_plan"`
+	OverCapacity bool              `json:"over_capacity"`
+	Guests       []*types.Attendee `json:"guests"`
+}
+
+// BuildChart lists the guests of every table of the plan, followed by tables
+// that attendees sit at but which are not in the plan, and finally the
+// unseated attendees under table number 0
+func BuildChart(plan *types.SeatingPlan, attendees []*types.Attendee) []ChartTable {
+	guests := make(map[int32][]*types.Attendee)
+	for _, attendee := range attendees {
+		guests[attendee.TableNo] = append(guests[attendee.TableNo], attendee)
+	}
+	for _, tableGuests := range guests {
+		sortByName(tableGuests)
+	}
+
+	chart := []ChartTable{}
+	for _, table := range plan.Tables {
+		chart = append(chart, ChartTable{
+			TableNo:      table.TableNo,
+			Name:         table.Name,
+			Capacity:     table.Capacity,
+			InPlan:       true,
+			OverCapacity: table.OverCapacity,
+			Guests:       orEmpty(guests[table.TableNo]),
+		})
+	}
+
+	for _, tableNo := range plan.UnknownTables {
+		chart = append(chart, ChartTable{TableNo: tableNo, Guests: guests[tableNo]})
+	}
+
+	if len(guests[0]) > 0 {
+		chart = append(chart, ChartTable{Name: "Unseated", Guests: guests[0]})
+	}
+
+	return chart
+}
+
+// WriteCSV writes the seating chart with one row per guest
+func WriteCSV(w io.Writer, chart []ChartTable) error {
+	writer := csv.NewWriter(w)
+
+	rows := [][]string{{"Table", "Table name", "Capacity", "First name", "Last name", "Email", "Company", "Role"}}
+	for _, table := range chart {
+		capacity := ""
+		if table.InPlan {
+			capacity = strconv.Itoa(int(table.Capacity))
+		}
+		for _, guest := range table.Guests {
+			rows = append(rows, []string{
+				tableLabel(table.TableNo),
+				table.Name,
+				capacity,
+				guest.FirstName,
+				guest.LastName,
+				guest.Email,
+				guest.CompanyName,
+				guest.Role,
+			})
+		}
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+
+	return writer.Error()
+}
+
+// RenderPDF renders the seating chart as an A4 document with a block per table
+func RenderPDF(event *types.Event, plan *types.SeatingPlan, chart []ChartTable) ([]byte, error) {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle(fmt.Sprintf("%s seating chart", event.Title), true)
+	pdf.SetMargins(15, 15, 15)
+	pdf.SetAutoPageBreak(true, 15)
+	pdf.AddPage()
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(0, 10, tr(event.Title), "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.CellFormat(0, 6, fmt.Sprintf("%d tables, %d seats, %d seated, %d unseated",
+		len(plan.Tables), plan.TotalCapacity, plan.Seated, plan.Unseated), "", 1, "L", false, 0, "")
+	pdf.Ln(4)
+
+	for _, table := range chart {
+		// Keep the heading of a table on the same page as its first guests
+		if pdf.GetY() > 260 {
+			pdf.AddPage()
+		}
+
+		heading := "Table " + tableLabel(table.TableNo)
+		if table.TableNo == 0 {
+			heading = "Unseated"
+		} else if table.Name != "" {
+			heading += " - " + table.Name
+		}
+
+		pdf.SetFont("Helvetica", "B", 12)
+		if table.OverCapacity || (!table.InPlan && table.TableNo != 0) {
+			pdf.SetTextColor(200, 30, 30)
+		}
+		pdf.CellFormat(120, 7, tr(heading), "B", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.CellFormat(0, 7, capacityLabel(table), "B", 1, "R", false, 0, "")
+		pdf.SetTextColor(0, 0, 0)
+
+		pdf.SetFont("Helvetica", "", 9)
+		for _, guest := range table.Guests {
+			pdf.CellFormat(60, 5, tr(guest.FirstName+" "+guest.LastName), "", 0, "L", false, 0, "")
+			pdf.CellFormat(70, 5, tr(guest.CompanyName), "", 0, "L", false, 0, "")
+			pdf.CellFormat(0, 5, tr(guest.Role), "", 1, "L", false, 0, "")
+		}
+		pdf.Ln(3)
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func tableLabel(tableNo int32) string {
+	if tableNo == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(int(tableNo))
+}
+
+func capacityLabel(table ChartTable) string {
+	switch {
+	case table.TableNo == 0:
+		return strconv.Itoa(len(table.Guests))
+	case !table.InPlan:
+		return fmt.Sprintf("%d (not in plan)", len(table.Guests))
+	case table.OverCapacity:
+		return fmt.Sprintf("%d/%d (over capacity)", len(table.Guests), table.Capacity)
+	default:
+		return fmt.Sprintf("%d/%d", len(table.Guests), table.Capacity)
+	}
+}
+
+func orEmpty(attendees []*types.Attendee) []*types.Attendee {
+	if attendees == nil {
+		return []*types.Attendee{}
+	}
+
+	return attendees
+}
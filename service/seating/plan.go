@@ -0,0 +1,275 @@
+package seating
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+const (
+	GroupByCompany = "company"
+	GroupByRole    = "role"
+)
+
+// BuildPlan combines the tables of an event with the number of attendees
+// seated at each table number and flags over-capacity and unknown tables
+func BuildPlan(eventID int32, tables []*types.EventTable, seated map[int32]int64) *types.SeatingPlan {
+	plan := &types.SeatingPlan{
+		EventID:       eventID,
+		Tables:        tables,
+		UnknownTables: []int32{},
+		OverCapacity:  []int32{},
+	}
+
+	known := make(map[int32]bool, len(tables))
+	for _, table := range tables {
+		known[table.TableNo] = true
+		table.Seated = seated[table.TableNo]
+		table.OverCapacity = table.Seated > int64(table.Capacity)
+		plan.TotalCapacity += int64(table.Capacity)
+		if table.OverCapacity {
+			plan.OverCapacity = append(plan.OverCapacity, table.TableNo)
+		}
+	}
+
+	for tableNo, count := range seated {
+		switch {
+		case tableNo == 0:
+			plan.Unseated += count
+		case known[tableNo]:
+			plan.Seated += count
+		default:
+			// Attendees at a table that is not in the plan are not really seated
+			plan.Unseated += count
+			plan.UnknownTables = append(plan.UnknownTables, tableNo)
+		}
+	}
+	sort.Slice(plan.UnknownTables, func(i, j int) bool { return plan.UnknownTables[i] < plan.UnknownTables[j] })
+
+	return plan
+}
+
+// AutoSeat seats attendees so that people of the same company or role share a
+// table where possible. Groups are placed largest first at the table that fits
+// them most tightly; a group that fits no table is split over the emptiest
+// tables. A primary attendee and their guests form a party that is never split
+// unless it fits no table at all, and that is grouped by the company or role of
+// the primary. Unless reseat is set, attendees already at a table of the plan
+// keep their seat and the rest of their party joins them when there is room.
+// It returns the new assignments and the attendees left without a seat because
+// the plan is full.
+func AutoSeat(tables []*types.EventTable, attendees []*types.Attendee, groupBy string, reseat bool) ([]types.SeatAssignment, []*types.Attendee) {
+	remaining := make(map[int32]int, len(tables))
+	for _, table := range tables {
+		remaining[table.TableNo] = int(table.Capacity)
+	}
+
+	present := make(map[int32]bool, len(attendees))
+	for _, attendee := range attendees {
+		present[attendee.ID] = true
+	}
+
+	// Collect the parties still to seat, keyed by their primary attendee
+	parties := make(map[int32][]*types.Attendee)
+	partyIDs := []int32{}
+	seatedAt := make(map[int32]int32)
+	for _, attendee := range attendees {
+		partyID := attendee.ID
+		if attendee.PrimaryAttendeeID != 0 && present[attendee.PrimaryAttendeeID] {
+			partyID = attendee.PrimaryAttendeeID
+		}
+
+		if _, ok := remaining[attendee.TableNo]; ok && !reseat {
+			remaining[attendee.TableNo]--
+			if _, ok := seatedAt[partyID]; !ok {
+				seatedAt[partyID] = attendee.TableNo
+			}
+			continue
+		}
+
+		if _, ok := parties[partyID]; !ok {
+			partyIDs = append(partyIDs, partyID)
+		}
+		parties[partyID] = append(parties[partyID], attendee)
+	}
+
+	assignments := []types.SeatAssignment{}
+	unseated := []*types.Attendee{}
+	seat := func(attendee *types.Attendee, tableNo int32) {
+		remaining[tableNo]--
+		if attendee.TableNo != tableNo {
+			assignments = append(assignments, types.SeatAssignment{AttendeeID: attendee.ID, TableNo: tableNo})
+		}
+	}
+
+	// Split a party or group over the emptiest tables so it stays as close together as possible
+	spread := func(members []*types.Attendee) {
+		for len(members) > 0 {
+			tableNo, ok := emptiest(tables, remaining)
+			if !ok {
+				unseated = append(unseated, members...)
+				return
+			}
+			n := min(remaining[tableNo], len(members))
+			for _, member := range members[:n] {
+				seat(member, tableNo)
+			}
+			members = members[n:]
+		}
+	}
+
+	groups := make(map[string][][]*types.Attendee)
+	var singles [][]*types.Attendee
+	for _, partyID := range partyIDs {
+		party := parties[partyID]
+		sortParty(party, partyID)
+
+		// The rest of a party joins the members that kept their seat
+		if tableNo, ok := seatedAt[partyID]; ok && remaining[tableNo] >= len(party) {
+			for _, member := range party {
+				seat(member, tableNo)
+			}
+			continue
+		}
+
+		key := party[0].CompanyName
+		if groupBy == GroupByRole {
+			key = party[0].Role
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		if key == "" {
+			singles = append(singles, party)
+			continue
+		}
+		groups[key] = append(groups[key], party)
+	}
+
+	keys := make([]string, 0, len(groups))
+	sizes := make(map[string]int, len(groups))
+	for key, units := range groups {
+		sortUnits(units)
+		for _, unit := range units {
+			sizes[key] += len(unit)
+		}
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if sizes[keys[i]] != sizes[keys[j]] {
+			return sizes[keys[i]] > sizes[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	sortUnits(singles)
+
+	for _, key := range keys {
+		units := groups[key]
+
+		if tableNo, ok := tightestFit(tables, remaining, sizes[key]); ok {
+			for _, unit := range units {
+				for _, member := range unit {
+					seat(member, tableNo)
+				}
+			}
+			continue
+		}
+
+		// Split the group between parties, filling the emptiest tables first
+		var tableNo int32
+		for _, unit := range units {
+			if remaining[tableNo] < len(unit) {
+				next, ok := emptiest(tables, remaining)
+				if !ok || remaining[next] < len(unit) {
+					spread(unit)
+					continue
+				}
+				tableNo = next
+			}
+			for _, member := range unit {
+				seat(member, tableNo)
+			}
+		}
+	}
+
+	for _, unit := range singles {
+		tableNo, ok := tightestFit(tables, remaining, len(unit))
+		if !ok {
+			spread(unit)
+			continue
+		}
+		for _, member := range unit {
+			seat(member, tableNo)
+		}
+	}
+
+	return assignments, unseated
+}
+
+// tightestFit returns the table with the fewest free seats that still has room for size attendees
+func tightestFit(tables []*types.EventTable, remaining map[int32]int, size int) (int32, bool) {
+	var best int32
+	found := false
+	for _, table := range tables {
+		free := remaining[table.TableNo]
+		if free < size {
+			continue
+		}
+		if !found || free < remaining[best] {
+			best, found = table.TableNo, true
+		}
+	}
+
+	return best, found
+}
+
+// emptiest returns the table with the most free seats
+func emptiest(tables []*types.EventTable, remaining map[int32]int) (int32, bool) {
+	var best int32
+	found := false
+	for _, table := range tables {
+		free := remaining[table.TableNo]
+		if free < 1 {
+			continue
+		}
+		if !found || free > remaining[best] {
+			best, found = table.TableNo, true
+		}
+	}
+
+	return best, found
+}
+
+func sortByName(attendees []*types.Attendee) {
+	sort.Slice(attendees, func(i, j int) bool {
+		if attendees[i].LastName != attendees[j].LastName {
+			return attendees[i].LastName < attendees[j].LastName
+		}
+		return attendees[i].FirstName < attendees[j].FirstName
+	})
+}
+
+// sortParty puts the primary attendee of a party first and their guests after by name
+func sortParty(party []*types.Attendee, primaryID int32) {
+	sort.Slice(party, func(i, j int) bool {
+		if (party[i].ID == primaryID) != (party[j].ID == primaryID) {
+			return party[i].ID == primaryID
+		}
+		if party[i].LastName != party[j].LastName {
+			return party[i].LastName < party[j].LastName
+		}
+		return party[i].FirstName < party[j].FirstName
+	})
+}
+
+// sortUnits orders parties largest first, then by the name of their primary attendee
+func sortUnits(units [][]*types.Attendee) {
+	sort.Slice(units, func(i, j int) bool {
+		if len(units[i]) != len(units[j]) {
+			return len(units[i]) > len(units[j])
+		}
+		if units[i][0].LastName != units[j][0].LastName {
+			return units[i][0].LastName < units[j][0].LastName
+		}
+		return units[i][0].FirstName < units[j][0].FirstName
+	})
+}
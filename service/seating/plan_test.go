@@ -0,0 +1,127 @@
+package seating
+
+import (
+	"testing"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+func TestBuildPlanFlagsOverCapacityAndUnknownTables(t *testing.T) {
+	tables := []*types.EventTable{
+		{TableNo: 1, Capacity: 2},
+		{TableNo: 2, Capacity: 8},
+	}
+
+	plan := BuildPlan(1, tables, map[int32]int64{0: 3, 1: 3, 2: 4, 9: 1})
+
+	if !tables[0].OverCapacity || tables[1].OverCapacity {
+		t.Errorf("expected only table 1 to be over capacity, got %+v %+v", tables[0], tables[1])
+	}
+	if len(plan.OverCapacity) != 1 || plan.OverCapacity[0] != 1 {
+		t.Errorf("unexpected over capacity tables %v", plan.OverCapacity)
+	}
+	if len(plan.UnknownTables) != 1 || plan.UnknownTables[0] != 9 {
+		t.Errorf("unexpected unknown tables %v", plan.UnknownTables)
+	}
+	if plan.TotalCapacity != 10 || plan.Seated != 7 || plan.Unseated != 4 {
+		t.Errorf("unexpected totals %+v", plan)
+	}
+}
+
+func TestAutoSeatKeepsCompaniesTogether(t *testing.T) {
+	tables := []*types.EventTable{
+		{TableNo: 1, Capacity: 4},
+		{TableNo: 2, Capacity: 3},
+	}
+	attendees := []*types.Attendee{
+		{ID: 1, CompanyName: "Acme"},
+		{ID: 2, CompanyName: "acme "},
+		{ID: 3, CompanyName: "Acme"},
+		{ID: 4, CompanyName: "Globex"},
+		{ID: 5, CompanyName: "Globex"},
+		{ID: 6},
+		{ID: 7, TableNo: 1, CompanyName: "Initech"},
+	}
+
+	assignments, unseated := AutoSeat(tables, attendees, GroupByCompany, false)
+
+	seats := map[int32]int32{}
+	for _, assignment := range assignments {
+		seats[assignment.AttendeeID] = assignment.TableNo
+	}
+
+	if len(unseated) != 0 {
+		t.Fatalf("expected everyone to be seated, got %d unseated", len(unseated))
+	}
+	if seats[1] != seats[2] || seats[2] != seats[3] {
+		t.Errorf("expected Acme to share a table, got %v", seats)
+	}
+	if seats[4] != seats[5] {
+		t.Errorf("expected Globex to share a table, got %v", seats)
+	}
+	if _, moved := seats[7]; moved {
+		t.Errorf("expected the seated attendee to keep their seat, got %v", seats)
+	}
+}
+
+func TestAutoSeatSplitsLargeGroupsAndReportsOverflow(t *testing.T) {
+	tables := []*types.EventTable{
+		{TableNo: 1, Capacity: 2},
+		{TableNo: 2, Capacity: 2},
+	}
+	attendees := []*types.Attendee{
+		{ID: 1, Role: "Speaker"},
+		{ID: 2, Role: "Speaker"},
+		{ID: 3, Role: "Speaker"},
+		{ID: 4, Role: "Speaker"},
+		{ID: 5, Role: "Speaker"},
+	}
+
+	assignments, unseated := AutoSeat(tables, attendees, GroupByRole, false)
+
+	perTable := map[int32]int{}
+	for _, assignment := range assignments {
+		perTable[assignment.TableNo]++
+	}
+
+	if perTable[1] != 2 || perTable[2] != 2 {
+		t.Errorf("expected both tables to be filled, got %v", perTable)
+	}
+	if len(unseated) != 1 {
+		t.Errorf("expected one attendee without a seat, got %d", len(unseated))
+	}
+}
+
+func TestAutoSeatKeepsPartiesTogether(t *testing.T) {
+	tables := []*types.EventTable{
+		{TableNo: 1, Capacity: 4},
+		{TableNo: 2, Capacity: 4},
+	}
+	attendees := []*types.Attendee{
+		{ID: 1, CompanyName: "Acme"},
+		{ID: 2, CompanyName: "Acme"},
+		{ID: 3, CompanyName: "Acme"},
+		{ID: 4, CompanyName: "Acme"},
+		{ID: 5, PrimaryAttendeeID: 4, CompanyName: "Globex"},
+		{ID: 6, PrimaryAttendeeID: 4},
+		{ID: 7, TableNo: 2},
+		{ID: 8, PrimaryAttendeeID: 7},
+	}
+
+	assignments, unseated := AutoSeat(tables, attendees, GroupByCompany, false)
+
+	seats := map[int32]int32{}
+	for _, assignment := range assignments {
+		seats[assignment.AttendeeID] = assignment.TableNo
+	}
+
+	if len(unseated) != 0 {
+		t.Fatalf("expected everyone to be seated, got %d unseated", len(unseated))
+	}
+	if seats[4] == 0 || seats[4] != seats[5] || seats[5] != seats[6] {
+		t.Errorf("expected the party of attendee 4 to share a table, got %v", seats)
+	}
+	if seats[8] != 2 {
+		t.Errorf("expected the guest to join their seated primary at table 2, got %v", seats)
+	}
+}
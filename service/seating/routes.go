@@ -0,0 +1,368 @@
+package seating
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+type Handler struct {
+	store         types.SeatingStore
+	attendeeStore types.AttendeeStore
+	eventStore    types.EventStore
+	userStore     types.UserStore
+}
+
+func NewHandler(store types.SeatingStore, attendeeStore types.AttendeeStore, eventStore types.EventStore, userStore types.UserStore) *Handler {
+	return &Handler{store: store, attendeeStore: attendeeStore, eventStore: eventStore, userStore: userStore}
+}
+
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/event/:id/tables", auth.WithJWTAuth(h.handleGetSeatingPlan, h.userStore))
+	router.Post("/event/:id/tables", auth.WithJWTAuth(h.handleCreateTable, h.userStore))
+	router.Put("/event/:id/tables/:table_no", auth.WithJWTAuth(h.handleUpdateTable, h.userStore))
+	router.Delete("/event/:id/tables/:table_no", auth.WithJWTAuth(h.handleDeleteTable, h.userStore))
+	router.Post("/event/:id/seating/assign", auth.WithJWTAuth(h.handleAssignSeats, h.userStore))
+	router.Post("/event/:id/seating/auto", auth.WithJWTAuth(h.handleAutoSeat, h.userStore))
+	router.Get("/event/:id/seating/chart", auth.WithJWTAuth(h.handleGetSeatingChart, h.userStore))
+}
+
+// Handler to get the tables of an event with their occupancy
+func (h *Handler) handleGetSeatingPlan(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	plan, err := h.store.GetSeatingPlan(event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get seating plan"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(plan)
+}
+
+// Handler to add a table to the seating plan of an event
+func (h *Handler) handleCreateTable(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var payload types.CreateEventTablePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	// Check if the table number is already taken
+	if _, err := h.store.GetEventTable(event.EventID, payload.TableNo); err == nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Table already exists"})
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get table"})
+	}
+
+	table := &types.EventTable{
+		EventID:  event.EventID,
+		TableNo:  payload.TableNo,
+		Name:     payload.Name,
+		Capacity: payload.Capacity,
+	}
+	if err := h.store.CreateEventTable(c.Context(), table); err != nil {
+		log.Printf("Error creating table %d for event ID %d: %v", payload.TableNo, event.EventID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create table"})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"message": "Table created successfully"})
+}
+
+// Handler to rename or resize a table
+func (h *Handler) handleUpdateTable(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	table, ferr := h.getTable(c, event.EventID)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var payload types.UpdateEventTablePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	table.Name = payload.Name
+	table.Capacity = payload.Capacity
+	if err := h.store.UpdateEventTable(c.Context(), table); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update table"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Table updated successfully"})
+}
+
+// Handler to remove a table from the plan, its attendees become unseated
+func (h *Handler) handleDeleteTable(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	table, ferr := h.getTable(c, event.EventID)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	if err := h.store.DeleteEventTable(c.Context(), event.EventID, table.TableNo); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to delete table"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Table deleted successfully"})
+}
+
+// Handler to seat, move or unseat attendees
+func (h *Handler) handleAssignSeats(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var payload types.AssignSeatsPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	tables, err := h.store.GetEventTables(event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get tables"})
+	}
+	known := make(map[int32]bool, len(tables))
+	for _, table := range tables {
+		known[table.TableNo] = true
+	}
+
+	// Validate every assignment before moving anyone
+	for _, assignment := range payload.Assignments {
+		if assignment.TableNo != 0 && !known[assignment.TableNo] {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"error": fmt.Sprintf("Table %d is not in the seating plan", assignment.TableNo),
+			})
+		}
+
+		attendee, err := h.attendeeStore.GetAttendeeByID(assignment.AttendeeID)
+		if err != nil || attendee.EventID != event.EventID {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
+				"error": fmt.Sprintf("Attendee %d not found", assignment.AttendeeID),
+			})
+		}
+	}
+
+	assignments := payload.Assignments
+	if payload.WithGuests {
+		assignments, err = h.withGuests(assignments)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get guests"})
+		}
+	}
+
+	for _, assignment := range assignments {
+		if err := h.store.AssignTable(c.Context(), event.EventID, assignment.AttendeeID, assignment.TableNo); err != nil {
+			log.Printf("Error seating attendee ID %d: %v", assignment.AttendeeID, err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to assign seats"})
+		}
+	}
+
+	plan, err := h.store.GetSeatingPlan(event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get seating plan"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(plan)
+}
+
+// Handler to automatically seat attendees grouped by company or role
+func (h *Handler) handleAutoSeat(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var payload types.AutoSeatPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	tables, err := h.store.GetEventTables(event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get tables"})
+	}
+	if len(tables) == 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "The event has no tables to seat attendees at"})
+	}
+
+	attendees, err := h.attendeeStore.GetAllAttendees(event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get attendees"})
+	}
+
+	assignments, unseated := AutoSeat(tables, attendees, payload.GroupBy, payload.Reseat)
+
+	for _, assignment := range assignments {
+		if err := h.store.AssignTable(c.Context(), event.EventID, assignment.AttendeeID, assignment.TableNo); err != nil {
+			log.Printf("Error seating attendee ID %d: %v", assignment.AttendeeID, err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to assign seats"})
+		}
+	}
+
+	// Attendees that no longer fit lose their seat at a table outside the plan
+	for _, attendee := range unseated {
+		if attendee.TableNo == 0 {
+			continue
+		}
+		if err := h.store.AssignTable(c.Context(), event.EventID, attendee.ID, 0); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to assign seats"})
+		}
+	}
+
+	plan, err := h.store.GetSeatingPlan(event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get seating plan"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"assigned": len(assignments),
+		"unseated": unseated,
+		"plan":     plan,
+	})
+}
+
+// Handler to export the seating chart of an event as JSON, CSV or PDF
+func (h *Handler) handleGetSeatingChart(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	plan, err := h.store.GetSeatingPlan(event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get seating plan"})
+	}
+
+	attendees, err := h.attendeeStore.GetAllAttendees(event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get attendees"})
+	}
+
+	chart := BuildChart(plan, attendees)
+	filename := fmt.Sprintf("event-%d-seating", event.EventID)
+
+	switch c.Query("format", "json") {
+	case "json":
+		return c.Status(fiber.StatusOK).JSON(fiber.Map{"plan": plan, "tables": chart})
+	case "csv":
+		var buf bytes.Buffer
+		if err := WriteCSV(&buf, chart); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to export seating chart"})
+		}
+		c.Set(fiber.HeaderContentType, "text/csv")
+		c.Attachment(filename + ".csv")
+		return c.Status(fiber.StatusOK).Send(buf.Bytes())
+	case "pdf":
+		pdf, err := RenderPDF(event, plan, chart)
+		if err != nil {
+			log.Printf("Error rendering seating chart for event ID %d: %v", event.EventID, err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to export seating chart"})
+		}
+		c.Set(fiber.HeaderContentType, "application/pdf")
+		c.Attachment(filename + ".pdf")
+		return c.Status(fiber.StatusOK).Send(pdf)
+	default:
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Format must be one of json, csv or pdf"})
+	}
+}
+
+// withGuests adds an assignment to the table of their primary attendee for
+// every guest that has no assignment of their own
+func (h *Handler) withGuests(assignments []types.SeatAssignment) ([]types.SeatAssignment, error) {
+	assigned := make(map[int32]bool, len(assignments))
+	for _, assignment := range assignments {
+		assigned[assignment.AttendeeID] = true
+	}
+
+	result := slices.Clone(assignments)
+	for _, assignment := range assignments {
+		guests, err := h.attendeeStore.GetGuests(assignment.AttendeeID)
+		if err != nil {
+			return nil, err
+		}
+		for _, guest := range guests {
+			if assigned[guest.ID] {
+				continue
+			}
+			assigned[guest.ID] = true
+			result = append(result, types.SeatAssignment{AttendeeID: guest.ID, TableNo: assignment.TableNo})
+		}
+	}
+
+	return result, nil
+}
+
+// getTable loads the table of the request from the plan of the event
+func (h *Handler) getTable(c *fiber.Ctx, eventID int32) (*types.EventTable, *fiber.Error) {
+	tableNo, err := strconv.Atoi(c.Params("table_no"))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid table number")
+	}
+
+	table, err := h.store.GetEventTable(eventID, int32(tableNo))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Table not found")
+		}
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to get table")
+	}
+
+	return table, nil
+}
@@ -0,0 +1,144 @@
+package seating
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jayden1905/event-registration-software/cmd/pkg/database"
+	"github.com/jayden1905/event-registration-software/db"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+type Store struct {
+	db   *database.Queries
+	conn *sql.DB
+}
+
+// NewStore initializes the Store with the database queries and the connection
+// pool its transactions run on
+func NewStore(queries *database.Queries, conn *sql.DB) *Store {
+	return &Store{db: queries, conn: conn}
+}
+
+// CreateEventTable adds a table to the seating plan of an event
+func (s *Store) CreateEventTable(ctx context.Context, table *types.EventTable) error {
+	err := s.db.CreateEventTable(ctx, database.CreateEventTableParams{
+		EventID:  table.EventID,
+		TableNo:  table.TableNo,
+		Name:     table.Name,
+		Capacity: table.Capacity,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetEventTables fetches the tables of an event ordered by table number
+func (s *Store) GetEventTables(eventID int32) ([]*types.EventTable, error) {
+	tables, err := s.db.GetEventTablesByEventID(context.Background(), eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	eventTables := []*types.EventTable{}
+	for _, table := range tables {
+		eventTables = append(eventTables, toEventTable(table))
+	}
+
+	return eventTables, nil
+}
+
+// GetEventTable fetches a table of an event by its table number
+func (s *Store) GetEventTable(eventID int32, tableNo int32) (*types.EventTable, error) {
+	table, err := s.db.GetEventTableByTableNo(context.Background(), database.GetEventTableByTableNoParams{
+		EventID: eventID,
+		TableNo: tableNo,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toEventTable(table), nil
+}
+
+// UpdateEventTable updates the name and capacity of a table
+func (s *Store) UpdateEventTable(ctx context.Context, table *types.EventTable) error {
+	err := s.db.UpdateEventTable(ctx, database.UpdateEventTableParams{
+		Name:     table.Name,
+		Capacity: table.Capacity,
+		EventID:  table.EventID,
+		TableNo:  table.TableNo,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteEventTable removes a table from the plan and unseats its attendees in
+// one transaction
+func (s *Store) DeleteEventTable(ctx context.Context, eventID int32, tableNo int32) error {
+	return db.WithTx(ctx, s.conn, s.db, func(q *database.Queries) error {
+		err := q.UnseatAttendeesByTableNo(ctx, database.UnseatAttendeesByTableNoParams{
+			EventID: eventID,
+			TableNo: sql.NullInt32{Int32: tableNo, Valid: true},
+		})
+		if err != nil {
+			return err
+		}
+
+		return q.DeleteEventTable(ctx, database.DeleteEventTableParams{
+			EventID: eventID,
+			TableNo: tableNo,
+		})
+	})
+}
+
+// AssignTable seats an attendee of the event at a table, a table number of 0 unseats them
+func (s *Store) AssignTable(ctx context.Context, eventID int32, attendeeID int32, tableNo int32) error {
+	err := s.db.AssignAttendeeTable(ctx, database.AssignAttendeeTableParams{
+		TableNo: sql.NullInt32{Int32: tableNo, Valid: tableNo != 0},
+		ID:      attendeeID,
+		EventID: eventID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetSeatingPlan fetches the tables of an event together with how many attendees sit at each
+func (s *Store) GetSeatingPlan(eventID int32) (*types.SeatingPlan, error) {
+	tables, err := s.GetEventTables(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	byTable, err := s.db.GetAttendanceByTableByEventID(context.Background(), eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	seated := make(map[int32]int64, len(byTable))
+	for _, row := range byTable {
+		seated[row.TableNo] = row.Expected
+	}
+
+	return BuildPlan(eventID, tables, seated), nil
+}
+
+func toEventTable(table database.EventTable) *types.EventTable {
+	return &types.EventTable{
+		ID:        table.ID,
+		EventID:   table.EventID,
+		TableNo:   table.TableNo,
+		Name:      table.Name,
+		Capacity:  table.Capacity,
+		CreatedAt: table.CreatedAt,
+		UpdatedAt: table.UpdatedAt,
+	}
+}
@@ -0,0 +1,328 @@
+package session
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+type Handler struct {
+	store         types.SessionStore
+	attendeeStore types.AttendeeStore
+	eventStore    types.EventStore
+	userStore     types.UserStore
+}
+
+func NewHandler(store types.SessionStore, attendeeStore types.AttendeeStore, eventStore types.EventStore, userStore types.UserStore) *Handler {
+	return &Handler{store: store, attendeeStore: attendeeStore, eventStore: eventStore, userStore: userStore}
+}
+
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/event/:id/agenda", h.handleGetAgenda)
+	router.Get("/event/:id/sessions", auth.WithJWTAuth(h.handleGetSessions, h.userStore))
+	router.Post("/event/:id/sessions", auth.WithJWTAuth(h.handleCreateSession, h.userStore))
+	router.Get("/event/:id/sessions/:session_id", auth.WithJWTAuth(h.handleGetSession, h.userStore))
+	router.Put("/event/:id/sessions/:session_id", auth.WithJWTAuth(h.handleUpdateSession, h.userStore))
+	router.Delete("/event/:id/sessions/:session_id", auth.WithJWTAuth(h.handleDeleteSession, h.userStore))
+	router.Get("/event/:id/sessions/:session_id/signups", auth.WithJWTAuth(h.handleGetSignups, h.userStore))
+	router.Post("/event/:id/sessions/:session_id/signups", auth.WithJWTAuth(h.handleSignUp, h.userStore))
+	router.Delete("/event/:id/sessions/:session_id/signups/:attendee_id", auth.WithJWTAuth(h.handleCancelSignup, h.userStore))
+}
+
+// Handler to get the public agenda of an event
+func (h *Handler) handleGetAgenda(c *fiber.Ctx) error {
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid event ID"})
+	}
+
+	event, err := h.eventStore.GetEventByID(int32(eventID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Event not found"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get event"})
+	}
+
+	sessions, err := h.store.GetSessionsByEventID(event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get sessions"})
+	}
+
+	agenda := types.Agenda{
+		EventID:   event.EventID,
+		Title:     event.Title,
+		Location:  event.Location,
+		StartDate: event.StartDate,
+		EndDate:   event.EndDate,
+		Sessions:  []types.AgendaSession{},
+	}
+	for _, session := range sessions {
+		agenda.Sessions = append(agenda.Sessions, toAgendaSession(session))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(agenda)
+}
+
+// toAgendaSession gives the public view of a session. Seats left are only
+// shown for a session with a capacity and never go below 0.
+func toAgendaSession(session *types.EventSession) types.AgendaSession {
+	entry := types.AgendaSession{
+		ID:        session.ID,
+		Title:     session.Title,
+		StartTime: session.StartTime,
+		EndTime:   session.EndTime,
+		Room:      session.Room,
+		Speaker:   session.Speaker,
+		Capacity:  session.Capacity,
+	}
+	if session.Capacity > 0 {
+		seatsLeft := max(session.Capacity-session.SignedUp, 0)
+		entry.SeatsLeft = &seatsLeft
+	}
+
+	return entry
+}
+
+// fitsSignups tells whether a session capacity leaves room for the attendees
+// already signed up. Seats already taken cannot be given away by lowering the
+// capacity; a capacity of 0 is unlimited.
+func fitsSignups(capacity int32, signedUp int32) bool {
+	return capacity == 0 || capacity >= signedUp
+}
+
+// Handler to list the sessions of an event with their attendance
+func (h *Handler) handleGetSessions(c *fiber.Ctx) error {
+	page, ferr := utils.ParsePage(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	sessions, err := h.store.GetSessionsByEventID(event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get sessions"})
+	}
+
+	list, ferr := utils.SlicePage(sessions, page, func(session *types.EventSession) int64 { return int64(session.ID) }, nil)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
+// Handler to add a session to an event
+func (h *Handler) handleCreateSession(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var payload types.CreateSessionPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	session := &types.EventSession{
+		EventID:   event.EventID,
+		Title:     payload.Title,
+		StartTime: payload.StartTime,
+		EndTime:   payload.EndTime,
+		Room:      payload.Room,
+		Speaker:   payload.Speaker,
+		Capacity:  payload.Capacity,
+	}
+	if err := h.store.CreateSession(c.Context(), session); err != nil {
+		log.Printf("Error creating session for event ID %d: %v", event.EventID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create session"})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(session)
+}
+
+// Handler to get a session of an event
+func (h *Handler) handleGetSession(c *fiber.Ctx) error {
+	session, ferr := h.getOwnedSession(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(session)
+}
+
+// Handler to update the agenda details of a session
+func (h *Handler) handleUpdateSession(c *fiber.Ctx) error {
+	session, ferr := h.getOwnedSession(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var payload types.CreateSessionPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	if !fitsSignups(payload.Capacity, session.SignedUp) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error":     "Capacity is lower than the number of sign-ups",
+			"signed_up": session.SignedUp,
+		})
+	}
+
+	session.Title = payload.Title
+	session.StartTime = payload.StartTime
+	session.EndTime = payload.EndTime
+	session.Room = payload.Room
+	session.Speaker = payload.Speaker
+	session.Capacity = payload.Capacity
+	if err := h.store.UpdateSession(c.Context(), session); err != nil {
+		log.Printf("Error updating session ID %d: %v", session.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update session"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(session)
+}
+
+// Handler to delete a session with its sign-ups and check-ins
+func (h *Handler) handleDeleteSession(c *fiber.Ctx) error {
+	session, ferr := h.getOwnedSession(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	if err := h.store.DeleteSession(c.Context(), session.ID); err != nil {
+		log.Printf("Error deleting session ID %d: %v", session.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to delete session"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Session deleted successfully"})
+}
+
+// Handler to list the attendees signed up for a session
+func (h *Handler) handleGetSignups(c *fiber.Ctx) error {
+	session, ferr := h.getOwnedSession(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	signups, err := h.store.GetSignups(session.ID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get sign-ups"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"session_id": session.ID,
+		"capacity":   session.Capacity,
+		"signed_up":  len(signups),
+		"signups":    signups,
+	})
+}
+
+// Handler to sign an attendee up for a session
+func (h *Handler) handleSignUp(c *fiber.Ctx) error {
+	session, ferr := h.getOwnedSession(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var payload types.SessionSignupPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	attendee, err := h.attendeeStore.GetAttendeeByID(payload.AttendeeID)
+	if err != nil || attendee.EventID != session.EventID {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Attendee not found"})
+	}
+
+	err = h.store.SignUp(c.Context(), session.ID, attendee.ID)
+	if errors.Is(err, types.ErrAlreadySignedUp) || errors.Is(err, types.ErrSessionFull) {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		log.Printf("Error signing up attendee ID %d for session ID %d: %v", attendee.ID, session.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to sign up attendee"})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"message": "Attendee signed up successfully"})
+}
+
+// Handler to cancel the sign-up of an attendee and free their seat
+func (h *Handler) handleCancelSignup(c *fiber.Ctx) error {
+	session, ferr := h.getOwnedSession(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	attendeeID, err := strconv.Atoi(c.Params("attendee_id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid attendee ID"})
+	}
+
+	err = h.store.CancelSignup(c.Context(), session.ID, int32(attendeeID))
+	if errors.Is(err, types.ErrSignupNotFound) {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": err.Error()})
+	}
+	if err != nil {
+		log.Printf("Error cancelling sign-up of attendee ID %d for session ID %d: %v", attendeeID, session.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to cancel sign-up"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Sign-up cancelled successfully"})
+}
+
+// getOwnedSession loads the session of the request and checks that it belongs to an event of the current user
+func (h *Handler) getOwnedSession(c *fiber.Ctx) (*types.EventSession, *fiber.Error) {
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return nil, ferr
+	}
+
+	sessionID, err := strconv.Atoi(c.Params("session_id"))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid session ID")
+	}
+
+	session, err := h.store.GetSessionByID(int32(sessionID))
+	if err != nil || session.EventID != event.EventID {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Session not found")
+	}
+
+	return session, nil
+}
@@ -0,0 +1,71 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+func TestCreateSessionPayloadValidation(t *testing.T) {
+	start := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		payload types.CreateSessionPayload
+		invalid string
+	}{
+		{"valid", types.CreateSessionPayload{Title: "Keynote", StartTime: start, EndTime: start.Add(time.Hour)}, ""},
+		{"no title", types.CreateSessionPayload{StartTime: start, EndTime: start.Add(time.Hour)}, "Title"},
+		{"ends before it starts", types.CreateSessionPayload{Title: "Keynote", StartTime: start, EndTime: start.Add(-time.Hour)}, "EndTime"},
+		{"ends when it starts", types.CreateSessionPayload{Title: "Keynote", StartTime: start, EndTime: start}, "EndTime"},
+		{"negative capacity", types.CreateSessionPayload{Title: "Keynote", StartTime: start, EndTime: start.Add(time.Hour), Capacity: -1}, "Capacity"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			invalidFields, err := utils.ValidatePayload(tt.payload)
+			if tt.invalid == "" {
+				if err != nil {
+					t.Fatalf("expected a valid payload, got %v", invalidFields)
+				}
+				return
+			}
+			if _, ok := invalidFields[tt.invalid]; !ok {
+				t.Errorf("expected %s to be invalid, got %v", tt.invalid, invalidFields)
+			}
+		})
+	}
+}
+
+func TestFitsSignups(t *testing.T) {
+	tests := []struct {
+		capacity int32
+		signedUp int32
+		want     bool
+	}{
+		{0, 120, true},
+		{50, 20, true},
+		{20, 20, true},
+		{10, 20, false},
+	}
+
+	for _, tt := range tests {
+		if got := fitsSignups(tt.capacity, tt.signedUp); got != tt.want {
+			t.Errorf("fitsSignups(%d, %d) = %v, want %v", tt.capacity, tt.signedUp, got, tt.want)
+		}
+	}
+}
+
+func TestToAgendaSessionSeatsLeft(t *testing.T) {
+	if entry := toAgendaSession(&types.EventSession{SignedUp: 40}); entry.SeatsLeft != nil {
+		t.Errorf("expected no seats left for an unlimited session, got %d", *entry.SeatsLeft)
+	}
+	if entry := toAgendaSession(&types.EventSession{Capacity: 50, SignedUp: 40}); entry.SeatsLeft == nil || *entry.SeatsLeft != 10 {
+		t.Errorf("expected 10 seats left, got %v", entry.SeatsLeft)
+	}
+	if entry := toAgendaSession(&types.EventSession{Capacity: 30, SignedUp: 40}); entry.SeatsLeft == nil || *entry.SeatsLeft != 0 {
+		t.Errorf("expected no seats left for an overbooked session, got %v", entry.SeatsLeft)
+	}
+}
@@ -0,0 +1,204 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jayden1905/event-registration-software/cmd/pkg/database"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// CreateSession creates a new session of an event in the database
+func (s *Store) CreateSession(ctx context.Context, session *types.EventSession) error {
+	id, err := s.db.CreateEventSession(ctx, database.CreateEventSessionParams{
+		EventID:   session.EventID,
+		Title:     session.Title,
+		StartTime: session.StartTime,
+		EndTime:   session.EndTime,
+		Room:      session.Room,
+		Capacity:  session.Capacity,
+		Speaker:   session.Speaker,
+	})
+	if err != nil {
+		return err
+	}
+	session.ID = int32(id)
+
+	return nil
+}
+
+// UpdateSession updates the agenda details of a session
+func (s *Store) UpdateSession(ctx context.Context, session *types.EventSession) error {
+	err := s.db.UpdateEventSession(ctx, database.UpdateEventSessionParams{
+		Title:     session.Title,
+		StartTime: session.StartTime,
+		EndTime:   session.EndTime,
+		Room:      session.Room,
+		Capacity:  session.Capacity,
+		Speaker:   session.Speaker,
+		ID:        session.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteSession deletes a session with its sign-ups and check-ins and
+// refreshes the attendance of the attendees that had checked in to it
+func (s *Store) DeleteSession(ctx context.Context, sessionID int32) error {
+	attendeeIDs, err := s.db.GetAttendeeIDsBySessionCheckIns(ctx, sql.NullInt32{Int32: sessionID, Valid: true})
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.DeleteEventSession(ctx, sessionID); err != nil {
+		return err
+	}
+
+	for _, attendeeID := range attendeeIDs {
+		if err := s.db.RefreshAttendeeAttendance(ctx, attendeeID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetSessionsByEventID fetches the sessions of an event in chronological order with their attendance
+func (s *Store) GetSessionsByEventID(eventID int32) ([]*types.EventSession, error) {
+	sessions, err := s.db.GetEventSessionsByEventID(context.Background(), eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	attendance, err := s.db.GetSessionAttendanceByEventID(context.Background(), eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	checkedIn := make(map[int32]int64, len(attendance))
+	for _, row := range attendance {
+		checkedIn[row.SessionID.Int32] = row.CheckedIn
+	}
+
+	eventSessions := []*types.EventSession{}
+	for _, session := range sessions {
+		eventSession := toEventSession(session)
+		eventSession.CheckedIn = checkedIn[session.ID]
+		eventSessions = append(eventSessions, eventSession)
+	}
+
+	return eventSessions, nil
+}
+
+// SignUp signs an attendee up for a session. The seat is reserved with a
+// single conditional update so concurrent sign-ups cannot exceed the capacity.
+func (s *Store) SignUp(ctx context.Context, sessionID int32, attendeeID int32) error {
+	_, err := s.db.GetSessionSignup(ctx, database.GetSessionSignupParams{SessionID: sessionID, AttendeeID: attendeeID})
+	if err == nil {
+		return types.ErrAlreadySignedUp
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return err
+	}
+
+	result, err := s.db.ReserveSessionSeat(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	reserved, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if reserved == 0 {
+		return types.ErrSessionFull
+	}
+
+	err = s.db.CreateSessionSignup(ctx, database.CreateSessionSignupParams{SessionID: sessionID, AttendeeID: attendeeID})
+	if err != nil {
+		// Give the seat back, e.g. when a concurrent request signed the attendee up first
+		if releaseErr := s.db.ReleaseSessionSeat(ctx, sessionID); releaseErr != nil {
+			return releaseErr
+		}
+		return err
+	}
+
+	return nil
+}
+
+// CancelSignup removes an attendee from a session and frees their seat
+func (s *Store) CancelSignup(ctx context.Context, sessionID int32, attendeeID int32) error {
+	_, err := s.db.GetSessionSignup(ctx, database.GetSessionSignupParams{SessionID: sessionID, AttendeeID: attendeeID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return types.ErrSignupNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.DeleteSessionSignup(ctx, database.DeleteSessionSignupParams{SessionID: sessionID, AttendeeID: attendeeID}); err != nil {
+		return err
+	}
+
+	return s.db.ReleaseSessionSeat(ctx, sessionID)
+}
+
+// GetSignups fetches the attendees signed up for a session
+func (s *Store) GetSignups(sessionID int32) ([]*types.SessionSignup, error) {
+	rows, err := s.db.GetSessionSignupsBySessionID(context.Background(), sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	signups := []*types.SessionSignup{}
+	for _, row := range rows {
+		signups = append(signups, &types.SessionSignup{
+			AttendeeID:  row.AttendeeID,
+			FirstName:   row.FirstName,
+			LastName:    row.LastName,
+			Email:       row.Email,
+			CompanyName: row.CompanyName.String,
+			SignedUpAt:  row.SignedUpAt,
+		})
+	}
+
+	return signups, nil
+}
+
+// GetSessionByID fetches a session by ID
+func (s *Store) GetSessionByID(sessionID int32) (*types.EventSession, error) {
+	session, err := s.db.GetEventSessionByID(context.Background(), sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toEventSession(session), nil
+}
+
+func toEventSession(session database.EventSession) *types.EventSession {
+	return &types.EventSession{
+		ID:        session.ID,
+		EventID:   session.EventID,
+		Title:     session.Title,
+		StartTime: session.StartTime,
+		EndTime:   session.EndTime,
+		Room:      session.Room,
+		Speaker:   session.Speaker,
+		Capacity:  session.Capacity,
+		SignedUp:  session.SignedUp,
+		CreatedAt: session.CreatedAt,
+		UpdatedAt: session.UpdatedAt,
+	}
+}
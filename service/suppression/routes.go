@@ -0,0 +1,198 @@
+package suppression
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"log"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/config"
+	"github.com/jayden1905/event-registration-software/service/audit"
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+type Handler struct {
+	store     types.SuppressionStore
+	userStore types.UserStore
+}
+
+func NewHandler(store types.SuppressionStore, userStore types.UserStore) *Handler {
+	return &Handler{store: store, userStore: userStore}
+}
+
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	// The mail provider reports bounces and complaints here
+	router.Post("/email/bounces", h.handleBounceWebhook)
+
+	router.Get("/email_suppressions", auth.WithJWTAuth(h.handleGetSuppressions, h.userStore))
+	router.Post("/email_suppressions", auth.WithJWTAuth(h.handleCreateSuppression, h.userStore))
+	router.Delete("/email_suppressions/:id", auth.WithJWTAuth(h.handleDeleteSuppression, h.userStore))
+}
+
+// Handler to suppress the addresses of a bounce webhook. Hard bounces and
+// complaints suppress the address for the whole platform, soft bounces are
+// only temporary and ignored.
+func (h *Handler) handleBounceWebhook(c *fiber.Ctx) error {
+	if err := Verify(c.Body(), c.Get(SignatureHeader), config.Envs.BounceWebhookSecret); err != nil {
+		if errors.Is(err, ErrWebhookDisabled) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Bounce webhook is not configured"})
+		}
+		return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Invalid webhook signature"})
+	}
+
+	var payload types.BounceWebhookPayload
+	if err := json.Unmarshal(c.Body(), &payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid payload"})
+	}
+	if invalidFields, err := utils.ValidatePayload(payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	suppressed, ignored := 0, 0
+	for _, event := range payload.Events {
+		if event.Type == types.SuppressionReasonBounce && !event.Permanent {
+			ignored++
+			continue
+		}
+
+		added, err := h.store.CreateSuppression(c.Context(), &types.Suppression{
+			Email:  event.Email,
+			Reason: event.Type,
+			Detail: event.Diagnostic,
+		})
+		if err != nil {
+			log.Printf("Error suppressing %s after a %s: %v", event.Email, event.Type, err)
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to handle webhook"})
+		}
+		if added {
+			suppressed++
+		}
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"suppressed": suppressed, "ignored": ignored})
+}
+
+// Handler to list the addresses the emails of the current user are not sent
+// to: the ones they suppressed and the ones suppressed for the whole platform
+// among the attendees of their events
+func (h *Handler) handleGetSuppressions(c *fiber.Ctx) error {
+	page, ferr := utils.ParsePage(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	suppressions, err := h.store.GetSuppressionsByUserID(c.Context(), auth.GetUserIDFromContext(c))
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get suppressions"})
+	}
+
+	list, ferr := utils.SlicePage(suppressions, page, func(suppression *types.Suppression) int64 { return int64(suppression.ID) }, nil)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
+// Handler to suppress an address for the events of the current user
+func (h *Handler) handleCreateSuppression(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	var payload types.CreateSuppressionPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid payload"})
+	}
+	if invalidFields, err := utils.ValidatePayload(payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	existing, err := h.store.GetSuppression(c.Context(), payload.Email, userID)
+	if err == nil {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Email is already suppressed", "reason": Reason(existing)})
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get suppression"})
+	}
+
+	if _, err := h.store.CreateSuppression(c.Context(), &types.Suppression{
+		Email:  payload.Email,
+		UserID: userID,
+		Reason: types.SuppressionReasonManual,
+		Detail: payload.Detail,
+	}); err != nil {
+		log.Printf("Error suppressing %s for user ID %d: %v", payload.Email, userID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create suppression"})
+	}
+
+	suppression, err := h.store.GetSuppression(c.Context(), payload.Email, userID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get suppression"})
+	}
+
+	audit.Note(c, audit.Mutation{
+		Action:     "email_suppression.create",
+		TargetType: "email_suppression",
+		TargetID:   suppression.ID,
+		After:      suppression,
+	})
+
+	return c.Status(fiber.StatusCreated).JSON(suppression)
+}
+
+// Handler to remove an address from the suppression list. Organizers remove
+// the addresses they suppressed, only super users remove the ones suppressed
+// for the whole platform.
+func (h *Handler) handleDeleteSuppression(c *fiber.Ctx) error {
+	userID := auth.GetUserIDFromContext(c)
+
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid suppression ID"})
+	}
+
+	suppression, err := h.store.GetSuppressionByID(c.Context(), int32(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Suppression not found"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get suppression"})
+	}
+
+	if suppression.UserID != userID {
+		superUser, err := utils.IsSuperUser(userID, h.userStore)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get user"})
+		}
+		if !superUser {
+			if suppression.UserID == 0 {
+				return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Only an administrator can remove this address from the suppression list"})
+			}
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Suppression not found"})
+		}
+	}
+
+	if err := h.store.DeleteSuppression(c.Context(), suppression.ID); err != nil {
+		log.Printf("Error deleting suppression ID %d: %v", suppression.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to delete suppression"})
+	}
+
+	audit.Note(c, audit.Mutation{
+		Action:     "email_suppression.delete",
+		TargetType: "email_suppression",
+		TargetID:   suppression.ID,
+		Before:     suppression,
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Suppression deleted"})
+}
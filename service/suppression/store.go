@@ -0,0 +1,112 @@
+package suppression
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jayden1905/event-registration-software/cmd/pkg/database"
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// CreateSuppression adds an address to the suppression list. It tells whether
+// it was added, an address already suppressed for the same scope is left as is.
+func (s *Store) CreateSuppression(ctx context.Context, suppression *types.Suppression) (bool, error) {
+	result, err := s.db.CreateEmailSuppression(ctx, database.CreateEmailSuppressionParams{
+		Email:  utils.NormalizeEmail(suppression.Email),
+		UserID: sql.NullInt32{Int32: suppression.UserID, Valid: suppression.UserID != 0},
+		Reason: database.EmailSuppressionsReason(suppression.Reason),
+		Detail: sql.NullString{String: suppression.Detail, Valid: suppression.Detail != ""},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return rows > 0, nil
+}
+
+// GetSuppressionByID fetches an entry of the suppression list from the database
+func (s *Store) GetSuppressionByID(ctx context.Context, id int32) (*types.Suppression, error) {
+	suppression, err := s.db.GetEmailSuppressionByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toSuppression(suppression), nil
+}
+
+// GetSuppression fetches the entry suppressing an address for the events of
+// an organizer, entries for the whole platform first
+func (s *Store) GetSuppression(ctx context.Context, email string, userID int32) (*types.Suppression, error) {
+	suppression, err := s.db.GetEmailSuppressionForUser(ctx, database.GetEmailSuppressionForUserParams{
+		Email:  utils.NormalizeEmail(email),
+		UserID: sql.NullInt32{Int32: userID, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toSuppression(suppression), nil
+}
+
+// GetSuppressionsByEventID fetches the entries suppressing the addresses of
+// the attendees of an event, entries for the whole platform first
+func (s *Store) GetSuppressionsByEventID(ctx context.Context, eventID int32) ([]*types.Suppression, error) {
+	rows, err := s.db.GetEmailSuppressionsByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	suppressions := make([]*types.Suppression, 0, len(rows))
+	for _, row := range rows {
+		suppressions = append(suppressions, toSuppression(row))
+	}
+
+	return suppressions, nil
+}
+
+// GetSuppressionsByUserID fetches the entries an organizer added and the
+// entries for the whole platform matching the attendees of their events
+func (s *Store) GetSuppressionsByUserID(ctx context.Context, userID int32) ([]*types.Suppression, error) {
+	rows, err := s.db.GetEmailSuppressionsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	suppressions := make([]*types.Suppression, 0, len(rows))
+	for _, row := range rows {
+		suppressions = append(suppressions, toSuppression(row))
+	}
+
+	return suppressions, nil
+}
+
+// DeleteSuppression removes an entry of the suppression list from the database
+func (s *Store) DeleteSuppression(ctx context.Context, id int32) error {
+	return s.db.DeleteEmailSuppressionByID(ctx, id)
+}
+
+func toSuppression(suppression database.EmailSuppression) *types.Suppression {
+	return &types.Suppression{
+		ID:        suppression.ID,
+		Email:     suppression.Email,
+		UserID:    suppression.UserID.Int32,
+		Reason:    string(suppression.Reason),
+		Detail:    suppression.Detail.String,
+		CreatedAt: suppression.CreatedAt,
+	}
+}
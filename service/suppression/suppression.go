@@ -0,0 +1,151 @@
+package suppression
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"net/textproto"
+	"strings"
+
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of a bounce webhook body
+const SignatureHeader = "X-Bounce-Signature"
+
+var (
+	ErrWebhookDisabled  = errors.New("bounce webhook is not configured")
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+)
+
+// IsPermanent reports whether a send failed because the recipient address was
+// rejected for good: a 550, 551 or 553 reply with a 5.1.x enhanced status code,
+// as a server answers RCPT TO for an unknown or invalid mailbox. Other 5xx
+// replies, such as failed authentication, a rejected message or 5.1.7 and 5.1.8
+// about the sender address, say nothing about the recipient and never suppress it.
+func IsPermanent(err error) bool {
+	var smtpErr *textproto.Error
+	if !errors.As(err, &smtpErr) {
+		return false
+	}
+
+	switch smtpErr.Code {
+	case 550, 551, 553:
+	default:
+		return false
+	}
+
+	status, _, _ := strings.Cut(strings.TrimSpace(smtpErr.Msg), " ")
+	detail, ok := strings.CutPrefix(status, "5.1.")
+	if !ok || detail == "" || strings.Trim(detail, "0123456789") != "" {
+		return false
+	}
+
+	return detail != "7" && detail != "8"
+}
+
+// SuppressFailed adds the address of a failed send to the suppression list of
+// the whole platform when the error is permanent. Failures are only logged,
+// the send itself is already recorded.
+func SuppressFailed(ctx context.Context, store types.SuppressionStore, email string, sendErr error) {
+	if !IsPermanent(sendErr) {
+		return
+	}
+
+	added, err := store.CreateSuppression(ctx, &types.Suppression{
+		Email:  email,
+		Reason: types.SuppressionReasonSMTPError,
+		Detail: truncate(sendErr.Error(), 1000),
+	})
+	if err != nil {
+		log.Printf("Error suppressing %s after a permanent SMTP error: %v", email, err)
+		return
+	}
+	if added {
+		log.Printf("Suppressed %s after a permanent SMTP error: %v", email, sendErr)
+	}
+}
+
+// Reason explains to the organizer why no email is sent to a suppressed address
+func Reason(suppression *types.Suppression) string {
+	var reason string
+	switch suppression.Reason {
+	case types.SuppressionReasonSMTPError:
+		reason = "Suppressed: the mail server permanently rejected a previous email"
+	case types.SuppressionReasonBounce:
+		reason = "Suppressed: a previous email bounced"
+	case types.SuppressionReasonComplaint:
+		reason = "Suppressed: the recipient reported a previous email as spam"
+	default:
+		reason = "Suppressed: the address was added to the suppression list"
+	}
+
+	if suppression.Detail != "" {
+		reason += " (" + suppression.Detail + ")"
+	}
+
+	return reason
+}
+
+// Filter leaves the attendees with a suppressed address out of the recipients
+// of a bulk send. It returns the attendees to email and the ones skipped with
+// the reason why.
+func Filter(recipients []*types.Attendee, suppressions []*types.Suppression) ([]*types.Attendee, []*types.SkippedRecipient) {
+	suppressed := make(map[string]*types.Suppression, len(suppressions))
+	for _, suppression := range suppressions {
+		email := utils.NormalizeEmail(suppression.Email)
+		if _, ok := suppressed[email]; !ok {
+			suppressed[email] = suppression
+		}
+	}
+
+	emailed := make([]*types.Attendee, 0, len(recipients))
+	skipped := []*types.SkippedRecipient{}
+	for _, attendee := range recipients {
+		suppression, ok := suppressed[utils.NormalizeEmail(attendee.Email)]
+		if !ok {
+			emailed = append(emailed, attendee)
+			continue
+		}
+		skipped = append(skipped, &types.SkippedRecipient{
+			AttendeeID: attendee.ID,
+			Email:      attendee.Email,
+			Reason:     Reason(suppression),
+		})
+	}
+
+	return emailed, skipped
+}
+
+// Sign returns the signature of a bounce webhook body
+func Sign(payload []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the signature of a bounce webhook body. Every webhook is
+// rejected while no secret is configured.
+func Verify(payload []byte, signature string, secret string) error {
+	if secret == "" {
+		return ErrWebhookDisabled
+	}
+	if !hmac.Equal([]byte(signature), []byte(Sign(payload, secret))) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+func truncate(value string, size int) string {
+	runes := []rune(value)
+	if len(runes) <= size {
+		return value
+	}
+
+	return string(runes[:size])
+}
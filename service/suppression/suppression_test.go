@@ -0,0 +1,82 @@
+package suppression
+
+import (
+	"errors"
+	"fmt"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+func TestIsPermanent(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"unknown mailbox", &textproto.Error{Code: 550, Msg: "5.1.1 User unknown"}, true},
+		{"wrapped", fmt.Errorf("failed to send: %w", &textproto.Error{Code: 553, Msg: "5.1.3 Mailbox name not allowed"}), true},
+		{"user not local", &textproto.Error{Code: 551, Msg: "5.1.6 User not local"}, true},
+		{"null MX", &textproto.Error{Code: 550, Msg: "5.1.10 Recipient address has null MX"}, true},
+		{"no enhanced status", &textproto.Error{Code: 553, Msg: "Mailbox name not allowed"}, false},
+		{"bad sender", &textproto.Error{Code: 553, Msg: "5.1.8 Sender address rejected"}, false},
+		{"message rejected", &textproto.Error{Code: 550, Msg: "5.7.1 Message rejected as spam"}, false},
+		{"transaction failed", &textproto.Error{Code: 554, Msg: "5.1.1 Transaction failed"}, false},
+		{"mailbox busy", &textproto.Error{Code: 450, Msg: "Mailbox unavailable"}, false},
+		{"authentication", &textproto.Error{Code: 535, Msg: "5.7.8 Authentication failed"}, false},
+		{"network", errors.New("dial tcp: connection refused"), false},
+		{"no error", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsPermanent(tt.err); got != tt.want {
+				t.Errorf("IsPermanent(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterSkipsSuppressedAddresses(t *testing.T) {
+	recipients := []*types.Attendee{
+		{ID: 1, Email: "kept@example.com"},
+		{ID: 2, Email: " Bounced@Example.com"},
+		{ID: 3, Email: "manual@example.com"},
+	}
+	suppressions := []*types.Suppression{
+		{Email: "bounced@example.com", Reason: types.SuppressionReasonBounce, Detail: "550 5.1.1 User unknown"},
+		{Email: "manual@example.com", UserID: 7, Reason: types.SuppressionReasonManual},
+		{Email: "manual@example.com", Reason: types.SuppressionReasonComplaint},
+	}
+
+	emailed, skipped := Filter(recipients, suppressions)
+
+	if len(emailed) != 1 || emailed[0].ID != 1 {
+		t.Fatalf("expected only attendee 1 to be emailed, got %+v", emailed)
+	}
+	if len(skipped) != 2 {
+		t.Fatalf("expected 2 skipped recipients, got %d", len(skipped))
+	}
+	if skipped[0].AttendeeID != 2 || !strings.Contains(skipped[0].Reason, "bounced") || !strings.Contains(skipped[0].Reason, "User unknown") {
+		t.Errorf("unexpected skipped recipient %+v", skipped[0])
+	}
+	if skipped[1].AttendeeID != 3 || !strings.Contains(skipped[1].Reason, "suppression list") {
+		t.Errorf("expected the first matching entry to give the reason, got %+v", skipped[1])
+	}
+}
+
+func TestVerify(t *testing.T) {
+	payload := []byte(`{"events":[{"type":"bounce","email":"a@example.com","permanent":true}]}`)
+
+	if err := Verify(payload, Sign(payload, "secret"), "secret"); err != nil {
+		t.Errorf("expected a valid signature, got %v", err)
+	}
+	if err := Verify(payload, Sign(payload, "other"), "secret"); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected ErrInvalidSignature, got %v", err)
+	}
+	if err := Verify(payload, Sign(payload, ""), ""); !errors.Is(err, ErrWebhookDisabled) {
+		t.Errorf("expected ErrWebhookDisabled without a secret, got %v", err)
+	}
+}
@@ -0,0 +1,236 @@
+package ticket
+
+import (
+	"database/sql"
+	"errors"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+type Handler struct {
+	store      types.TicketTypeStore
+	eventStore types.EventStore
+	userStore  types.UserStore
+}
+
+func NewHandler(store types.TicketTypeStore, eventStore types.EventStore, userStore types.UserStore) *Handler {
+	return &Handler{store: store, eventStore: eventStore, userStore: userStore}
+}
+
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	router.Get("/event/:id/tickets", h.handleGetPublicTicketTypes)
+	router.Get("/event/:id/ticket_types", auth.WithJWTAuth(h.handleGetTicketTypes, h.userStore))
+	router.Post("/event/:id/ticket_types", auth.WithJWTAuth(h.handleCreateTicketType, h.userStore))
+	router.Get("/event/:id/ticket_types/:ticket_type_id", auth.WithJWTAuth(h.handleGetTicketType, h.userStore))
+	router.Put("/event/:id/ticket_types/:ticket_type_id", auth.WithJWTAuth(h.handleUpdateTicketType, h.userStore))
+	router.Delete("/event/:id/ticket_types/:ticket_type_id", auth.WithJWTAuth(h.handleDeleteTicketType, h.userStore))
+}
+
+// Handler to list the ticket types of an event that are on sale to the public
+func (h *Handler) handleGetPublicTicketTypes(c *fiber.Ctx) error {
+	eventID, err := strconv.Atoi(c.Params("id"))
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid event ID"})
+	}
+
+	event, err := h.eventStore.GetEventByID(int32(eventID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Event not found"})
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get event"})
+	}
+
+	ticketTypes, err := h.store.GetTicketTypesByEventID(event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get ticket types"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{
+		"event_id": event.EventID,
+		"tickets":  Public(ticketTypes, time.Now()),
+	})
+}
+
+// Handler to list all ticket types of an event with their sales
+func (h *Handler) handleGetTicketTypes(c *fiber.Ctx) error {
+	page, ferr := utils.ParsePage(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	ticketTypes, err := h.store.GetTicketTypesByEventID(event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get ticket types"})
+	}
+
+	list, ferr := utils.SlicePage(ticketTypes, page, func(ticketType *types.TicketType) int64 { return int64(ticketType.ID) }, nil)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(list)
+}
+
+// Handler to add a ticket type to an event
+func (h *Handler) handleCreateTicketType(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var payload types.CreateTicketTypePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+	if payload.SaleStartsAt != nil && payload.SaleEndsAt != nil && !payload.SaleEndsAt.After(*payload.SaleStartsAt) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Sale end must be after sale start"})
+	}
+
+	ticketType := &types.TicketType{EventID: event.EventID}
+	applyPayload(ticketType, &payload)
+	if err := h.store.CreateTicketType(c.Context(), ticketType); err != nil {
+		if isDuplicateName(err) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Ticket type with same name already exists"})
+		}
+		log.Printf("Error creating ticket type for event ID %d: %v", event.EventID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to create ticket type"})
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(ticketType)
+}
+
+// Handler to get a ticket type of an event
+func (h *Handler) handleGetTicketType(c *fiber.Ctx) error {
+	ticketType, ferr := h.getOwnedTicketType(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(ticketType)
+}
+
+// Handler to update a ticket type
+func (h *Handler) handleUpdateTicketType(c *fiber.Ctx) error {
+	ticketType, ferr := h.getOwnedTicketType(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var payload types.CreateTicketTypePayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid request payload"})
+	}
+
+	invalidFields, validationErr := utils.ValidatePayload(payload)
+	if validationErr != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+	if payload.SaleStartsAt != nil && payload.SaleEndsAt != nil && !payload.SaleEndsAt.After(*payload.SaleStartsAt) {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Sale end must be after sale start"})
+	}
+
+	// Tickets already taken cannot be given away by lowering the quantity
+	if payload.Quantity > 0 && payload.Quantity < ticketType.Sold {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Quantity is lower than the number of tickets sold",
+			"sold":  ticketType.Sold,
+		})
+	}
+
+	applyPayload(ticketType, &payload)
+	if err := h.store.UpdateTicketType(c.Context(), ticketType); err != nil {
+		if isDuplicateName(err) {
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{"error": "Ticket type with same name already exists"})
+		}
+		log.Printf("Error updating ticket type ID %d: %v", ticketType.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update ticket type"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(ticketType)
+}
+
+// Handler to delete a ticket type nobody holds yet
+func (h *Handler) handleDeleteTicketType(c *fiber.Ctx) error {
+	ticketType, ferr := h.getOwnedTicketType(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	if ticketType.Sold > 0 {
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"error": "Ticket type is held by attendees, move them to another ticket type first",
+			"sold":  ticketType.Sold,
+		})
+	}
+
+	if err := h.store.DeleteTicketType(c.Context(), ticketType.ID); err != nil {
+		log.Printf("Error deleting ticket type ID %d: %v", ticketType.ID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to delete ticket type"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "Ticket type deleted successfully"})
+}
+
+// applyPayload copies a validated payload onto a ticket type
+func applyPayload(ticketType *types.TicketType, payload *types.CreateTicketTypePayload) {
+	ticketType.Name = strings.TrimSpace(payload.Name)
+	ticketType.Quantity = payload.Quantity
+	ticketType.PriceCents = payload.PriceCents
+	ticketType.Currency = strings.ToUpper(payload.Currency)
+	if ticketType.Currency == "" {
+		ticketType.Currency = DefaultCurrency
+	}
+	ticketType.SaleStartsAt = payload.SaleStartsAt
+	ticketType.SaleEndsAt = payload.SaleEndsAt
+	ticketType.Visible = payload.Visible == nil || *payload.Visible
+}
+
+// isDuplicateName reports whether an insert or update hit the unique ticket type name of an event
+func isDuplicateName(err error) bool {
+	return strings.Contains(err.Error(), "ticket_types_event_id_name_unique")
+}
+
+// getOwnedTicketType loads the ticket type of the request and checks that it belongs to an event of the current user
+func (h *Handler) getOwnedTicketType(c *fiber.Ctx) (*types.TicketType, *fiber.Error) {
+	event, ferr := utils.GetOwnedEvent(c.Params("id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return nil, ferr
+	}
+
+	ticketTypeID, err := strconv.Atoi(c.Params("ticket_type_id"))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid ticket type ID")
+	}
+
+	ticketType, err := h.store.GetTicketTypeByID(int32(ticketTypeID))
+	if err != nil || ticketType.EventID != event.EventID {
+		return nil, fiber.NewError(fiber.StatusNotFound, "Ticket type not found")
+	}
+
+	return ticketType, nil
+}
@@ -0,0 +1,121 @@
+package ticket
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/cmd/pkg/database"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// CreateTicketType creates a new ticket type of an event in the database
+func (s *Store) CreateTicketType(ctx context.Context, ticketType *types.TicketType) error {
+	id, err := s.db.CreateTicketType(ctx, database.CreateTicketTypeParams{
+		EventID:      ticketType.EventID,
+		Name:         ticketType.Name,
+		Quantity:     ticketType.Quantity,
+		PriceCents:   ticketType.PriceCents,
+		Currency:     ticketType.Currency,
+		SaleStartsAt: nullTime(ticketType.SaleStartsAt),
+		SaleEndsAt:   nullTime(ticketType.SaleEndsAt),
+		Visible:      ticketType.Visible,
+	})
+	if err != nil {
+		return err
+	}
+	ticketType.ID = int32(id)
+
+	return nil
+}
+
+// UpdateTicketType updates a ticket type in the database
+func (s *Store) UpdateTicketType(ctx context.Context, ticketType *types.TicketType) error {
+	err := s.db.UpdateTicketType(ctx, database.UpdateTicketTypeParams{
+		Name:         ticketType.Name,
+		Quantity:     ticketType.Quantity,
+		PriceCents:   ticketType.PriceCents,
+		Currency:     ticketType.Currency,
+		SaleStartsAt: nullTime(ticketType.SaleStartsAt),
+		SaleEndsAt:   nullTime(ticketType.SaleEndsAt),
+		Visible:      ticketType.Visible,
+		ID:           ticketType.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DeleteTicketType deletes a ticket type from the database
+func (s *Store) DeleteTicketType(ctx context.Context, ticketTypeID int32) error {
+	return s.db.DeleteTicketType(ctx, ticketTypeID)
+}
+
+// GetTicketTypesByEventID fetches the ticket types of an event, cheapest first
+func (s *Store) GetTicketTypesByEventID(eventID int32) ([]*types.TicketType, error) {
+	ticketTypes, err := s.db.GetTicketTypesByEventID(context.Background(), eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	allTicketTypes := []*types.TicketType{}
+	for _, ticketType := range ticketTypes {
+		allTicketTypes = append(allTicketTypes, toTicketType(ticketType))
+	}
+
+	return allTicketTypes, nil
+}
+
+// GetTicketTypeByID fetches a ticket type by ID
+func (s *Store) GetTicketTypeByID(ticketTypeID int32) (*types.TicketType, error) {
+	ticketType, err := s.db.GetTicketTypeByID(context.Background(), ticketTypeID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toTicketType(ticketType), nil
+}
+
+func toTicketType(ticketType database.TicketType) *types.TicketType {
+	return &types.TicketType{
+		ID:           ticketType.ID,
+		EventID:      ticketType.EventID,
+		Name:         ticketType.Name,
+		Quantity:     ticketType.Quantity,
+		Sold:         ticketType.Sold,
+		PriceCents:   ticketType.PriceCents,
+		Currency:     ticketType.Currency,
+		SaleStartsAt: timePtr(ticketType.SaleStartsAt),
+		SaleEndsAt:   timePtr(ticketType.SaleEndsAt),
+		Visible:      ticketType.Visible,
+		CreatedAt:    ticketType.CreatedAt,
+		UpdatedAt:    ticketType.UpdatedAt,
+	}
+}
+
+func nullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
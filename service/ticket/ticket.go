@@ -0,0 +1,89 @@
+package ticket
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+// DefaultCurrency is used for ticket types created without a currency
+const DefaultCurrency = "USD"
+
+// OnSale reports whether a ticket type is inside its sale window at the given time
+func OnSale(ticketType *types.TicketType, now time.Time) bool {
+	if ticketType.SaleStartsAt != nil && now.Before(*ticketType.SaleStartsAt) {
+		return false
+	}
+	if ticketType.SaleEndsAt != nil && !now.Before(*ticketType.SaleEndsAt) {
+		return false
+	}
+
+	return true
+}
+
+// Remaining returns the number of tickets left, or nil when the quantity is unlimited
+func Remaining(ticketType *types.TicketType) *int32 {
+	if ticketType.Quantity == 0 {
+		return nil
+	}
+
+	remaining := max(ticketType.Quantity-ticketType.Sold, 0)
+	return &remaining
+}
+
+// Public lists the ticket types guests can currently buy: visible ones inside
+// their sale window, sold-out ones included so they can be shown as such
+func Public(ticketTypes []*types.TicketType, now time.Time) []types.PublicTicketType {
+	public := []types.PublicTicketType{}
+	for _, ticketType := range ticketTypes {
+		if !ticketType.Visible || !OnSale(ticketType, now) {
+			continue
+		}
+
+		public = append(public, types.PublicTicketType{
+			ID:         ticketType.ID,
+			Name:       ticketType.Name,
+			PriceCents: ticketType.PriceCents,
+			Currency:   ticketType.Currency,
+			SaleEndsAt: ticketType.SaleEndsAt,
+			Remaining:  Remaining(ticketType),
+		})
+	}
+
+	return public
+}
+
+// ByName indexes ticket types by their case-insensitive name, as used by CSV imports
+func ByName(ticketTypes []*types.TicketType) map[string]*types.TicketType {
+	byName := make(map[string]*types.TicketType, len(ticketTypes))
+	for _, ticketType := range ticketTypes {
+		byName[normalizeName(ticketType.Name)] = ticketType
+	}
+
+	return byName
+}
+
+// Lookup finds a ticket type in an index built by ByName
+func Lookup(byName map[string]*types.TicketType, name string) (*types.TicketType, bool) {
+	ticketType, ok := byName[normalizeName(name)]
+	return ticketType, ok
+}
+
+// ColumnIndex finds a column in a CSV header row by its case-insensitive
+// name, returning -1 when the header has no such column
+func ColumnIndex(header []string, column string) int {
+	for i, name := range header {
+		// Spreadsheet exports may start the file with a byte order mark
+		name = strings.TrimPrefix(name, "\ufeff")
+		if normalizeName(name) == normalizeName(column) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
@@ -0,0 +1,88 @@
+package ticket
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+func TestOnSaleRespectsSaleWindow(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	before := now.Add(-time.Hour)
+	after := now.Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		ticketType *types.TicketType
+		want       bool
+	}{
+		{"no window", &types.TicketType{}, true},
+		{"started", &types.TicketType{SaleStartsAt: &before}, true},
+		{"not started", &types.TicketType{SaleStartsAt: &after}, false},
+		{"ends later", &types.TicketType{SaleEndsAt: &after}, true},
+		{"ended", &types.TicketType{SaleEndsAt: &before}, false},
+		{"ends now", &types.TicketType{SaleEndsAt: &now}, false},
+		{"inside window", &types.TicketType{SaleStartsAt: &before, SaleEndsAt: &after}, true},
+	}
+
+	for _, test := range tests {
+		if got := OnSale(test.ticketType, now); got != test.want {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestRemaining(t *testing.T) {
+	if remaining := Remaining(&types.TicketType{Quantity: 0, Sold: 12}); remaining != nil {
+		t.Errorf("expected unlimited ticket type to have no remaining count, got %d", *remaining)
+	}
+	if remaining := Remaining(&types.TicketType{Quantity: 10, Sold: 4}); remaining == nil || *remaining != 6 {
+		t.Errorf("expected 6 remaining, got %v", remaining)
+	}
+	// A quantity lowered below the tickets sold never reports a negative count
+	if remaining := Remaining(&types.TicketType{Quantity: 3, Sold: 5}); remaining == nil || *remaining != 0 {
+		t.Errorf("expected 0 remaining, got %v", remaining)
+	}
+}
+
+func TestPublicHidesInvisibleAndClosedTicketTypes(t *testing.T) {
+	now := time.Now()
+	ended := now.Add(-time.Hour)
+
+	public := Public([]*types.TicketType{
+		{ID: 1, Name: "General", Visible: true, Quantity: 100, Sold: 100},
+		{ID: 2, Name: "Staff", Visible: false},
+		{ID: 3, Name: "Early bird", Visible: true, SaleEndsAt: &ended},
+		{ID: 4, Name: "VIP", Visible: true},
+	}, now)
+
+	if len(public) != 2 || public[0].ID != 1 || public[1].ID != 4 {
+		t.Fatalf("unexpected public ticket types %+v", public)
+	}
+	if public[0].Remaining == nil || *public[0].Remaining != 0 {
+		t.Errorf("expected sold out ticket type to be listed with 0 remaining, got %v", public[0].Remaining)
+	}
+}
+
+func TestColumnIndexAndLookupIgnoreCase(t *testing.T) {
+	header := []string{"\ufeffFirst Name", "Last Name", "Email", "Ticket_Type "}
+
+	if index := ColumnIndex(header, "ticket_type"); index != 3 {
+		t.Errorf("expected ticket type column 3, got %d", index)
+	}
+	if index := ColumnIndex(header, "first name"); index != 0 {
+		t.Errorf("expected first name column 0, got %d", index)
+	}
+	if index := ColumnIndex(header, "pass"); index != -1 {
+		t.Errorf("expected missing column -1, got %d", index)
+	}
+
+	byName := ByName([]*types.TicketType{{ID: 7, Name: "VIP"}, {ID: 8, Name: "General Admission"}})
+	if ticketType, ok := Lookup(byName, " vip "); !ok || ticketType.ID != 7 {
+		t.Errorf("expected VIP ticket type, got %+v", ticketType)
+	}
+	if _, ok := Lookup(byName, "Speaker"); ok {
+		t.Errorf("expected unknown ticket type not to be found")
+	}
+}
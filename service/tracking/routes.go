@@ -0,0 +1,187 @@
+package tracking
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/service/audit"
+	"github.com/jayden1905/event-registration-software/service/auth"
+	"github.com/jayden1905/event-registration-software/types"
+	"github.com/jayden1905/event-registration-software/utils"
+)
+
+type Handler struct {
+	store      types.EmailTrackingStore
+	eventStore types.EventStore
+	userStore  types.UserStore
+}
+
+func NewHandler(store types.EmailTrackingStore, eventStore types.EventStore, userStore types.UserStore) *Handler {
+	return &Handler{store: store, eventStore: eventStore, userStore: userStore}
+}
+
+func (h *Handler) RegisterRoutes(router fiber.Router) {
+	// Mail clients fetch the pixel and attendees follow the links of tracked emails
+	router.Get("/track/open/:token", h.handleTrackOpen)
+	router.Get("/track/click/:token", h.handleTrackClick)
+
+	router.Get("/event/:event_id/email_tracking", auth.WithJWTAuth(h.handleGetTrackingReport, h.userStore))
+	router.Get("/event/:event_id/email_tracking/attendees", auth.WithJWTAuth(h.handleGetAttendeeTracking, h.userStore))
+	router.Put("/event/:event_id/email_tracking", auth.WithJWTAuth(h.handleUpdateTracking, h.userStore))
+}
+
+// Handler to serve the tracking pixel of an email and record that it was opened.
+// The pixel is served whatever happens so the email never shows a broken image.
+func (h *Handler) handleTrackOpen(c *fiber.Ctx) error {
+	if trackingID, _, err := Verify(types.TrackingEventOpen, c.Params("token")); err == nil {
+		h.record(c.Context(), types.TrackingEventOpen, trackingID, "")
+	}
+
+	c.Set(fiber.HeaderCacheControl, "no-store, no-cache, must-revalidate, max-age=0")
+	c.Set(fiber.HeaderContentType, "image/gif")
+	return c.Status(fiber.StatusOK).Send(Pixel)
+}
+
+// Handler to record a click on a link of an email and redirect to the link
+func (h *Handler) handleTrackClick(c *fiber.Ctx) error {
+	trackingID, target, err := Verify(types.TrackingEventClick, c.Params("token"))
+	if err != nil {
+		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{"error": "Link not found"})
+	}
+
+	h.record(c.Context(), types.TrackingEventClick, trackingID, target)
+
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Redirect(target, fiber.StatusFound)
+}
+
+// Handler to get the opens and clicks of the tracked emails of an event, in total and per template
+func (h *Handler) handleGetTrackingReport(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("event_id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	enabled, err := h.store.IsTrackingEnabled(c.Context(), event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get tracking settings"})
+	}
+
+	totals, err := h.store.GetTrackingStats(c.Context(), event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get tracking stats"})
+	}
+
+	templates, err := h.store.GetTemplateTrackingStats(c.Context(), event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get tracking stats"})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(&types.EmailTrackingReport{
+		EventID:   event.EventID,
+		Enabled:   enabled,
+		Totals:    totals,
+		Templates: templates,
+	})
+}
+
+// Handler to list the opens and clicks of the tracked emails of an event per attendee
+func (h *Handler) handleGetAttendeeTracking(c *fiber.Ctx) error {
+	page, ferr := utils.ParsePage(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	event, ferr := utils.GetOwnedEvent(c.Params("event_id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	attendees, next, err := h.store.GetAttendeeTrackingStats(c.Context(), event.EventID, page)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get tracking stats"})
+	}
+
+	var total *int64
+	if page.WithTotal {
+		count, err := h.store.CountAttendeeTrackingStats(c.Context(), event.EventID)
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to count tracking stats"})
+		}
+		total = &count
+	}
+
+	return c.Status(fiber.StatusOK).JSON(utils.NewPage(attendees, next, total, nil))
+}
+
+// Handler to turn the tracking of the emails of an event on or off. Turning it
+// off also stops recording opens and clicks of emails already sent.
+func (h *Handler) handleUpdateTracking(c *fiber.Ctx) error {
+	event, ferr := utils.GetOwnedEvent(c.Params("event_id"), auth.GetUserIDFromContext(c), h.eventStore)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
+	}
+
+	var payload types.UpdateEmailTrackingPayload
+	if err := c.BodyParser(&payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Invalid payload"})
+	}
+	if invalidFields, err := utils.ValidatePayload(payload); err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+			"error":          "Invalid payload",
+			"invalid_fields": invalidFields,
+		})
+	}
+
+	before, err := h.store.IsTrackingEnabled(c.Context(), event.EventID)
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to get tracking settings"})
+	}
+
+	if err := h.store.SetTrackingEnabled(c.Context(), event.EventID, *payload.Enabled); err != nil {
+		log.Printf("Error updating the email tracking of event ID %d: %v", event.EventID, err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to update tracking settings"})
+	}
+
+	audit.Note(c, audit.Mutation{
+		Action:       "event.email_tracking",
+		TargetType:   "event",
+		TargetID:     event.EventID,
+		EventID:      event.EventID,
+		EventOwnerID: event.UserID,
+		Before:       fiber.Map{"enabled": before},
+		After:        fiber.Map{"enabled": *payload.Enabled},
+	})
+
+	return c.Status(fiber.StatusOK).JSON(fiber.Map{"enabled": *payload.Enabled})
+}
+
+// record stores an open or a click of a tracked email, unless the organizer of
+// its event opted out of tracking. Failures are only logged, the attendee
+// opening or clicking must not notice anything.
+func (h *Handler) record(ctx context.Context, kind string, trackingID string, target string) {
+	delivery, err := h.store.GetTrackedDelivery(ctx, trackingID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			log.Printf("Error getting the email with tracking ID %s: %v", trackingID, err)
+		}
+		return
+	}
+
+	enabled, err := h.store.IsTrackingEnabled(ctx, delivery.EventID)
+	if err != nil {
+		log.Printf("Error getting the tracking settings of event ID %d: %v", delivery.EventID, err)
+		return
+	}
+	if !enabled {
+		return
+	}
+
+	if err := h.store.CreateTrackingEvent(ctx, delivery.ID, kind, target); err != nil {
+		log.Printf("Error recording the %s of email delivery ID %d: %v", kind, delivery.ID, err)
+	}
+}
@@ -0,0 +1,165 @@
+package tracking
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jayden1905/event-registration-software/cmd/pkg/database"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+type Store struct {
+	db *database.Queries
+}
+
+// NewStore initializes the Store with the database queries
+func NewStore(db *database.Queries) *Store {
+	return &Store{db: db}
+}
+
+// GetTrackedDelivery fetches the email sent with a tracking ID
+func (s *Store) GetTrackedDelivery(ctx context.Context, trackingID string) (*types.TrackedDelivery, error) {
+	delivery, err := s.db.GetTrackedDeliveryByTrackingID(ctx, sql.NullString{String: trackingID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.TrackedDelivery{
+		ID:         delivery.ID,
+		EventID:    delivery.EventID,
+		AttendeeID: delivery.AttendeeID.Int32,
+		TemplateID: delivery.TemplateID.Int32,
+	}, nil
+}
+
+// CreateTrackingEvent records an open or a click of a tracked email, with the link clicked
+func (s *Store) CreateTrackingEvent(ctx context.Context, deliveryID int32, kind string, url string) error {
+	return s.db.CreateEmailTrackingEvent(ctx, database.CreateEmailTrackingEventParams{
+		DeliveryID: deliveryID,
+		Kind:       database.EmailTrackingEventsKind(kind),
+		Url:        sql.NullString{String: url, Valid: url != ""},
+	})
+}
+
+// GetTrackingStats fetches the opens and clicks of the tracked emails of an event
+func (s *Store) GetTrackingStats(ctx context.Context, eventID int32) (*types.EmailTrackingStats, error) {
+	stats, err := s.db.GetEmailTrackingStatsByEventID(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toStats(stats.Tracked, stats.Opened, stats.Clicked, stats.Opens, stats.Clicks), nil
+}
+
+// GetTemplateTrackingStats fetches the opens and clicks of the tracked emails of an event per template
+func (s *Store) GetTemplateTrackingStats(ctx context.Context, eventID int32) ([]*types.TemplateTrackingStats, error) {
+	rows, err := s.db.GetEmailTrackingStatsByTemplate(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	templates := []*types.TemplateTrackingStats{}
+	for _, row := range rows {
+		templates = append(templates, &types.TemplateTrackingStats{
+			TemplateID:         row.TemplateID.Int32,
+			Purpose:            string(row.Purpose.EmailTemplatePurpose),
+			Subject:            row.Subject.String,
+			EmailTrackingStats: *toStats(row.Tracked, row.Opened, row.Clicked, row.Opens, row.Clicks),
+		})
+	}
+
+	return templates, nil
+}
+
+// GetAttendeeTrackingStats fetches the page of the opens and clicks of the tracked emails of an
+// event per attendee after a cursor, and the cursor of the next page when there is one
+func (s *Store) GetAttendeeTrackingStats(ctx context.Context, eventID int32, page *types.PageRequest) ([]*types.AttendeeTrackingStats, *types.Cursor, error) {
+	params := database.GetEmailTrackingStatsByAttendeeParams{
+		EventID: eventID,
+		Limit:   page.Limit + 1,
+	}
+	if page.After != nil {
+		params.AfterID = sql.NullInt32{Int32: int32(page.After.ID), Valid: true}
+	}
+
+	rows, err := s.db.GetEmailTrackingStatsByAttendee(ctx, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attendees := []*types.AttendeeTrackingStats{}
+	for _, row := range rows {
+		attendees = append(attendees, &types.AttendeeTrackingStats{
+			AttendeeID:    row.AttendeeID,
+			FirstName:     row.FirstName,
+			LastName:      row.LastName,
+			Email:         row.Email,
+			Tracked:       row.Tracked,
+			Opens:         row.Opens,
+			Clicks:        row.Clicks,
+			FirstOpenedAt: timeValue(row.FirstOpenedAt),
+			LastOpenedAt:  timeValue(row.LastOpenedAt),
+			LastClickedAt: timeValue(row.LastClickedAt),
+		})
+	}
+
+	// The row past the limit only tells that there is a next page
+	if len(attendees) <= int(page.Limit) {
+		return attendees, nil, nil
+	}
+	attendees = attendees[:page.Limit]
+
+	return attendees, &types.Cursor{ID: int64(attendees[len(attendees)-1].AttendeeID)}, nil
+}
+
+// CountAttendeeTrackingStats counts the attendees of an event that were sent a tracked email
+func (s *Store) CountAttendeeTrackingStats(ctx context.Context, eventID int32) (int64, error) {
+	return s.db.CountEmailTrackingAttendees(ctx, eventID)
+}
+
+// IsTrackingEnabled tells whether the emails of an event are tracked, unless its organizer opted out
+func (s *Store) IsTrackingEnabled(ctx context.Context, eventID int32) (bool, error) {
+	optOuts, err := s.db.GetEmailTrackingOptOut(ctx, eventID)
+	if err != nil {
+		return false, err
+	}
+
+	return optOuts == 0, nil
+}
+
+// SetTrackingEnabled turns the tracking of the emails of an event on or off
+func (s *Store) SetTrackingEnabled(ctx context.Context, eventID int32, enabled bool) error {
+	if enabled {
+		return s.db.DeleteEmailTrackingOptOut(ctx, eventID)
+	}
+
+	return s.db.CreateEmailTrackingOptOut(ctx, eventID)
+}
+
+// toStats builds tracking totals with the share of emails opened and clicked
+func toStats(tracked, opened, clicked, opens, clicks int64) *types.EmailTrackingStats {
+	stats := &types.EmailTrackingStats{
+		Tracked: tracked,
+		Opened:  opened,
+		Clicked: clicked,
+		Opens:   opens,
+		Clicks:  clicks,
+	}
+	if tracked > 0 {
+		stats.OpenRate = float64(opened) / float64(tracked)
+		stats.ClickRate = float64(clicked) / float64(tracked)
+	}
+
+	return stats
+}
+
+// timeValue reads a computed timestamp column, which is NULL when nothing happened
+func timeValue(value interface{}) *time.Time {
+	t, ok := value.(time.Time)
+	if !ok {
+		return nil
+	}
+
+	return &t
+}
@@ -0,0 +1,124 @@
+package tracking
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/jayden1905/event-registration-software/config"
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+// Pixel is a transparent 1x1 GIF, served for the tracking pixel of an email
+var Pixel = []byte{
+	0x47, 0x49, 0x46, 0x38, 0x39, 0x61, 0x01, 0x00, 0x01, 0x00, 0x80, 0x00, 0x00, 0x00, 0x00, 0x00,
+	0xff, 0xff, 0xff, 0x21, 0xf9, 0x04, 0x01, 0x00, 0x00, 0x00, 0x00, 0x2c, 0x00, 0x00, 0x00, 0x00,
+	0x01, 0x00, 0x01, 0x00, 0x00, 0x02, 0x02, 0x44, 0x01, 0x00, 0x3b,
+}
+
+// linkPattern matches the web links of an email, in double or single quotes
+var linkPattern = regexp.MustCompile(`(?i)(<a\s[^>]*?\bhref\s*=\s*)(?:"(https?://[^"]*)"|'(https?://[^']*)')`)
+
+// NewID generates the random ID a tracked email is recognized by, or returns
+// an empty ID when tracking is disabled. An email is sent untracked rather
+// than not at all when no ID can be generated.
+func NewID(enabled bool) string {
+	if !enabled {
+		return ""
+	}
+
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		log.Printf("Error generating a tracking ID: %v", err)
+		return ""
+	}
+
+	return hex.EncodeToString(b)
+}
+
+// secret derives the key signing tracking tokens from the JWT secret
+func secret() []byte {
+	mac := hmac.New(sha256.New, []byte(config.Envs.JWTSecret))
+	mac.Write([]byte("email:tracking"))
+	return mac.Sum(nil)
+}
+
+// Sign generates the token of a tracking URL. The target of a link is part of
+// the signed token, so the click endpoint only ever redirects to links that
+// were in an email.
+func Sign(kind string, trackingID string, target string) string {
+	payload := kind + "\n" + trackingID + "\n" + target
+
+	mac := hmac.New(sha256.New, secret())
+	mac.Write([]byte(payload))
+
+	return base64.RawURLEncoding.EncodeToString([]byte(payload)) + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Verify returns the tracking ID and the target of a token signed for kind
+func Verify(kind string, token string) (string, string, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", "", fmt.Errorf("token is invalid")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", "", fmt.Errorf("token is invalid")
+	}
+	sum, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil {
+		return "", "", fmt.Errorf("token is invalid")
+	}
+
+	mac := hmac.New(sha256.New, secret())
+	mac.Write(payload)
+	if !hmac.Equal(sum, mac.Sum(nil)) {
+		return "", "", fmt.Errorf("token is invalid")
+	}
+
+	parts := strings.SplitN(string(payload), "\n", 3)
+	if len(parts) != 3 || parts[0] != kind || parts[1] == "" {
+		return "", "", fmt.Errorf("token is invalid")
+	}
+
+	return parts[1], parts[2], nil
+}
+
+// OpenURL returns the URL of the tracking pixel of an email
+func OpenURL(trackingID string) string {
+	return fmt.Sprintf("%s/api/v1/track/open/%s", config.Envs.BackendHost, Sign(types.TrackingEventOpen, trackingID, ""))
+}
+
+// ClickURL returns the URL a link of an email is rewritten to, redirecting to target
+func ClickURL(trackingID string, target string) string {
+	return fmt.Sprintf("%s/api/v1/track/click/%s", config.Envs.BackendHost, Sign(types.TrackingEventClick, trackingID, target))
+}
+
+// Instrument rewrites the web links of an HTML email to go through the click
+// endpoint and adds the tracking pixel at the end of its body
+func Instrument(body string, trackingID string) string {
+	body = linkPattern.ReplaceAllStringFunc(body, func(match string) string {
+		parts := linkPattern.FindStringSubmatch(match)
+		target := parts[2]
+		if target == "" {
+			target = parts[3]
+		}
+
+		return fmt.Sprintf(`%s"%s"`, parts[1], html.EscapeString(ClickURL(trackingID, html.UnescapeString(target))))
+	})
+
+	pixel := fmt.Sprintf(`<img src="%s" width="1" height="1" alt="" style="display:none" />`, OpenURL(trackingID))
+	if i := strings.LastIndex(strings.ToLower(body), "</body>"); i >= 0 {
+		return body[:i] + pixel + body[i:]
+	}
+
+	return body + pixel
+}
@@ -0,0 +1,61 @@
+package tracking
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	token := Sign(types.TrackingEventClick, "abc", "https://example.com/a?b=1&c=2")
+
+	trackingID, target, err := Verify(types.TrackingEventClick, token)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if trackingID != "abc" || target != "https://example.com/a?b=1&c=2" {
+		t.Errorf("unexpected token content %q %q", trackingID, target)
+	}
+
+	if _, _, err := Verify(types.TrackingEventOpen, token); err == nil {
+		t.Error("expected a click token to be rejected as an open token")
+	}
+
+	encoded, signature, _ := strings.Cut(token, ".")
+	forged := Sign(types.TrackingEventClick, "abc", "https://evil.example.com")
+	forgedEncoded, _, _ := strings.Cut(forged, ".")
+	if _, _, err := Verify(types.TrackingEventClick, forgedEncoded+"."+signature); err == nil {
+		t.Error("expected a token with another target to be rejected")
+	}
+	if _, _, err := Verify(types.TrackingEventClick, encoded); err == nil {
+		t.Error("expected a token without signature to be rejected")
+	}
+}
+
+func TestInstrument(t *testing.T) {
+	body := `<html><body><a href="https://example.com/?a=1&amp;b=2">Go</a> <a class="x" href='http://example.org'>Org</a> <a href="mailto:x@example.com">Mail</a></body></html>`
+
+	result := Instrument(body, "abc")
+
+	if strings.Contains(result, `href="https://example.com`) || strings.Contains(result, `href='http://example.org'`) {
+		t.Errorf("expected web links to be rewritten, got %s", result)
+	}
+	if !strings.Contains(result, `href="mailto:x@example.com"`) {
+		t.Errorf("expected other links to be kept, got %s", result)
+	}
+	if strings.Count(result, "/api/v1/track/click/") != 2 {
+		t.Errorf("expected two tracked links, got %s", result)
+	}
+	if !strings.Contains(result, `style="display:none" /></body>`) {
+		t.Errorf("expected the pixel at the end of the body, got %s", result)
+	}
+
+	// The first link keeps its query once unescaped
+	start := strings.Index(result, "/api/v1/track/click/") + len("/api/v1/track/click/")
+	end := strings.Index(result[start:], `"`)
+	_, target, err := Verify(types.TrackingEventClick, result[start:start+end])
+	if err != nil || target != "https://example.com/?a=1&b=2" {
+		t.Errorf("unexpected target %q: %v", target, err)
+	}
+}
@@ -9,19 +9,23 @@ import (
 	"github.com/golang-jwt/jwt/v4"
 
 	"github.com/jayden1905/event-registration-software/config"
+	"github.com/jayden1905/event-registration-software/service/audit"
 	"github.com/jayden1905/event-registration-software/service/auth"
 	"github.com/jayden1905/event-registration-software/service/email"
+	"github.com/jayden1905/event-registration-software/service/plan"
 	"github.com/jayden1905/event-registration-software/types"
 	"github.com/jayden1905/event-registration-software/utils"
 )
 
 type Handler struct {
-	store  types.UserStore
-	mailer email.Mailer
+	store     types.UserStore
+	planStore types.PlanStore
+	enforcer  *plan.Enforcer
+	mailer    email.Mailer
 }
 
-func NewHandler(store types.UserStore, mailer email.Mailer) *Handler {
-	return &Handler{store: store, mailer: mailer}
+func NewHandler(store types.UserStore, planStore types.PlanStore, enforcer *plan.Enforcer, mailer email.Mailer) *Handler {
+	return &Handler{store: store, planStore: planStore, enforcer: enforcer, mailer: mailer}
 }
 
 // RegisterRoutes for Fiber
@@ -33,9 +37,9 @@ func (h *Handler) RegisterRoutes(router fiber.Router) {
 	router.Post("/user/register", h.handleRegister)
 	router.Patch("/user/super-user", h.handleCreateSuperUser)
 	router.Put("/user/update-user/:id", auth.WithJWTAuth(h.handleUpdateUserInformation, h.store))
-	router.Get("/users", auth.WithJWTAuth(h.handleGetUsersPaginated, h.store))
-	router.Get("/user/:id", auth.WithJWTAuth(h.handleGetUserByID, h.store))
-	router.Delete("/user/:id", auth.WithJWTAuth(h.handleDeleteUser, h.store))
+	router.Get("/users", auth.WithSuperUser(h.handleGetUsersPaginated, h.store))
+	router.Get("/user/:id", auth.WithSuperUser(h.handleGetUserByID, h.store))
+	router.Delete("/user/:id", auth.WithSuperUser(h.handleDeleteUser, h.store))
 	router.Get("/user/auth/status", h.handleIsAuthenticated)
 	router.Get("/user/verify/email", h.handleVerifyAccount)
 	router.Post("/user/verify/email/resend", rateLimiterEmailVerification, h.handleResendVerificationEmail)
@@ -213,6 +217,10 @@ func (h *Handler) handleLogin(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": "Please verify your email"})
 	}
 
+	if reason := auth.AccountBlockedReason(u, time.Now()); reason != "" {
+		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": reason})
+	}
+
 	secret := []byte(config.Envs.JWTSecret)
 	token, err := auth.CreateJWT(secret, int(u.ID))
 	if err != nil {
@@ -291,7 +299,17 @@ func (h *Handler) handleCreateSuperUser(c *fiber.Ctx) error {
 		}
 
 		// update user to super user
-		h.store.UpdateUserToSuperUser(c.Context(), u.ID)
+		if err := h.store.UpdateUserToSuperUser(c.Context(), u.ID); err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error updating user role: %v", err)})
+		}
+
+		audit.Note(c, audit.Mutation{
+			Action:     "user.role",
+			TargetType: "user",
+			TargetID:   u.ID,
+			Before:     map[string]any{"role": role},
+			After:      map[string]any{"role": "super_user"},
+		})
 		return c.Status(fiber.StatusOK).JSON(fiber.Map{
 			"message": "User updated to super user successfully",
 		})
@@ -308,6 +326,12 @@ func (h *Handler) handleCreateSuperUser(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": err.Error()})
 	}
 
+	audit.Note(c, audit.Mutation{
+		Action:     "user.create",
+		TargetType: "user",
+		After:      map[string]any{"email": payload.Email, "role": "super_user"},
+	})
+
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{"message": "Super user created successfully"})
 }
 
@@ -316,15 +340,6 @@ func (h *Handler) handleDeleteUser(c *fiber.Ctx) error {
 	// get user id from context
 	userID := auth.GetUserIDFromContext(c)
 
-	// check if user is a super user
-	superUser, err := utils.IsSuperUser(userID, h.store)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting user role by id: %v", err)})
-	}
-	if !superUser {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Access denied"})
-	}
-
 	paramsID := c.Params("id")
 	// convert id to int
 	intID, err := strconv.Atoi(paramsID)
@@ -335,7 +350,7 @@ func (h *Handler) handleDeleteUser(c *fiber.Ctx) error {
 	id := int32(intID)
 
 	// Check if the user exists in the database
-	_, err = h.store.GetUserByID(id)
+	user, err := h.store.GetUserByID(id)
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{"error": fmt.Sprintf("%v", err)})
 	}
@@ -350,6 +365,8 @@ func (h *Handler) handleDeleteUser(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error deleting user by id: %v", err)})
 	}
 
+	audit.Note(c, audit.Mutation{Action: "user.delete", TargetType: "user", TargetID: id, Before: user})
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "User deleted successfully"})
 }
 
@@ -406,67 +423,43 @@ func (h *Handler) handleUpdateUserInformation(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error updating user information: %v", err)})
 	}
 
+	audit.Note(c, audit.Mutation{
+		Action:     "user.update",
+		TargetType: "user",
+		TargetID:   id,
+		Before:     map[string]any{"first_name": user.FirstName, "last_name": user.LastName, "email": user.Email},
+		After:      payload,
+	})
+
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{"message": "User information updated successfully"})
 }
 
-// Handler for getting all users by page
+// Handler for getting a page of users, newest first
 func (h *Handler) handleGetUsersPaginated(c *fiber.Ctx) error {
-	userID := auth.GetUserIDFromContext(c)
-
-	// Checking if user have access
-	superUser, err := utils.IsSuperUser(userID, h.store)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting user role by id: %v", err)})
+	page, ferr := utils.ParsePage(c)
+	if ferr != nil {
+		return c.Status(ferr.Code).JSON(fiber.Map{"error": ferr.Message})
 	}
-	if !superUser {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Access denied"})
-	}
-
-	const (
-		defaultPageSize = 10
-		maxPageSize     = 100
-	)
 
-	pageStr := c.Query("page")
-	pageSizeStr := c.Query("page_size")
-
-	page := 1
-	pageSize := defaultPageSize
-
-	// Parse page if provided
-	if pageStr != "" {
-		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
-			page = p
-		}
+	users, next, err := h.store.SearchUsers(types.UserFilter{}, page)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).JSON("Error getting users by page and page size")
 	}
 
-	// Parse pageSize if provided
-	if pageSizeStr != "" {
-		if ps, err := strconv.Atoi(pageSizeStr); err == nil && ps > 0 && ps <= maxPageSize {
-			pageSize = ps
+	var total *int64
+	if page.WithTotal {
+		count, err := h.store.CountUsers(types.UserFilter{})
+		if err != nil {
+			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": "Failed to count users"})
 		}
+		total = &count
 	}
 
-	users, err := h.store.GetUsersPaginated(int32(page), int32(pageSize))
-	if err != nil {
-		return c.Status(fiber.StatusBadRequest).JSON("Error getting users by page and page size")
-	}
-
-	return c.Status(fiber.StatusOK).JSON(users)
+	return c.Status(fiber.StatusOK).JSON(utils.NewPage(users, next, total, nil))
 }
 
 // Handler for getting a user by id
 func (h *Handler) handleGetUserByID(c *fiber.Ctx) error {
-	userID := auth.GetUserIDFromContext(c)
-
-	superUser, err := utils.IsSuperUser(userID, h.store)
-	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting user role by id: %v", err)})
-	}
-	if !superUser {
-		return c.Status(fiber.StatusForbidden).JSON(fiber.Map{"error": "Access denied"})
-	}
-
 	stringID := c.Params("id")
 
 	// convert id to int
@@ -483,6 +476,14 @@ func (h *Handler) handleGetUserByID(c *fiber.Ctx) error {
 		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting user by id: %v", err)})
 	}
 
+	// Attach the limits that apply to the user and what they have used of them
+	if u.Plan, err = h.enforcer.PlanFor(id); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting user plan: %v", err)})
+	}
+	if u.Usage, err = h.planStore.GetUsage(id, time.Now()); err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{"error": fmt.Sprintf("Error getting user usage: %v", err)})
+	}
+
 	return c.Status(fiber.StatusOK).JSON(u)
 }
 
@@ -3,6 +3,7 @@ package user
 import (
 	"database/sql"
 	"fmt"
+	"time"
 
 	"golang.org/x/net/context"
 
@@ -19,38 +20,6 @@ func NewStore(db *database.Queries) *Store {
 	return &Store{db: db}
 }
 
-// GetUsersPaginated fetches users by page from the database
-func (s *Store) GetUsersPaginated(page int32, pageSize int32) ([]*types.User, error) {
-	offset := (page - 1) * pageSize
-	users, err := s.db.GetAllUsersPaginated(context.Background(), database.GetAllUsersPaginatedParams{
-		Limit:  pageSize,
-		Offset: offset,
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	// Convert the database user to the user type
-	var allUsers []*types.User
-
-	for _, user := range users {
-		allUsers = append(allUsers, &types.User{
-			ID:           int32(user.UserID),
-			FirstName:    user.FirstName,
-			LastName:     user.LastName,
-			Role:         string(user.Role),
-			Subscription: string(user.SubscriptionStatus),
-			Email:        user.Email,
-			Password:     user.Password,
-			Verify:       user.Verify,
-			CreatedAt:    user.CreatedAt,
-			UpdatedAt:    user.UpdatedAt,
-		})
-	}
-
-	return allUsers, nil
-}
-
 // GetUserByEmail fetches a user by email from the database
 func (s *Store) GetUserByEmail(email string) (*types.User, error) {
 	user, err := s.db.GetUserByEmail(context.Background(), email) // Use the SQLC-generated method
@@ -70,6 +39,8 @@ func (s *Store) GetUserByEmail(email string) (*types.User, error) {
 		Email:        user.Email,
 		Password:     user.Password,
 		Verify:       user.Verify,
+		Disabled:     user.DisabledAt.Valid,
+		LockedUntil:  timePtr(user.LockedUntil),
 		CreatedAt:    user.CreatedAt,
 		UpdatedAt:    user.UpdatedAt,
 	}, nil
@@ -94,6 +65,8 @@ func (s *Store) GetUserByID(id int32) (*types.User, error) {
 		Email:        user.Email,
 		Password:     user.Password,
 		Verify:       user.Verify,
+		Disabled:     user.DisabledAt.Valid,
+		LockedUntil:  timePtr(user.LockedUntil),
 		CreatedAt:    user.CreatedAt,
 		UpdatedAt:    user.UpdatedAt,
 	}, nil
@@ -198,3 +171,131 @@ func (s *Store) DeleteUserByID(ctx context.Context, id int32) error {
 
 	return nil
 }
+
+// SearchUsers fetches the page of the users matching the filter after a cursor, newest first,
+// and the cursor of the next page when there is one
+func (s *Store) SearchUsers(filter types.UserFilter, page *types.PageRequest) ([]*types.User, *types.Cursor, error) {
+	params := database.SearchUsersParams{
+		EmailPattern: sql.NullString{String: "%" + filter.Email + "%", Valid: filter.Email != ""},
+		Role:         database.NullRolesName{RolesName: database.RolesName(filter.Role), Valid: filter.Role != ""},
+		Verify:       nullBool(filter.Verified),
+		Subscription: database.NullSubscriptionsStatus{SubscriptionsStatus: database.SubscriptionsStatus(filter.Subscription), Valid: filter.Subscription != ""},
+		Disabled:     nullFlag(filter.Disabled),
+		Limit:        page.Limit + 1,
+	}
+	if page.After != nil {
+		createdAt, err := time.Parse(time.RFC3339Nano, page.After.Key)
+		if err != nil {
+			return nil, nil, err
+		}
+		params.AfterID = sql.NullInt32{Int32: int32(page.After.ID), Valid: true}
+		params.AfterTime = sql.NullTime{Time: createdAt, Valid: true}
+	}
+
+	users, err := s.db.SearchUsers(context.Background(), params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	allUsers := []*types.User{}
+	for _, user := range users {
+		allUsers = append(allUsers, &types.User{
+			ID:           user.UserID,
+			FirstName:    user.FirstName,
+			LastName:     user.LastName,
+			Role:         string(user.Role),
+			Subscription: string(user.SubscriptionStatus),
+			Email:        user.Email,
+			Verify:       user.Verify,
+			Disabled:     user.DisabledAt.Valid,
+			LockedUntil:  timePtr(user.LockedUntil),
+			CreatedAt:    user.CreatedAt,
+			UpdatedAt:    user.UpdatedAt,
+		})
+	}
+
+	// The row past the limit only tells that there is a next page
+	if len(allUsers) <= int(page.Limit) {
+		return allUsers, nil, nil
+	}
+	allUsers = allUsers[:page.Limit]
+	last := allUsers[len(allUsers)-1]
+
+	return allUsers, &types.Cursor{Key: last.CreatedAt.Format(time.RFC3339Nano), ID: int64(last.ID)}, nil
+}
+
+// CountUsers counts the users matching the filter
+func (s *Store) CountUsers(filter types.UserFilter) (int64, error) {
+	return s.db.CountSearchUsers(context.Background(), database.CountSearchUsersParams{
+		EmailPattern: sql.NullString{String: "%" + filter.Email + "%", Valid: filter.Email != ""},
+		Role:         database.NullRolesName{RolesName: database.RolesName(filter.Role), Valid: filter.Role != ""},
+		Verify:       nullBool(filter.Verified),
+		Subscription: database.NullSubscriptionsStatus{SubscriptionsStatus: database.SubscriptionsStatus(filter.Subscription), Valid: filter.Subscription != ""},
+		Disabled:     nullFlag(filter.Disabled),
+	})
+}
+
+// SetUserVerification marks the email of a user as verified or not
+func (s *Store) SetUserVerification(ctx context.Context, id int32, verify bool) error {
+	return s.db.UpdateUserVerificationStatus(ctx, database.UpdateUserVerificationStatusParams{
+		Verify: verify,
+		UserID: id,
+	})
+}
+
+// UpdateUserPassword replaces the password hash of a user
+func (s *Store) UpdateUserPassword(ctx context.Context, id int32, hashedPassword string) error {
+	return s.db.UpdateUserPassword(ctx, database.UpdateUserPasswordParams{
+		Password: hashedPassword,
+		UserID:   id,
+	})
+}
+
+// SetUserDisabled disables or re-enables the account of a user
+func (s *Store) SetUserDisabled(ctx context.Context, id int32, disabled bool) error {
+	return s.db.SetUserDisabledAt(ctx, database.SetUserDisabledAtParams{
+		DisabledAt: sql.NullTime{Time: time.Now(), Valid: disabled},
+		UserID:     id,
+	})
+}
+
+// SetUserLockedUntil locks the account of a user until the given time, or unlocks it when nil
+func (s *Store) SetUserLockedUntil(ctx context.Context, id int32, lockedUntil *time.Time) error {
+	lock := sql.NullTime{}
+	if lockedUntil != nil {
+		lock = sql.NullTime{Time: *lockedUntil, Valid: true}
+	}
+
+	return s.db.SetUserLockedUntil(ctx, database.SetUserLockedUntilParams{
+		LockedUntil: lock,
+		UserID:      id,
+	})
+}
+
+func nullBool(b *bool) sql.NullBool {
+	if b == nil {
+		return sql.NullBool{}
+	}
+
+	return sql.NullBool{Bool: *b, Valid: true}
+}
+
+// nullFlag passes an optional boolean filter as the 0 or 1 the queries compare against
+func nullFlag(b *bool) sql.NullInt64 {
+	if b == nil {
+		return sql.NullInt64{}
+	}
+	if *b {
+		return sql.NullInt64{Int64: 1, Valid: true}
+	}
+
+	return sql.NullInt64{Valid: true}
+}
+
+func timePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+
+	return &t.Time
+}
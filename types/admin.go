@@ -0,0 +1,38 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// SystemStats is an overview of the whole system for super users
+type SystemStats struct {
+	Users          int64            `json:"users"`
+	VerifiedUsers  int64            `json:"verified_users"`
+	DisabledUsers  int64            `json:"disabled_users"`
+	UsersByPlan    map[string]int64 `json:"users_by_plan"`
+	Events         int64            `json:"events"`
+	UpcomingEvents int64            `json:"upcoming_events"`
+	Attendees      int64            `json:"attendees"`
+	CheckedIn      int64            `json:"checked_in"`
+	PaidOrders     int64            `json:"paid_orders"`
+	Revenue        map[string]int64 `json:"revenue_cents"`
+	EmailsSent     int64            `json:"emails_sent_this_month"`
+	GeneratedAt    time.Time        `json:"generated_at"`
+}
+
+type AdminStore interface {
+	GetSystemStats(ctx context.Context, now time.Time) (*SystemStats, error)
+}
+
+type SetVerificationPayload struct {
+	Verify *bool `json:"verify" validate:"required"`
+}
+
+type ResetPasswordPayload struct {
+	Password string `json:"password" validate:"omitempty,min=3,max=20"`
+}
+
+type LockUserPayload struct {
+	Minutes int `json:"minutes" validate:"required,gte=1"`
+}
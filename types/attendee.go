@@ -1,51 +1,171 @@
 package types
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type Attendee struct {
-	ID          int32  `json:"id"`
-	FirstName   string `json:"first_name"`
-	LastName    string `json:"last_name"`
-	Email       string `json:"email"`
-	EventID     int32  `json:"event_id"`
-	QrCode      string `json:"qr_code"`
-	CompanyName string `json:"company_name"`
-	Title       string `json:"title"`
-	TableNo     int32  `json:"table_no"`
-	Role        string `json:"role"`
-	Attendance  bool   `json:"attendance"`
+	ID                 int32      `json:"id"`
+	FirstName          string     `json:"first_name"`
+	LastName           string     `json:"last_name"`
+	Email              string     `json:"email"`
+	EventID            int32      `json:"event_id"`
+	QrCode             string     `json:"qr_code"`
+	CompanyName        string     `json:"company_name"`
+	Title              string     `json:"title"`
+	TableNo            int32      `json:"table_no"`
+	Role               string     `json:"role"`
+	Attendance         bool       `json:"attendance"`
+	FirstCheckedInAt   *time.Time `json:"first_checked_in_at"`
+	SessionsAttended   int32      `json:"sessions_attended"`
+	TicketTypeID       int32      `json:"ticket_type_id"`
+	RegistrationStatus string     `json:"registration_status"`
+	RSVPStatus         string     `json:"rsvp_status"`
+	RSVPReason         string     `json:"rsvp_reason,omitempty"`
+	RSVPPlusOnes       int32      `json:"rsvp_plus_ones"`
+	RSVPRespondedAt    *time.Time `json:"rsvp_responded_at"`
+	PrimaryAttendeeID  int32      `json:"primary_attendee_id,omitempty"`
+	DeletedAt          *time.Time `json:"deleted_at,omitempty"`
 }
 
+// Registration statuses of an attendee. Paid registrations stay pending until
+// the payment provider confirms the payment.
+const (
+	RegistrationStatusConfirmed      = "confirmed"
+	RegistrationStatusPendingPayment = "pending_payment"
+)
+
+// RSVP responses of an attendee to their invitation. Everyone is pending until they respond.
+const (
+	RSVPStatusPending  = "pending"
+	RSVPStatusAccepted = "accepted"
+	RSVPStatusDeclined = "declined"
+	RSVPStatusMaybe    = "maybe"
+)
+
 type AttendeeStore interface {
 	GetAllAttendeesPaginated(page int32, pageSize int32, eventID int32) ([]*Attendee, error)
 	GetAllAttendees(eventID int32) ([]*Attendee, error)
+	GetFilteredAttendees(eventID int32, filter *AttendeeFilter, page *PageRequest) ([]*Attendee, *Cursor, error)
+	GetAllFilteredAttendees(eventID int32, filter *AttendeeFilter) ([]*Attendee, error)
+	CountFilteredAttendees(eventID int32, filter *AttendeeFilter) (int64, error)
 	GetAttendeeRowCount(eventID int32) (int64, error)
 	GetAttendeeByEmail(email string) (*Attendee, error)
+	GetAttendeeByEmailAndEventID(email string, eventID int32) (*Attendee, error)
 	GetAttendeeByID(attendeeID int32) (*Attendee, error)
 	CreateAttendee(ctx context.Context, attendee *Attendee) error
 	DeleteAttendeeByID(attendeeID int32) error
 	DeleteAllAttendeesByEventID(eventID int32) error
 	UpdateAttendeeByID(attendeeID int32, data *Attendee) error
+	UpdateAttendeesByID(ctx context.Context, attendees []*Attendee) error
+	GetAttendanceStats(eventID int32) (*AttendanceStats, error)
+	GetRSVPCounts(eventID int32) (*RSVPCounts, error)
+	UpdateAttendeeRSVP(ctx context.Context, attendeeID int32, status string, reason string, plusOnes int32) error
+	GetDeletedAttendees(eventID int32) ([]*Attendee, error)
+	GetDeletedAttendeesPaginated(eventID int32, page *PageRequest) ([]*Attendee, *Cursor, error)
+	CountDeletedAttendees(eventID int32) (int64, error)
+	GetDeletedAttendeeByID(attendeeID int32) (*Attendee, error)
+	RestoreAttendeeByID(ctx context.Context, attendeeID int32) error
+	GetGuests(primaryAttendeeID int32) ([]*Attendee, error)
+	UpdatePrimaryAttendee(ctx context.Context, attendeeID int32, primaryAttendeeID int32) error
+	ClearGuests(ctx context.Context, primaryAttendeeID int32) error
+}
+
+// AttendeeFilter selects the attendees of an event that a listing or a bulk
+// action applies to, empty fields match every attendee. Search matches part
+// of the name, email or company and Sort names a column, descending when
+// prefixed with a minus.
+type AttendeeFilter struct {
+	Role        string `query:"role" json:"role,omitempty"`
+	CompanyName string `query:"company_name" json:"company_name,omitempty"`
+	TableNo     *int32 `query:"table_no" json:"table_no,omitempty"`
+	Attendance  *bool  `query:"attendance" json:"attendance,omitempty"`
+	RSVPStatus  string `query:"rsvp_status" json:"rsvp_status,omitempty" validate:"omitempty,oneof=pending accepted declined maybe"`
+	Invited     *bool  `query:"invited" json:"invited,omitempty"`
+	FieldName   string `query:"field_name" json:"field_name,omitempty" validate:"required_with=FieldValue"`
+	FieldValue  string `query:"field_value" json:"field_value,omitempty"`
+	Search      string `query:"q" json:"q,omitempty"`
+	Sort        string `query:"sort" json:"sort,omitempty" validate:"omitempty,oneof=name -name email -email company_name -company_name role -role table_no -table_no checked_in_at -checked_in_at"`
+}
+
+// BulkUpdateAttendeesPayload sets one field of every attendee matching a filter
+type BulkUpdateAttendeesPayload struct {
+	Field string `json:"field" validate:"required,oneof=role company_name title table_no"`
+	Value string `json:"value"`
+}
+
+// AttendanceStats holds the live check-in totals of an event
+type AttendanceStats struct {
+	EventID   int32                  `json:"event_id"`
+	Expected  int64                  `json:"expected"`
+	CheckedIn int64                  `json:"checked_in"`
+	ByRole    []AttendanceGroupStats `json:"by_role"`
+	ByTable   []AttendanceGroupStats `json:"by_table"`
+	RSVP      *RSVPCounts            `json:"rsvp"`
+}
+
+// RSVPCounts breaks the attendees of an event down by their response to the
+// invitation, with the guests those who accepted bring along
+type RSVPCounts struct {
+	Pending  int64 `json:"pending"`
+	Accepted int64 `json:"accepted"`
+	Declined int64 `json:"declined"`
+	Maybe    int64 `json:"maybe"`
+	PlusOnes int64 `json:"plus_ones"`
+}
+
+// AttendanceGroupStats holds the check-in totals of one role or table
+type AttendanceGroupStats struct {
+	Role      string `json:"role,omitempty"`
+	TableNo   int32  `json:"table_no,omitempty"`
+	Expected  int64  `json:"expected"`
+	CheckedIn int64  `json:"checked_in"`
 }
 
 type CreateAttendeePayload struct {
-	FirstName   string `json:"first_name" validate:"required"`
-	LastName    string `json:"last_name" validate:"required"`
-	Email       string `json:"email" validate:"required,email"`
-	EventID     int32  `json:"event_id" validate:"required"`
-	CompanyName string `json:"company_name"`
-	Title       string `json:"title"`
-	TableNo     int32  `json:"table_no"`
-	Role        string `json:"role"`
+	FirstName    string `json:"first_name" validate:"required"`
+	LastName     string `json:"last_name" validate:"required"`
+	Email        string `json:"email" validate:"required,email"`
+	EventID      int32  `json:"event_id" validate:"required"`
+	CompanyName  string `json:"company_name"`
+	Title        string `json:"title"`
+	TableNo      int32  `json:"table_no"`
+	Role         string `json:"role"`
+	TicketTypeID int32  `json:"ticket_type_id"`
+	// PrimaryAttendeeID registers the attendee as a guest of another attendee
+	// of the event, seated at their table unless a table is given
+	PrimaryAttendeeID int32 `json:"primary_attendee_id"`
 }
 
 type UpdateAttendeePayload struct {
-	FirstName   string `json:"first_name"`
-	LastName    string `json:"last_name"`
-	Email       string `json:"email"`
-	CompanyName string `json:"company_name"`
-	Title       string `json:"title"`
-	TableNo     int32  `json:"table_no"`
-	Role        string `json:"role"`
-	Attendance  bool   `json:"attendance"`
+	FirstName    string `json:"first_name"`
+	LastName     string `json:"last_name"`
+	Email        string `json:"email"`
+	CompanyName  string `json:"company_name"`
+	Title        string `json:"title"`
+	TableNo      int32  `json:"table_no"`
+	Role         string `json:"role"`
+	Attendance   bool   `json:"attendance"`
+	TicketTypeID int32  `json:"ticket_type_id"`
+}
+
+// RSVPInvitation is what an attendee sees when they answer their invitation
+type RSVPInvitation struct {
+	FirstName   string     `json:"first_name"`
+	LastName    string     `json:"last_name"`
+	Event       *Event     `json:"event"`
+	Status      string     `json:"status"`
+	Reason      string     `json:"reason,omitempty"`
+	PlusOnes    int32      `json:"plus_ones"`
+	RespondedAt *time.Time `json:"responded_at"`
+	CanRespond  bool       `json:"can_respond"`
+}
+
+// RespondRSVPPayload is the answer of an attendee to their invitation. Those
+// who decline bring no plus-ones.
+type RespondRSVPPayload struct {
+	Response string `json:"response" validate:"required,oneof=accepted declined maybe"`
+	Reason   string `json:"reason" validate:"max=500"`
+	PlusOnes int32  `json:"plus_ones" validate:"min=0,max=10"`
 }
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// AuditEntry records an action taken by a user. Entries are never updated or deleted.
+type AuditEntry struct {
+	ID             int64           `json:"id"`
+	ActorID        int32           `json:"actor_id"`
+	ImpersonatorID int32           `json:"impersonator_id,omitempty"`
+	Action         string          `json:"action"`
+	TargetType     string          `json:"target_type"`
+	TargetID       int32           `json:"target_id,omitempty"`
+	EventID        int32           `json:"event_id,omitempty"`
+	EventOwnerID   int32           `json:"event_owner_id,omitempty"`
+	IP             string          `json:"ip"`
+	UserAgent      string          `json:"user_agent"`
+	Details        json.RawMessage `json:"details,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+}
+
+// AuditFilter narrows a search of the audit log. Zero and nil fields match every entry.
+type AuditFilter struct {
+	ActorID      int32      `json:"actor_id,omitempty"`
+	Action       string     `json:"action,omitempty"`
+	TargetType   string     `json:"target_type,omitempty"`
+	TargetID     int32      `json:"target_id,omitempty"`
+	EventID      int32      `json:"event_id,omitempty"`
+	EventOwnerID int32      `json:"-"`
+	From         *time.Time `json:"from,omitempty"`
+	To           *time.Time `json:"to,omitempty"`
+}
+
+type AuditStore interface {
+	CreateAuditEntry(ctx context.Context, entry *AuditEntry) error
+	GetAuditEntries(filter AuditFilter, page *PageRequest) ([]*AuditEntry, *Cursor, error)
+	CountAuditEntries(filter AuditFilter) (int64, error)
+}
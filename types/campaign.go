@@ -0,0 +1,76 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// EmailCampaign is an email sent automatically to part of the attendees of an
+// event at a time relative to its start or end date
+type EmailCampaign struct {
+	ID            int32      `json:"id"`
+	EventID       int32      `json:"event_id"`
+	Name          string     `json:"name"`
+	Purpose       string     `json:"purpose"`
+	Anchor        string     `json:"anchor"`
+	OffsetMinutes int32      `json:"offset_minutes"`
+	Audience      string     `json:"audience"`
+	Role          string     `json:"role,omitempty"`
+	Status        string     `json:"status"`
+	SentCount     int32      `json:"sent_count"`
+	FailedCount   int32      `json:"failed_count"`
+	LastError     string     `json:"last_error,omitempty"`
+	SendAt        *time.Time `json:"send_at,omitempty"`
+	StartedAt     *time.Time `json:"started_at,omitempty"`
+	FinishedAt    *time.Time `json:"finished_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// Dates of the event a campaign is scheduled from
+const (
+	CampaignAnchorStart = "start"
+	CampaignAnchorEnd   = "end"
+)
+
+// Attendees a campaign is sent to
+const (
+	CampaignAudienceAll          = "all"
+	CampaignAudienceNotCheckedIn = "not_checked_in"
+	CampaignAudienceCheckedIn    = "checked_in"
+	CampaignAudienceRole         = "role"
+)
+
+// Statuses of a campaign. A scheduled campaign is claimed by moving it to
+// sending, and ends as sent, failed or skipped when it was missed for too long.
+const (
+	CampaignStatusScheduled = "scheduled"
+	CampaignStatusSending   = "sending"
+	CampaignStatusSent      = "sent"
+	CampaignStatusFailed    = "failed"
+	CampaignStatusSkipped   = "skipped"
+)
+
+type CampaignStore interface {
+	CreateCampaign(ctx context.Context, campaign *EmailCampaign) error
+	UpdateCampaign(ctx context.Context, campaign *EmailCampaign) (bool, error)
+	DeleteCampaign(ctx context.Context, id int32) error
+	GetCampaignsByEventID(eventID int32) ([]*EmailCampaign, error)
+	GetCampaignByID(id int32) (*EmailCampaign, error)
+	GetDueCampaigns(now time.Time) ([]*EmailCampaign, error)
+	ClaimCampaign(ctx context.Context, id int32) (bool, error)
+	FinishCampaign(ctx context.Context, id int32, status string, lastError string) error
+	RequeueSendingCampaigns(ctx context.Context) (int64, error)
+	GetCampaignSentAttendeeIDs(campaignID int32) ([]int32, error)
+}
+
+// CreateCampaignPayload schedules a campaign offset_minutes after (or before
+// when negative) the start or end of the event
+type CreateCampaignPayload struct {
+	Name          string `json:"name" validate:"required,max=255"`
+	Purpose       string `json:"purpose" validate:"required,oneof=invitation confirmation reminder thank_you waitlist_promotion cancellation"`
+	Anchor        string `json:"anchor" validate:"required,oneof=start end"`
+	OffsetMinutes int32  `json:"offset_minutes" validate:"min=-525600,max=525600"`
+	Audience      string `json:"audience" validate:"required,oneof=all not_checked_in checked_in role"`
+	Role          string `json:"role" validate:"required_if=Audience role,max=100"`
+}
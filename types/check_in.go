@@ -0,0 +1,111 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// Types of check-in history entries
+const (
+	CheckInTypeCheckIn  = "check_in"
+	CheckInTypeCheckOut = "check_out"
+)
+
+// CheckIn is one scan in the check-in history of an attendee
+type CheckIn struct {
+	ID         int32     `json:"id"`
+	AttendeeID int32     `json:"attendee_id"`
+	EventID    int32     `json:"event_id"`
+	SessionID  int32     `json:"session_id,omitempty"`
+	Type       string    `json:"type"`
+	ScannedBy  int32     `json:"scanned_by,omitempty"`
+	Entrance   string    `json:"entrance,omitempty"`
+	DeviceID   string    `json:"device_id,omitempty"`
+	ScannedAt  time.Time `json:"scanned_at"`
+}
+
+type CheckInStore interface {
+	RecordCheckIn(ctx context.Context, checkIn *CheckIn) error
+	UndoCheckIn(ctx context.Context, checkIn *CheckIn) error
+	GetCheckInByID(checkInID int32) (*CheckIn, error)
+	GetCheckInsByAttendeeID(attendeeID int32) ([]*CheckIn, error)
+	GetCheckInsByEventID(eventID int32, sessionID int32, page *PageRequest) ([]*CheckIn, *Cursor, error)
+	CountCheckInsByEventID(eventID int32, sessionID int32) (int64, error)
+}
+
+// ScanPayload describes where a scan happened, every field is optional
+type ScanPayload struct {
+	SessionID int32  `json:"session_id"`
+	Entrance  string `json:"entrance"`
+	DeviceID  string `json:"device_id"`
+}
+
+// Results of an offline check-in uploaded by a scanner device
+const (
+	CheckInStatusCheckedIn = "checked_in"
+	CheckInStatusDuplicate = "duplicate"
+	CheckInStatusDeleted   = "deleted"
+	CheckInStatusUnknown   = "unknown"
+	CheckInStatusInvalid   = "invalid"
+)
+
+// ManifestAttendee is the part of an attendee a scanner device needs to check them in offline
+type ManifestAttendee struct {
+	ID          int32      `json:"id"`
+	FirstName   string     `json:"first_name"`
+	LastName    string     `json:"last_name"`
+	TokenHash   string     `json:"token_hash"`
+	TableNo     int32      `json:"table_no"`
+	Role        string     `json:"role"`
+	CheckedIn   bool       `json:"checked_in"`
+	CheckedInAt *time.Time `json:"checked_in_at"`
+}
+
+// DeletedManifestAttendee tells a device to forget an attendee
+type DeletedManifestAttendee struct {
+	ID        int32     `json:"id"`
+	TokenHash string    `json:"token_hash"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// CheckInManifest is the attendee list of an event for scanner devices. When
+// requested with a version only the changes since that version are included;
+// the returned version is passed as since on the next download.
+type CheckInManifest struct {
+	EventID   int32                     `json:"event_id"`
+	Version   int64                     `json:"version"`
+	Since     int64                     `json:"since"`
+	Full      bool                      `json:"full"`
+	Attendees []*ManifestAttendee       `json:"attendees"`
+	Deleted   []DeletedManifestAttendee `json:"deleted"`
+}
+
+type CheckInSyncStore interface {
+	GetSyncVersion(ctx context.Context) (int64, error)
+	GetManifestAttendees(ctx context.Context, eventID int32, since int64) ([]*ManifestAttendee, error)
+	GetDeletedManifestAttendees(ctx context.Context, eventID int32, since int64) ([]DeletedManifestAttendee, error)
+}
+
+// OfflineCheckIn is a scan recorded by a device while offline. The attendee
+// is identified by ID or, when the device only knows the scanned QR code, by
+// its token hash.
+type OfflineCheckIn struct {
+	ClientID   string    `json:"client_id" validate:"required"`
+	AttendeeID int32     `json:"attendee_id" validate:"required_without=TokenHash"`
+	TokenHash  string    `json:"token_hash" validate:"required_without=AttendeeID"`
+	ScannedAt  time.Time `json:"scanned_at" validate:"required"`
+}
+
+type SyncCheckInsPayload struct {
+	DeviceID string           `json:"device_id" validate:"required"`
+	CheckIns []OfflineCheckIn `json:"check_ins" validate:"required,min=1,max=1000,dive"`
+}
+
+// CheckInResult is the outcome of one uploaded check-in
+type CheckInResult struct {
+	ClientID    string     `json:"client_id"`
+	AttendeeID  int32      `json:"attendee_id,omitempty"`
+	Status      string     `json:"status"`
+	CheckedInAt *time.Time `json:"checked_in_at,omitempty"`
+	Message     string     `json:"message,omitempty"`
+}
@@ -0,0 +1,42 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// EventCustomField is an extra field collected for the attendees of an event
+type EventCustomField struct {
+	ID        int32     `json:"id"`
+	EventID   int32     `json:"event_id"`
+	Name      string    `json:"name"`
+	FieldType string    `json:"field_type"`
+	Required  bool      `json:"required"`
+	Position  int32     `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// AttendeeCustomFieldValue is the value an attendee gave for a custom field
+type AttendeeCustomFieldValue struct {
+	Name      string `json:"name"`
+	FieldType string `json:"field_type"`
+	Value     string `json:"value"`
+}
+
+type CustomFieldStore interface {
+	CreateCustomField(ctx context.Context, field *EventCustomField) error
+	GetCustomFields(eventID int32) ([]*EventCustomField, error)
+	GetCustomFieldByID(id int32) (*EventCustomField, error)
+	DeleteCustomField(ctx context.Context, id int32) error
+	CopyAttendeeCustomFields(ctx context.Context, fromAttendeeID int32, toAttendeeID int32) error
+	GetAttendeeCustomFieldValues(attendeeID int32) ([]*AttendeeCustomFieldValue, error)
+	SetAttendeeCustomFieldValue(ctx context.Context, attendeeID int32, value *AttendeeCustomFieldValue) error
+}
+
+type CreateCustomFieldPayload struct {
+	Name      string `json:"name" validate:"required,max=100"`
+	FieldType string `json:"field_type" validate:"omitempty,oneof=text number date boolean"`
+	Required  bool   `json:"required"`
+	Position  int32  `json:"position" validate:"min=0"`
+}
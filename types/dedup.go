@@ -0,0 +1,37 @@
+package types
+
+import "context"
+
+// Reasons two attendees are taken for the same person
+const (
+	DuplicateReasonEmail       = "email"
+	DuplicateReasonNameCompany = "name_and_company"
+	DuplicateReasonName        = "name"
+)
+
+// DuplicateMatch is a pair of attendees that look like the same person, with
+// how sure the match is from 0 to 1
+type DuplicateMatch struct {
+	AttendeeID int32   `json:"attendee_id"`
+	OtherID    int32   `json:"other_id"`
+	Reason     string  `json:"reason"`
+	Score      float64 `json:"score"`
+}
+
+// DuplicateGroup is a set of attendees linked by matches, with the attendee
+// suggested to keep when they are merged
+type DuplicateGroup struct {
+	Attendees []*Attendee      `json:"attendees"`
+	Matches   []DuplicateMatch `json:"matches"`
+	Score     float64          `json:"score"`
+	KeepID    int32            `json:"keep_id"`
+}
+
+type MergeStore interface {
+	MergeAttendee(ctx context.Context, eventID int32, keepID int32, mergeID int32) error
+}
+
+type MergeAttendeesPayload struct {
+	KeepID   int32   `json:"keep_id" validate:"required"`
+	MergeIDs []int32 `json:"merge_ids" validate:"required,min=1,max=50,dive,required"`
+}
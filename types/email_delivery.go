@@ -0,0 +1,41 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// Delivery statuses recorded for every email sent to an attendee
+const (
+	DeliveryStatusSent    = "sent"
+	DeliveryStatusFailed  = "failed"
+	DeliveryStatusSkipped = "skipped"
+)
+
+type EmailDelivery struct {
+	ID         int32  `json:"id"`
+	EventID    int32  `json:"event_id"`
+	AttendeeID int32  `json:"attendee_id"`
+	TemplateID int32  `json:"template_id"`
+	CampaignID int32  `json:"campaign_id,omitempty"`
+	Email      string `json:"email"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	// TrackingID identifies the email in its tracking pixel and links, it is empty when untracked
+	TrackingID string    `json:"-"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type EmailDeliveryStats struct {
+	Total      int64 `json:"total"`
+	Sent       int64 `json:"sent"`
+	Failed     int64 `json:"failed"`
+	Skipped    int64 `json:"skipped"`
+	Recipients int64 `json:"recipients"`
+}
+
+type EmailDeliveryStore interface {
+	CreateEmailDelivery(ctx context.Context, delivery *EmailDelivery) error
+	GetEmailDeliveryStats(ctx context.Context, eventID int32) (*EmailDeliveryStats, error)
+	IsTrackingEnabled(ctx context.Context, eventID int32) (bool, error)
+}
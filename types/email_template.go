@@ -1,41 +1,75 @@
 package types
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type EmailTemplate struct {
-	ID          int32  `json:"id"`
-	EventID     int32  `json:"event_id"`
-	HeaderImage string `json:"header_image"`
-	Content     string `json:"content"`
-	FooterImage string `json:"footer_image"`
-	Subject     string `json:"subject"`
-	BgColor     string `json:"bg_color"`
-	Message     string `json:"message"`
+	ID          int32      `json:"id"`
+	EventID     int32      `json:"event_id"`
+	Purpose     string     `json:"purpose"`
+	HeaderImage string     `json:"header_image"`
+	Content     string     `json:"content"`
+	FooterImage string     `json:"footer_image"`
+	Subject     string     `json:"subject"`
+	BgColor     string     `json:"bg_color"`
+	Message     string     `json:"message"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
+}
+
+// Purposes of an email template. An event has at most one template for each purpose.
+const (
+	EmailPurposeInvitation        = "invitation"
+	EmailPurposeConfirmation      = "confirmation"
+	EmailPurposeReminder          = "reminder"
+	EmailPurposeThankYou          = "thank_you"
+	EmailPurposeWaitlistPromotion = "waitlist_promotion"
+	EmailPurposeCancellation      = "cancellation"
+)
+
+// EmailPurposes lists the purposes of an email template in the order they are shown
+var EmailPurposes = []string{
+	EmailPurposeInvitation,
+	EmailPurposeConfirmation,
+	EmailPurposeReminder,
+	EmailPurposeThankYou,
+	EmailPurposeWaitlistPromotion,
+	EmailPurposeCancellation,
 }
 
 type EmailTempalteStore interface {
-	GetEmailTemplateByEventID(ctx context.Context, eventID int32) (*EmailTemplate, error)
+	GetEmailTemplatesByEventID(ctx context.Context, eventID int32) ([]*EmailTemplate, error)
+	GetEmailTemplateByPurpose(ctx context.Context, eventID int32, purpose string) (*EmailTemplate, error)
 	CreateEmailTemplate(ctx context.Context, emailTemplate *EmailTemplate) error
 	UpdateEmailTemplate(ctx context.Context, emailTemplate *EmailTemplate) error
+	GetEmailTemplateByID(ctx context.Context, id int32) (*EmailTemplate, error)
+	DeleteEmailTemplate(ctx context.Context, id int32) error
+	GetDeletedEmailTemplateByID(ctx context.Context, id int32) (*EmailTemplate, error)
+	RestoreEmailTemplate(ctx context.Context, id int32) error
 }
 
+// CreateEmailTemplatePayload creates a template of an event, an invitation when no purpose is given
 type CreateEmailTemplatePayload struct {
 	EventID     int32  `json:"event_id" validate:"required"`
-	HeaderImage string `json:"header_image" validate:"required"`
+	Purpose     string `json:"purpose" validate:"omitempty,oneof=invitation confirmation reminder thank_you waitlist_promotion cancellation"`
+	HeaderImage string `json:"header_image"`
 	Content     string `json:"content" validate:"required"`
-	FooterImage string `json:"footer_image" validate:"required"`
+	FooterImage string `json:"footer_image"`
 	Subject     string `json:"subject" validate:"required"`
-	BgColor     string `json:"bg_color" validate:"required"`
+	BgColor     string `json:"bg_color"`
 	Message     string `json:"message" validate:"required"`
 }
 
+// UpdateEmailTemplatePayload updates a template by its ID, the purpose is kept when none is given
 type UpdateEmailTemplatePayload struct {
-	ID          int32  `json:"id"`
+	ID          int32  `json:"id" validate:"required"`
 	EventID     int32  `json:"event_id" validate:"required"`
-	HeaderImage string `json:"header_image" validate:"required"`
+	Purpose     string `json:"purpose" validate:"omitempty,oneof=invitation confirmation reminder thank_you waitlist_promotion cancellation"`
+	HeaderImage string `json:"header_image"`
 	Content     string `json:"content" validate:"required"`
-	FooterImage string `json:"footer_image" validate:"required"`
+	FooterImage string `json:"footer_image"`
 	Subject     string `json:"subject" validate:"required"`
-	BgColor     string `json:"bg_color" validate:"required"`
+	BgColor     string `json:"bg_color"`
 	Message     string `json:"message" validate:"required"`
 }
@@ -0,0 +1,75 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// Kinds of events recorded for a tracked email
+const (
+	TrackingEventOpen  = "open"
+	TrackingEventClick = "click"
+)
+
+// EmailTrackingStats sums up the opens and clicks of tracked emails. Opened
+// and Clicked count emails, Opens and Clicks count every time it happened.
+type EmailTrackingStats struct {
+	Tracked   int64   `json:"tracked"`
+	Opened    int64   `json:"opened"`
+	Clicked   int64   `json:"clicked"`
+	Opens     int64   `json:"opens"`
+	Clicks    int64   `json:"clicks"`
+	OpenRate  float64 `json:"open_rate"`
+	ClickRate float64 `json:"click_rate"`
+}
+
+type TemplateTrackingStats struct {
+	TemplateID int32  `json:"template_id"`
+	Purpose    string `json:"purpose"`
+	Subject    string `json:"subject"`
+	EmailTrackingStats
+}
+
+type AttendeeTrackingStats struct {
+	AttendeeID    int32      `json:"attendee_id"`
+	FirstName     string     `json:"first_name"`
+	LastName      string     `json:"last_name"`
+	Email         string     `json:"email"`
+	Tracked       int64      `json:"tracked"`
+	Opens         int64      `json:"opens"`
+	Clicks        int64      `json:"clicks"`
+	FirstOpenedAt *time.Time `json:"first_opened_at"`
+	LastOpenedAt  *time.Time `json:"last_opened_at"`
+	LastClickedAt *time.Time `json:"last_clicked_at"`
+}
+
+// EmailTrackingReport is the tracking of the emails of an event, in total and per template
+type EmailTrackingReport struct {
+	EventID   int32                    `json:"event_id"`
+	Enabled   bool                     `json:"enabled"`
+	Totals    *EmailTrackingStats      `json:"totals"`
+	Templates []*TemplateTrackingStats `json:"templates"`
+}
+
+// TrackedDelivery is the email a tracking event is recorded for
+type TrackedDelivery struct {
+	ID         int32
+	EventID    int32
+	AttendeeID int32
+	TemplateID int32
+}
+
+type EmailTrackingStore interface {
+	GetTrackedDelivery(ctx context.Context, trackingID string) (*TrackedDelivery, error)
+	CreateTrackingEvent(ctx context.Context, deliveryID int32, kind string, url string) error
+	GetTrackingStats(ctx context.Context, eventID int32) (*EmailTrackingStats, error)
+	GetTemplateTrackingStats(ctx context.Context, eventID int32) ([]*TemplateTrackingStats, error)
+	GetAttendeeTrackingStats(ctx context.Context, eventID int32, page *PageRequest) ([]*AttendeeTrackingStats, *Cursor, error)
+	CountAttendeeTrackingStats(ctx context.Context, eventID int32) (int64, error)
+	IsTrackingEnabled(ctx context.Context, eventID int32) (bool, error)
+	SetTrackingEnabled(ctx context.Context, eventID int32, enabled bool) error
+}
+
+type UpdateEmailTrackingPayload struct {
+	Enabled *bool `json:"enabled" validate:"required"`
+}
@@ -6,15 +6,16 @@ import (
 )
 
 type Event struct {
-	EventID     int32     `json:"id"`
-	Title       string    `json:"title"`
-	Description string    `json:"description"`
-	StartDate   time.Time `json:"start_date"`
-	EndDate     time.Time `json:"end_date"`
-	Location    string    `json:"location"`
-	UserID      int32     `json:"user_id"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	EventID     int32      `json:"id"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	StartDate   time.Time  `json:"start_date"`
+	EndDate     time.Time  `json:"end_date"`
+	Location    string     `json:"location"`
+	UserID      int32      `json:"user_id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
 }
 
 type EventStore interface {
@@ -23,8 +24,13 @@ type EventStore interface {
 	DeleteEvent(ctx context.Context, eventID int32) error
 	DeleteAllEvents(ctx context.Context, userID int32) error
 	GetAllEvents(userID int32) ([]*Event, error)
+	GetEventsPaginated(userID int32, page *PageRequest) ([]*Event, *Cursor, error)
+	CountEvents(userID int32) (int64, error)
 	GetEventByTitle(title string) (*Event, error)
 	GetEventByID(eventID int32) (*Event, error)
+	GetDeletedEvents(userID int32) ([]*Event, error)
+	GetDeletedEventByID(eventID int32) (*Event, error)
+	RestoreEvent(ctx context.Context, eventID int32) error
 }
 
 type CreateEventPayload struct {
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// EventBlueprint is what is copied from one event to another: its details,
+// email templates, custom fields and seating plan. The dates are kept as the
+// length of the event so a blueprint can be placed at any start date.
+type EventBlueprint struct {
+	Title          string              `json:"title"`
+	Description    string              `json:"description"`
+	Location       string              `json:"location"`
+	DurationSecs   int64               `json:"duration_secs"`
+	EmailTemplates []*EmailTemplate    `json:"email_templates,omitempty"`
+	CustomFields   []*EventCustomField `json:"custom_fields,omitempty"`
+	Tables         []*EventTable       `json:"tables,omitempty"`
+}
+
+// EventTemplate is an event saved by a user to create new events from
+type EventTemplate struct {
+	ID        int32          `json:"id"`
+	UserID    int32          `json:"user_id"`
+	Name      string         `json:"name"`
+	Blueprint EventBlueprint `json:"blueprint"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+type EventTemplateStore interface {
+	CreateEventTemplate(ctx context.Context, template *EventTemplate) error
+	GetEventTemplates(userID int32) ([]*EventTemplate, error)
+	GetEventTemplateByID(id int32) (*EventTemplate, error)
+	DeleteEventTemplate(ctx context.Context, id int32) error
+}
+
+// CloneEventPayload describes the copy of an event. The email templates,
+// custom fields and seating plan are copied unless turned off, attendees only
+// when asked for. Without dates the copy keeps the dates of the original.
+type CloneEventPayload struct {
+	Title              string     `json:"title" validate:"required"`
+	StartDate          *time.Time `json:"start_date"`
+	EndDate            *time.Time `json:"end_date" validate:"required_with=StartDate"`
+	CopyEmailTemplates *bool      `json:"copy_email_templates"`
+	CopyCustomFields   *bool      `json:"copy_custom_fields"`
+	CopySeatingPlan    *bool      `json:"copy_seating_plan"`
+	CopyAttendees      bool       `json:"copy_attendees"`
+}
+
+type SaveEventTemplatePayload struct {
+	Name string `json:"name" validate:"required,max=255"`
+}
+
+type CreateEventFromTemplatePayload struct {
+	Title     string    `json:"title"`
+	StartDate time.Time `json:"start_date" validate:"required"`
+	EndDate   time.Time `json:"end_date"`
+}
@@ -0,0 +1,14 @@
+package types
+
+// AttendeeGroup is a party registered together: a primary attendee, who
+// receives the invitations of the group, and the guests they bring
+type AttendeeGroup struct {
+	Primary *Attendee   `json:"primary"`
+	Guests  []*Attendee `json:"guests"`
+}
+
+type LinkGuestsPayload struct {
+	GuestIDs []int32 `json:"guest_ids" validate:"required,min=1,max=50,dive,required"`
+	// SeatWithPrimary moves the guests to the table of the primary attendee
+	SeatWithPrimary bool `json:"seat_with_primary"`
+}
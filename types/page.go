@@ -0,0 +1,30 @@
+package types
+
+import "errors"
+
+// ErrInvalidCursor is returned for a cursor that does not fit the list it is used on
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor marks the last item of a page, the next page starts right after it.
+// Key holds the value the list is sorted by when that is not the ID.
+type Cursor struct {
+	Key string `json:"k,omitempty"`
+	ID  int64  `json:"id"`
+}
+
+// PageRequest asks for up to Limit items after a cursor, the first page has no cursor
+type PageRequest struct {
+	After     *Cursor
+	Limit     int32
+	WithTotal bool
+}
+
+// Page is the envelope of every list response. NextCursor is null on the last
+// page, Total is only counted when asked for and Filters echoes the filters
+// the items were selected by.
+type Page struct {
+	Items      any            `json:"items"`
+	NextCursor *string        `json:"next_cursor"`
+	Total      *int64         `json:"total,omitempty"`
+	Filters    map[string]any `json:"filters"`
+}
@@ -0,0 +1,86 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrOrderNotPending = errors.New("order is not awaiting payment")
+	ErrOrderNotPaid    = errors.New("order is not paid")
+)
+
+// Statuses of an order
+const (
+	OrderStatusPending  = "pending"
+	OrderStatusPaid     = "paid"
+	OrderStatusFailed   = "failed"
+	OrderStatusRefunded = "refunded"
+	OrderStatusExpired  = "expired"
+)
+
+// Order is the purchase of a ticket by a guest registering for an event.
+// Amounts are in the minor unit of the currency.
+type Order struct {
+	ID            int32      `json:"id"`
+	Reference     string     `json:"reference"`
+	EventID       int32      `json:"event_id"`
+	AttendeeID    int32      `json:"attendee_id,omitempty"`
+	TicketTypeID  int32      `json:"ticket_type_id,omitempty"`
+	Email         string     `json:"email"`
+	FirstName     string     `json:"first_name"`
+	LastName      string     `json:"last_name"`
+	AmountCents   int64      `json:"amount_cents"`
+	Currency      string     `json:"currency"`
+	Status        string     `json:"status"`
+	Provider      string     `json:"provider"`
+	ProviderRef   string     `json:"provider_ref,omitempty"`
+	CheckoutURL   string     `json:"checkout_url,omitempty"`
+	ReceiptNumber string     `json:"receipt_number,omitempty"`
+	PaidAt        *time.Time `json:"paid_at"`
+	RefundedAt    *time.Time `json:"refunded_at"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// Receipt is the proof of payment handed to the guest
+type Receipt struct {
+	ReceiptNumber  string     `json:"receipt_number"`
+	OrderReference string     `json:"order_reference"`
+	EventTitle     string     `json:"event_title"`
+	EventStartDate time.Time  `json:"event_start_date"`
+	EventLocation  string     `json:"event_location"`
+	Name           string     `json:"name"`
+	Email          string     `json:"email"`
+	TicketType     string     `json:"ticket_type"`
+	AmountCents    int64      `json:"amount_cents"`
+	Currency       string     `json:"currency"`
+	PaidAt         time.Time  `json:"paid_at"`
+	RefundedAt     *time.Time `json:"refunded_at,omitempty"`
+}
+
+type OrderStore interface {
+	CreateOrder(ctx context.Context, order *Order) error
+	SetOrderCheckout(ctx context.Context, order *Order) error
+	GetOrderByID(orderID int32) (*Order, error)
+	GetOrderByReference(reference string) (*Order, error)
+	GetOrderByProviderRef(provider string, providerRef string) (*Order, error)
+	GetOrdersByEventID(eventID int32, page *PageRequest) ([]*Order, *Cursor, error)
+	CountOrdersByEventID(eventID int32) (int64, error)
+	GetPendingOrdersCreatedBefore(cutoff time.Time) ([]*Order, error)
+	MarkOrderPaid(ctx context.Context, order *Order) error
+	MarkOrderFailed(ctx context.Context, order *Order) error
+	MarkOrderExpired(ctx context.Context, order *Order) error
+	MarkOrderRefunded(ctx context.Context, order *Order) error
+}
+
+// RegisterPayload is a guest registering for an event with a ticket
+type RegisterPayload struct {
+	FirstName    string `json:"first_name" validate:"required"`
+	LastName     string `json:"last_name" validate:"required"`
+	Email        string `json:"email" validate:"required,email"`
+	CompanyName  string `json:"company_name"`
+	Title        string `json:"title"`
+	TicketTypeID int32  `json:"ticket_type_id" validate:"required"`
+}
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrEmailQuotaExceeded = errors.New("monthly email quota exceeded")
+
+// FreePlanID is the plan given to new users and to users whose subscription
+// is not active. It is seeded by the migration adding plans, together with
+// Pro and Enterprise; users who signed up before plans were added are on the
+// seeded Legacy plan, which has no limits.
+const FreePlanID = 1
+
+// Plan holds the limits of a subscription plan. A limit of 0 means unlimited.
+// A plan that requires a subscription only applies while the subscription of
+// the user is active.
+type Plan struct {
+	ID                   int32     `json:"id"`
+	Name                 string    `json:"name"`
+	MaxEvents            int32     `json:"max_events"`
+	MaxAttendeesPerEvent int32     `json:"max_attendees_per_event"`
+	EmailsPerMonth       int32     `json:"emails_per_month"`
+	CustomBranding       bool      `json:"custom_branding"`
+	RequiresSubscription bool      `json:"requires_subscription"`
+	CreatedAt            time.Time `json:"created_at"`
+	UpdatedAt            time.Time `json:"updated_at"`
+}
+
+// EventUsage is the number of attendees registered for one event of a user
+type EventUsage struct {
+	EventID   int32  `json:"event_id"`
+	Title     string `json:"title"`
+	Attendees int64  `json:"attendees"`
+}
+
+// PlanUsage counts what a user has used of their plan
+type PlanUsage struct {
+	Events      int64        `json:"events"`
+	EmailsSent  int64        `json:"emails_sent"`
+	EmailPeriod string       `json:"email_period"`
+	Attendees   []EventUsage `json:"attendees"`
+}
+
+type PlanStore interface {
+	GetPlans() ([]*Plan, error)
+	GetPlanByID(planID int32) (*Plan, error)
+	GetPlanByUserID(userID int32) (*Plan, error)
+	CreatePlan(ctx context.Context, plan *Plan) error
+	UpdatePlan(ctx context.Context, plan *Plan) error
+	UpdateUserPlan(ctx context.Context, userID int32, planID int32) error
+	UpdateUserSubscriptionStatus(ctx context.Context, userID int32, status string) error
+	CountEventsByUserID(userID int32) (int64, error)
+	CountAttendeesByEventID(eventID int32) (int64, error)
+	GetUsage(userID int32, now time.Time) (*PlanUsage, error)
+	GetEmailsSent(userID int32, now time.Time) (int64, error)
+	ReserveEmails(ctx context.Context, userID int32, count int32, limit int32, now time.Time) error
+	ReleaseEmails(ctx context.Context, userID int32, count int32, now time.Time) error
+}
+
+type CreatePlanPayload struct {
+	Name                 string `json:"name" validate:"required,max=50"`
+	MaxEvents            int32  `json:"max_events" validate:"gte=0"`
+	MaxAttendeesPerEvent int32  `json:"max_attendees_per_event" validate:"gte=0"`
+	EmailsPerMonth       int32  `json:"emails_per_month" validate:"gte=0"`
+	CustomBranding       bool   `json:"custom_branding"`
+}
+
+type UpdateUserPlanPayload struct {
+	PlanID             int32  `json:"plan_id" validate:"required"`
+	SubscriptionStatus string `json:"subscription_status" validate:"omitempty,oneof=Active Inactive Pending Cancelled"`
+}
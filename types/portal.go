@@ -0,0 +1,37 @@
+package types
+
+import "time"
+
+// PortalRegistration is what an attendee sees of their own registration in the self-service portal
+type PortalRegistration struct {
+	Attendee     *Attendee            `json:"attendee"`
+	Event        *Event               `json:"event"`
+	TicketType   string               `json:"ticket_type,omitempty"`
+	CustomFields []*PortalCustomField `json:"custom_fields"`
+	CanEdit      bool                 `json:"can_edit"`
+	CanCancel    bool                 `json:"can_cancel"`
+}
+
+// PortalCustomField is a custom field of the event with the value the attendee gave for it
+type PortalCustomField struct {
+	Name      string `json:"name"`
+	FieldType string `json:"field_type"`
+	Required  bool   `json:"required"`
+	Value     string `json:"value"`
+}
+
+// PortalLink is a magic link an organizer can hand to an attendee
+type PortalLink struct {
+	Link      string    `json:"link"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// UpdatePortalRegistrationPayload holds the details an attendee may change
+// themselves. Fields left out are kept, the email cannot be changed.
+type UpdatePortalRegistrationPayload struct {
+	FirstName    *string           `json:"first_name" validate:"omitnil,min=1,max=255"`
+	LastName     *string           `json:"last_name" validate:"omitnil,min=1,max=255"`
+	CompanyName  *string           `json:"company_name" validate:"omitnil,max=255"`
+	Title        *string           `json:"title" validate:"omitnil,max=255"`
+	CustomFields map[string]string `json:"custom_fields" validate:"omitempty,max=50,dive,max=1000"`
+}
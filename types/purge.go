@@ -0,0 +1,16 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// PurgeStore permanently removes soft-deleted rows once their retention period is over
+type PurgeStore interface {
+	GetEventIDsDeletedBefore(cutoff time.Time) ([]int32, error)
+	GetQrCodesByEventID(eventID int32) ([]string, error)
+	PurgeEvent(ctx context.Context, eventID int32) error
+	GetAttendeesDeletedBefore(cutoff time.Time) ([]*Attendee, error)
+	PurgeAttendee(ctx context.Context, attendeeID int32) error
+	PurgeEmailTemplatesDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
@@ -0,0 +1,50 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+type EventReport struct {
+	Event        *Event            `json:"event"`
+	GeneratedAt  time.Time         `json:"generated_at"`
+	Totals       ReportTotals      `json:"totals"`
+	CheckIns     []CheckInBucket   `json:"check_ins"`
+	ByCompany    []ReportBreakdown `json:"by_company"`
+	ByRole       []ReportBreakdown `json:"by_role"`
+	ByTicketType []ReportBreakdown `json:"by_ticket_type"`
+	ByTable      []ReportBreakdown `json:"by_table"`
+	Invitations  InvitationStats   `json:"invitations"`
+	NoShows      []*Attendee       `json:"no_shows"`
+}
+
+type ReportTotals struct {
+	Registered     int64   `json:"registered"`
+	CheckedIn      int64   `json:"checked_in"`
+	NoShows        int64   `json:"no_shows"`
+	AttendanceRate float64 `json:"attendance_rate"`
+}
+
+// CheckInBucket counts the check-ins that happened in [Start, Start+bucket size)
+type CheckInBucket struct {
+	Start    time.Time `json:"start"`
+	CheckIns int64     `json:"check_ins"`
+}
+
+type ReportBreakdown struct {
+	Label      string `json:"label"`
+	Registered int64  `json:"registered"`
+	CheckedIn  int64  `json:"checked_in"`
+}
+
+type InvitationStats struct {
+	Sent       int64 `json:"sent"`
+	Failed     int64 `json:"failed"`
+	Skipped    int64 `json:"skipped"`
+	Recipients int64 `json:"recipients"`
+	NotInvited int64 `json:"not_invited"`
+}
+
+type ReportStore interface {
+	GetEventReport(ctx context.Context, event *Event, bucketSize time.Duration) (*EventReport, error)
+}
@@ -0,0 +1,15 @@
+package types
+
+// AttendeeSearchResult is an attendee found by a search, with the relevance
+// score it is ranked by and the matching words of each field highlighted
+type AttendeeSearchResult struct {
+	Attendee
+	EventTitle string            `json:"event_title,omitempty"`
+	Score      float64           `json:"score"`
+	Highlights map[string]string `json:"highlights"`
+}
+
+type SearchStore interface {
+	SearchEventAttendees(eventID int32, query string, limit int32) ([]*AttendeeSearchResult, error)
+	SearchUserAttendees(userID int32, query string, limit int32) ([]*AttendeeSearchResult, error)
+}
@@ -0,0 +1,70 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+type EventTable struct {
+	ID           int32     `json:"id"`
+	EventID      int32     `json:"event_id"`
+	TableNo      int32     `json:"table_no"`
+	Name         string    `json:"name"`
+	Capacity     int32     `json:"capacity"`
+	Seated       int64     `json:"seated"`
+	OverCapacity bool      `json:"over_capacity"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// SeatingPlan is the seating state of an event: its tables with their
+// occupancy, and the attendees that are unseated or sat at tables that are
+// not part of the plan
+type SeatingPlan struct {
+	EventID       int32         `json:"event_id"`
+	Tables        []*EventTable `json:"tables"`
+	TotalCapacity int64         `json:"total_capacity"`
+	Seated        int64         `json:"seated"`
+	Unseated      int64         `json:"unseated"`
+	UnknownTables []int32       `json:"unknown_tables"`
+	OverCapacity  []int32       `json:"over_capacity"`
+}
+
+type SeatingStore interface {
+	CreateEventTable(ctx context.Context, table *EventTable) error
+	GetEventTables(eventID int32) ([]*EventTable, error)
+	GetEventTable(eventID int32, tableNo int32) (*EventTable, error)
+	UpdateEventTable(ctx context.Context, table *EventTable) error
+	DeleteEventTable(ctx context.Context, eventID int32, tableNo int32) error
+	AssignTable(ctx context.Context, eventID int32, attendeeID int32, tableNo int32) error
+	GetSeatingPlan(eventID int32) (*SeatingPlan, error)
+}
+
+type CreateEventTablePayload struct {
+	TableNo  int32  `json:"table_no" validate:"required,min=1"`
+	Name     string `json:"name"`
+	Capacity int32  `json:"capacity" validate:"required,min=1"`
+}
+
+type UpdateEventTablePayload struct {
+	Name     string `json:"name"`
+	Capacity int32  `json:"capacity" validate:"required,min=1"`
+}
+
+// SeatAssignment moves an attendee to a table, a table number of 0 unseats them
+type SeatAssignment struct {
+	AttendeeID int32 `json:"attendee_id" validate:"required"`
+	TableNo    int32 `json:"table_no" validate:"min=0"`
+}
+
+type AssignSeatsPayload struct {
+	Assignments []SeatAssignment `json:"assignments" validate:"required,min=1,dive"`
+	// WithGuests moves the guests of a primary attendee along with them,
+	// unless the guests have an assignment of their own
+	WithGuests bool `json:"with_guests"`
+}
+
+type AutoSeatPayload struct {
+	GroupBy string `json:"group_by" validate:"required,oneof=company role"`
+	Reseat  bool   `json:"reseat"`
+}
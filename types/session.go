@@ -0,0 +1,86 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrSessionFull     = errors.New("session is full")
+	ErrAlreadySignedUp = errors.New("attendee already signed up for the session")
+	ErrSignupNotFound  = errors.New("attendee is not signed up for the session")
+)
+
+// EventSession is one part of a multi-day or multi-session event with its own
+// agenda slot, sign-ups and attendance. A capacity of 0 means unlimited.
+type EventSession struct {
+	ID        int32     `json:"id"`
+	EventID   int32     `json:"event_id"`
+	Title     string    `json:"title"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Room      string    `json:"room"`
+	Speaker   string    `json:"speaker"`
+	Capacity  int32     `json:"capacity"`
+	SignedUp  int32     `json:"signed_up"`
+	CheckedIn int64     `json:"checked_in"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SessionSignup is an attendee who signed up for a session
+type SessionSignup struct {
+	AttendeeID  int32     `json:"attendee_id"`
+	FirstName   string    `json:"first_name"`
+	LastName    string    `json:"last_name"`
+	Email       string    `json:"email"`
+	CompanyName string    `json:"company_name"`
+	SignedUpAt  time.Time `json:"signed_up_at"`
+}
+
+// AgendaSession is the public view of a session
+type AgendaSession struct {
+	ID        int32     `json:"id"`
+	Title     string    `json:"title"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Room      string    `json:"room"`
+	Speaker   string    `json:"speaker"`
+	Capacity  int32     `json:"capacity"`
+	SeatsLeft *int32    `json:"seats_left"`
+}
+
+// Agenda is the public programme of an event
+type Agenda struct {
+	EventID   int32           `json:"event_id"`
+	Title     string          `json:"title"`
+	Location  string          `json:"location"`
+	StartDate time.Time       `json:"start_date"`
+	EndDate   time.Time       `json:"end_date"`
+	Sessions  []AgendaSession `json:"sessions"`
+}
+
+type SessionStore interface {
+	CreateSession(ctx context.Context, session *EventSession) error
+	UpdateSession(ctx context.Context, session *EventSession) error
+	DeleteSession(ctx context.Context, sessionID int32) error
+	GetSessionsByEventID(eventID int32) ([]*EventSession, error)
+	GetSessionByID(sessionID int32) (*EventSession, error)
+	SignUp(ctx context.Context, sessionID int32, attendeeID int32) error
+	CancelSignup(ctx context.Context, sessionID int32, attendeeID int32) error
+	GetSignups(sessionID int32) ([]*SessionSignup, error)
+}
+
+type CreateSessionPayload struct {
+	Title     string    `json:"title" validate:"required"`
+	StartTime time.Time `json:"start_time" validate:"required"`
+	EndTime   time.Time `json:"end_time" validate:"required,gtfield=StartTime"`
+	Room      string    `json:"room"`
+	Speaker   string    `json:"speaker"`
+	Capacity  int32     `json:"capacity" validate:"min=0"`
+}
+
+type SessionSignupPayload struct {
+	AttendeeID int32 `json:"attendee_id" validate:"required"`
+}
@@ -0,0 +1,59 @@
+package types
+
+import (
+	"context"
+	"time"
+)
+
+// Reasons an email address is suppressed for
+const (
+	SuppressionReasonSMTPError = "smtp_error"
+	SuppressionReasonBounce    = "bounce"
+	SuppressionReasonComplaint = "complaint"
+	SuppressionReasonManual    = "manual"
+)
+
+// Suppression is an email address no email is sent to. It applies to every
+// event on the platform unless UserID is set, then only to the events of
+// that organizer.
+type Suppression struct {
+	ID        int32     `json:"id"`
+	Email     string    `json:"email"`
+	UserID    int32     `json:"user_id,omitempty"`
+	Reason    string    `json:"reason"`
+	Detail    string    `json:"detail,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SkippedRecipient is an attendee a bulk send left out because their address is suppressed
+type SkippedRecipient struct {
+	AttendeeID int32  `json:"attendee_id"`
+	Email      string `json:"email"`
+	Reason     string `json:"reason"`
+}
+
+type SuppressionStore interface {
+	CreateSuppression(ctx context.Context, suppression *Suppression) (bool, error)
+	GetSuppressionByID(ctx context.Context, id int32) (*Suppression, error)
+	GetSuppression(ctx context.Context, email string, userID int32) (*Suppression, error)
+	GetSuppressionsByEventID(ctx context.Context, eventID int32) ([]*Suppression, error)
+	GetSuppressionsByUserID(ctx context.Context, userID int32) ([]*Suppression, error)
+	DeleteSuppression(ctx context.Context, id int32) error
+}
+
+type CreateSuppressionPayload struct {
+	Email  string `json:"email" validate:"required,email"`
+	Detail string `json:"detail" validate:"max=1000"`
+}
+
+// BounceEvent is a bounce or a complaint reported by the mail provider
+type BounceEvent struct {
+	Type       string `json:"type" validate:"required,oneof=bounce complaint"`
+	Email      string `json:"email" validate:"required,email"`
+	Permanent  bool   `json:"permanent"`
+	Diagnostic string `json:"diagnostic" validate:"max=1000"`
+}
+
+type BounceWebhookPayload struct {
+	Events []BounceEvent `json:"events" validate:"required,min=1,max=1000,dive"`
+}
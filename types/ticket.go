@@ -0,0 +1,55 @@
+package types
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrTicketTypeSoldOut = errors.New("ticket type is sold out")
+
+// TicketType is a kind of ticket sold for an event, e.g. VIP, General,
+// Speaker or Staff. A quantity of 0 means unlimited; prices are in the minor
+// unit of the currency.
+type TicketType struct {
+	ID           int32      `json:"id"`
+	EventID      int32      `json:"event_id"`
+	Name         string     `json:"name"`
+	Quantity     int32      `json:"quantity"`
+	Sold         int32      `json:"sold"`
+	PriceCents   int64      `json:"price_cents"`
+	Currency     string     `json:"currency"`
+	SaleStartsAt *time.Time `json:"sale_starts_at"`
+	SaleEndsAt   *time.Time `json:"sale_ends_at"`
+	Visible      bool       `json:"visible"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// PublicTicketType is a ticket type as shown to guests while it is on sale
+type PublicTicketType struct {
+	ID         int32      `json:"id"`
+	Name       string     `json:"name"`
+	PriceCents int64      `json:"price_cents"`
+	Currency   string     `json:"currency"`
+	SaleEndsAt *time.Time `json:"sale_ends_at"`
+	Remaining  *int32     `json:"remaining"`
+}
+
+type TicketTypeStore interface {
+	CreateTicketType(ctx context.Context, ticketType *TicketType) error
+	UpdateTicketType(ctx context.Context, ticketType *TicketType) error
+	DeleteTicketType(ctx context.Context, ticketTypeID int32) error
+	GetTicketTypesByEventID(eventID int32) ([]*TicketType, error)
+	GetTicketTypeByID(ticketTypeID int32) (*TicketType, error)
+}
+
+type CreateTicketTypePayload struct {
+	Name         string     `json:"name" validate:"required,max=100"`
+	Quantity     int32      `json:"quantity" validate:"min=0"`
+	PriceCents   int64      `json:"price_cents" validate:"min=0"`
+	Currency     string     `json:"currency" validate:"omitempty,iso4217"`
+	SaleStartsAt *time.Time `json:"sale_starts_at"`
+	SaleEndsAt   *time.Time `json:"sale_ends_at"`
+	Visible      *bool      `json:"visible"`
+}
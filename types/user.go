@@ -6,20 +6,23 @@ import (
 )
 
 type User struct {
-	ID           int32     `json:"id"`
-	FirstName    string    `json:"first_name"`
-	LastName     string    `json:"last_name"`
-	Role         string    `json:"role"`
-	Subscription string    `json:"subscription"`
-	Email        string    `json:"email"`
-	Password     string    `json:"password"`
-	Verify       bool      `json:"verify"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID           int32      `json:"id"`
+	FirstName    string     `json:"first_name"`
+	LastName     string     `json:"last_name"`
+	Role         string     `json:"role"`
+	Subscription string     `json:"subscription"`
+	Email        string     `json:"email"`
+	Password     string     `json:"password"`
+	Verify       bool       `json:"verify"`
+	Disabled     bool       `json:"disabled"`
+	LockedUntil  *time.Time `json:"locked_until"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	Plan         *Plan      `json:"plan,omitempty"`
+	Usage        *PlanUsage `json:"usage,omitempty"`
 }
 
 type UserStore interface {
-	GetUsersPaginated(page int32, pageSize int32) ([]*User, error)
 	GetUserByEmail(email string) (*User, error)
 	GetUserByID(id int32) (*User, error)
 	GetUserRoleByID(id int32) (string, error)
@@ -30,6 +33,21 @@ type UserStore interface {
 	UpdateUserInformation(ctx context.Context, user *User) error
 	UpdateUserVerification(ctx context.Context, id int32) error
 	DeleteUserByID(ctx context.Context, id int32) error
+	SearchUsers(filter UserFilter, page *PageRequest) ([]*User, *Cursor, error)
+	CountUsers(filter UserFilter) (int64, error)
+	SetUserVerification(ctx context.Context, id int32, verify bool) error
+	UpdateUserPassword(ctx context.Context, id int32, hashedPassword string) error
+	SetUserDisabled(ctx context.Context, id int32, disabled bool) error
+	SetUserLockedUntil(ctx context.Context, id int32, lockedUntil *time.Time) error
+}
+
+// UserFilter narrows a user search. Empty and nil fields match every user.
+type UserFilter struct {
+	Email        string `json:"email,omitempty"`
+	Role         string `json:"role,omitempty"`
+	Verified     *bool  `json:"verified,omitempty"`
+	Subscription string `json:"subscription,omitempty"`
+	Disabled     *bool  `json:"disabled,omitempty"`
 }
 
 type RegisterUserPayload struct {
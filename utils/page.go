@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+const (
+	DefaultPageLimit = 20
+	MaxPageLimit     = 100
+)
+
+// ParsePage reads the cursor, limit and total query parameters of a list request
+func ParsePage(c *fiber.Ctx) (*types.PageRequest, *fiber.Error) {
+	page := &types.PageRequest{Limit: DefaultPageLimit, WithTotal: c.QueryBool("total")}
+
+	if limit := c.QueryInt("limit", DefaultPageLimit); limit > 0 {
+		page.Limit = int32(min(limit, MaxPageLimit))
+	}
+
+	if str := c.Query("cursor"); str != "" {
+		cursor, err := DecodeCursor(str)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid cursor")
+		}
+		page.After = cursor
+	}
+
+	return page, nil
+}
+
+// EncodeCursor turns a cursor into the opaque string handed to clients
+func EncodeCursor(cursor *types.Cursor) *string {
+	if cursor == nil {
+		return nil
+	}
+
+	data, _ := json.Marshal(cursor)
+	str := base64.RawURLEncoding.EncodeToString(data)
+	return &str
+}
+
+// DecodeCursor reads a cursor handed out by EncodeCursor
+func DecodeCursor(str string) (*types.Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(str)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor types.Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+
+	return &cursor, nil
+}
+
+// NewPage wraps a page of items in the list envelope
+func NewPage(items any, next *types.Cursor, total *int64, filters map[string]any) types.Page {
+	if filters == nil {
+		filters = map[string]any{}
+	}
+
+	return types.Page{Items: items, NextCursor: EncodeCursor(next), Total: total, Filters: filters}
+}
+
+// AppliedFilters lists the fields of a filter that are set, under their json names
+func AppliedFilters(filter any) map[string]any {
+	applied := map[string]any{}
+	if data, err := json.Marshal(filter); err == nil {
+		_ = json.Unmarshal(data, &applied)
+	}
+
+	return applied
+}
+
+// SlicePage pages a short list that is read whole, in the order it is read.
+// The cursor holds the ID of the last item of the previous page.
+func SlicePage[T any](items []T, page *types.PageRequest, idOf func(T) int64, filters map[string]any) (types.Page, *fiber.Error) {
+	start := 0
+	if page.After != nil {
+		start = -1
+		for i, item := range items {
+			if idOf(item) == page.After.ID {
+				start = i + 1
+				break
+			}
+		}
+		if start < 0 {
+			return types.Page{}, fiber.NewError(fiber.StatusBadRequest, "The cursor is no longer in the list")
+		}
+	}
+
+	end := min(start+int(page.Limit), len(items))
+	var next *types.Cursor
+	if end < len(items) {
+		next = &types.Cursor{ID: idOf(items[end-1])}
+	}
+
+	var total *int64
+	if page.WithTotal {
+		count := int64(len(items))
+		total = &count
+	}
+
+	return NewPage(append([]T{}, items[start:end]...), next, total, filters), nil
+}
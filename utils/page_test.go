@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jayden1905/event-registration-software/types"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := &types.Cursor{Key: "2026-10-19T09:00:00Z", ID: 42}
+
+	got, err := DecodeCursor(*EncodeCursor(cursor))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *got != *cursor {
+		t.Errorf("expected %+v, got %+v", cursor, got)
+	}
+
+	if EncodeCursor(nil) != nil {
+		t.Error("expected no cursor after the last page")
+	}
+	if _, err := DecodeCursor("not a cursor"); err == nil {
+		t.Error("expected an invalid cursor to be rejected")
+	}
+}
+
+func TestSlicePage(t *testing.T) {
+	items := []int64{5, 3, 8, 1, 9}
+	id := func(i int64) int64 { return i }
+
+	var seen []int64
+	page := &types.PageRequest{Limit: 2, WithTotal: true}
+	for {
+		list, ferr := SlicePage(items, page, id, nil)
+		if ferr != nil {
+			t.Fatal(ferr)
+		}
+		if *list.Total != 5 {
+			t.Errorf("expected a total of 5, got %d", *list.Total)
+		}
+		seen = append(seen, list.Items.([]int64)...)
+		if list.NextCursor == nil {
+			break
+		}
+
+		after, err := DecodeCursor(*list.NextCursor)
+		if err != nil {
+			t.Fatal(err)
+		}
+		page.After = after
+	}
+
+	if !reflect.DeepEqual(seen, items) {
+		t.Errorf("expected %v, got %v", items, seen)
+	}
+
+	page.After = &types.Cursor{ID: 7}
+	if _, ferr := SlicePage(items, page, id, nil); ferr == nil {
+		t.Error("expected a cursor that is not in the list to be rejected")
+	}
+}
+
+func TestAppliedFilters(t *testing.T) {
+	checkedIn := true
+	got := AppliedFilters(types.AttendeeFilter{Role: "Speaker", Attendance: &checkedIn})
+
+	want := map[string]any{"role": "Speaker", "attendance": true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
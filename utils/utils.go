@@ -3,6 +3,8 @@ package utils
 import (
 	"bytes"
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -10,6 +12,7 @@ import (
 	"github.com/cloudinary/cloudinary-go"
 	"github.com/cloudinary/cloudinary-go/api/uploader"
 	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
 	"github.com/skip2/go-qrcode"
 
 	"github.com/jayden1905/event-registration-software/config"
@@ -47,6 +50,40 @@ func IsSuperUser(userID int32, store types.UserStore) (bool, error) {
 	return false, nil
 }
 
+// GetOwnedEvent loads the event with the ID of a route parameter and checks
+// that it belongs to the user
+func GetOwnedEvent(eventIDString string, userID int32, store types.EventStore) (*types.Event, *fiber.Error) {
+	eventID, err := strconv.Atoi(eventIDString)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid event ID")
+	}
+
+	return GetOwnedEventByID(int32(eventID), userID, store)
+}
+
+// GetOwnedEventByID loads an event and checks that it belongs to the user
+func GetOwnedEventByID(eventID int32, userID int32, store types.EventStore) (*types.Event, *fiber.Error) {
+	event, err := store.GetEventByID(eventID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fiber.NewError(fiber.StatusNotFound, "Event not found")
+		}
+		return nil, fiber.NewError(fiber.StatusInternalServerError, "Failed to get event")
+	}
+
+	if event.UserID != userID {
+		return nil, fiber.NewError(fiber.StatusUnauthorized, "Unauthorized")
+	}
+
+	return event, nil
+}
+
+// NormalizeEmail trims an email address and lowercases it, so the same address
+// is stored the same way however it was typed
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
 // Parsing an integer for table number
 func ParseTableNo(value string) int32 {
 	// remove the whitespaces
@@ -64,6 +101,23 @@ func ParseTableNo(value string) int32 {
 	return int32(num)
 }
 
+// zeroDecimalCurrencies have no minor unit, so their amounts are stored as whole units
+var zeroDecimalCurrencies = map[string]bool{"JPY": true, "KRW": true, "VND": true, "CLP": true, "ISK": true}
+
+// FormatAmount formats an amount in the minor unit of a currency, e.g. "USD 25.00"
+func FormatAmount(amountCents int64, currency string) string {
+	if zeroDecimalCurrencies[currency] {
+		return fmt.Sprintf("%s %d", currency, amountCents)
+	}
+
+	sign := ""
+	if amountCents < 0 {
+		sign = "-"
+		amountCents = -amountCents
+	}
+	return fmt.Sprintf("%s %s%d.%02d", currency, sign, amountCents/100, amountCents%100)
+}
+
 // Function to upload an image to Cloudinary
 func UploadImageToCloudinary(img []byte, folder string, format string) (string, error) {
 	// Initialize Cloudinary client
@@ -88,15 +142,20 @@ func UploadImageToCloudinary(img []byte, folder string, format string) (string,
 	return uploadResult.SecureURL, nil
 }
 
-// Function to generate QR Code image and upload to Cloudinary
-func GenerateQRCodeImage(data string) (string, error) {
+// Function to generate a QR Code PNG image in memory
+func GenerateQRCodePNG(data string) ([]byte, error) {
 	qrCode, err := qrcode.New(data, qrcode.Medium)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
+	return qrCode.PNG(256)
+}
+
+// Function to generate QR Code image and upload to Cloudinary
+func GenerateQRCodeImage(data string) (string, error) {
 	// Create a new image
-	img, err := qrCode.PNG(256)
+	img, err := GenerateQRCodePNG(data)
 	if err != nil {
 		return "", err
 	}